@@ -0,0 +1,115 @@
+// 时间戳查区块工具
+// 本程序把 Unix 时间戳或 ISO 8601 时间解析为区块号：默认给出该时刻之后出的第一个区块，
+// -before 给出该时刻的链上状态所在的区块（时间戳不晚于该时刻的最后一个区块）；
+// 同时给出 -since / -until 时输出时间范围对应的区块范围，可直接用于日志和历史查询
+//
+//	go run ./cmd/block-at -time 2024-01-01
+//	go run ./cmd/block-at -time 1704067200,2024-02-01,2024-03-01T08:00:00+08:00 -before
+//	go run ./cmd/block-at -since 2024-01-01 -until 2024-02-01
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	times := flag.String("time", "", "要查询的时刻，Unix 秒或 ISO 8601 时间，多个用逗号分隔")
+	before := flag.Bool("before", false, "返回时间戳不晚于该时刻的最后一个区块（该时刻的状态），而不是之后的第一个区块")
+	since := flag.String("since", "", "时间范围的开始（含）")
+	until := flag.String("until", "", "时间范围的结束（不含），默认到最新区块")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== 时间戳查区块工具 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+	// 同一个查找器查询多个时刻，后面的查找复用前面取得的区块头
+	r := blocktime.New(client)
+
+	// ===== 第2步：逐个时刻查找区块 =====
+	if *times != "" {
+		fmt.Println()
+		for _, s := range strings.Split(*times, ",") {
+			t, err := blocktime.ParseTime(s)
+			if err != nil {
+				log.Fatal(err)
+			}
+			var header *types.Header
+			if *before {
+				header, err = r.Before(ctx, t)
+			} else {
+				header, err = r.After(ctx, t)
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+			blockTime := time.Unix(int64(header.Time), 0).UTC()
+			fmt.Printf("%s → 区块 %s（时间 %s，相差 %s）\n",
+				t.UTC().Format(time.RFC3339), header.Number, blockTime.Format(time.RFC3339), blockTime.Sub(t).Abs())
+		}
+	}
+
+	// ===== 第3步：时间范围 =====
+	if *since != "" {
+		from, err := blocktime.ParseTime(*since)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var to time.Time
+		if *until != "" {
+			if to, err = blocktime.ParseTime(*until); err != nil {
+				log.Fatal(err)
+			}
+		}
+		first, last, err := r.Range(ctx, from, to)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("\n时间范围 [%s, %s) → 区块 %d - %d，共 %d 个区块\n",
+			from.UTC().Format(time.RFC3339), describe(to), first, last, last-first+1)
+	} else if *times == "" {
+		log.Fatal("请用 -time 指定时刻，或用 -since / -until 指定时间范围")
+	}
+
+	st := r.Stats()
+	fmt.Printf("\n请求区块头 %d 次，缓存命中 %d 次\n", st.Requests, st.Hits)
+
+	// 小白说明：
+	// 1. 以太坊按区块记录状态，没有“某一天”的概念；报表里的日期要先换算成区块号才能查询
+	// 2. 默认给出某个时刻之后的第一个区块，适合作为时间范围的起点；
+	//    -before 给出某个时刻之前的最后一个区块，余额等“当时的状态”应查询这个区块
+	// 3. 不带时区的时间按 UTC 计算，北京时间请写成 2024-01-01T08:00:00+08:00
+	//
+	// 技术说明：
+	// 1. 区块时间戳单调递增，可以在区块头上做有序查找
+	// 2. 合并后每 12 秒一个 slot，按时间比例插值通常三四次请求就能命中；
+	//    合并前出块间隔不固定，某一步没能把区间缩小一半时改用二分，最坏约 25 次请求
+	// 3. 时间范围为左闭右开：结束区块是时间戳早于 -until 的最后一个区块，相邻的范围不会重复计算
+	// 4. 同一次运行中查过的区块头保存在缓存里，多个时刻一起查询时后面的查找几乎不再请求节点
+}
+
+// describe 显示范围的结束时间，零值表示最新区块
+func describe(t time.Time) string {
+	if t.IsZero() {
+		return "最新"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
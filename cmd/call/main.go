@@ -0,0 +1,257 @@
+// 合约只读调用工具（支持状态覆盖和区块覆盖）
+// 本程序通过 eth_call 调用合约，调用前可以临时修改任意账户的余额、nonce、代码、存储槽，
+// 以及执行时的区块号、时间戳、基础费，覆盖只在这一次调用中生效
+//
+//	# 假设 0xHolder 持有 1000 个代币（18 位小数），模拟它的转账能否成功
+//	go run ./cmd/call -from 0xHolder -to 0x代币 -sig "transfer(address,uint256)" -args 0x接收方,1000000000000000000 \
+//	    -token-balance 0x代币:0xHolder=1000000000000000000000 -returns bool
+//	# 把本地编译的运行时字节码放到主网合约地址上，在主网状态上执行
+//	go run ./cmd/call -to 0x合约 -data 0x... -code 0x合约=build/Contract.bin-runtime
+//	# 在一年后的时间点上执行
+//	go run ./cmd/call -to 0x合约 -sig "claimable(address)" -args 0xUser -block-time 1767225600 -returns uint256
+//
+// -state 可以读取 geth 格式的状态覆盖 JSON 文件：{"0x地址":{"balance":"0x..","code":"0x..","stateDiff":{"0x槽":"0x值"}}}
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/override"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	from := flag.String("from", "", "调用方地址（可选）")
+	to := flag.String("to", "", "被调用的合约地址")
+	data := flag.String("data", "", "0x 开头的调用数据，与 -sig 二选一")
+	sig := flag.String("sig", "", "方法签名，如 balanceOf(address)")
+	args := flag.String("args", "", "方法参数，多个用逗号分隔")
+	returns := flag.String("returns", "", "返回值类型，如 uint256 或 (uint256,address)，用于解码返回数据")
+	value := flag.String("value", "", "随调用发送的 ETH（Wei）")
+	var block blocktag.Selector
+	flag.Var(&block, "block", "在哪个区块的状态上执行：latest、pending、safe、finalized、区块号、区块哈希、-N 或 ISO 时间，默认最新区块")
+
+	stateFile := flag.String("state", "", "geth 格式的状态覆盖 JSON 文件")
+	balances := flag.String("balance", "", "覆盖 ETH 余额，格式为 地址=Wei，多个用逗号分隔")
+	nonces := flag.String("nonce", "", "覆盖 nonce，格式为 地址=数值，多个用逗号分隔")
+	codes := flag.String("code", "", "覆盖合约代码，格式为 地址=运行时字节码文件（十六进制文本），多个用逗号分隔")
+	slots := flag.String("storage", "", "覆盖存储槽，格式为 地址:槽=值，多个用逗号分隔")
+	tokens := flag.String("token-balance", "", "覆盖 ERC20 余额（自动查找存储槽），格式为 代币:持有人=数量，多个用逗号分隔")
+
+	blockNumber := flag.String("block-number", "", "覆盖执行时的区块号")
+	blockTime := flag.Uint64("block-time", 0, "覆盖执行时的区块时间戳（Unix 秒）")
+	baseFee := flag.String("block-basefee", "", "覆盖执行时的基础费（Wei）")
+	coinbase := flag.String("coinbase", "", "覆盖执行时的 coinbase 地址")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== 合约调用工具（状态覆盖） ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	caller := override.New(client.Client())
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：构造调用 =====
+	if !common.IsHexAddress(*to) {
+		log.Fatal("请用 -to 指定合约地址")
+	}
+	target := common.HexToAddress(*to)
+	msg := ethereum.CallMsg{To: &target}
+	if *from != "" {
+		msg.From = mustAddress(*from)
+	}
+	if *value != "" {
+		msg.Value = mustBig(*value)
+	}
+	switch {
+	case *sig != "":
+		var list []string
+		if *args != "" {
+			list = strings.Split(*args, ",")
+		}
+		if msg.Data, err = calldata.Encode(*sig, list...); err != nil {
+			log.Fatal(err)
+		}
+	case *data != "":
+		if msg.Data, err = hexutil.Decode(*data); err != nil {
+			log.Fatal("无效的 -data: ", err)
+		}
+	}
+	at, err := block.BlockNumber(ctx, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if at != nil && at.Sign() >= 0 {
+		fmt.Printf("在区块 %s（%s）的状态上执行\n", at, block)
+	}
+
+	// ===== 第3步：准备状态覆盖 =====
+	// 余额、nonce、代码、存储槽直接写入覆盖集合；代币余额需要先找到余额映射所在的槽
+	state := override.State{}
+	if *stateFile != "" {
+		raw, err := os.ReadFile(*stateFile)
+		if err != nil {
+			log.Fatal("读取状态覆盖文件失败: ", err)
+		}
+		if err := json.Unmarshal(raw, &state); err != nil {
+			log.Fatal("解析状态覆盖文件失败: ", err)
+		}
+	}
+	for _, kv := range pairs(*balances) {
+		state.SetBalance(mustAddress(kv[0]), mustBig(kv[1]))
+	}
+	for _, kv := range pairs(*nonces) {
+		n, err := strconv.ParseUint(kv[1], 0, 64)
+		if err != nil {
+			log.Fatalf("无效的 nonce %q", kv[1])
+		}
+		state.SetNonce(mustAddress(kv[0]), n)
+	}
+	for _, kv := range pairs(*codes) {
+		raw, err := os.ReadFile(kv[1])
+		if err != nil {
+			log.Fatal("读取字节码文件失败: ", err)
+		}
+		code := strings.TrimSpace(string(raw))
+		if !strings.HasPrefix(code, "0x") {
+			code = "0x" + code
+		}
+		bytecode, err := hexutil.Decode(code)
+		if err != nil {
+			log.Fatalf("%s 不是十六进制字节码: %v", kv[1], err)
+		}
+		state.SetCode(mustAddress(kv[0]), bytecode)
+	}
+	for _, kv := range pairs(*slots) {
+		addr, slot, ok := strings.Cut(kv[0], ":")
+		if !ok {
+			log.Fatalf("无效的 -storage 参数 %q，格式为 地址:槽=值", kv[0])
+		}
+		state.SetStorage(mustAddress(addr), mustHash(slot), mustHash(kv[1]))
+	}
+	for _, kv := range pairs(*tokens) {
+		token, holder, ok := strings.Cut(kv[0], ":")
+		if !ok {
+			log.Fatalf("无效的 -token-balance 参数 %q，格式为 代币:持有人=数量", kv[0])
+		}
+		if err := caller.SetTokenBalance(ctx, state, mustAddress(token), mustAddress(holder), mustBig(kv[1]), at); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("✓ 已覆盖 %s 在代币 %s 中的余额\n", holder, token)
+	}
+	if len(state) == 0 {
+		state = nil
+	}
+
+	// ===== 第4步：准备区块覆盖 =====
+	var blockOv *override.Block
+	if *blockNumber != "" || *blockTime != 0 || *baseFee != "" || *coinbase != "" {
+		blockOv = &override.Block{}
+		if *blockNumber != "" {
+			blockOv.Number = mustBig(*blockNumber)
+		}
+		if *blockTime != 0 {
+			blockOv.Time = blockTime
+		}
+		if *baseFee != "" {
+			blockOv.BaseFee = mustBig(*baseFee)
+		}
+		if *coinbase != "" {
+			addr := mustAddress(*coinbase)
+			blockOv.Coinbase = &addr
+		}
+	}
+
+	// ===== 第5步：执行调用 =====
+	out, err := caller.Call(ctx, msg, at, state, blockOv)
+	if err != nil {
+		if reason := revert.NewDecoder(calldata.DefaultSignatures()).FromError(err); reason != nil {
+			log.Fatalf("❌ 调用失败: %s", reason)
+		}
+		log.Fatal(err)
+	}
+	// -block 为区块哈希时按区块号执行，确认期间该区块没有被重组掉
+	if err := block.Verify(ctx, client, at); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n返回数据: %s\n", hexutil.Encode(out))
+	if *returns != "" {
+		values, err := calldata.DecodeOutput(*returns, out)
+		if err != nil {
+			log.Fatal("解码返回数据失败: ", err)
+		}
+		for i, v := range values {
+			fmt.Printf("  [%d] %s\n", i, calldata.FormatValue(v))
+		}
+	}
+
+	// 小白说明：
+	// 1. eth_call 在节点上执行调用但不上链，不花 Gas，也不需要签名
+	// 2. 状态覆盖让节点“假装”某个账户的余额、代码或存储是另一个值，用于回答“如果……会怎样”
+	// 3. ERC20 余额保存在合约的 mapping(address => uint256) 里，-token-balance 会自动找到对应的存储槽
+	//
+	// 技术说明：
+	// 1. 状态覆盖是 eth_call 的第 3 个参数，geth、Erigon、Nethermind 和主流服务商都支持；
+	//    区块覆盖是第 4 个参数，较旧的节点不支持时返回 override.ErrBlockOverridesNotSupported
+	// 2. 代码覆盖使用运行时字节码（solc --bin-runtime），不是包含构造函数的创建字节码
+	// 3. state 替换整个存储、stateDiff 只修改列出的槽，命令行参数使用 stateDiff
+	// 4. 余额存储槽按 Solidity 和 Vyper 的映射布局探测，代理合约和 rebase 代币可能找不到
+}
+
+// pairs 把 "a=b,c=d" 拆分为键值对
+func pairs(list string) [][2]string {
+	if list == "" {
+		return nil
+	}
+	var out [][2]string
+	for _, item := range strings.Split(list, ",") {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok {
+			log.Fatalf("无效的参数 %q，缺少 =", item)
+		}
+		out = append(out, [2]string{strings.TrimSpace(k), strings.TrimSpace(v)})
+	}
+	return out
+}
+
+func mustAddress(s string) common.Address {
+	if !common.IsHexAddress(s) {
+		log.Fatalf("无效的地址 %q", s)
+	}
+	return common.HexToAddress(s)
+}
+
+func mustBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		log.Fatalf("无效的数值 %q", s)
+	}
+	return n
+}
+
+// mustHash 解析存储槽或值：十进制、0x 十六进制都按 32 字节左填充
+func mustHash(s string) common.Hash {
+	return common.BigToHash(mustBig(s))
+}
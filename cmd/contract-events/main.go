@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/events"
 )
 
 // StoreABI 智能合约的ABI定义
@@ -19,13 +23,16 @@ import (
 var StoreABI = `[{"inputs":[{"internalType":"string","name":"_version","type":"string"}],"stateMutability":"nonpayable","type":"constructor"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"bytes32","name":"key","type":"bytes32"},{"indexed":false,"internalType":"bytes32","name":"value","type":"bytes32"}],"name":"ItemSet","type":"event"},{"inputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"name":"items","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes32","name":"key","type":"bytes32"},{"internalType":"bytes32","name":"value","type":"bytes32"}],"name":"setItem","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"version","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}]`
 
 func main() {
+	since := flag.String("since", "", "开始监听前先补发该时刻（Unix 秒或 ISO 8601 时间）以来的历史事件")
+	flag.Parse()
+
 	// ===== 第1步：连接以太坊网络 =====
 	// 使用WebSocket连接，支持实时事件推送
 	// 注意：这里使用的是Rinkeby测试网，现在已经废弃
 	// 建议替换为Sepolia测试网或主网的WebSocket端点
-	client, err := ethclient.Dial("wss://rinkeby.infura.io/ws")
+	client, err := backend.Dial("wss://rinkeby.infura.io/ws")
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("✅ 成功连接到以太坊网络（WebSocket）")
 
@@ -45,101 +52,94 @@ func main() {
 	}
 	fmt.Println("📡 创建事件过滤器")
 
-	// ===== 第4步：创建事件订阅 =====
-	// logs通道用于接收事件日志
-	// SubscribeFilterLogs创建一个实时事件订阅
-	logs := make(chan types.Log)
-	sub, err := client.SubscribeFilterLogs(context.Background(), query, logs)
-	if err != nil {
-		log.Fatal("创建事件订阅失败:", err)
-	}
-	fmt.Println("🔔 开始监听合约事件...")
-
-	// ===== 第5步：解析合约ABI =====
-	// 将ABI字符串解析为Go可以使用的ABI对象
-	// 这个对象用于解码事件数据
-	contractAbi, err := abi.JSON(strings.NewReader(string(StoreABI)))
+	// ===== 第4步：解析合约ABI =====
+	// 将ABI字符串解析为事件解码器
+	// 解码器会根据Topics[0]找到对应的事件定义
+	decoder, err := events.NewDecoder(StoreABI)
 	if err != nil {
-		log.Fatal("解析合约ABI失败:", err)
+		log.Fatal(err)
 	}
 
-	// ===== 第6步：事件监听主循环 =====
-	// 使用select语句同时监听多个通道
-	for {
-		select {
-		// 监听订阅错误
-		case err := <-sub.Err():
-			log.Fatal("事件订阅出错:", err)
-
-		// 监听新的事件日志
-		case vLog := <-logs:
-			fmt.Println("\n🎉 收到新事件！")
-			fmt.Println("===========================================")
-
-			// ===== 第7步：显示事件基本信息 =====
-			// 区块哈希：包含此事件的区块的唯一标识
-			fmt.Printf("📦 区块哈希: %s\n", vLog.BlockHash.Hex())
-			// 区块号：事件发生的区块编号
-			fmt.Printf("🔢 区块号: %d\n", vLog.BlockNumber)
-			// 交易哈希：触发此事件的交易的唯一标识
-			fmt.Printf("💳 交易哈希: %s\n", vLog.TxHash.Hex())
-
-			// ===== 第8步：解析事件数据 =====
-			// 定义事件数据结构，必须与合约中的事件定义匹配
-			// ItemSet事件包含key和value两个字段
-			event := struct {
-				Key   [32]byte // bytes32类型的key
-				Value [32]byte // bytes32类型的value
-			}{}
-
-			// 使用ABI解码事件数据
-			// UnpackIntoInterface将原始事件数据解码到结构体中
-			// "ItemSet"是事件名称，vLog.Data包含非indexed参数的数据
-			err := contractAbi.UnpackIntoInterface(&event, "ItemSet", vLog.Data)
-			if err != nil {
-				log.Fatal("解析事件数据失败:", err)
-			}
-
-			// ===== 第9步：显示解析后的事件数据 =====
-			// 将bytes32数据转换为十六进制字符串显示
-			fmt.Printf("🔑 Key (hex): %s\n", common.Bytes2Hex(event.Key[:]))
-			fmt.Printf("💎 Value (hex): %s\n", common.Bytes2Hex(event.Value[:]))
-
-			// 尝试将bytes32数据转换为可读字符串（如果是文本数据）
-			// 移除末尾的零字节
-			keyStr := strings.TrimRight(string(event.Key[:]), "\x00")
-			valueStr := strings.TrimRight(string(event.Value[:]), "\x00")
-			if keyStr != "" {
-				fmt.Printf("🔑 Key (string): %s\n", keyStr)
-			}
-			if valueStr != "" {
-				fmt.Printf("💎 Value (string): %s\n", valueStr)
-			}
+	// ===== 第5步：定义事件处理函数 =====
+	// 补发的历史事件和实时收到的事件使用同一个处理函数
+	handle := func(event *events.Event) error {
+		vLog := event.Log
+		fmt.Println("\n🎉 收到新事件！")
+		fmt.Println("===========================================")
+
+		// ===== 第6步：显示事件基本信息 =====
+		// 区块哈希：包含此事件的区块的唯一标识
+		fmt.Printf("📦 区块哈希: %s\n", vLog.BlockHash.Hex())
+		// 区块号：事件发生的区块编号
+		fmt.Printf("🔢 区块号: %d\n", vLog.BlockNumber)
+		// 交易哈希：触发此事件的交易的唯一标识
+		fmt.Printf("💳 交易哈希: %s\n", vLog.TxHash.Hex())
+		fmt.Printf("📛 事件名称: %s\n", event.Name)
+
+		// ===== 第7步：显示解析后的事件数据 =====
+		// ItemSet事件包含key（indexed，来自topics）和value（来自data）两个字段
+		key, _ := event.Fields["key"].([32]byte)
+		value, _ := event.Fields["value"].([32]byte)
+		fmt.Printf("🔑 Key (hex): %s\n", common.Bytes2Hex(key[:]))
+		fmt.Printf("💎 Value (hex): %s\n", common.Bytes2Hex(value[:]))
+
+		// 尝试将bytes32数据转换为可读字符串（如果是文本数据）
+		// 移除末尾的零字节
+		keyStr := strings.TrimRight(string(key[:]), "\x00")
+		valueStr := strings.TrimRight(string(value[:]), "\x00")
+		if keyStr != "" {
+			fmt.Printf("🔑 Key (string): %s\n", keyStr)
+		}
+		if valueStr != "" {
+			fmt.Printf("💎 Value (string): %s\n", valueStr)
+		}
 
-			// ===== 第10步：处理事件主题(Topics) =====
-			// Topics包含indexed参数和事件签名
-			// Topics[0]是事件签名的哈希
-			// Topics[1:]是indexed参数的值
-			var topics []string
-			for i := range vLog.Topics {
-				topics = append(topics, vLog.Topics[i].Hex())
-			}
+		// ===== 第8步：处理事件主题(Topics) =====
+		// Topics包含indexed参数和事件签名
+		// Topics[0]是事件签名的哈希
+		// Topics[1:]是indexed参数的值
+		fmt.Printf("📋 事件签名: %s\n", vLog.Topics[0].Hex())
+		if len(vLog.Topics) > 1 {
+			fmt.Printf("🏷️  Indexed参数: %v\n", vLog.Topics[1:])
+		}
 
-			// 显示事件签名（第一个topic）
-			fmt.Printf("📋 事件签名: %s\n", topics[0])
+		fmt.Println("===========================================")
+		return nil
+	}
 
-			// 显示indexed参数（如果有的话）
-			// 在ItemSet事件中，key是indexed参数，所以会出现在topics[1]中
-			if len(topics) > 1 {
-				fmt.Printf("🏷️  Indexed参数: %v\n", topics[1:])
-				// 第一个indexed参数就是key值
-				fmt.Printf("🔑 Key (from topic): %s\n", topics[1])
+	// ===== 第9步：补发 -since 以来的历史事件 =====
+	// 时刻先由 pkg/blocktime 换算成之后的第一个区块，再用 eth_getLogs 查询到最新区块
+	if *since != "" {
+		t, err := blocktime.ParseTime(*since)
+		if err != nil {
+			log.Fatal(err)
+		}
+		first, last, err := blocktime.New(client).Range(context.Background(), t, time.Time{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		history := query
+		history.FromBlock, history.ToBlock = new(big.Int).SetUint64(first), new(big.Int).SetUint64(last)
+		past, err := events.History(context.Background(), client, history, decoder)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("⏪ 补发区块 %d - %d 中的 %d 个历史事件\n", first, last, len(past))
+		for _, event := range past {
+			if err := handle(event); err != nil {
+				log.Fatal(err)
 			}
-
-			fmt.Println("===========================================")
 		}
 	}
 
+	// ===== 第10步：创建事件订阅并进入监听循环 =====
+	// events.Watch内部使用SubscribeFilterLogs创建实时订阅，
+	// 每收到一条日志就解码后交给回调处理，订阅出错时返回错误
+	fmt.Println("🔔 开始监听合约事件...")
+	if err := events.Watch(context.Background(), client, query, decoder, handle); err != nil {
+		log.Fatal(err)
+	}
+
 	// 注意：这个程序会一直运行，监听新的事件
 	// 在实际应用中，你可能需要添加优雅关闭的逻辑
 	// 例如监听系统信号，在收到关闭信号时清理资源并退出
@@ -165,4 +165,9 @@ func main() {
 	// 5. 性能考虑：
 	//    - 可以通过Topics过滤特定事件类型
 	//    - 可以设置区块范围避免处理过多历史数据
-}
\ No newline at end of file
+	//
+	// 6. 补发历史事件（-since）：
+	//    - 程序重启期间错过的事件不会通过订阅推送，可以用 -since 从上次停止的时间补发
+	//    - 补发到查询时的最新区块为止，之后的事件由订阅推送；补发和建立订阅之间如果恰好出了新区块，
+	//      其中的事件可能遗漏，要求不漏的场景应在订阅建立后再补查一次，并按 (交易哈希, 日志索引) 去重
+}
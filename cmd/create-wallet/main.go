@@ -5,93 +5,63 @@
 package main
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"log"
 
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
-	"golang.org/x/crypto/sha3"
+
+	"github.com/duanyu/new-eth-project/pkg/wallet"
 )
 
 func main() {
 	fmt.Println("=== 以太坊钱包创建工具 ===")
-	fmt.Println("本工具演示如何生成新的以太坊钱包\n")
+	fmt.Print("本工具演示如何生成新的以太坊钱包\n\n")
 
-	// ===== 第1步：生成私钥 =====
+	// ===== 第1步：生成钱包 =====
 	// 使用椭圆曲线数字签名算法(ECDSA)生成随机私钥
 	// 以太坊使用secp256k1椭圆曲线，这与比特币相同
-	privateKey, err := crypto.GenerateKey()
+	// wallet.New 同时从私钥推导出公钥和地址
+	w, err := wallet.New()
 	if err != nil {
-		log.Fatal("生成私钥失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("✓ 成功生成私钥")
 
 	// ===== 第2步：导出私钥 =====
-	// 将私钥转换为字节数组，然后编码为十六进制字符串
-	// 私钥是32字节(256位)的随机数
-	privateKeyBytes := crypto.FromECDSA(privateKey)
-	// 去掉'0x'前缀，只保留十六进制字符串
-	privateKeyHex := hexutil.Encode(privateKeyBytes)[2:]
-	fmt.Printf("私钥 (Hex): %s\n", privateKeyHex)
-	fmt.Printf("私钥长度: %d 字节\n\n", len(privateKeyBytes))
+	// 私钥是32字节(256位)的随机数，这里去掉了'0x'前缀
+	fmt.Printf("私钥 (Hex): %s\n", w.PrivateKeyHex)
+	fmt.Printf("私钥长度: %d 字节\n\n", len(crypto.FromECDSA(w.PrivateKey)))
 
-	// ===== 第3步：从私钥推导公钥 =====
-	// 从私钥计算对应的公钥
+	// ===== 第3步：导出公钥 =====
 	// 公钥是椭圆曲线上的一个点，由私钥通过椭圆曲线乘法得到
-	publicKey := privateKey.Public()
-	// 将接口类型转换为具体的ECDSA公钥类型
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("公钥类型转换失败: 无法转换为*ecdsa.PublicKey类型")
-	}
+	// 公钥包含x和y坐标，总共65字节(1字节前缀+32字节x+32字节y)，这里去掉了'0x04'前缀
 	fmt.Println("✓ 成功推导公钥")
+	fmt.Printf("公钥 (Hex): %s\n", w.PublicKeyHex)
+	fmt.Printf("公钥长度: %d 字节\n\n", len(crypto.FromECDSAPub(&w.PrivateKey.PublicKey)))
 
-	// ===== 第4步：导出公钥 =====
-	// 将公钥转换为字节数组
-	// 公钥包含x和y坐标，总共65字节(1字节前缀+32字节x+32字节y)
-	publicKeyBytes := crypto.FromECDSAPub(publicKeyECDSA)
-	// 去掉'0x04'前缀，只保留坐标数据
-	publicKeyHex := hexutil.Encode(publicKeyBytes)[4:]
-	fmt.Printf("公钥 (Hex): %s\n", publicKeyHex)
-	fmt.Printf("公钥长度: %d 字节\n\n", len(publicKeyBytes))
-
-	// ===== 第5步：从公钥推导以太坊地址 =====
+	// ===== 第4步：从公钥推导以太坊地址 =====
 	// 以太坊地址是公钥的Keccak256哈希值的后20字节
-	address := crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
-	fmt.Printf("以太坊地址: %s\n\n", address)
+	fmt.Printf("以太坊地址: %s\n\n", w.Address.Hex())
 
-	// ===== 第6步：手动验证地址推导过程 =====
+	// ===== 第5步：手动验证地址推导过程 =====
+	// 对去掉0x04前缀的公钥（只含x和y坐标）做Keccak256哈希，
+	// 截去32字节哈希的前12字节，保留后20字节
 	fmt.Println("=== 地址推导验证 ===")
-	// 创建Keccak256哈希器
-	hash := sha3.NewLegacyKeccak256()
-	// 对公钥进行哈希(去掉第一个字节的前缀)
-	// 公钥格式：0x04 + 32字节x坐标 + 32字节y坐标
-	// 地址计算只使用x和y坐标，不包括0x04前缀
-	hash.Write(publicKeyBytes[1:])
-
-	// 获取完整的32字节哈希值
-	fullHash := hash.Sum(nil)
-	fullHashHex := hexutil.Encode(fullHash[:])
-	fmt.Printf("公钥Keccak256哈希 (完整32字节): %s\n", fullHashHex)
-
-	// 取哈希值的后20字节作为以太坊地址
-	// 原长32字节，截去前12字节，保留后20字节
-	addressFromHash := hexutil.Encode(fullHash[12:])
-	fmt.Printf("地址 (后20字节): %s\n", addressFromHash)
+	addressFromHash := wallet.AddressFromPublicKey(&w.PrivateKey.PublicKey)
+	fmt.Printf("地址 (后20字节): %s\n", addressFromHash.Hex())
 
-	// ===== 第7步：验证结果一致性 =====
+	// ===== 第6步：验证结果一致性 =====
 	fmt.Println("\n=== 验证结果 ===")
-	if address == addressFromHash {
+	if w.Address == addressFromHash {
 		fmt.Println("✓ 地址推导验证成功！两种方法得到相同结果")
 	} else {
 		fmt.Println("✗ 地址推导验证失败！结果不一致")
 	}
 
 	fmt.Println("\n=== 钱包信息汇总 ===")
-	fmt.Printf("私钥: %s\n", privateKeyHex)
-	fmt.Printf("公钥: %s\n", publicKeyHex)
-	fmt.Printf("地址: %s\n", address)
+	fmt.Printf("私钥: %s\n", w.PrivateKeyHex)
+	fmt.Printf("公钥: %s\n", w.PublicKeyHex)
+	fmt.Printf("地址: %s\n", w.Address.Hex())
 
 	fmt.Println("\n=== 重要提醒 ===")
 	fmt.Println("1. 私钥是您钱包的唯一凭证，请务必安全保管")
@@ -125,4 +95,4 @@ func main() {
 	//    - 私钥泄露等于资产丢失
 	//    - 建议使用硬件随机数生成器
 	//    - 生产环境使用专业的密钥管理方案
-}
\ No newline at end of file
+}
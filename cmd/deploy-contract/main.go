@@ -2,18 +2,13 @@ package main
 
 import (
 	"context"      // 上下文管理，用于控制请求的生命周期
-	"crypto/ecdsa" // 椭圆曲线数字签名算法
 	"encoding/hex" // 十六进制编码解码
+	"errors"       // 错误判断
 	"fmt"          // 格式化输入输出
 	"log"          // 日志记录
-	"math/big"     // 大整数运算
-	"time"         // 时间处理
 
-	"github.com/ethereum/go-ethereum"            // 以太坊核心库
-	"github.com/ethereum/go-ethereum/common"     // 以太坊通用类型
-	"github.com/ethereum/go-ethereum/core/types" // 以太坊核心类型
-	"github.com/ethereum/go-ethereum/crypto"     // 以太坊加密工具
-	"github.com/ethereum/go-ethereum/ethclient"  // 以太坊客户端
+	"github.com/duanyu/new-eth-project/pkg/backend"  // 以太坊后端接口与交易签名
+	"github.com/duanyu/new-eth-project/pkg/contract" // 合约部署与调用功能库
 )
 
 // 合约字节码（示例 - 一个简单的存储合约）
@@ -27,50 +22,22 @@ func main() {
 	// 步骤1：连接到以太坊网络
 	// 使用Goerli测试网络作为示例（您需要替换为您的Infura项目ID）
 	// 注意：请将YOUR-PROJECT-ID替换为您的实际Infura项目ID
-	client, err := ethclient.Dial("https://goerli.infura.io/v3/YOUR-PROJECT-ID")
+	client, err := backend.Dial("https://goerli.infura.io/v3/YOUR-PROJECT-ID")
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("成功连接到以太坊网络")
 
-	// 步骤2：创建私钥
+	// 步骤2：加载私钥并推导部署账户地址
 	// 在实际应用中，您应该使用更安全的方式来管理私钥
 	// 注意：请将YOUR-PRIVATE-KEY-HERE替换为您的实际私钥（不包含0x前缀）
-	privateKey, err := crypto.HexToECDSA("YOUR-PRIVATE-KEY-HERE")
+	signer, err := backend.SignerFromHex("YOUR-PRIVATE-KEY-HERE")
 	if err != nil {
-		log.Fatal("创建私钥失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Println("私钥加载成功")
+	fmt.Printf("部署账户地址: %s\n", signer.Address.Hex())
 
-	// 步骤3：从私钥推导公钥和地址
-	// 公钥是从私钥计算得出的，地址是从公钥计算得出的
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("公钥类型转换失败")
-	}
-
-	// 从公钥推导出以太坊地址
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	fmt.Printf("部署账户地址: %s\n", fromAddress.Hex())
-
-	// 步骤4：获取nonce
-	// nonce是账户发送交易的序号，防止重放攻击
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		log.Fatal("获取nonce失败:", err)
-	}
-	fmt.Printf("当前nonce: %d\n", nonce)
-
-	// 步骤5：获取建议的gas价格
-	// Gas价格决定了交易的优先级，价格越高越容易被矿工打包
-	gasPrice, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		log.Fatal("获取gas价格失败:", err)
-	}
-	fmt.Printf("建议gas价格: %s wei\n", gasPrice.String())
-
-	// 步骤6：解码合约字节码
+	// 步骤3：解码合约字节码
 	// 合约字节码是编译后的智能合约代码
 	data, err := hex.DecodeString(contractBytecode)
 	if err != nil {
@@ -78,81 +45,26 @@ func main() {
 	}
 	fmt.Printf("合约字节码长度: %d bytes\n", len(data))
 
-	// 步骤7：创建合约部署交易
-	// NewContractCreation创建一个合约部署交易
-	// 参数：nonce, value(发送的ETH数量), gasLimit, gasPrice, 合约字节码
-	tx := types.NewContractCreation(nonce, big.NewInt(0), 3000000, gasPrice, data)
-	fmt.Println("合约部署交易创建成功")
-
-	// 步骤8：获取网络ID并签名交易
-	// 网络ID用于防止跨链重放攻击
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		log.Fatal("获取网络ID失败:", err)
-	}
-	fmt.Printf("网络ID: %s\n", chainID.String())
-
-	// 使用EIP155签名标准签名交易
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		log.Fatal("签名交易失败:", err)
-	}
-	fmt.Println("交易签名成功")
-
-	// 步骤9：发送交易到网络
-	// 将签名后的交易广播到以太坊网络
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		log.Fatal("发送交易失败:", err)
+	// 步骤4：创建、签名并发送合约部署交易，然后等待交易被挖矿
+	// contract.DeployAndWait 会获取nonce和Gas价格，以300万Gas限制创建部署交易，
+	// 用链ID做EIP155签名并广播，随后轮询交易收据直到交易被打包
+	fmt.Println("正在发送部署交易并等待确认...")
+	deployment, err := contract.DeployAndWait(context.Background(), client, signer, data, nil)
+	if errors.Is(err, backend.ErrTxFailed) {
+		fmt.Println("合约部署失败")
+		return
 	}
-
-	fmt.Printf("交易已发送，交易哈希: %s\n", signedTx.Hash().Hex())
-	fmt.Println("等待交易被挖矿...")
-
-	// 步骤10：等待交易被挖矿并获取收据
-	// 交易收据包含合约地址等重要信息
-	receipt, err := waitForReceipt(client, signedTx.Hash())
 	if err != nil {
-		log.Fatal("等待交易收据失败:", err)
-	}
-
-	// 步骤11：显示部署结果
-	if receipt.Status == 1 {
-		fmt.Println("\n=== 合约部署成功! ===")
-		fmt.Printf("合约地址: %s\n", receipt.ContractAddress.Hex())
-		fmt.Printf("区块号: %d\n", receipt.BlockNumber.Uint64())
-		fmt.Printf("Gas使用量: %d\n", receipt.GasUsed)
-		fmt.Printf("交易哈希: %s\n", receipt.TxHash.Hex())
-	} else {
-		fmt.Println("合约部署失败")
+		log.Fatal(err)
 	}
-}
-
-// waitForReceipt 等待交易被挖矿并返回交易收据
-// 参数：client - 以太坊客户端，txHash - 交易哈希
-// 返回：交易收据和可能的错误
-func waitForReceipt(client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
-	fmt.Println("正在等待交易确认...")
 
-	// 无限循环直到交易被确认
-	for {
-		// 尝试获取交易收据
-		receipt, err := client.TransactionReceipt(context.Background(), txHash)
-		if err == nil {
-			// 交易已被确认，返回收据
-			return receipt, nil
-		}
-
-		// 如果错误不是"未找到"，说明出现了其他问题
-		if err != ethereum.NotFound {
-			return nil, err
-		}
-
-		// 等待1秒后再次查询
-		// 避免过于频繁的查询
-		time.Sleep(1 * time.Second)
-		fmt.Print(".")
-	}
+	// 步骤5：显示部署结果
+	receipt := deployment.Receipt
+	fmt.Println("\n=== 合约部署成功! ===")
+	fmt.Printf("合约地址: %s\n", deployment.Address.Hex())
+	fmt.Printf("区块号: %d\n", receipt.BlockNumber.Uint64())
+	fmt.Printf("Gas使用量: %d\n", receipt.GasUsed)
+	fmt.Printf("交易哈希: %s\n", receipt.TxHash.Hex())
 
 	// 小白说明：
 	// 1. 合约部署是一种特殊的交易，to地址为空，data字段包含合约字节码
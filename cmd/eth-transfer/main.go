@@ -1,129 +1,102 @@
 // 以太坊转账工具
 // 本程序演示如何在以太坊网络上进行ETH转账
 // 包含完整的交易创建、签名和发送流程
+//
+//	go run ./cmd/eth-transfer            # 模拟后询问是否发送
+//	go run ./cmd/eth-transfer -dry-run   # 只模拟，不发送
+//	go run ./cmd/eth-transfer -yes       # 模拟后直接发送，不询问
+//	go run ./cmd/eth-transfer -force     # 模拟执行失败时仍然发送
 
 package main
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
+	"os"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/simulate"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "只模拟交易并显示预期结果，不发送")
+	yes := flag.Bool("yes", false, "模拟后不询问，直接发送")
+	force := flag.Bool("force", false, "模拟执行失败时仍然发送（会消耗 Gas）")
+	flag.Parse()
+
 	fmt.Println("=== 以太坊转账工具 ===")
-	fmt.Println("本工具演示如何进行ETH转账，包括交易创建、签名和发送\n")
+	fmt.Print("本工具演示如何进行ETH转账，包括交易创建、签名和发送\n\n")
 
 	// ===== 第1步：连接以太坊网络 =====
 	// 注意：这里使用的是Rinkeby测试网（已废弃），建议改为Sepolia测试网
 	// 生产环境请使用主网端点
-	client, err := ethclient.Dial("https://rinkeby.infura.io")
+	client, err := backend.Dial("https://rinkeby.infura.io")
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("✓ 成功连接到以太坊网络")
 
-	// ===== 第2步：加载私钥 =====
-	// 从十六进制字符串加载私钥
+	// ===== 第2步：加载私钥并推导发送方地址 =====
 	// 警告：这是示例私钥，实际使用时请使用安全的私钥管理方式
 	// 生产环境中绝不要在代码中硬编码私钥
-	privateKey, err := crypto.HexToECDSA("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
-	if err != nil {
-		log.Fatal("加载私钥失败:", err)
-	}
-	fmt.Println("✓ 成功加载私钥")
-
-	// ===== 第3步：从私钥推导公钥和地址 =====
-	// 获取公钥
-	publicKey := privateKey.Public()
-	// 将公钥转换为ECDSA公钥类型
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("公钥类型转换失败: 无法转换为*ecdsa.PublicKey类型")
-	}
-
-	// 从公钥推导以太坊地址
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	fmt.Printf("✓ 发送方地址: %s\n", fromAddress.Hex())
-
-	// ===== 第4步：获取账户nonce =====
-	// nonce是账户发送的交易序号，用于防止重放攻击
-	// PendingNonceAt获取包含待处理交易的最新nonce
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	signer, err := backend.SignerFromHex("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
 	if err != nil {
-		log.Fatal("获取nonce失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("✓ 当前nonce: %d\n", nonce)
+	fmt.Printf("✓ 发送方地址: %s\n", signer.Address.Hex())
 
-	// ===== 第5步：设置交易参数 =====
+	// ===== 第3步：设置转账参数 =====
 	// 转账金额：1 ETH = 10^18 Wei
 	value := big.NewInt(1000000000000000000) // 1 ETH in wei
 	fmt.Printf("转账金额: %s Wei (1 ETH)\n", value.String())
-
-	// Gas限制：ETH转账的标准Gas限制是21000
-	gasLimit := uint64(21000)
-	fmt.Printf("Gas限制: %d\n", gasLimit)
-
-	// 获取建议的Gas价格
-	// Gas价格决定了交易的优先级，价格越高越容易被矿工打包
-	gasPrice, err := client.SuggestGasPrice(context.Background())
-	if err != nil {
-		log.Fatal("获取Gas价格失败:", err)
-	}
-	fmt.Printf("建议Gas价格: %s Wei\n", gasPrice.String())
-
 	// 接收方地址
 	toAddress := common.HexToAddress("0x4592d8f8d7b001e72cb26a73e4fa1806a51ac79d")
 	fmt.Printf("✓ 接收方地址: %s\n\n", toAddress.Hex())
 
-	// ===== 第6步：创建交易 =====
-	// 对于ETH转账，data字段为空
-	var data []byte
-	// 创建新的交易对象
-	tx := types.NewTransaction(nonce, toAddress, value, gasLimit, gasPrice, data)
-	fmt.Println("✓ 交易对象创建成功")
-
-	// ===== 第7步：获取网络ID并签名交易 =====
-	// 获取网络ID（链ID），用于EIP155签名防止重放攻击
-	chainID, err := client.NetworkID(context.Background())
+	// ===== 第4步：创建交易、模拟执行并确认 =====
+	// transfer.SendETH 依次完成：获取nonce（PendingNonceAt）、获取建议Gas价格、
+	// 以21000的Gas限制创建交易、用链ID做EIP155签名、广播交易
+	// 设置了 Confirm 时，签名前先模拟执行交易，显示余额变化和手续费，确认后才签名发送
+	sim, err := simulate.New(client)
 	if err != nil {
-		log.Fatal("获取网络ID失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("✓ 网络ID: %s\n", chainID.String())
-
-	// 使用EIP155签名器对交易进行签名
-	// EIP155是以太坊改进提案，用于防止跨链重放攻击
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		log.Fatal("交易签名失败:", err)
+	var in io.Reader // -yes 时为 nil，不询问
+	if !*yes {
+		in = os.Stdin
+	}
+	opts := &backend.TxOptions{Confirm: sim.Confirm(*dryRun, *force, in, os.Stdout)}
+	result, err := transfer.SendETH(context.Background(), client, signer, toAddress, value, opts)
+	if errors.Is(err, simulate.ErrDryRun) || errors.Is(err, simulate.ErrCancelled) {
+		fmt.Println(err)
+		return
+	}
+	if errors.Is(err, simulate.ErrWillFail) {
+		log.Fatalf("%v（仍要发送请加 -force）", err)
 	}
-	fmt.Println("✓ 交易签名成功")
-
-	// ===== 第8步：发送交易到网络 =====
-	// 将签名后的交易广播到以太坊网络
-	err = client.SendTransaction(context.Background(), signedTx)
 	if err != nil {
-		log.Fatal("发送交易失败:", err)
+		log.Fatal(err)
 	}
 
-	// ===== 第9步：显示交易结果 =====
+	// ===== 第5步：显示交易结果 =====
 	fmt.Println("\n=== 交易发送成功 ===")
-	fmt.Printf("交易哈希: %s\n", signedTx.Hash().Hex())
+	fmt.Printf("交易哈希: %s\n", result.Tx.Hash().Hex())
 	fmt.Println("\n=== 交易详情 ===")
-	fmt.Printf("发送方: %s\n", fromAddress.Hex())
-	fmt.Printf("接收方: %s\n", toAddress.Hex())
-	fmt.Printf("金额: %s Wei (1 ETH)\n", value.String())
-	fmt.Printf("Gas限制: %d\n", gasLimit)
-	fmt.Printf("Gas价格: %s Wei\n", gasPrice.String())
-	fmt.Printf("Nonce: %d\n", nonce)
-	fmt.Printf("网络ID: %s\n", chainID.String())
+	fmt.Printf("发送方: %s\n", result.From.Hex())
+	fmt.Printf("接收方: %s\n", result.To.Hex())
+	fmt.Printf("金额: %s Wei (1 ETH)\n", result.Amount.String())
+	fmt.Printf("Gas限制: %d\n", result.GasLimit)
+	fmt.Printf("Gas价格: %s Wei\n", result.GasPrice.String())
+	fmt.Printf("Nonce: %d\n", result.Nonce)
+	fmt.Printf("链ID: %s\n", result.Tx.ChainId().String())
 
 	fmt.Println("\n=== 重要提醒 ===")
 	fmt.Println("1. 这是示例代码，使用的是测试网络")
@@ -156,4 +129,8 @@ func main() {
 	//    - 发送成功只表示交易进入内存池
 	//    - 需要等待矿工打包确认才算真正完成
 	//    - 可以通过交易哈希查询确认状态
-}
\ No newline at end of file
+	//
+	// 6. 发送前模拟：
+	//    - 节点支持 debug_traceCall 时模拟得到实际的余额变化，否则用 eth_call 检查能否执行
+	//    - 模拟基于最新区块的状态，交易实际打包时状态可能已经变化
+}
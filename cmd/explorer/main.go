@@ -0,0 +1,85 @@
+// 区块浏览器
+// 本程序启动一个本地 HTTP 服务，以网页和 REST 接口展示区块、交易、地址和代币信息，
+// 数据与 query-block、query-transaction、query-receipt 一样实时从节点查询
+//
+//	go run ./cmd/explorer -abi 0x代币地址=MyToken:token.abi.json
+//
+// 浏览器打开 http://127.0.0.1:8080 查看网页；程序中请求同样的路径（或加 ?format=json）得到 JSON：
+//
+//	curl http://127.0.0.1:8080/tx/0x交易哈希
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/explorer"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8080", "监听地址")
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-sepolia.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	abis := flag.String("abi", "", "已知合约的 ABI，格式为 地址=名称:ABI文件，多个用逗号分隔")
+	flag.Parse()
+
+	fmt.Println("=== 区块浏览器 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	conn, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 已确定的区块、交易和收据用磁盘缓存，重复打开页面时不再请求节点
+	store, err := cache.NewDiskStore(filepath.Join(os.TempDir(), "eth-cache"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := cache.Wrap(conn, cache.WithStore(store))
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：登记已知合约的 ABI =====
+	// 登记后，发往这些合约的交易和它们产生的日志会按 ABI 解码；
+	// 未登记的合约按通用 ERC20 ABI 尝试解码
+	var opts []explorer.Option
+	if *abis != "" {
+		for _, item := range strings.Split(*abis, ",") {
+			addr, rest, ok1 := strings.Cut(item, "=")
+			name, path, ok2 := strings.Cut(rest, ":")
+			if !ok1 || !ok2 || !common.IsHexAddress(addr) {
+				log.Fatalf("无效的 -abi 参数 %q，格式为 地址=名称:ABI文件", item)
+			}
+			abiJSON, err := os.ReadFile(path)
+			if err != nil {
+				log.Fatal("读取 ABI 文件失败:", err)
+			}
+			opts = append(opts, explorer.WithContract(common.HexToAddress(addr), name, string(abiJSON)))
+			fmt.Printf("已登记合约: %s %s\n", name, addr)
+		}
+	}
+	srv, err := explorer.New(client, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ===== 第3步：启动服务 =====
+	fmt.Printf("✓ 浏览器已启动: http://%s\n", *listen)
+	fmt.Println("接口: /block/{区块号|哈希|latest}、/tx/{哈希}、/address/{地址}、/token/{地址}?holder={地址}")
+	log.Fatal(http.ListenAndServe(*listen, srv))
+
+	// 小白说明：
+	// 1. 同一个路径既是网页也是接口：浏览器请求时返回网页，程序请求时返回 JSON
+	// 2. calldata 是交易的输入数据，前 4 字节（方法选择器）决定调用哪个方法，其余是 ABI 编码的参数
+	// 3. 事件日志的 topics[0] 是事件签名哈希，indexed 参数在其余 topics 中，其他参数在 data 中
+	// 4. 浏览器不建立索引，所以无法列出一个地址的全部交易，这需要专门的索引服务
+}
@@ -0,0 +1,110 @@
+// Gas 费用报告工具
+// 本程序通过 eth_feeHistory 展示当前基础费、优先费百分位、区块 Gas 使用率趋势和基础费的短期预测，
+// 并可以为一笔具体的调用估算 Gas 上限（含安全余量）和 slow / normal / fast 三档的总费用
+//
+//	go run ./cmd/gas
+//	go run ./cmd/gas -from 0x发送方 -to 0x接收方 -value 1000000000000000000
+//	go run ./cmd/gas -from 0x发送方 -to 0x代币 -sig "transfer(address,uint256)" -args 0x接收方,1000 -margin 0.3
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/gas"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	blocks := flag.Uint64("blocks", gas.DefaultBlocks, "统计最近多少个区块")
+	forecast := flag.Int("forecast", gas.DefaultForecast, "预测多少个区块的基础费")
+	margin := flag.Float64("margin", gas.DefaultMargin, "Gas 上限在估算值上增加的比例")
+	from := flag.String("from", "", "估算调用的发送方地址")
+	to := flag.String("to", "", "估算调用的接收方或合约地址，不指定时只显示费用报告")
+	value := flag.String("value", "", "随调用发送的 ETH（Wei）")
+	data := flag.String("data", "", "0x 开头的调用数据，与 -sig 二选一")
+	sig := flag.String("sig", "", "方法签名，如 transfer(address,uint256)")
+	args := flag.String("args", "", "方法参数，多个用逗号分隔")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== Gas 费用报告 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：生成费用报告 =====
+	// eth_feeHistory 一次返回多个区块的基础费、Gas 使用率和优先费百分位
+	report, err := gas.NewReport(ctx, client, gas.WithBlocks(*blocks), gas.WithForecast(*forecast), gas.WithMargin(*margin))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println()
+	fmt.Print(report)
+
+	// ===== 第3步：估算具体调用的费用 =====
+	if *to == "" {
+		return
+	}
+	if !common.IsHexAddress(*to) || (*from != "" && !common.IsHexAddress(*from)) {
+		log.Fatal("无效的 -from 或 -to 地址")
+	}
+	target := common.HexToAddress(*to)
+	msg := ethereum.CallMsg{From: common.HexToAddress(*from), To: &target}
+	if *value != "" {
+		v, ok := new(big.Int).SetString(*value, 0)
+		if !ok {
+			log.Fatalf("无效的 -value %q", *value)
+		}
+		msg.Value = v
+	}
+	switch {
+	case *sig != "":
+		var list []string
+		if *args != "" {
+			list = strings.Split(*args, ",")
+		}
+		if msg.Data, err = calldata.Encode(*sig, list...); err != nil {
+			log.Fatal(err)
+		}
+	case *data != "":
+		if msg.Data, err = hexutil.Decode(*data); err != nil {
+			log.Fatal("无效的 -data: ", err)
+		}
+	}
+	est, err := report.Estimate(ctx, client, msg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("\n=== 调用费用估算 ===")
+	fmt.Print(est)
+
+	// 小白说明：
+	// 1. 交易费 = Gas 消耗 × (基础费 + 优先费)，基础费被销毁，优先费给出块者
+	// 2. 优先费越高越容易被尽快打包；slow / normal / fast 分别参考最近区块中第 10 / 50 / 90 百分位的出价
+	// 3. 最大费用（maxFeePerGas）是愿意支付的单价上限，实际只按当时的基础费 + 优先费收取，多出的部分不会扣除
+	// 4. Gas 上限给估算值留出余量，防止执行时状态变化导致 Gas 不足；没用完的 Gas 不收费
+	//
+	// 技术说明：
+	// 1. 基础费由上一个区块的使用率决定：高于 50% 上涨、低于 50% 下降，每个区块最多变化 12.5%，
+	//    所以下一个区块的基础费是确定的，更远的预测按平均使用率外推，仅供参考
+	// 2. maxFeePerGas 按期望打包的区块数预留基础费的最大涨幅：fast 1 个区块、normal 3 个、slow 6 个
+	// 3. 统计优先费时忽略空区块；节点不支持 eth_feeHistory 时退回区块头和 eth_maxPriorityFeePerGas
+	// 4. 普通 ETH 转账的 Gas 固定为 21000，不加余量
+}
@@ -0,0 +1,303 @@
+// 合约绑定生成工具
+// 本程序编译 contracts/ 目录下的所有 Solidity 源文件，
+// 并为其中每个可部署的合约生成类型化的 Go 绑定（输出到 pkg/bindings）
+//
+// 通常不直接运行，而是通过 pkg/bindings 中的 go:generate 指令调用：
+//
+//	go generate ./pkg/bindings/...
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+// pinnedSolcVersion 固定使用的 solc 版本
+// 只有编译器版本与之一致时才会重新编译，避免不同版本产生不同的字节码
+const pinnedSolcVersion = "0.8.21"
+
+// evmVersion 编译目标的 EVM 版本
+// 0.8.20 起默认目标为 shanghai，会使用 PUSH0；go-ethereum 的模拟后端（以及部分 L2）不支持，
+// 固定为 paris 保证同一份字节码在模拟链和各条公链上都能部署
+const evmVersion = "paris"
+
+// canonical 不重新编译的源文件，直接使用已提交的产物
+// Multicall3 使用各公链上 0xcA11bde05977b3631167028862bE2a173976CA11 部署的字节码（solc 0.8.12），
+// 重新编译会得到与链上已部署的合约不一致的字节码
+var canonical = map[string]bool{"Multicall3.sol": true}
+
+// soljsonDriver 用 node 运行 soljson（solc 的 Emscripten 构建）的最小驱动：
+// 带 --version 参数时输出版本号，否则从标准输入读取 standard JSON 并输出编译结果
+const soljsonDriver = `
+const solc = require(process.argv[1]);
+if (process.argv[2] === "--version") {
+  console.log(solc.cwrap("solidity_version", "string", [])());
+  process.exit(0);
+}
+let input = "";
+process.stdin.on("data", (d) => (input += d));
+process.stdin.on("end", () => process.stdout.write(solc.cwrap("solidity_compile", "string", ["string", "number", "number"])(input, 0, 0)));
+`
+
+func main() {
+	contractsDir := flag.String("contracts", "contracts", "Solidity 源文件目录")
+	outDir := flag.String("out", "pkg/bindings", "Go 绑定输出目录")
+	solcPath := flag.String("solc", "solc", "solc 可执行文件路径")
+	soljson := flag.String("soljson", "", "soljson-v"+pinnedSolcVersion+"+commit.*.js 的路径，没有原生 solc 时用 node 运行它编译")
+	requireSolc := flag.Bool("require-solc", false, "找不到 solc "+pinnedSolcVersion+" 时失败，而不是使用已提交的产物")
+	flag.Parse()
+
+	// ===== 第1步：查找 Solidity 源文件 =====
+	sources, err := filepath.Glob(filepath.Join(*contractsDir, "*.sol"))
+	if err != nil {
+		log.Fatal("查找 Solidity 源文件失败:", err)
+	}
+	if len(sources) == 0 {
+		log.Fatalf("目录 %s 中没有 Solidity 源文件", *contractsDir)
+	}
+	sort.Strings(sources)
+
+	// ===== 第2步：检查编译器 =====
+	// 找不到固定版本的 solc 时，直接使用 contracts/build 中已提交的编译产物
+	buildDir := filepath.Join(*contractsDir, "build")
+	solc := findCompiler(*solcPath, *soljson)
+	if solc == nil {
+		if *requireSolc {
+			log.Fatalf("未找到 solc %s", pinnedSolcVersion)
+		}
+		fmt.Printf("未找到 solc %s，使用 %s 中已提交的编译产物\n", pinnedSolcVersion, buildDir)
+	}
+
+	for _, source := range sources {
+		artifact := filepath.Join(buildDir, strings.TrimSuffix(filepath.Base(source), ".sol")+".json")
+
+		// ===== 第3步：编译源文件 =====
+		if solc != nil && !canonical[filepath.Base(source)] {
+			if err := solc.compile(*contractsDir, source, artifact); err != nil {
+				log.Fatalf("编译 %s 失败: %v", source, err)
+			}
+			fmt.Printf("✓ 已编译 %s -> %s\n", source, artifact)
+		} else if err := checkArtifact(artifact, canonical[filepath.Base(source)]); err != nil {
+			fmt.Printf("⚠ %v\n", err)
+		}
+
+		// ===== 第4步：生成 Go 绑定 =====
+		if err := generate(artifact, *outDir); err != nil {
+			log.Fatalf("为 %s 生成绑定失败: %v", artifact, err)
+		}
+	}
+}
+
+// solcCompiler 以 standard JSON 方式调用的编译器，原生 solc 和 node + soljson 使用同一套输入输出
+type solcCompiler struct {
+	args    []string // 编译时执行的命令，标准输入为 standard JSON
+	version string   // 完整版本号，例如 0.8.21+commit.d9974bed.Linux.g++
+}
+
+// findCompiler 依次尝试 PATH 上的 solc 和 soljson，返回版本与 pinnedSolcVersion 一致的编译器
+func findCompiler(solcPath, soljson string) *solcCompiler {
+	if out, err := exec.Command(solcPath, "--version").Output(); err == nil {
+		// 输出形如 "Version: 0.8.21+commit.d9974bed.Linux.g++"
+		_, version, _ := strings.Cut(string(out), "Version: ")
+		version = strings.TrimSpace(version)
+		if strings.HasPrefix(version, pinnedSolcVersion+"+") {
+			return &solcCompiler{args: []string{solcPath, "--standard-json"}, version: version}
+		}
+		fmt.Printf("solc 版本不匹配（需要 %s）:\n%s", pinnedSolcVersion, out)
+	}
+	if soljson == "" {
+		return nil
+	}
+	abs, err := filepath.Abs(soljson)
+	if err != nil {
+		return nil
+	}
+	out, err := exec.Command("node", "-e", soljsonDriver, abs, "--version").Output()
+	if err != nil {
+		fmt.Printf("用 node 运行 %s 失败: %v\n", soljson, err)
+		return nil
+	}
+	version := strings.TrimSpace(string(out))
+	if !strings.HasPrefix(version, pinnedSolcVersion+"+") {
+		fmt.Printf("soljson 版本不匹配（需要 %s）: %s\n", pinnedSolcVersion, version)
+		return nil
+	}
+	return &solcCompiler{args: []string{"node", "-e", soljsonDriver, abs}, version: version}
+}
+
+// checkArtifact 检查已提交的产物是否由固定版本的 solc 生成
+// canonical 的产物只要求记录了编译器版本
+func checkArtifact(artifact string, canonical bool) error {
+	data, err := os.ReadFile(artifact)
+	if err != nil {
+		return err
+	}
+	var meta struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fmt.Errorf("解析 %s 失败: %v", artifact, err)
+	}
+	switch {
+	case canonical && meta.Version != "":
+		return nil
+	case !strings.HasPrefix(meta.Version, pinnedSolcVersion+"+"):
+		return fmt.Errorf("%s 不是 solc %s 的产物（version 为 %q），请安装该版本后重新生成", artifact, pinnedSolcVersion, meta.Version)
+	}
+	return nil
+}
+
+// standardOutput solc standard JSON 输出中用到的部分
+type standardOutput struct {
+	Errors []struct {
+		Severity         string `json:"severity"`
+		FormattedMessage string `json:"formattedMessage"`
+	} `json:"errors"`
+	Contracts map[string]map[string]struct {
+		ABI           json.RawMessage `json:"abi"`
+		StorageLayout json.RawMessage `json:"storageLayout"`
+		EVM           struct {
+			Bytecode          struct{ Object string } `json:"bytecode"`
+			DeployedBytecode  struct{ Object string } `json:"deployedBytecode"`
+			MethodIdentifiers map[string]string       `json:"methodIdentifiers"`
+		} `json:"evm"`
+	} `json:"contracts"`
+}
+
+// combinedContract solc --combined-json abi,bin,bin-runtime,hashes,storage-layout 中单个合约的格式
+type combinedContract struct {
+	ABI           json.RawMessage   `json:"abi"`
+	Bin           string            `json:"bin"`
+	BinRuntime    string            `json:"bin-runtime"`
+	Hashes        map[string]string `json:"hashes"`
+	StorageLayout json.RawMessage   `json:"storage-layout"`
+}
+
+// compile 编译单个源文件，并按 combined-json 的格式写入 artifact
+// 源文件以 <contracts 目录名>/<文件名> 登记，合约全名与 solc --base-path 的结果一致
+func (c *solcCompiler) compile(baseDir, source, artifact string) error {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	unit := filepath.ToSlash(filepath.Join(filepath.Base(baseDir), filepath.Base(source)))
+	input, err := json.Marshal(map[string]interface{}{
+		"language": "Solidity",
+		"sources":  map[string]interface{}{unit: map[string]string{"content": string(content)}},
+		"settings": map[string]interface{}{
+			"optimizer":  map[string]interface{}{"enabled": true, "runs": 200},
+			"evmVersion": evmVersion,
+			"outputSelection": map[string]interface{}{"*": map[string][]string{"*": {
+				"abi", "evm.bytecode.object", "evm.deployedBytecode.object", "evm.methodIdentifiers", "storageLayout",
+			}}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(c.args[0], c.args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = bytes.NewReader(input), &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	var output standardOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return fmt.Errorf("解析编译结果失败: %v", err)
+	}
+	var problems []string
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			problems = append(problems, e.FormattedMessage)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+
+	combined := map[string]combinedContract{}
+	for file, contracts := range output.Contracts {
+		for name, out := range contracts {
+			combined[file+":"+name] = combinedContract{
+				ABI:           out.ABI,
+				Bin:           out.EVM.Bytecode.Object,
+				BinRuntime:    out.EVM.DeployedBytecode.Object,
+				Hashes:        out.EVM.MethodIdentifiers,
+				StorageLayout: out.StorageLayout,
+			}
+		}
+	}
+	// 缩进输出，保证提交到仓库中的产物便于比较差异
+	data, err := json.MarshalIndent(map[string]interface{}{"contracts": combined, "version": c.version}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(artifact), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(artifact, append(data, '\n'), 0o644)
+}
+
+// generate 为编译产物中的每个合约生成一个独立的绑定包
+// 包名为合约名的小写形式，例如 MyToken -> pkg/bindings/mytoken
+func generate(artifact, outDir string) error {
+	data, err := os.ReadFile(artifact)
+	if err != nil {
+		return err
+	}
+	contracts, err := compiler.ParseCombinedJSON(data, "", "", "", "")
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(contracts))
+	for name := range contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		contract := contracts[name]
+		// 接口和抽象合约没有字节码，不单独生成绑定
+		if contract.Code == "" || contract.Code == "0x" {
+			continue
+		}
+		// 完整名称格式为 <源文件路径>:<合约名>
+		typeName := name[strings.LastIndex(name, ":")+1:]
+		pkg := strings.ToLower(typeName)
+
+		abiJSON, err := json.Marshal(contract.Info.AbiDefinition)
+		if err != nil {
+			return fmt.Errorf("解析 %s 的 ABI 失败: %v", name, err)
+		}
+		code, err := bind.Bind([]string{typeName}, []string{string(abiJSON)}, []string{contract.Code},
+			[]map[string]string{contract.Hashes}, pkg, bind.LangGo, nil, nil)
+		if err != nil {
+			return fmt.Errorf("生成 %s 的绑定失败: %v", name, err)
+		}
+
+		target := filepath.Join(outDir, pkg, pkg+".go")
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(code), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("✓ %s -> %s\n", name, target)
+	}
+	return nil
+}
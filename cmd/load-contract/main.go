@@ -1,12 +1,15 @@
 package main
 
 import (
-	"fmt" // 格式化输入输出
-	"log" // 日志记录
+	"context" // 上下文管理
+	"fmt"     // 格式化输入输出
+	"log"     // 日志记录
 
-	"github.com/ethereum/go-ethereum/common"    // 以太坊通用类型
-	"github.com/ethereum/go-ethereum/ethclient"  // 以太坊客户端
-	// "github.com/learn/init_order/store" // 智能合约绑定包（需要根据实际情况调整）
+	"github.com/ethereum/go-ethereum/common" // 以太坊通用类型
+
+	"github.com/duanyu/new-eth-project/pkg/backend"                // 以太坊后端接口
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplestorage" // SimpleStorage 合约绑定
+	"github.com/duanyu/new-eth-project/pkg/contract"               // 合约加载功能库
 )
 
 // 合约地址常量
@@ -22,9 +25,9 @@ func main() {
 	// 步骤1：连接到以太坊网络
 	// 这里使用本地Ganache网络作为示例（端口7545）
 	// 在实际使用中，您可以连接到测试网或主网
-	client, err := ethclient.Dial("http://127.0.0.1:7545")
+	client, err := backend.Dial("http://127.0.0.1:7545")
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("成功连接到以太坊网络")
 
@@ -33,56 +36,46 @@ func main() {
 	contractAddress := common.HexToAddress(contractAddr)
 	fmt.Printf("合约地址: %s\n", contractAddress.Hex())
 
-	// 步骤3：检查合约是否存在
-	// 通过获取合约地址的字节码来验证合约是否已部署
-	bytecode, err := client.CodeAt(nil, contractAddress, nil)
-	if err != nil {
-		log.Fatal("获取合约字节码失败:", err)
-	}
-
-	if len(bytecode) == 0 {
-		log.Fatal("指定地址没有部署合约")
+	// 步骤3：检查合约是否存在并解析ABI
+	// contract.Load 通过获取合约地址的字节码来验证合约是否已部署，
+	// 地址上没有代码时返回 contract.ErrNoCode
+	if _, err := contract.Load(context.Background(), client, contractAddress, simplestorage.SimpleStorageMetaData.ABI); err != nil {
+		log.Fatal(err)
 	}
-	fmt.Printf("合约字节码长度: %d bytes\n", len(bytecode))
 	fmt.Println("合约验证成功，合约已部署")
 
-	// 步骤4：创建合约实例（需要合约绑定）
-	// 注意：以下代码需要先生成合约的Go绑定文件
-	// 使用abigen工具生成：abigen --abi=Store.abi --pkg=store --out=store.go
-	
-	// 由于合约绑定文件可能不存在，这里注释掉实际的合约实例化代码
-	// 并提供详细的使用说明
-	
-	/*
-	// 创建合约实例
-	storeContract, err := store.NewStore(contractAddress, client)
+	// 步骤4：创建合约实例
+	// pkg/bindings 中的绑定由 go generate ./pkg/bindings/... 根据 contracts/ 生成
+	storeContract, err := simplestorage.NewSimpleStorage(contractAddress, client)
 	if err != nil {
 		log.Fatal("创建合约实例失败:", err)
 	}
 	fmt.Println("合约实例创建成功")
-	
+
 	// 现在可以使用storeContract来调用合约方法
-	// 例如：
-	// result, err := storeContract.SomeMethod(nil) // 调用只读方法
-	// 或者：
-	// tx, err := storeContract.SomeWriteMethod(opts, param1, param2) // 调用写入方法
-	*/
+	value, err := storeContract.Get(nil) // 调用只读方法
+	if err != nil {
+		log.Fatal("调用合约方法失败:", err)
+	}
+	fmt.Printf("当前存储的值: %s\n", value.String())
+	// 调用写入方法需要交易签名参数，例如：
+	// tx, err := storeContract.Set(opts, big.NewInt(42))
 
 	fmt.Println("\n=== 合约加载完成 ===")
 	fmt.Println("合约已成功加载并验证")
 	fmt.Println("\n使用说明：")
-	fmt.Println("1. 要与合约交互，需要先生成合约的Go绑定文件")
-	fmt.Println("2. 使用abigen工具：abigen --abi=YourContract.abi --pkg=yourpackage --out=contract.go")
-	fmt.Println("3. 导入生成的包并创建合约实例")
+	fmt.Println("1. 把合约源码放到 contracts/ 目录下")
+	fmt.Println("2. 运行 go generate ./pkg/bindings/... 生成Go绑定")
+	fmt.Println("3. 导入 pkg/bindings 下生成的包并创建合约实例")
 	fmt.Println("4. 然后就可以调用合约的方法了")
 
 	// 小白说明：
 	// 1. 合约地址是合约部署后的唯一标识符
 	// 2. 连接网络后需要验证合约是否真的存在于该地址
 	// 3. 合约绑定是Go代码与智能合约交互的桥梁
-	// 4. abigen是以太坊官方提供的工具，用于生成Go绑定代码
+	// 4. cmd/gen-bindings 基于以太坊官方的abigen生成Go绑定代码
 	// 5. ABI（Application Binary Interface）描述了合约的接口
 	// 6. 只读方法不需要gas费用，写入方法需要发送交易
 	// 7. 本地网络（如Ganache）适合开发和测试
 	// 8. 生产环境建议使用Infura等服务提供商的节点
-}
\ No newline at end of file
+}
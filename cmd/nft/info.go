@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/nft"
+)
+
+// 注意：请替换<API_KEY>为您的实际API密钥
+const defaultRPC = "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>"
+
+// info 查询合约信息和 token 的持有、授权情况
+func info(args []string) {
+	fs := flag.NewFlagSet("nft info", flag.ExitOnError)
+	rpcURL := fs.String("rpc", defaultRPC, "节点地址")
+	collection := fs.String("nft", "", "ERC721 合约地址")
+	id := fs.String("id", "", "要查询的 tokenId（十进制）")
+	owner := fs.String("owner", "", "查询该地址的 balanceOf")
+	operator := fs.String("operator", "", "与 -owner 一起使用，查询 isApprovedForAll(owner, operator)")
+	block := blocktag.Selector{Kind: blocktag.Latest}
+	fs.Var(&block, "block", "查询的区块：latest、safe、finalized、区块号、-N 或 ISO 时间")
+	fs.Parse(args)
+	ctx := context.Background()
+
+	addr := mustAddress("nft", *collection)
+	fmt.Println("=== NFT 查询 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+	number, err := block.BlockNumber(ctx, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ===== 第2步：合约信息（ERC165 接口检测）=====
+	c, err := nft.Inspect(ctx, client, addr, number)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n合约: %s\n", c.Address.Hex())
+	fmt.Printf("名称: %s (%s)\n", c.Name, c.Symbol)
+	fmt.Printf("ERC721Metadata: %s，ERC721Enumerable: %s\n", yesNo(c.Metadata), yesNo(c.Enumerable))
+
+	// ===== 第3步：token 的持有人、授权和 tokenURI =====
+	if *id != "" {
+		tokenID := mustTokenID(*id)
+		token, err := nft.TokenInfo(ctx, client, addr, tokenID, number)
+		if errors.Is(err, nft.ErrNoToken) {
+			fmt.Printf("\ntoken #%s 不存在（未铸造或已销毁）\n", tokenID)
+		} else if err != nil {
+			log.Fatal(err)
+		} else {
+			fmt.Printf("\n=== token #%s ===\n", tokenID)
+			fmt.Printf("持有人: %s\n", token.Owner.Hex())
+			if token.Approved == (common.Address{}) {
+				fmt.Println("单独授权: 无")
+			} else {
+				fmt.Printf("单独授权: %s\n", token.Approved.Hex())
+			}
+			switch {
+			case !c.Metadata:
+				fmt.Println("tokenURI: 合约不支持 ERC721Metadata")
+			case token.URI == "":
+				fmt.Println("tokenURI: （空）")
+			default:
+				fmt.Printf("tokenURI: %s\n", token.URI)
+				printMetadata(token.URI)
+			}
+		}
+	}
+
+	// ===== 第4步：地址的持有数量和全部授权 =====
+	if *owner != "" {
+		holder := mustAddress("owner", *owner)
+		balance, err := nft.Balance(ctx, client, addr, holder, number)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("\n%s 持有 %s 个 token\n", holder.Hex(), balance)
+		if *operator != "" {
+			op := mustAddress("operator", *operator)
+			approved, err := nft.IsApprovedForAll(ctx, client, addr, holder, op, number)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s 管理其全部 token 的授权: %s\n", op.Hex(), yesNo(approved))
+		}
+	}
+
+	// ===== 第5步：确认区块仍在规范链上 =====
+	// -block 为区块哈希时以上查询按区块号进行，期间该区块被重组掉就会读到另一条分叉的状态
+	if err := block.Verify(ctx, client, number); err != nil {
+		log.Fatal(err)
+	}
+
+	// 小白说明：
+	// 1. NFT（ERC721）的每个 token 都有唯一的 tokenId 和一个持有人，不能像 ERC20 那样拆分
+	// 2. 持有人可以把单个 token 授权给某个地址（approve），也可以把全部 token 授权给操作员（setApprovalForAll），
+	//    交易市场通常使用后一种
+	// 3. tokenURI 指向描述 token 的元数据 JSON（名称、图片、属性），是可选的 ERC721Metadata 扩展
+	//
+	// 技术说明：
+	// 1. 合约通过 ERC165 的 supportsInterface 声明支持的接口：ERC721 为 0x80ac58cd，
+	//    ERC721Metadata 为 0x5b5e139f，ERC721Enumerable 为 0x780e9d63
+	// 2. 不存在的 token 按标准 ownerOf 必须回滚，这里显示为“不存在”
+}
+
+// printMetadata 读取并校验 file:// 或 data: 元数据，其他协议只提示
+func printMetadata(uri string) {
+	m, err := nft.FetchMetadata(uri)
+	switch {
+	case errors.Is(err, nft.ErrUnsupportedURI):
+		fmt.Println("（元数据不在 file:// 或 data: URI 中，未读取）")
+		return
+	case errors.Is(err, nft.ErrInvalidMetadata) && m != nil:
+		fmt.Printf("⚠ %v\n", err)
+	case err != nil:
+		fmt.Printf("⚠ 读取元数据失败: %v\n", err)
+		return
+	default:
+		fmt.Println("✓ 元数据符合规范")
+	}
+	fmt.Printf("  name: %s\n", m.Name)
+	if m.Description != "" {
+		fmt.Printf("  description: %s\n", m.Description)
+	}
+	if m.Image != "" {
+		fmt.Printf("  image: %s\n", m.Image)
+	}
+	for _, attr := range m.Attributes {
+		fmt.Printf("  %s: %v\n", attr.TraitType, attr.Value)
+	}
+}
+
+// metadata 读取并校验元数据；给出 -nft 和 -id 时先在链上查询 tokenURI
+func metadata(args []string) {
+	fs := flag.NewFlagSet("nft metadata", flag.ExitOnError)
+	uri := fs.String("uri", "", "元数据 URI（file:// 或 data:），与 -nft / -id 二选一")
+	rpcURL := fs.String("rpc", defaultRPC, "节点地址")
+	collection := fs.String("nft", "", "ERC721 合约地址，查询 tokenURI")
+	id := fs.String("id", "", "tokenId（十进制）")
+	fs.Parse(args)
+
+	if *uri == "" {
+		if *collection == "" || *id == "" {
+			log.Fatal("请用 -uri 指定元数据 URI，或用 -nft 和 -id 从链上查询 tokenURI")
+		}
+		client, err := backend.Dial(*rpcURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *uri, err = nft.TokenURI(context.Background(), client, mustAddress("nft", *collection), mustTokenID(*id), nil); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("tokenURI: %s\n", *uri)
+	}
+	if _, err := nft.ReadURI(*uri); err != nil {
+		log.Fatal(err)
+	}
+	printMetadata(*uri)
+
+	// 技术说明：
+	// 1. data: URI 常见于完全链上生成的 NFT，格式为 data:application/json;base64,<数据>
+	// 2. 校验规则：必须是 JSON 对象，name 为非空字符串，image 等链接使用 https、ipfs、ar、data 等协议，
+	//    attributes 中每一项的 value 为字符串、数字或布尔值
+	// 3. ipfs:// 和 https:// 的元数据需要联网获取，这里不读取
+}
+
+func mustAddress(name, s string) common.Address {
+	if !common.IsHexAddress(s) {
+		log.Fatalf("请用 -%s 指定有效的地址", name)
+	}
+	return common.HexToAddress(s)
+}
+
+func mustTokenID(s string) *big.Int {
+	id, ok := new(big.Int).SetString(s, 10)
+	if !ok || id.Sign() < 0 {
+		log.Fatalf("无效的 tokenId %q", s)
+	}
+	return id
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "是"
+	}
+	return "否"
+}
@@ -0,0 +1,47 @@
+// NFT（ERC721）工具
+// 第一个参数为子命令：
+//
+//	info      查询合约信息、token 的持有人、授权和 tokenURI，以及地址的 balanceOf 和 isApprovedForAll
+//	owned     回放 Transfer 日志，列出一个地址持有的全部 token
+//	transfer  用 safeTransferFrom 转移 token，发送前检查授权和接收方合约的 onERC721Received
+//	metadata  读取并校验 tokenURI 指向的元数据 JSON（file:// 或 data:）
+//
+//	go run ./cmd/nft info -nft 0x... -id 1 -owner 0x...
+//	go run ./cmd/nft owned -nft 0x... -owner 0x...
+//	go run ./cmd/nft transfer -nft 0x... -id 1 -to 0x... -key <私钥>
+//	go run ./cmd/nft metadata -uri file:///tmp/1.json
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	args := os.Args[2:]
+	switch os.Args[1] {
+	case "info":
+		info(args)
+	case "owned":
+		owned(args)
+	case "transfer":
+		transfer(args)
+	case "metadata":
+		metadata(args)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法: nft <子命令> [参数]")
+	fmt.Fprintln(os.Stderr, "  info      合约信息、token 持有人、授权和 tokenURI")
+	fmt.Fprintln(os.Stderr, "  owned     回放 Transfer 日志，列出地址持有的全部 token")
+	fmt.Fprintln(os.Stderr, "  transfer  safeTransferFrom 转移 token（检查 onERC721Received）")
+	fmt.Fprintln(os.Stderr, "  metadata  读取并校验元数据 JSON（file:// 或 data:）")
+	fmt.Fprintln(os.Stderr, "使用 nft <子命令> -h 查看子命令的参数")
+	os.Exit(2)
+}
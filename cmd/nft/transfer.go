@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/nft"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/simulate"
+)
+
+// transfer 用 safeTransferFrom 转移 token
+func transfer(args []string) {
+	fs := flag.NewFlagSet("nft transfer", flag.ExitOnError)
+	rpcURL := fs.String("rpc", "https://eth-sepolia.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	collection := fs.String("nft", "", "ERC721 合约地址")
+	id := fs.String("id", "", "要转移的 tokenId（十进制）")
+	to := fs.String("to", "", "接收地址")
+	from := fs.String("from", "", "token 当前的持有人，默认为发送方；以被授权者身份转移时指定")
+	key := fs.String("key", "", "发送方私钥（64 位十六进制）")
+	data := fs.String("data", "", "随 safeTransferFrom 传给接收方合约的数据")
+	dryRun := fs.Bool("dry-run", false, "只模拟交易并显示预期结果，不发送")
+	yes := fs.Bool("yes", false, "模拟后不询问，直接发送")
+	force := fs.Bool("force", false, "模拟执行失败时仍然发送（会消耗 Gas）")
+	fs.Parse(args)
+	ctx := context.Background()
+
+	addr := mustAddress("nft", *collection)
+	receiver := mustAddress("to", *to)
+	tokenID := mustTokenID(*id)
+
+	// ===== 第1步：连接以太坊网络并加载私钥 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	signer, err := backend.SignerFromHex(*key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	owner := signer.Address
+	if *from != "" {
+		owner = mustAddress("from", *from)
+	}
+	fmt.Printf("发送方: %s\n", signer.Address.Hex())
+	fmt.Printf("转移 token #%s: %s → %s\n", tokenID, owner.Hex(), receiver.Hex())
+
+	// ===== 第2步：检查授权和接收方、模拟并确认，再签名发送 =====
+	// 接收方是合约时先以 NFT 合约的身份调用它的 onERC721Received，
+	// 返回值不是 0x150b7a02 的合约收到 NFT 后无法转出，这里直接拒绝发送
+	sim, err := simulate.New(client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var in io.Reader // -yes 时为 nil，不询问
+	if !*yes {
+		in = os.Stdin
+	}
+	opts := &backend.TxOptions{Confirm: sim.Confirm(*dryRun, *force, in, os.Stdout)}
+	tx, err := nft.SafeTransfer(ctx, client, signer, addr, owner, receiver, tokenID, []byte(*data), opts)
+	switch {
+	case errors.Is(err, simulate.ErrDryRun), errors.Is(err, simulate.ErrCancelled):
+		fmt.Println(err)
+		return
+	case errors.Is(err, simulate.ErrWillFail):
+		log.Fatalf("%v（仍要发送请加 -force）", err)
+	case errors.Is(err, nft.ErrNotOwner), errors.Is(err, nft.ErrNotReceiver), errors.Is(err, nft.ErrNoToken):
+		log.Fatalf("交易未发送: %v", err)
+	case err != nil:
+		if reason := revert.NewDecoder(calldata.DefaultSignatures()).FromError(err); reason != nil {
+			log.Fatalf("转移会失败，交易未发送: %s", reason)
+		}
+		log.Fatal(err)
+	}
+	fmt.Printf("交易已发送: %s\n", tx.Hash().Hex())
+
+	// ===== 第3步：等待确认 =====
+	receipt, err := backend.WaitMined(ctx, client, tx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("✓ 已打包在区块 %s，Gas 消耗 %d\n", receipt.BlockNumber, receipt.GasUsed)
+
+	// 小白说明：
+	// 1. safeTransferFrom 与 transferFrom 的区别是：接收方是合约时会询问它能否处理 NFT，
+	//    避免 NFT 被转进一个无法再转出的合约而永久锁死
+	// 2. 只有持有人、被单独授权的地址或被授权管理全部 token 的操作员可以转移
+	// 3. 发送前会先模拟执行，确认结果后输入 y 发送；加 -dry-run 只看模拟结果
+}
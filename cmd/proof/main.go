@@ -0,0 +1,112 @@
+// Merkle 证明验证工具
+// 本程序通过 eth_getProof 获取账户和存储槽的 Merkle 证明，用区块头中的 stateRoot 验证账户证明，
+// 再用账户的 storageRoot 验证存储证明，输出经过验证的余额、nonce、codeHash 和存储值
+//
+//	# 验证账户余额和 nonce
+//	go run ./cmd/proof -address 0x账户
+//	# 验证原始存储槽
+//	go run ./cmd/proof -address 0x代币 -slot 0x2
+//	# 按存储布局计算槽位置（见 cmd/storage），并从另一个节点读取可信的区块头
+//	go run ./cmd/proof -address 0x代币 -var "_balances[0x持有人]" -header-rpc https://可信节点
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/proof"
+	"github.com/duanyu/new-eth-project/pkg/storage"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "提供 eth_getProof 的节点地址")
+	headerURL := flag.String("header-rpc", "", "提供区块头的节点地址，默认与 -rpc 相同")
+	address := flag.String("address", "", "账户或合约地址")
+	slots := flag.String("slot", "", "要验证的存储槽，多个用逗号分隔")
+	vars := flag.String("var", "", "按存储布局计算槽位置的表达式，如 _balances[0x..]，多个用逗号分隔")
+	artifact := flag.String("artifact", "contracts/build/MyToken.json", "-var 使用的编译产物（需包含 storage-layout）")
+	contract := flag.String("contract", "", "编译产物中的合约名，只有一个合约时可以省略")
+	var block blocktag.Selector
+	flag.Var(&block, "block", "在哪个区块上验证：latest、safe、finalized、区块号、区块哈希、-N 或 ISO 时间，默认最新区块（历史区块需要归档节点）")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== Merkle 证明验证工具 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	headers := backend.EthBackend(client)
+	if *headerURL != "" {
+		if headers, err = backend.Dial(*headerURL); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：确定要验证的存储槽 =====
+	if !common.IsHexAddress(*address) {
+		log.Fatal("请用 -address 指定账户地址")
+	}
+	var keys []common.Hash
+	if *slots != "" {
+		for _, s := range strings.Split(*slots, ",") {
+			keys = append(keys, common.HexToHash(strings.TrimSpace(s)))
+		}
+	}
+	if *vars != "" {
+		layout, err := storage.LoadArtifact(*artifact, *contract)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, expr := range strings.Split(*vars, ",") {
+			loc, err := layout.Locate(strings.TrimSpace(expr))
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s 位于槽 %s\n", loc.Path, loc.SlotHash().Hex())
+			keys = append(keys, loc.SlotHash())
+		}
+	}
+
+	// ===== 第3步：获取证明并验证 =====
+	// 区块由提供区块头的节点确定；待打包区块还没有确定的状态根，无法验证
+	if block.IsPending() {
+		log.Fatal("待打包区块没有确定的状态根，请使用 latest、safe 或 finalized")
+	}
+	number, err := block.BlockNumber(ctx, headers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p, err := proof.New(client.Client(), headers).Verified(ctx, common.HexToAddress(*address), keys, number)
+	if err != nil {
+		log.Fatal("验证失败: ", err)
+	}
+	if err := block.Verify(ctx, headers, number); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println()
+	fmt.Print(p)
+
+	// 小白说明：
+	// 1. 普通查询（eth_getBalance 等）只能相信节点返回的结果；Merkle 证明让你自己检查结果是否真的在链上状态中
+	// 2. 区块头里的 stateRoot 是整个以太坊状态的“指纹”，证明就是从这个指纹一路走到你的账户的路径
+	// 3. 只要区块头可信（例如用 -header-rpc 从另一个独立节点获取），数据节点就无法伪造余额或存储值
+	//
+	// 技术说明：
+	// 1. 账户证明：状态树中键为 keccak256(地址)，叶子为 RLP(nonce, balance, storageRoot, codeHash)
+	// 2. 存储证明：账户存储树（根为 storageRoot）中键为 keccak256(槽号)，叶子为 RLP(去掉前导零的值)
+	// 3. 证明中的每个节点按 keccak256(节点) 索引，从根开始逐层查找；路径终止于空节点即证明了“不存在”（值为 0）
+	// 4. 先读区块头再用同一个区块号请求 eth_getProof，避免两次请求之间出现新区块
+}
@@ -0,0 +1,117 @@
+// 本地 JSON-RPC 代理
+// 本程序在本地启动一个 JSON-RPC 服务（HTTP 和 WebSocket），把请求转发给配置的上游节点，
+// 钱包、脚本和其他命令只需连接本地地址，即可获得节点故障切换、响应缓存、请求日志和监控指标
+//
+//	go run ./cmd/proxy -upstream https://节点1,https://节点2 -log proxy.log
+//
+// 然后把钱包或程序的 RPC 地址设置为 http://127.0.0.1:8545（WebSocket 为 ws://127.0.0.1:8545），
+// Prometheus 指标在 http://127.0.0.1:8545/metrics
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
+	"github.com/duanyu/new-eth-project/pkg/proxy"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8545", "代理监听地址")
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	upstreams := flag.String("upstream", "https://eth-sepolia.g.alchemy.com/v2/<API_KEY>", "上游节点地址，多个用逗号分隔")
+	cacheDir := flag.String("cache-dir", filepath.Join(os.TempDir(), "eth-cache"), "响应缓存目录，为空时只缓存在内存中")
+	logPath := flag.String("log", "", "请求日志文件（JSON Lines 格式），\"-\" 表示标准输出，为空时不记录")
+	logBodies := flag.Bool("log-bodies", false, "日志中记录请求参数和响应结果")
+	allow := flag.String("allow", "", "只放行这些方法，多个用逗号分隔，以 * 结尾表示前缀匹配，例如 eth_*,net_version")
+	origins := flag.String("allowed-origins", "", "允许跨源建立 WebSocket 连接的网页 Origin，多个用逗号分隔，\"*\" 表示全部放行；默认只接受同源和非浏览器客户端")
+	flag.Parse()
+
+	fmt.Println("=== 本地 JSON-RPC 代理 ===")
+
+	// ===== 第1步：连接上游节点 =====
+	// multiclient 对多个节点做健康检查，请求失败时自动切换到下一个节点
+	ctx := context.Background()
+	upstream, err := multiclient.Dial(ctx, strings.Split(*upstreams, ","))
+	if err != nil {
+		log.Fatal("连接上游节点失败:", err)
+	}
+	defer upstream.Close()
+	for _, st := range upstream.Endpoints() {
+		fmt.Printf("上游节点: %s（健康: %v，最新区块: %d）\n", st.Name, st.Healthy, st.Head)
+	}
+
+	// ===== 第2步：配置缓存、日志和方法限制 =====
+	var opts []proxy.Option
+	if *cacheDir != "" {
+		store, err := cache.NewDiskStore(*cacheDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, proxy.WithCache(cache.WithStore(store)))
+		fmt.Printf("响应缓存目录: %s\n", *cacheDir)
+	}
+	if *logPath != "" {
+		var w io.Writer = os.Stdout
+		if *logPath != "-" {
+			f, err := os.OpenFile(*logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				log.Fatal("打开日志文件失败:", err)
+			}
+			defer f.Close()
+			w = f
+		}
+		opts = append(opts, proxy.WithLog(w, *logBodies))
+	}
+	if *allow != "" {
+		opts = append(opts, proxy.WithAllowed(strings.Split(*allow, ",")...))
+	}
+	if *origins != "" {
+		opts = append(opts, proxy.WithAllowedOrigins(strings.Split(*origins, ",")...))
+	}
+	fmt.Printf("拒绝的方法: %s\n", strings.Join(proxy.DefaultDenied, ", "))
+
+	// ===== 第3步：启动服务 =====
+	server := &http.Server{Addr: *listen, Handler: proxy.New(upstream, opts...)}
+	go func() {
+		fmt.Printf("✓ 代理已启动: http://%s（WebSocket: ws://%s，指标: http://%s/metrics）\n", *listen, *listen, *listen)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	// ===== 第4步：按 Ctrl+C 优雅退出 =====
+	// 等待进行中的请求处理完毕后再关闭，最多等待 5 秒
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+	fmt.Println("\n正在关闭代理...")
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println("关闭代理失败:", err)
+	}
+
+	// 小白说明：
+	// 1. 代理本身不保存私钥，eth_sendTransaction、personal_* 等需要节点托管私钥的方法默认被拒绝，
+	//    请在本地签名后用 eth_sendRawTransaction 发送
+	// 2. 按哈希查询的区块、已确定区块上的查询和已确定的收据不会再变化，会缓存到磁盘，重复查询不再请求节点
+	// 3. "latest" 等标签的查询结果随时变化，总是转发给上游
+	// 4. 一个上游节点出错时 multiclient 会自动切换到其他健康节点
+	// 5. WebSocket 连接支持 eth_subscribe 订阅 newHeads 和 logs（上游也需要是 WebSocket 地址）
+	// 6. /metrics 可以直接被 Prometheus 抓取
+	// 7. 浏览器里的任何网页都能尝试连接本机端口，所以默认拒绝跨源的 WebSocket 连接；
+	//    网页版钱包等需要连接时，用 -allowed-origins 放行它的地址
+}
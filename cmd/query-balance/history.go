@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/multicall"
+	"github.com/duanyu/new-eth-project/pkg/portfolio"
+)
+
+// history 余额历史模式：按固定的区块或时间间隔采样一组地址的 ETH 和 ERC20 余额，输出 CSV 或 JSON 时间序列
+//
+//	go run ./cmd/query-balance history -addresses 0x...,0x... -tokens 0xA0b8...eB48 -from 2024-01-01 -every 7200
+//	go run ./cmd/query-balance history -addresses 0x... -since 2024-01-01 -until 2024-02-01 -interval 24h -format json -out balances.json
+//
+// 进度信息输出到标准错误，标准输出只有时间序列，可以直接重定向到文件
+func history(args []string) {
+	fs := flag.NewFlagSet("query-balance history", flag.ExitOnError)
+	// 注意：请替换<API_KEY>为您的实际API密钥，查询历史余额需要归档节点
+	rpcURL := fs.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "归档节点地址")
+	addresses := fs.String("addresses", "", "要采样的地址，多个用逗号分隔")
+	tokens := fs.String("tokens", "", "同时采样的 ERC20 代币地址，多个用逗号分隔")
+	var from, to blocktag.Selector
+	to.Kind = blocktag.Latest
+	fs.Var(&from, "from", "按区块采样的起始区块：区块号、-N、safe、finalized 或 ISO 时间")
+	fs.Var(&to, "to", "按区块采样的结束区块（含），默认最新区块")
+	every := fs.Uint64("every", 7200, "按区块采样的间隔（区块数，7200 约为一天）")
+	since := fs.String("since", "", "按时间采样的开始时间，Unix 秒或 ISO 8601 时间；设置后忽略 -from / -to / -every")
+	until := fs.String("until", "", "按时间采样的结束时间（含），默认现在")
+	interval := fs.Duration("interval", 24*time.Hour, "按时间采样的间隔，如 1h、24h")
+	format := fs.String("format", "csv", "输出格式：csv 或 json")
+	out := fs.String("out", "", "输出文件，默认标准输出")
+	mcAddress := fs.String("multicall", "", "Multicall3 合约地址，默认使用各链通用的部署地址")
+	noMulticall := fs.Bool("no-multicall", false, "不使用 Multicall3，全部通过批量请求查询")
+	fs.Parse(args)
+	ctx := context.Background()
+
+	if *format != "csv" && *format != "json" {
+		log.Fatalf("不支持的输出格式 %q，可用 csv 或 json", *format)
+	}
+	holders, err := parseAddresses(*addresses)
+	if err != nil || len(holders) == 0 {
+		log.Fatalf("请用 -addresses 指定要采样的地址: %v", err)
+	}
+	tokenAddrs, err := parseAddresses(*tokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintln(os.Stderr, "✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：确定采样区块 =====
+	var blocks []uint64
+	if *since != "" {
+		start, err := blocktime.ParseTime(*since)
+		if err != nil {
+			log.Fatal(err)
+		}
+		end := time.Now()
+		if *until != "" {
+			if end, err = blocktime.ParseTime(*until); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if blocks, err = portfolio.TimeBlocks(ctx, blocktime.New(client), start, end, *interval); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		if from.Kind == blocktag.Latest {
+			log.Fatal("请用 -from 指定起始区块，或用 -since 按时间采样")
+		}
+		first, err := resolveBlock(ctx, client, from)
+		if err != nil {
+			log.Fatal(err)
+		}
+		last, err := resolveBlock(ctx, client, to)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if last < first || *every == 0 {
+			log.Fatalf("无效的采样范围：区块 %d - %d，间隔 %d", first, last, *every)
+		}
+		blocks = portfolio.Blocks(first, last, *every)
+	}
+	if len(blocks) == 0 {
+		log.Fatal("时间范围内没有区块")
+	}
+	fmt.Fprintf(os.Stderr, "采样 %d 个区块（%d - %d），%d 个地址，%d 种代币\n",
+		len(blocks), blocks[0], blocks[len(blocks)-1], len(holders), len(tokenAddrs))
+
+	// ===== 第3步：检查节点是否保留历史状态 =====
+	// 最早的采样点最可能被普通全节点裁剪掉，先查一次，免得采样到一半才失败
+	opts := []portfolio.Option{portfolio.WithTokens(tokenAddrs...)}
+	if *noMulticall {
+		opts = append(opts, portfolio.WithoutMulticall())
+	} else if *mcAddress != "" {
+		opts = append(opts, portfolio.WithMulticall(multicall.WithAddress(common.HexToAddress(*mcAddress))))
+	}
+	sampler := portfolio.New(client, holders, opts...)
+	if err := sampler.CheckArchive(ctx, blocks[0]); err != nil {
+		if errors.Is(err, portfolio.ErrNoArchive) {
+			log.Fatalf("%v\n该节点不是归档节点，请用 -rpc 换成归档节点，或把 -from / -since 改到最近约 128 个区块之内", err)
+		}
+		log.Fatal(err)
+	}
+	fmt.Fprintln(os.Stderr, "✓ 节点可以提供历史状态")
+
+	// ===== 第4步：逐个区块采样 =====
+	series, err := sampler.Series(ctx, blocks)
+	if err != nil {
+		log.Fatal(err)
+	}
+	batched := 0
+	for _, snap := range series {
+		if snap.Multicall {
+			batched++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "✓ 采样完成，其中 %d 个区块通过 Multicall3 一次查询\n", batched)
+
+	// ===== 第5步：输出时间序列 =====
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "json" {
+		err = portfolio.WriteJSON(w, series)
+	} else {
+		err = portfolio.WriteCSV(w, series)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, "✓ 已写入 %s\n", *out)
+	}
+
+	// 小白说明：
+	// 1. 余额历史就是在一串区块上分别查询余额，画成曲线可以看到资产随时间的变化
+	// 2. 普通节点只保存最近的状态，查询几个月前的余额需要归档节点（Alchemy、Infura 等通常默认提供）
+	// 3. CSV 每行是一个区块上一个地址的一种资产，用表格软件做数据透视即可按地址或资产汇总；
+	//    JSON 中每个区块还附带全部地址按资产汇总的组合余额
+	//
+	// 技术说明：
+	// 1. 每个采样区块上的余额通过 Multicall3 的 getEthBalance 和 balanceOf 合并成一次 eth_call，
+	//    同一次调用中的余额一定来自同一个区块
+	// 2. 早于 Multicall3 部署的区块退回 JSON-RPC 批量请求，结果与 Multicall3 相同，只是请求更多
+	// 3. 按时间采样时每个时间点取时间戳不晚于该时刻的最后一个区块，即该时刻的链上状态
+	// 4. 节点返回 missing trie node 等错误时判定为缺少历史状态，提示更换归档节点
+}
+
+// parseAddresses 解析逗号分隔的地址列表，空字符串返回空列表
+func parseAddresses(s string) ([]common.Address, error) {
+	var out []common.Address
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !common.IsHexAddress(part) {
+			return nil, fmt.Errorf("无效的地址 %q", part)
+		}
+		out = append(out, common.HexToAddress(part))
+	}
+	return out, nil
+}
+
+// resolveBlock 把区块选择器固定为具体的区块号，历史采样不支持 pending
+func resolveBlock(ctx context.Context, client backend.EthBackend, sel blocktag.Selector) (uint64, error) {
+	if sel.IsPending() {
+		return 0, errors.New("余额历史不支持 pending 区块")
+	}
+	header, err := sel.Resolve(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
@@ -1,76 +1,114 @@
 package main
 
 import (
-	"context"   // 上下文管理，用于控制请求的生命周期
-	"fmt"       // 格式化输入输出
-	"log"       // 日志记录
-	"math"      // 数学运算
-	"math/big"  // 大数运算，处理以太币数量等大整数
+	"context" // 上下文管理，用于控制请求的生命周期
+	"flag"    // 命令行参数
+	"fmt"     // 格式化输入输出
+	"log"     // 日志记录
+	"os"      // 命令行子命令
 
-	"github.com/ethereum/go-ethereum/common"    // 以太坊通用工具
-	"github.com/ethereum/go-ethereum/ethclient" // 以太坊客户端
+	"github.com/ethereum/go-ethereum/common" // 以太坊通用工具
+
+	"github.com/duanyu/new-eth-project/pkg/backend"  // 以太坊后端接口
+	"github.com/duanyu/new-eth-project/pkg/blocktag" // 区块选择器
+	"github.com/duanyu/new-eth-project/pkg/query"    // 查询功能库
 )
 
 // main函数 - 查询账户余额
 // 功能：查询指定地址的ETH余额（当前余额、历史余额、待处理余额）
 // 这是一个完整的余额查询实现，展示了多种余额查询方式
+// 第一个参数为 history 时进入余额历史模式，见 history.go
+//
+//	go run ./cmd/query-balance -block finalized
+//	go run ./cmd/query-balance -address 0x... -block 2024-01-01
+//	go run ./cmd/query-balance history -addresses 0x... -from 2024-01-01 -every 7200
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		history(os.Args[2:])
+		return
+	}
+	address := flag.String("address", "0x25836239F7b632635F815689389C537133248edb", "要查询的地址")
+	block := blocktag.Selector{Kind: blocktag.Number, Number: 5532993}
+	flag.Var(&block, "block", "历史余额的区块：latest、pending、safe、finalized、区块号、区块哈希、-N 或 ISO 时间")
+	flag.Parse()
+	ctx := context.Background()
+
 	// 步骤1：连接以太坊网络
 	// 使用Cloudflare提供的以太坊主网节点
 	// Cloudflare是一个免费且稳定的以太坊节点提供商
-	client, err := ethclient.Dial("https://cloudflare-eth.com")
+	client, err := backend.Dial("https://cloudflare-eth.com")
 	if err != nil {
-		log.Fatal("连接以太坊客户端失败:", err)
+		log.Fatal(err)
 	}
 
 	// 步骤2：设置要查询的账户地址
-	// 这里使用一个示例地址，您可以替换为任何有效的以太坊地址
-	account := common.HexToAddress("0x25836239F7b632635F815689389C537133248edb")
+	// 默认使用一个示例地址，可以用 -address 替换为任何有效的以太坊地址
+	account := common.HexToAddress(*address)
 	fmt.Printf("查询地址: %s\n\n", account.Hex())
 
 	// 步骤3：查询当前最新余额
-	// BalanceAt的第三个参数为nil表示查询最新区块的余额
-	balance, err := client.BalanceAt(context.Background(), account, nil)
+	// 区块号为nil表示查询最新区块的余额
+	balance, err := query.Balance(ctx, client, account, nil)
 	if err != nil {
-		log.Fatal("查询当前余额失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("当前余额 (Wei): %s\n", balance.String())
+	fmt.Printf("当前余额 (Wei): %s\n", balance.Wei.String())
 
-	// 步骤4：查询指定区块高度的历史余额
-	// 这里查询区块高度5532993时的余额
-	// 这对于查看账户在特定时间点的余额很有用
-	blockNumber := big.NewInt(5532993)
-	balanceAt, err := client.BalanceAt(context.Background(), account, blockNumber)
+	// 步骤4：查询 -block 指定的区块的余额
+	// 默认查询区块高度5532993时的余额，也可以是 safe、finalized、-100 或某个时间点
+	// 标签、相对区块和时间先固定为具体的区块号，这对于查看账户在特定时间点的余额很有用
+	blockNumber, err := block.BlockNumber(ctx, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	label := block.String()
+	if blockNumber != nil && blockNumber.Sign() >= 0 && block.Kind != blocktag.Number {
+		label = fmt.Sprintf("%s = %s", block, blockNumber)
+	}
+	balanceAt, err := query.Balance(ctx, client, account, blockNumber)
 	if err != nil {
-		log.Fatal("查询历史余额失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("区块 %s 时的余额 (Wei): %s\n", blockNumber.String(), balanceAt.String())
+	// 区块哈希只能按区块号查询，查询后确认该区块仍在规范链上，避免读到重组后另一个区块的余额
+	if err := block.Verify(ctx, client, blockNumber); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("区块 %s 时的余额 (Wei): %s\n", label, balanceAt.Wei.String())
 
 	// 步骤5：将Wei转换为ETH单位显示
 	// Wei是以太坊的最小单位，1 ETH = 10^18 Wei
-	// 为了便于阅读，我们将Wei转换为ETH
-	fbalance := new(big.Float)
-	fbalance.SetString(balanceAt.String()) // 将big.Int转换为big.Float
-	// 除以10^18将Wei转换为ETH
-	ethValue := new(big.Float).Quo(fbalance, big.NewFloat(math.Pow10(18)))
-	fmt.Printf("区块 %s 时的余额 (ETH): %s\n", blockNumber.String(), ethValue.String())
+	fmt.Printf("区块 %s 时的余额 (ETH): %s\n", label, balanceAt.Ether().String())
 
 	// 步骤6：查询待处理余额
 	// 待处理余额包括尚未被打包到区块中的交易影响
 	// 这对于查看账户的"即将到账"余额很有用
-	pendingBalance, err := client.PendingBalanceAt(context.Background(), account)
+	pendingBalance, err := query.PendingBalance(ctx, client, account)
 	if err != nil {
-		log.Fatal("查询待处理余额失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Printf("待处理余额 (Wei): %s\n", pendingBalance.String())
 
-	// 步骤7：将当前余额也转换为ETH单位显示
-	currentBalance := new(big.Float)
-	currentBalance.SetString(balance.String())
-	currentEthValue := new(big.Float).Quo(currentBalance, big.NewFloat(math.Pow10(18)))
+	// 步骤7：批量查询多个地址的余额
+	// 地址较多时，query.Balances 会把 eth_getBalance 合并成JSON-RPC批量请求，
+	// 一次网络往返查询一批地址，比逐个查询快得多
+	accounts := []common.Address{
+		account,
+		common.HexToAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F"),
+		common.HexToAddress("0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"),
+	}
+	balances, err := query.Balances(ctx, client, accounts, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n=== 批量查询 %d 个地址 ===\n", len(balances))
+	for _, b := range balances {
+		fmt.Printf("%s: %s ETH\n", b.Address.Hex(), b.Ether().String())
+	}
+
+	// 步骤8：汇总显示
 	fmt.Printf("\n=== 余额查询结果汇总 ===\n")
-	fmt.Printf("当前余额: %s ETH\n", currentEthValue.String())
-	fmt.Printf("历史余额: %s ETH (区块 %s)\n", ethValue.String(), blockNumber.String())
+	fmt.Printf("当前余额: %s ETH\n", balance.Ether().String())
+	fmt.Printf("历史余额: %s ETH (区块 %s)\n", balanceAt.Ether().String(), label)
 
 	// 小白说明：
 	// 1. Wei是以太坊的最小单位，类似于"分"对于"元"
@@ -79,4 +117,7 @@ func main() {
 	// 4. 历史余额：指定区块高度时的余额
 	// 5. 待处理余额：包含未确认交易的余额
 	// 6. 区块高度：以太坊网络中区块的序号，越大越新
-}
\ No newline at end of file
+	// 7. 批量查询：把多个请求打包成一个HTTP请求发送，适合一次查询大量地址
+	// 8. safe / finalized：合并后的区块标签，finalized 的余额不会再因为区块重组而改变
+	// 9. 想看余额随时间的变化，用 history 模式按区块或时间间隔采样，输出 CSV / JSON
+}
@@ -1,100 +1,143 @@
 // 以太坊区块查询工具
 // 本程序演示如何查询以太坊网络上的区块信息
 // 包含区块头信息、完整区块信息和交易数量统计
+//
+// -verify 不再完全信任节点：重新计算区块哈希、交易树根、收据树根等并与区块头比对，
+// 检查之前 -range 个区块的父哈希链接，可用于比较不可信的节点服务商
+//
+//	go run ./cmd/query-block -block 5671744 -verify -range 100
+//	go run ./cmd/query-block -block finalized
+//	go run ./cmd/query-block -block 2024-06-01T00:00:00Z
 
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
-	"math/big"
+	"os"
+	"path/filepath"
 
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/query"
 )
 
 func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-sepolia.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	sel := blocktag.Selector{Kind: blocktag.Number, Number: 5671744}
+	flag.Var(&sel, "block", "要查询的区块：latest、safe、finalized、区块号、区块哈希、-N 或 ISO 时间")
+	verify := flag.Bool("verify", false, "校验区块哈希、交易树根、收据树根与区块头是否一致")
+	span := flag.Uint64("range", 0, "-verify 时同时检查之前多少个区块的父哈希链接")
+	flag.Parse()
+
 	fmt.Println("=== 以太坊区块查询工具 ===")
-	fmt.Println("本工具演示如何查询以太坊区块信息，包括区块头和完整区块数据\n")
+	fmt.Print("本工具演示如何查询以太坊区块信息，包括区块头和完整区块数据\n\n")
 
 	// ===== 第1步：连接以太坊网络 =====
-	// 连接到Sepolia测试网络
-	// 注意：请替换<API_KEY>为您的实际API密钥
-	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	// 默认连接到Sepolia测试网络
+	conn, err := backend.Dial(*rpcURL)
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
+	// 已确定的区块、交易和收据不会再变化，用响应缓存包装客户端：
+	// 结果保存在临时目录的 eth-cache 中，再次运行时直接从磁盘读取，不再请求节点
+	store, err := cache.NewDiskStore(filepath.Join(os.TempDir(), "eth-cache"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := cache.Wrap(conn, cache.WithStore(store))
 	fmt.Println("✓ 成功连接到Sepolia测试网络")
 
 	// ===== 第2步：设置查询参数 =====
-	// 指定要查询的区块号
-	blockNumber := big.NewInt(5671744)
-	fmt.Printf("查询区块号: %s\n\n", blockNumber.String())
-
-	// ===== 第3步：查询区块头信息 =====
-	fmt.Println("=== 方法1：查询区块头信息 ===")
-	// 区块头包含区块的基本元数据，不包含交易详情
-	// 这种方式查询速度更快，数据量更小
-	header, err := client.HeaderByNumber(context.Background(), blockNumber)
+	// 把 -block 固定为具体的区块号：标签、相对区块和时间都先解析成区块头
+	if sel.IsPending() {
+		log.Fatal("待打包区块还没有确定的哈希和内容，请使用 latest、safe 或 finalized")
+	}
+	resolved, err := sel.Resolve(context.Background(), client)
 	if err != nil {
-		log.Fatal("获取区块头失败:", err)
+		log.Fatal(err)
 	}
+	blockNumber := resolved.Number
+	fmt.Printf("查询区块号: %s（%s）\n\n", blockNumber.String(), sel)
+
+	// ===== 第3步：用三种方式查询同一区块 =====
+	// query.CheckBlock 依次执行：
+	//   方法1：HeaderByNumber 只查询区块头，速度快、数据量小
+	//   方法2：BlockByNumber 查询完整区块，包含所有交易
+	//   方法3：TransactionCount 通过区块哈希查询交易数量
+	check, err := query.CheckBlock(context.Background(), client, blockNumber)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, block := check.Header, check.Block
 
-	// 显示区块头信息
+	fmt.Println("=== 方法1：查询区块头信息 ===")
 	fmt.Printf("区块号: %d\n", header.Number.Uint64())
 	fmt.Printf("时间戳: %d\n", header.Time)
 	fmt.Printf("难度: %d\n", header.Difficulty.Uint64())
 	fmt.Printf("区块哈希: %s\n\n", header.Hash().Hex())
 
-	// ===== 第4步：查询完整区块信息 =====
 	fmt.Println("=== 方法2：查询完整区块信息 ===")
-	// 完整区块包含所有交易数据，数据量较大但信息完整
-	block, err := client.BlockByNumber(context.Background(), blockNumber)
-	if err != nil {
-		log.Fatal("获取完整区块失败:", err)
-	}
-
-	// 显示完整区块信息
-	fmt.Printf("区块号: %d\n", block.Number().Uint64())
-	fmt.Printf("时间戳: %d\n", block.Time())
-	fmt.Printf("难度: %d\n", block.Difficulty().Uint64())
-	fmt.Printf("区块哈希: %s\n", block.Hash().Hex())
-	fmt.Printf("交易数量: %d\n\n", len(block.Transactions()))
+	fmt.Printf("区块号: %d\n", block.Number)
+	fmt.Printf("时间戳: %d\n", block.Time)
+	fmt.Printf("难度: %d\n", block.Difficulty.Uint64())
+	fmt.Printf("区块哈希: %s\n", block.Hash.Hex())
+	fmt.Printf("交易数量: %d\n\n", block.TxCount)
 
-	// ===== 第5步：通过区块哈希查询交易数量 =====
 	fmt.Println("=== 方法3：通过区块哈希查询交易数量 ===")
-	// 使用区块哈希来查询交易数量，这是另一种验证方式
-	count, err := client.TransactionCount(context.Background(), block.Hash())
-	if err != nil {
-		log.Fatal("获取交易数量失败:", err)
-	}
-	fmt.Printf("通过区块哈希查询的交易数量: %d\n", count)
+	fmt.Printf("通过区块哈希查询的交易数量: %d\n", check.TxCount)
 
-	// ===== 第6步：数据一致性验证 =====
+	// ===== 第4步：数据一致性验证 =====
 	fmt.Println("\n=== 数据一致性验证 ===")
 	fmt.Printf("区块头中的区块号: %d\n", header.Number.Uint64())
-	fmt.Printf("完整区块中的区块号: %d\n", block.Number().Uint64())
+	fmt.Printf("完整区块中的区块号: %d\n", block.Number)
 	fmt.Printf("区块头中的哈希: %s\n", header.Hash().Hex())
-	fmt.Printf("完整区块中的哈希: %s\n", block.Hash().Hex())
-	fmt.Printf("完整区块中的交易数量: %d\n", len(block.Transactions()))
-	fmt.Printf("通过哈希查询的交易数量: %d\n", count)
-
-	// 验证数据一致性
-	if header.Number.Uint64() == block.Number().Uint64() &&
-		header.Hash().Hex() == block.Hash().Hex() &&
-		uint(len(block.Transactions())) == count {
+	fmt.Printf("完整区块中的哈希: %s\n", block.Hash.Hex())
+	fmt.Printf("完整区块中的交易数量: %d\n", block.TxCount)
+	fmt.Printf("通过哈希查询的交易数量: %d\n", check.TxCount)
+
+	if check.Consistent {
 		fmt.Println("\n✓ 所有查询结果一致，数据验证通过！")
 	} else {
 		fmt.Println("\n✗ 查询结果不一致，请检查网络连接或数据")
 	}
 
+	// ===== 第5步：校验区块内容（-verify） =====
+	// 校验直接请求节点（不经过缓存），哈希校验需要原始 JSON 中的 hash 字段
+	if *verify {
+		fmt.Println("\n=== 区块内容校验 ===")
+		v, err := query.VerifyBlock(context.Background(), conn, blockNumber)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(v)
+		if *span > 0 {
+			from := uint64(0)
+			if blockNumber.Uint64() > *span {
+				from = blockNumber.Uint64() - *span
+			}
+			chain, err := query.VerifyChain(context.Background(), conn, from, blockNumber.Uint64())
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Print(chain)
+		}
+	}
+
+	st := client.Stats()
+	fmt.Printf("\n缓存统计: 内存命中 %d，磁盘命中 %d，未命中 %d\n", st.Hits, st.DiskHits, st.Misses)
 	fmt.Println("\n=== 查询完成 ===")
 	fmt.Println("\n=== 重要说明 ===")
 	fmt.Println("1. 请替换API_KEY为您的实际密钥")
-	fmt.Println("2. 区块号可以根据需要调整")
+	fmt.Println("2. -block 可以是区块号，也可以是 latest、safe、finalized、-10（10 个区块之前）或时间")
 	fmt.Println("3. 区块头查询速度快，适合获取基本信息")
 	fmt.Println("4. 完整区块查询包含所有交易，数据量大")
 	fmt.Println("5. 难度为0表示使用权益证明(PoS)共识")
+	fmt.Println("6. -verify 在本地重新计算哈希和树根，节点返回的数据被篡改时会显示 ✗")
 
 	// ===== 技术说明 =====
 	// 1. 区块查询方式对比：
@@ -127,6 +170,17 @@ func main() {
 	//    - 区块头查询适合快速获取基本信息
 	//    - 完整区块查询适合需要交易详情的场景
 	//    - 根据实际需求选择合适的查询方式
+	//
+	// 7. 区块校验（-verify）：
+	//    - 区块哈希 = keccak256(RLP(区块头))，ethclient 会丢弃节点返回的 hash 字段，需要读原始 JSON 比对
+	//    - transactionsRoot / receiptsRoot 是以交易下标为键的 Merkle-Patricia 树根，用取回的交易和收据重建
+	//    - 每个区块头的 parentHash 必须等于前一个区块的哈希，整段链条由最新区块的哈希唯一确定
+	//    - 节点返回新硬分叉引入的、当前 go-ethereum 版本不认识的字段时，哈希一项显示为无法校验
+	//
+	// 8. 区块标签（-block）：
+	//    - latest：最新区块，可能在几秒后因重组被替换
+	//    - safe：共识层认为不太可能被重组的区块，通常落后最新区块几个 slot
+	//    - finalized：已最终确定的区块（约两个 epoch，12.8 分钟前），除非大量质押被罚没否则不会改变
+	//    - 时间：时间戳不晚于该时刻的最后一个区块，通过二分查找区块头得到
 
-
-}
\ No newline at end of file
+}
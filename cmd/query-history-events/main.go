@@ -5,21 +5,31 @@
 // 使用方法：
 // 1. 在此目录下运行: go run main.go
 // 2. 或者构建后运行: go build && ./query-history-events
+// 3. 用 -from / -to 指定区块范围，支持区块号、safe、finalized、-N 和时间：
+//    go run ./cmd/query-history-events -from -5000 -to finalized
+//    go run ./cmd/query-history-events -from 2024-10-01 -to 2024-10-02
+// 4. 按时间范围查询时用 -since / -until（左闭右开），会换算成对应的区块范围：
+//    go run ./cmd/query-history-events -since 2024-10-01 -until 2024-11-01
 
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
-	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/events"
+	"github.com/duanyu/new-eth-project/pkg/middleware"
 )
 
 // StoreABI 智能合约的ABI定义
@@ -31,17 +41,28 @@ var StoreABI = `[{"inputs":[{"internalType":"string","name":"_version","type":"s
 // 功能：查询指定区块范围内的历史事件，而不是实时监听
 // 适用场景：数据分析、历史记录查询、事件回放等
 func main() {
+	from := blocktag.Selector{Kind: blocktag.Number, Number: 6920583}
+	var to blocktag.Selector
+	flag.Var(&from, "from", "起始区块：区块号、safe、finalized、-N 或 ISO 时间")
+	flag.Var(&to, "to", "结束区块：同 -from，默认最新区块")
+	since := flag.String("since", "", "按时间查询的开始时刻（含），Unix 秒或 ISO 8601 时间，指定后忽略 -from / -to")
+	until := flag.String("until", "", "按时间查询的结束时刻（不含），默认到最新区块")
+	flag.Parse()
+
 	fmt.Println("=== 智能合约历史事件查询工具 ===")
 	fmt.Println("本工具用于查询指定区块范围内的合约事件")
-	fmt.Println("与实时事件监听不同，这是一次性的历史数据查询\n")
+	fmt.Print("与实时事件监听不同，这是一次性的历史数据查询\n\n")
 
 	// ===== 第1步：连接以太坊网络 =====
 	// 使用HTTP连接，适合一次性查询操作
 	// 对于历史数据查询，HTTP连接比WebSocket更稳定
-	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	conn, err := backend.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
+	// 大范围查询容易触发服务商限流，这里限制为每秒 10 个请求，
+	// 遇到限流、超时等临时错误时自动退避重试，而不是直接退出
+	client := middleware.Wrap(conn, middleware.WithRateLimit(10, 5))
 	fmt.Println("✓ 成功连接到以太坊Sepolia测试网")
 
 	// ===== 第2步：设置查询参数 =====
@@ -50,13 +71,21 @@ func main() {
 	fmt.Printf("✓ 目标合约地址: %s\n", contractAddress.Hex())
 
 	// ===== 第3步：创建事件过滤器 =====
+	// -from / -to 先固定为具体的区块号：分段查询时每一段都需要确定的边界
+	// 指定了 -since 时改为按时间范围换算区块范围
+	var fromBlock, toBlock *big.Int
+	if *since != "" {
+		fromBlock, toBlock = timeRange(client, *since, *until)
+	} else {
+		fromBlock, toBlock = resolve(client, from), resolve(client, to)
+	}
 	// FilterQuery定义了查询条件
 	query := ethereum.FilterQuery{
 		// FromBlock: 查询的起始区块号
 		// 注意：区块范围不要太大，避免查询超时
-		FromBlock: big.NewInt(6920583),
-		// ToBlock: 查询的结束区块号（注释掉表示查询到最新区块）
-		// ToBlock:   big.NewInt(2394201),
+		FromBlock: fromBlock,
+		// ToBlock: 查询的结束区块号（nil 表示查询到最新区块）
+		ToBlock: toBlock,
 		// Addresses: 要监听的合约地址列表
 		Addresses: []common.Address{
 			contractAddress,
@@ -68,70 +97,58 @@ func main() {
 		//  {}, // 后续元素是indexed参数的值
 		// },
 	}
-	fmt.Printf("✓ 查询区块范围: 从 %d 到最新区块\n", query.FromBlock.Int64())
+	switch {
+	case *since != "":
+		fmt.Printf("✓ 查询区块范围: 从 %s 到 %s（时间 %s 至 %s）\n", fromBlock, toBlock, *since, orLatest(*until))
+	case toBlock == nil:
+		fmt.Printf("✓ 查询区块范围: 从 %s（%s）到最新区块\n", fromBlock, from)
+	default:
+		fmt.Printf("✓ 查询区块范围: 从 %s（%s）到 %s（%s）\n", fromBlock, from, toBlock, to)
+	}
 
-	// ===== 第4步：执行日志查询 =====
-	// FilterLogs返回符合条件的所有历史日志
-	// 这是一次性操作，不同于实时订阅
-	logs, err := client.FilterLogs(context.Background(), query)
+	// ===== 第4步：解析合约ABI =====
+	// 将ABI字符串解析为事件解码器，用于把原始日志解码为事件字段
+	decoder, err := events.NewDecoder(StoreABI)
 	if err != nil {
-		log.Fatal("查询事件日志失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("✓ 找到 %d 个事件日志\n\n", len(logs))
+	fmt.Println("✓ 成功解析合约ABI")
 
-	// ===== 第5步：解析合约ABI =====
-	// 将ABI字符串解析为Go可以使用的ABI对象
-	contractAbi, err := abi.JSON(strings.NewReader(StoreABI))
+	// ===== 第5步：执行日志查询 =====
+	// events.History内部使用FilterLogs返回符合条件的所有历史日志，并逐条解码
+	// 这是一次性操作，不同于实时订阅
+	history, err := events.History(context.Background(), client, query, decoder)
 	if err != nil {
-		log.Fatal("解析合约ABI失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Println("✓ 成功解析合约ABI")
+	fmt.Printf("✓ 找到 %d 个事件日志\n\n", len(history))
 
-	// ===== 第6步：遍历和解析每个事件日志 =====
-	for i, vLog := range logs {
+	// ===== 第6步：遍历每个事件 =====
+	for i, event := range history {
+		vLog := event.Log
 		fmt.Printf("\n=== 事件 #%d ===\n", i+1)
-		
+
 		// 显示事件的基本信息
 		fmt.Printf("区块哈希: %s\n", vLog.BlockHash.Hex())
 		fmt.Printf("区块号: %d\n", vLog.BlockNumber)
 		fmt.Printf("交易哈希: %s\n", vLog.TxHash.Hex())
 		fmt.Printf("日志索引: %d\n", vLog.Index)
+		fmt.Printf("事件名称: %s\n", event.Name)
 
-		// ===== 第7步：解析事件数据 =====
-		// 定义与ItemSet事件匹配的结构体
-		// 注意：这里只包含non-indexed参数（value）
-		// indexed参数（key）在Topics中处理
-		event := struct {
-			Key   [32]byte // 这个字段实际不会被UnpackIntoInterface填充
-			Value [32]byte // 这个字段会被填充
-		}{}
-		
-		// UnpackIntoInterface解析事件的Data字段
-		// 只解析non-indexed参数
-		err := contractAbi.UnpackIntoInterface(&event, "ItemSet", vLog.Data)
-		if err != nil {
-			log.Printf("解析事件数据失败: %v", err)
-			continue
-		}
-
-		// 显示解析后的事件数据
-		fmt.Printf("Value (non-indexed): 0x%s\n", common.Bytes2Hex(event.Value[:]))
+		// ===== 第7步：显示解码后的事件数据 =====
+		// value是non-indexed参数，从Data中解析
+		// key是indexed参数，从Topics[1]中解析
+		value, _ := event.Fields["value"].([32]byte)
+		key, _ := event.Fields["key"].([32]byte)
+		fmt.Printf("Value (non-indexed): 0x%s\n", common.Bytes2Hex(value[:]))
+		fmt.Printf("Key (indexed): 0x%s\n", common.Bytes2Hex(key[:]))
 
 		// ===== 第8步：处理事件Topics =====
 		// Topics[0]: 事件签名的Keccak256哈希
 		// Topics[1:]: indexed参数的值
-		var topics []string
-		for i := range vLog.Topics {
-			topics = append(topics, vLog.Topics[i].Hex())
-		}
-
-		// 显示事件签名
-		fmt.Printf("事件签名哈希: %s\n", topics[0])
-		
-		// 显示indexed参数
-		if len(topics) > 1 {
-			fmt.Printf("Key (indexed): %s\n", topics[1])
-			fmt.Printf("所有indexed参数: %v\n", topics[1:])
+		fmt.Printf("事件签名哈希: %s\n", vLog.Topics[0].Hex())
+		if len(vLog.Topics) > 1 {
+			fmt.Printf("所有indexed参数: %v\n", vLog.Topics[1:])
 		}
 	}
 
@@ -143,11 +160,19 @@ func main() {
 	fmt.Printf("计算得到的事件签名哈希: %s\n", hash.Hex())
 	fmt.Println("此哈希应该与所有事件的Topics[0]匹配")
 
+	// ===== 第10步：显示RPC调用统计 =====
+	fmt.Printf("\n=== RPC调用统计 ===\n")
+	stats := client.Stats()
+	for _, method := range client.Methods() {
+		st := stats[method]
+		fmt.Printf("%s: 调用 %d 次, 重试 %d 次, 限流 %d 次, 失败 %d 次\n", method, st.Calls, st.Retries, st.RateLimited, st.Failures)
+	}
+
 	fmt.Println("\n=== 查询完成 ===")
 	fmt.Println("\n=== 使用说明 ===")
 	fmt.Println("1. 替换<API_KEY>为实际的Alchemy或Infura密钥")
 	fmt.Println("2. 调整合约地址为要查询的实际合约")
-	fmt.Println("3. 根据需要调整查询的区块范围（FromBlock/ToBlock）")
+	fmt.Println("3. 用 -from / -to 调整查询的区块范围，如 -from -5000 -to finalized 只看已最终确定的事件")
 	fmt.Println("4. 如需查询其他事件，请更新ABI和事件结构")
 	fmt.Println("5. 可以使用Topics过滤器来查询特定的事件或参数值")
 
@@ -162,15 +187,60 @@ func main() {
 	//    - Data：non-indexed参数的ABI编码数据
 	//
 	// 3. ABI解析：
-	//    - UnpackIntoInterface只解析Data字段
-	//    - indexed参数需要从Topics中手动提取
+	//    - non-indexed参数从Data字段解析
+	//    - indexed参数从Topics[1:]中解析，events.Decoder会把两者合并到Fields中
 	//
 	// 4. 查询优化：
 	//    - 使用合适的区块范围避免超时
 	//    - 使用Topics过滤器减少不必要的数据
-	//    - 考虑分批查询大范围的历史数据
+	//    - 考虑分批查询大范围的历史数据（events.History 遇到范围过大的错误会自动二分区间）
 	//
 	// 5. 性能考虑：
 	//    - 历史查询：一次性查询大量数据，注意区块范围不要太大
 	//    - 实时监听：持续运行，注意内存管理和错误处理
-}
\ No newline at end of file
+	//
+	// 6. 区块选择器：
+	//    - -to finalized 只返回已最终确定的事件，不会因为重组而失效，适合对账等场景
+	//    - 时间会解析为不晚于该时刻的最后一个区块，需要二分查找若干个区块头
+	//    - -since / -until 由 pkg/blocktime 换算：开始区块是 -since 之后的第一个区块，
+	//      结束区块是 -until 之前的最后一个区块，按月、按天拆分的报表不会重复或遗漏事件
+}
+
+// timeRange 把 [since, until) 时间范围换算成区块范围
+func timeRange(client *middleware.Client, since, until string) (*big.Int, *big.Int) {
+	from, err := blocktime.ParseTime(since)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var to time.Time
+	if until != "" {
+		if to, err = blocktime.ParseTime(until); err != nil {
+			log.Fatal(err)
+		}
+	}
+	first, last, err := blocktime.New(client).Range(context.Background(), from, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return new(big.Int).SetUint64(first), new(big.Int).SetUint64(last)
+}
+
+// orLatest 空字符串显示为“最新”
+func orLatest(s string) string {
+	if s == "" {
+		return "最新"
+	}
+	return s
+}
+
+// resolve 把区块选择器固定为具体的区块号，最新区块返回 nil
+func resolve(client *middleware.Client, sel blocktag.Selector) *big.Int {
+	if sel.IsPending() {
+		log.Fatal("日志查询不支持 pending，请使用 latest、safe 或 finalized")
+	}
+	number, err := sel.BlockNumber(context.Background(), client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return number
+}
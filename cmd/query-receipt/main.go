@@ -9,23 +9,39 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"os"
+	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/query"
+	"github.com/duanyu/new-eth-project/pkg/revert"
 )
 
 func main() {
+	ctx := context.Background()
 	fmt.Println("=== 以太坊交易回执查询工具 ===")
-	fmt.Println("本工具演示如何查询交易回执信息，包括批量和单个查询\n")
+	fmt.Print("本工具演示如何查询交易回执信息，包括批量和单个查询\n\n")
 
 	// ===== 第1步：连接以太坊网络 =====
 	// 连接到以太坊Sepolia测试网
 	// 注意：需要将<API_KEY>替换为实际的Alchemy或Infura API密钥
-	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	conn, err := backend.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 已确定的区块、交易和收据不会再变化，用响应缓存包装客户端：
+	// 结果保存在临时目录的 eth-cache 中，再次运行时直接从磁盘读取，不再请求节点
+	store, err := cache.NewDiskStore(filepath.Join(os.TempDir(), "eth-cache"))
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
+	client := cache.Wrap(conn, cache.WithStore(store))
 	fmt.Println("✓ 成功连接到以太坊Sepolia测试网")
 
 	// ===== 第2步：设置查询参数 =====
@@ -38,19 +54,19 @@ func main() {
 	// ===== 第3步：按区块哈希批量查询交易回执 =====
 	fmt.Println("=== 按区块哈希批量查询交易回执 ===")
 	// BlockReceipts方法可以一次性获取整个区块中所有交易的回执
-	// 这比逐个查询交易回执更高效
-	receiptByHash, err := client.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithHash(blockHash, false))
+	// 这比逐个查询交易回执更高效；节点不支持时 query.BlockReceipts 会退回逐笔查询
+	receiptByHash, err := query.BlockReceipts(ctx, client, rpc.BlockNumberOrHashWithHash(blockHash, false))
 	if err != nil {
-		log.Fatal("按区块哈希查询回执失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Printf("✓ 通过区块哈希获取到 %d 个交易回执\n", len(receiptByHash))
 
 	// ===== 第4步：按区块号批量查询交易回执 =====
 	fmt.Println("\n=== 按区块号批量查询交易回执 ===")
 	// 同样的功能，但使用区块号而不是区块哈希
-	receiptsByNum, err := client.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNumber.Int64())))
+	receiptsByNum, err := query.BlockReceipts(ctx, client, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNumber.Int64())))
 	if err != nil {
-		log.Fatal("按区块号查询回执失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Printf("✓ 通过区块号获取到 %d 个交易回执\n", len(receiptsByNum))
 
@@ -70,14 +86,14 @@ func main() {
 	fmt.Println("\n=== 分析第一个交易回执详情 ===")
 	if len(receiptByHash) > 0 {
 		firstReceipt := receiptByHash[0]
-		
+
 		// 交易执行状态
 		if firstReceipt.Status == 1 {
 			fmt.Println("交易状态: 成功执行")
 		} else {
 			fmt.Println("交易状态: 执行失败")
 		}
-		
+
 		// 事件日志信息
 		fmt.Printf("事件日志数量: %d\n", len(firstReceipt.Logs))
 		if len(firstReceipt.Logs) == 0 {
@@ -85,20 +101,20 @@ func main() {
 		} else {
 			fmt.Println("事件日志: 包含智能合约事件")
 		}
-		
+
 		// 交易基本信息
 		fmt.Printf("交易哈希: %s\n", firstReceipt.TxHash.Hex())
 		fmt.Printf("交易在区块中的索引: %d\n", firstReceipt.TransactionIndex)
 		fmt.Printf("Gas消耗量: %d\n", firstReceipt.GasUsed)
 		fmt.Printf("累计Gas消耗: %d\n", firstReceipt.CumulativeGasUsed)
-		
+
 		// 合约地址信息
 		if firstReceipt.ContractAddress == (common.Address{}) {
 			fmt.Println("合约地址: 无（非合约创建交易）")
 		} else {
 			fmt.Printf("合约地址: %s（合约创建交易）\n", firstReceipt.ContractAddress.Hex())
 		}
-		
+
 		// 区块信息
 		fmt.Printf("所在区块哈希: %s\n", firstReceipt.BlockHash.Hex())
 		fmt.Printf("所在区块号: %d\n", firstReceipt.BlockNumber.Uint64())
@@ -111,24 +127,35 @@ func main() {
 	// 指定要查询的交易哈希
 	txHash := common.HexToHash("0x20294a03e8766e9aeab58327fc4112756017c6c28f6f99c7722f4a29075601c5")
 	fmt.Printf("查询交易哈希: %s\n", txHash.Hex())
-	
+
 	// 使用TransactionReceipt方法查询单个交易的回执
-	receipt, err := client.TransactionReceipt(context.Background(), txHash)
+	receipt, err := query.Receipt(ctx, client, txHash)
 	if err != nil {
-		log.Fatal("查询单个交易回执失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("✓ 成功获取交易回执")
 
 	// ===== 第8步：显示单个交易回执的详细信息 =====
 	fmt.Println("\n=== 单个交易回执详细信息 ===")
-	
+
 	// 交易执行状态
 	if receipt.Status == 1 {
 		fmt.Println("交易状态: 成功执行")
 	} else {
 		fmt.Println("交易状态: 执行失败")
+		// 回执里没有失败原因，在父区块的状态上重放交易取回 revert 数据并解码
+		// 普通节点只保留最近一段时间的状态，较早的交易需要归档节点才能重放
+		reverts := revert.NewDecoder(calldata.DefaultSignatures())
+		if err := reverts.AddABI("ERC20", mytoken.MyTokenMetaData.ABI); err != nil {
+			log.Fatal(err)
+		}
+		if reason, err := reverts.Replay(ctx, client, txHash); err != nil {
+			fmt.Printf("失败原因: 无法取得（%v）\n", err)
+		} else {
+			fmt.Printf("失败原因: %s\n", reason)
+		}
 	}
-	
+
 	// 事件日志
 	fmt.Printf("事件日志数量: %d\n", len(receipt.Logs))
 	if len(receipt.Logs) == 0 {
@@ -139,27 +166,27 @@ func main() {
 			fmt.Printf("  日志 #%d: 地址=%s, 主题数量=%d\n", i+1, eventLog.Address.Hex(), len(eventLog.Topics))
 		}
 	}
-	
+
 	// 交易标识信息
 	fmt.Printf("交易哈希: %s\n", receipt.TxHash.Hex())
 	fmt.Printf("交易索引: %d\n", receipt.TransactionIndex)
-	
+
 	// Gas相关信息
 	fmt.Printf("Gas消耗量: %d\n", receipt.GasUsed)
 	fmt.Printf("累计Gas消耗: %d\n", receipt.CumulativeGasUsed)
 	fmt.Printf("有效Gas价格: %s Wei\n", receipt.EffectiveGasPrice.String())
-	
+
 	// 合约相关信息
 	if receipt.ContractAddress == (common.Address{}) {
 		fmt.Println("合约地址: 无（非合约创建交易）")
 	} else {
 		fmt.Printf("新创建的合约地址: %s\n", receipt.ContractAddress.Hex())
 	}
-	
+
 	// 区块位置信息
 	fmt.Printf("所在区块哈希: %s\n", receipt.BlockHash.Hex())
 	fmt.Printf("所在区块号: %d\n", receipt.BlockNumber.Uint64())
-	
+
 	// 交易类型（EIP-2718）
 	fmt.Printf("交易类型: %d\n", receipt.Type)
 
@@ -168,6 +195,8 @@ func main() {
 	fmt.Println("✓ 单个回执查询完成")
 	fmt.Println("✓ 回执信息解析完成")
 
+	st := client.Stats()
+	fmt.Printf("\n缓存统计: 内存命中 %d，磁盘命中 %d，未命中 %d\n", st.Hits, st.DiskHits, st.Misses)
 	fmt.Println("\n=== 使用说明 ===")
 	fmt.Println("1. 替换<API_KEY>为实际的Alchemy或Infura密钥")
 	fmt.Println("2. 调整区块号、区块哈希和交易哈希为要查询的实际值")
@@ -196,8 +225,13 @@ func main() {
 	//    - 包含事件的地址、主题(Topics)和数据
 	//    - 普通转账交易通常没有事件日志
 	//
-	// 5. 交易类型：
+	// 5. 失败原因：
+	//    - 回执只记录 Status=0，不包含 revert 数据
+	//    - revert.Replay 在父区块状态上用 eth_call 重放交易，解码 Error(string)、
+	//      Panic(uint256)（附错误码说明）和自定义错误；Gas 不足等执行错误也会如实报告
+	//
+	// 6. 交易类型：
 	//    - Type 0: Legacy交易
 	//    - Type 1: EIP-2930 (访问列表交易)
 	//    - Type 2: EIP-1559 (动态费用交易)
-}
\ No newline at end of file
+}
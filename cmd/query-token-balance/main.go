@@ -1,30 +1,26 @@
 package main
 
 import (
-	"fmt" // 格式化输入输出
-	"log" // 日志记录
+	"context" // 上下文管理
+	"fmt"     // 格式化输入输出
+	"log"     // 日志记录
 
-	"github.com/ethereum/go-ethereum/common"    // 以太坊通用工具
-	"github.com/ethereum/go-ethereum/ethclient" // 以太坊客户端
+	"github.com/ethereum/go-ethereum/common" // 以太坊通用工具
 
-	// 注意：在实际项目中，您需要使用abigen工具生成ERC20代币合约的Go绑定文件
-	// 命令：abigen --abi token.abi --pkg token --out token.go
-	// 然后导入以下包：
-	// "math"
-	// "math/big"
-	// "github.com/ethereum/go-ethereum/accounts/abi/bind"
-	// token "./contracts_erc20"
+	"github.com/duanyu/new-eth-project/pkg/backend"   // 以太坊后端接口
+	"github.com/duanyu/new-eth-project/pkg/multicall" // Multicall3 合并调用
+	"github.com/duanyu/new-eth-project/pkg/query"     // 查询功能库
 )
 
 // main函数 - 查询ERC20代币余额
 // 功能：查询指定地址的ERC20代币余额及代币基本信息
 // 这是一个完整的代币余额查询实现，使用智能合约绑定
 func main() {
-	// 步骤1：连接以太坊网络（演示用）
+	// 步骤1：连接以太坊网络
 	// 使用Cloudflare提供的以太坊主网节点
-	_, err := ethclient.Dial("https://cloudflare-eth.com")
+	client, err := backend.Dial("https://cloudflare-eth.com")
 	if err != nil {
-		log.Fatal("连接以太坊客户端失败:", err)
+		log.Fatal(err)
 	}
 	fmt.Println("成功连接到以太坊网络")
 
@@ -38,61 +34,39 @@ func main() {
 	address := common.HexToAddress("0x25836239F7b632635F815689389C537133248edb")
 	fmt.Printf("查询地址: %s\n\n", address.Hex())
 
-	// 注意：以下代码展示了如何使用ERC20代币合约绑定查询代币信息
-	// 在实际使用时，您需要先生成合约绑定文件
-	
-	fmt.Printf("=== ERC20代币余额查询示例 ===\n")
-	fmt.Printf("代币合约地址: %s\n", tokenAddress.Hex())
-	fmt.Printf("查询地址: %s\n", address.Hex())
-	fmt.Printf("\n=== 使用说明 ===\n")
-	fmt.Printf("1. 首先需要获取ERC20代币合约的ABI文件\n")
-	fmt.Printf("2. 使用abigen工具生成Go绑定代码:\n")
-	fmt.Printf("   abigen --abi token.abi --pkg token --out token.go\n")
-	fmt.Printf("3. 导入生成的包并创建合约实例\n")
-	fmt.Printf("4. 调用合约函数查询代币信息\n")
-	
-	// 以下是完整的代码示例（需要合约绑定文件）：
-	/*
-	// 创建代币合约实例
-	instance, err := token.NewToken(tokenAddress, client)
-	if err != nil {
-		log.Fatal("创建代币合约实例失败:", err)
-	}
-	
-	// 查询代币余额
-	bal, err := instance.BalanceOf(&bind.CallOpts{}, address)
+	// 步骤4：查询代币余额和基本信息
+	// query.TokenBalance 使用 pkg/bindings/mytoken 的绑定调用
+	// balanceOf、name、symbol、decimals 这几个ERC20标准函数
+	info, err := query.TokenBalance(context.Background(), client, tokenAddress, address)
 	if err != nil {
-		log.Fatal("查询代币余额失败:", err)
+		log.Fatal(err)
 	}
-	
-	// 查询代币基本信息
-	name, err := instance.Name(&bind.CallOpts{})
-	if err != nil {
-		log.Fatal("查询代币名称失败:", err)
+
+	// 步骤5：显示结果
+	fmt.Printf("=== ERC20代币余额 ===\n")
+	fmt.Printf("代币名称: %s\n", info.Name)
+	fmt.Printf("代币符号: %s\n", info.Symbol)
+	fmt.Printf("小数位数: %v\n", info.Decimals)
+	fmt.Printf("原始余额: %s\n", info.Raw)
+	// 按小数位数转换为可读格式
+	fmt.Printf("余额: %f %s\n", info.Value(), info.Symbol)
+
+	// 步骤6：用 Multicall3 一次查询多个地址的余额
+	// 主网已在标准地址部署 Multicall3，name、symbol、decimals 和每个地址的 balanceOf
+	// 合并成一次 eth_call 执行，所有结果都来自同一个区块
+	holders := []common.Address{
+		address,
+		common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72"),
+		common.HexToAddress("0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe"),
 	}
-	
-	symbol, err := instance.Symbol(&bind.CallOpts{})
+	balances, err := multicall.New(client).TokenBalances(context.Background(), tokenAddress, holders, nil)
 	if err != nil {
-		log.Fatal("查询代币符号失败:", err)
+		log.Fatal(err)
 	}
-	
-	decimals, err := instance.Decimals(&bind.CallOpts{})
-	if err != nil {
-		log.Fatal("查询代币小数位数失败:", err)
+	fmt.Printf("\n=== Multicall 批量余额（1 次请求）===\n")
+	for _, b := range balances {
+		fmt.Printf("%s: %f %s\n", b.Holder.Hex(), b.Value(), b.Symbol)
 	}
-	
-	// 显示结果
-	fmt.Printf("代币名称: %s\n", name)
-	fmt.Printf("代币符号: %s\n", symbol)
-	fmt.Printf("小数位数: %v\n", decimals)
-	fmt.Printf("原始余额: %s\n", bal)
-	
-	// 转换为可读格式
-	fbal := new(big.Float)
-	fbal.SetString(bal.String())
-	value := new(big.Float).Quo(fbal, big.NewFloat(math.Pow10(int(decimals))))
-	fmt.Printf("余额: %f %s\n", value, symbol)
-	*/
 
 	// 小白说明：
 	// 1. ERC20是以太坊上最常用的代币标准
@@ -101,5 +75,6 @@ func main() {
 	// 4. BalanceOf、Name、Symbol、Decimals是ERC20标准的基本函数
 	// 5. &bind.CallOpts{}表示只读调用，不消耗Gas
 	// 6. 智能合约绑定让我们可以像调用普通Go函数一样调用合约函数
-	// 7. 需要使用abigen工具从合约ABI生成Go绑定代码
-}
\ No newline at end of file
+	// 7. 合约绑定由 go generate ./pkg/bindings/... 从合约源码生成
+	// 8. Multicall3 是一个把多个只读调用打包执行的合约，查询大量地址时能显著减少请求次数
+}
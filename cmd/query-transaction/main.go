@@ -9,29 +9,43 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"os"
+	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/query"
 )
 
 func main() {
+	ctx := context.Background()
 	fmt.Println("=== 以太坊交易查询工具 ===")
-	fmt.Println("本工具演示如何查询以太坊交易信息，包括多种查询方式\n")
+	fmt.Print("本工具演示如何查询以太坊交易信息，包括多种查询方式\n\n")
 
 	// ===== 第1步：连接以太坊网络 =====
 	// 连接到Sepolia测试网络
 	// 注意：请替换<API_KEY>为您的实际API密钥
-	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	conn, err := backend.Dial("https://eth-sepolia.g.alchemy.com/v2/<API_KEY>")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 已确定的区块、交易和收据不会再变化，用响应缓存包装客户端：
+	// 结果保存在临时目录的 eth-cache 中，再次运行时直接从磁盘读取，不再请求节点
+	store, err := cache.NewDiskStore(filepath.Join(os.TempDir(), "eth-cache"))
 	if err != nil {
-		log.Fatal("连接以太坊网络失败:", err)
+		log.Fatal(err)
 	}
+	client := cache.Wrap(conn, cache.WithStore(store))
 	fmt.Println("✓ 成功连接到Sepolia测试网络")
 
 	// ===== 第2步：获取网络链ID =====
 	// 链ID用于识别不同的以太坊网络
 	// Sepolia测试网的链ID是11155111
-	chainID, err := client.ChainID(context.Background())
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		log.Fatal("获取链ID失败:", err)
 	}
@@ -44,17 +58,31 @@ func main() {
 	fmt.Printf("查询区块号: %s\n", blockNumber.String())
 
 	// 根据区块号获取区块信息
-	block, err := client.BlockByNumber(context.Background(), blockNumber)
+	block, err := query.Block(ctx, client, blockNumber)
 	if err != nil {
-		log.Fatal("获取区块信息失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("区块哈希: %s\n", block.Hash().Hex())
-	fmt.Printf("区块中交易数量: %d\n\n", len(block.Transactions()))
-
-	// 遍历区块中的所有交易
-	for i, tx := range block.Transactions() {
+	fmt.Printf("区块哈希: %s\n", block.Hash.Hex())
+	fmt.Printf("区块中交易数量: %d\n\n", block.TxCount)
+
+	// ===== 第4-5步：恢复发送方地址并获取交易回执 =====
+	// query.BlockTransactionsOf 直接使用上一步取得的区块，用同一个签名器恢复所有发送方地址，
+	// 并为每笔交易查询回执（包含交易执行结果和Gas使用情况），
+	// 回执通过 eth_getBlockReceipts 一次取回，并先查响应缓存
+	txs, err := query.BlockTransactionsOf(ctx, client, block.Block)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// calldata 解码器：先按登记的 ABI 解码，再查离线的 4 字节签名库
+	// 可以用 decoder 的签名库 Import 从 4byte.directory 导出的文件补充更多签名
+	decoder := calldata.NewDecoder(calldata.DefaultSignatures())
+	if err := decoder.AddABI("ERC20", mytoken.MyTokenMetaData.ABI); err != nil {
+		log.Fatal(err)
+	}
+	for i, info := range txs {
+		tx, receipt := info.Tx, info.Receipt
 		fmt.Printf("--- 交易 #%d ---\n", i+1)
-		
+
 		// ===== 交易基本信息 =====
 		fmt.Printf("交易哈希: %s\n", tx.Hash().Hex())
 		fmt.Printf("转账金额: %s Wei\n", tx.Value().String())
@@ -62,28 +90,18 @@ func main() {
 		fmt.Printf("Gas价格: %d Wei\n", tx.GasPrice().Uint64())
 		fmt.Printf("Nonce: %d\n", tx.Nonce())
 		fmt.Printf("交易数据: %x\n", tx.Data())
-		
+		// 解码调用的方法和参数，选择器碰撞时列出全部候选，multicall 等嵌套调用逐层展开
+		if call := decoder.Decode(tx.To(), tx.Data()); call != nil {
+			fmt.Printf("解码结果:\n%s", call)
+		}
+
 		// 接收方地址
 		if tx.To() != nil {
 			fmt.Printf("接收方地址: %s\n", tx.To().Hex())
 		} else {
 			fmt.Println("接收方地址: 合约创建交易")
 		}
-
-		// ===== 第4步：恢复发送方地址 =====
-		// 使用EIP155签名器从交易签名中恢复发送方地址
-		if sender, err := types.Sender(types.NewEIP155Signer(chainID), tx); err == nil {
-			fmt.Printf("发送方地址: %s\n", sender.Hex())
-		} else {
-			log.Fatal("恢复发送方地址失败:", err)
-		}
-
-		// ===== 第5步：获取交易回执 =====
-		// 交易回执包含交易执行结果和Gas使用情况
-		receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
-		if err != nil {
-			log.Fatal("获取交易回执失败:", err)
-		}
+		fmt.Printf("发送方地址: %s\n", info.From.Hex())
 
 		// 交易状态：1表示成功，0表示失败
 		fmt.Printf("交易状态: %d ", receipt.Status)
@@ -92,12 +110,12 @@ func main() {
 		} else {
 			fmt.Println("(失败)")
 		}
-		
+
 		// 事件日志数量
 		fmt.Printf("事件日志数量: %d\n", len(receipt.Logs))
 		fmt.Printf("实际Gas使用: %d\n", receipt.GasUsed)
 		fmt.Printf("累积Gas使用: %d\n\n", receipt.CumulativeGasUsed)
-		
+
 		// 只显示第一个交易的详细信息
 		break
 	}
@@ -108,22 +126,15 @@ func main() {
 	blockHash := common.HexToHash("0xae713dea1419ac72b928ebe6ba9915cd4fc1ef125a606f90f5e783c47cb1a4b5")
 	fmt.Printf("查询区块哈希: %s\n", blockHash.Hex())
 
-	// 获取指定区块中的交易数量
-	count, err := client.TransactionCount(context.Background(), blockHash)
+	// 按哈希获取区块，再取出其中的全部交易
+	hashTxs, err := query.BlockTransactions(ctx, client, blockHash)
 	if err != nil {
-		log.Fatal("获取交易数量失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("区块中交易数量: %d\n", count)
+	fmt.Printf("区块中交易数量: %d\n", len(hashTxs))
+	for idx, info := range hashTxs {
+		fmt.Printf("交易索引 %d: %s\n", idx, info.Tx.Hash().Hex())
 
-	// 通过索引获取区块中的特定交易
-	for idx := uint(0); idx < count; idx++ {
-		tx, err := client.TransactionInBlock(context.Background(), blockHash, idx)
-		if err != nil {
-			log.Fatal("获取交易失败:", err)
-		}
-
-		fmt.Printf("交易索引 %d: %s\n", idx, tx.Hash().Hex())
-		
 		// 只显示第一个交易
 		break
 	}
@@ -136,20 +147,22 @@ func main() {
 	fmt.Printf("查询交易哈希: %s\n", txHash.Hex())
 
 	// 通过交易哈希获取交易信息
-	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	info, err := query.Transaction(ctx, client, txHash)
 	if err != nil {
-		log.Fatal("获取交易信息失败:", err)
+		log.Fatal(err)
 	}
 
 	// 检查交易是否还在待处理状态
-	fmt.Printf("交易是否待处理: %t\n", isPending)
-	if isPending {
+	fmt.Printf("交易是否待处理: %t\n", info.IsPending)
+	if info.IsPending {
 		fmt.Println("交易状态: 待处理(在内存池中)")
 	} else {
 		fmt.Println("交易状态: 已确认(已打包到区块)")
 	}
-	fmt.Printf("交易哈希验证: %s\n", tx.Hash().Hex())
+	fmt.Printf("交易哈希验证: %s\n", info.Tx.Hash().Hex())
 
+	st := client.Stats()
+	fmt.Printf("\n缓存统计: 内存命中 %d，磁盘命中 %d，未命中 %d\n", st.Hits, st.DiskHits, st.Misses)
 	fmt.Println("\n=== 查询完成 ===")
 	fmt.Println("\n=== 重要说明 ===")
 	fmt.Println("1. 请替换API_KEY为您的实际密钥")
@@ -173,6 +186,11 @@ func main() {
 	//    - Data: 交易附带的数据(合约调用参数)
 	//    - To: 接收方地址(nil表示合约创建)
 	//
+	// 2.1 交易数据解码：
+	//    - Data 的前 4 字节是方法选择器，即方法签名 keccak256 哈希的前 4 字节
+	//    - 有合约 ABI 时按 ABI 解码，参数带名称；否则查 4 字节签名库，参数命名为 arg0、arg1...
+	//    - 不同签名可能得到相同的选择器，重新编码后与原数据一致的候选排在前面
+	//
 	// 3. 地址恢复：
 	//    - 使用EIP155签名器从交易签名恢复发送方地址
 	//    - 需要正确的链ID来防止重放攻击
@@ -187,4 +205,4 @@ func main() {
 	//    - Pending: 交易在内存池中等待打包
 	//    - Confirmed: 交易已被打包到区块中
 	//    - Failed: 交易执行失败但仍被打包
-}
\ No newline at end of file
+}
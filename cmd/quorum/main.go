@@ -0,0 +1,146 @@
+// 多节点一致性查询工具
+// 本程序把同一个关键读请求（余额、收据、区块哈希、eth_call）发给多个节点，在同一个固定区块上比较结果，
+// 至少 K 个节点一致时才输出结果，并列出每个节点的回答，用于发现返回错误数据或落后的节点
+//
+//	go run ./cmd/quorum -rpcs https://节点1,https://节点2,https://节点3 -k 2 -balance 0x账户
+//	go run ./cmd/quorum -rpcs ... -k 3 -receipt 0x交易哈希
+//	go run ./cmd/quorum -rpcs ... -k 2 -block-hash -block 19000000
+//	go run ./cmd/quorum -rpcs ... -k 2 -to 0x代币 -sig "balanceOf(address)" -args 0x持有人
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcs := flag.String("rpcs", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>,https://mainnet.infura.io/v3/<API_KEY>,https://cloudflare-eth.com", "节点地址，多个用逗号分隔")
+	k := flag.Int("k", 2, "至少多少个节点一致才接受结果")
+	block := flag.Int64("block", -1, "在哪个区块上比较，默认至少 K 个节点已到达的最高区块")
+	balance := flag.String("balance", "", "查询该地址的 ETH 余额")
+	receipt := flag.String("receipt", "", "查询该交易的收据")
+	blockHash := flag.Bool("block-hash", false, "查询区块哈希")
+	to := flag.String("to", "", "eth_call 的合约地址")
+	data := flag.String("data", "", "eth_call 的调用数据，与 -sig 二选一")
+	sig := flag.String("sig", "", "方法签名，如 balanceOf(address)")
+	args := flag.String("args", "", "方法参数，多个用逗号分隔")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== 多节点一致性查询工具 ===")
+
+	// ===== 第1步：连接全部节点 =====
+	client, err := multiclient.Dial(ctx, strings.Split(*rpcs, ","), multiclient.WithCheckInterval(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	for _, st := range client.Endpoints() {
+		if st.Healthy {
+			fmt.Printf("✓ %s 区块 %d，延迟 %s\n", st.Name, st.Head, st.Latency)
+		} else {
+			fmt.Printf("✗ %s %v\n", st.Name, st.Err)
+		}
+	}
+	q, err := client.Quorum(*k)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ===== 第2步：确定比较的区块 =====
+	var number *big.Int
+	if *block >= 0 {
+		number = big.NewInt(*block)
+	} else if *receipt == "" {
+		if number, err = q.Pin(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if number != nil {
+		fmt.Printf("\n固定在区块 %s 上比较\n\n", number)
+	}
+
+	// ===== 第3步：发送一致性请求 =====
+	var (
+		result string
+		report *multiclient.Report
+	)
+	switch {
+	case *balance != "":
+		var wei *big.Int
+		wei, report, err = q.BalanceAt(ctx, common.HexToAddress(*balance), number)
+		if err == nil {
+			result = fmt.Sprintf("余额: %s Wei", wei)
+		}
+	case *receipt != "":
+		r, rep, e := q.TransactionReceipt(ctx, common.HexToHash(*receipt))
+		report, err = rep, e
+		if err == nil {
+			result = fmt.Sprintf("收据: 区块 %s，状态 %d，Gas %d，日志 %d 条", r.BlockNumber, r.Status, r.GasUsed, len(r.Logs))
+		}
+	case *blockHash:
+		var hash common.Hash
+		hash, report, err = q.BlockHash(ctx, number)
+		if err == nil {
+			result = "区块哈希: " + hash.Hex()
+		}
+	case *to != "":
+		target := common.HexToAddress(*to)
+		msg := ethereum.CallMsg{To: &target}
+		if *sig != "" {
+			var list []string
+			if *args != "" {
+				list = strings.Split(*args, ",")
+			}
+			if msg.Data, err = calldata.Encode(*sig, list...); err != nil {
+				log.Fatal(err)
+			}
+		} else if msg.Data, err = hexutil.Decode(*data); err != nil {
+			log.Fatal("无效的 -data: ", err)
+		}
+		var out []byte
+		out, report, err = q.CallContract(ctx, msg, number)
+		if err == nil {
+			result = "返回数据: " + hexutil.Encode(out)
+		}
+	default:
+		log.Fatal("请指定 -balance、-receipt、-block-hash 或 -to 之一")
+	}
+
+	if report != nil {
+		fmt.Print(report)
+	}
+	if errors.Is(err, multiclient.ErrNoQuorum) {
+		log.Fatalf("✗ 节点之间没有达成一致，结果不可信")
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n✓ %s\n", result)
+
+	// 小白说明：
+	// 1. 只连一个节点时，节点返回什么就只能信什么；多个独立的服务商同时返回相同结果，被篡改的可能性就小得多
+	// 2. K 表示至少几个节点一致才接受，例如 3 个节点中要求 2 个一致，可以容忍 1 个节点出错
+	// 3. ✗ 标记的节点给出了不同的答案或请求失败，可能是数据落后、节点故障或故意返回错误数据
+	//
+	// 技术说明：
+	// 1. 不同节点的最新区块可能相差一两个，直接查询“最新”会因为高度不同而误判分歧；
+	//    这里先按健康检查取至少 K 个节点已到达的最高区块，所有请求固定在该区块上
+	// 2. 先并发询问 K 个节点，全部一致就不再请求其余节点；不一致时再询问剩下的节点
+	// 3. 交易不存在、合约回滚是确定性的结果，同样参与投票；超时等网络错误只记为该节点的分歧
+	// 4. 收据比较共识编码（状态、累计 Gas、布隆过滤器、日志）和所在区块
+}
@@ -0,0 +1,128 @@
+// 合约存储查看工具
+// 本程序根据 solc 编译产物中的存储布局（storage-layout），计算状态变量、映射键、数组元素所在的存储槽，
+// 通过 eth_getStorageAt 读取并解码（包括打包在同一槽中的变量和长 string），也可以查看历史区块的状态
+//
+//	# 列出 MyToken 的全部状态变量
+//	go run ./cmd/storage -address 0x代币 -artifact contracts/build/MyToken.json
+//	# 读取某个地址的余额和授权额度
+//	go run ./cmd/storage -address 0x代币 -var "_balances[0x持有人],_allowances[0x持有人][0x被授权人]"
+//	# 在历史区块上读取结构体
+//	go run ./cmd/storage -address 0x投票合约 -artifact contracts/build/contract-templates.json -contract Voting \
+//	    -var "proposals[0]" -block 19000000
+//	# 直接读取原始存储槽
+//	go run ./cmd/storage -address 0x合约 -slot 0x5
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/storage"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := flag.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	address := flag.String("address", "", "合约地址")
+	artifact := flag.String("artifact", "contracts/build/MyToken.json", "solc --combined-json 编译产物（需包含 storage-layout）")
+	contract := flag.String("contract", "", "编译产物中的合约名，只有一个合约时可以省略")
+	vars := flag.String("var", "", "要读取的表达式，如 _balances[0x..] 或 proposals[1].name，多个用逗号分隔；不指定时列出全部状态变量")
+	slot := flag.String("slot", "", "直接读取的原始存储槽，多个用逗号分隔")
+	var block blocktag.Selector
+	flag.Var(&block, "block", "读取哪个区块的状态：latest、pending、safe、finalized、区块号、区块哈希、-N 或 ISO 时间，默认最新区块（历史区块需要归档节点）")
+	limit := flag.Int("limit", storage.DefaultArrayLimit, "数组最多展开的元素个数")
+	flag.Parse()
+	ctx := context.Background()
+
+	fmt.Println("=== 合约存储查看工具 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	if !common.IsHexAddress(*address) {
+		log.Fatal("请用 -address 指定合约地址")
+	}
+	opts := []storage.Option{storage.WithArrayLimit(*limit)}
+	var number *big.Int
+	if !block.IsLatest() {
+		if number, err = block.BlockNumber(ctx, client); err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, storage.WithBlock(number))
+		fmt.Printf("读取区块 %s 的状态\n", block)
+	}
+	// -block 为区块哈希时按区块号读取，读完后确认该区块没有被重组掉
+	verify := func() {
+		if err := block.Verify(ctx, client, number); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// ===== 第2步：读取原始存储槽 =====
+	if *slot != "" {
+		in := storage.New(client, common.HexToAddress(*address), &storage.Layout{}, opts...)
+		for _, s := range strings.Split(*slot, ",") {
+			key := common.HexToHash(strings.TrimSpace(s))
+			value, err := in.Slot(ctx, key)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("槽 %s = %s\n", key.Hex(), value.Hex())
+		}
+		verify()
+		return
+	}
+
+	// ===== 第3步：加载存储布局 =====
+	layout, err := storage.LoadArtifact(*artifact, *contract)
+	if err != nil {
+		log.Fatal(err)
+	}
+	in := storage.New(client, common.HexToAddress(*address), layout, opts...)
+	fmt.Printf("✓ 已加载存储布局，共 %d 个状态变量\n\n", len(layout.Storage))
+
+	// ===== 第4步：读取并解码 =====
+	if *vars == "" {
+		values, err := in.Dump(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, v := range values {
+			fmt.Print(v)
+		}
+	} else {
+		for _, expr := range strings.Split(*vars, ",") {
+			v, err := in.Read(ctx, strings.TrimSpace(expr))
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Print(v)
+		}
+	}
+	verify()
+
+	// 小白说明：
+	// 1. 合约的状态变量都保存在存储槽里，每个槽 32 字节，按声明顺序从槽 0 开始排列
+	// 2. 小于 32 字节的相邻变量会挤在同一个槽里（如 MyToken 的 decimals 和 owner 都在槽 5），“偏移”表示从右往左第几个字节
+	// 3. 映射没有办法列出所有键，需要用 变量名[键] 的方式指定要查的键
+	// 4. private 变量也能这样读出来，链上没有真正的“私有”数据
+	//
+	// 技术说明：
+	// 1. 映射键 k 的值在 keccak256(pad32(k) . 槽号)，string / bytes 类型的键不填充
+	// 2. 动态数组的槽存长度，元素从 keccak256(槽号) 开始；不超过 16 字节的元素会多个打包在一个槽中
+	// 3. string / bytes 不超过 31 字节时内容和 长度*2 在同一个槽，否则槽中存 长度*2+1，内容从 keccak256(槽号) 开始
+	// 4. 存储布局来自 solc --storage-layout，go run ./cmd/gen-bindings 生成的 contracts/build/*.json 已包含
+}
@@ -1,153 +1,106 @@
 package main
 
 import (
-	"context"      // 上下文管理，用于控制请求的生命周期
-	"crypto/ecdsa" // 椭圆曲线数字签名算法
-	"fmt"          // 格式化输入输出
-	"log"          // 日志记录
-	"math/big"     // 大数运算，处理代币数量等大整数
+	"context"  // 上下文管理，用于控制请求的生命周期
+	"errors"   // 错误判断
+	"flag"     // 命令行参数
+	"fmt"      // 格式化输入输出
+	"io"       // 读取用户确认
+	"log"      // 日志记录
+	"math/big" // 大数运算，处理代币数量等大整数
+	"os"       // 标准输入输出
 
-	"golang.org/x/crypto/sha3" // SHA3哈希算法
-
-	"github.com/ethereum/go-ethereum"                // 以太坊核心库
 	"github.com/ethereum/go-ethereum/common"         // 以太坊通用工具
 	"github.com/ethereum/go-ethereum/common/hexutil" // 十六进制工具
-	"github.com/ethereum/go-ethereum/core/types"     // 以太坊交易类型
-	"github.com/ethereum/go-ethereum/crypto"         // 以太坊加密工具
-	"github.com/ethereum/go-ethereum/ethclient"      // 以太坊客户端
+
+	"github.com/duanyu/new-eth-project/pkg/backend"  // 以太坊后端接口与交易签名
+	"github.com/duanyu/new-eth-project/pkg/calldata" // 4字节签名库
+	"github.com/duanyu/new-eth-project/pkg/revert"   // 回滚原因解码
+	"github.com/duanyu/new-eth-project/pkg/simulate" // 发送前模拟
+	"github.com/duanyu/new-eth-project/pkg/transfer" // 转账功能库
 )
 
 // main函数 - ERC20代币转账
 // 功能：发送ERC20代币从一个地址到另一个地址
 // 这是一个完整的代币转账实现，包括智能合约调用
 func main() {
+	// -dry-run 只模拟不发送；-yes 模拟后不询问直接发送；-force 模拟执行失败时仍然发送
+	dryRun := flag.Bool("dry-run", false, "只模拟交易并显示预期结果，不发送")
+	yes := flag.Bool("yes", false, "模拟后不询问，直接发送")
+	force := flag.Bool("force", false, "模拟执行失败时仍然发送（会消耗 Gas）")
+	flag.Parse()
+
 	// 步骤1：连接以太坊网络
 	// 使用Alchemy提供的Sepolia测试网络节点
 	// 注意：需要将URL中的API_KEY替换为您的实际密钥
-	client, err := ethclient.Dial("https://eth-sepolia.g.alchemy.com/v2/")
+	client, err := backend.Dial("https://eth-sepolia.g.alchemy.com/v2/")
 	if err != nil {
-		log.Fatal("连接以太坊客户端失败:", err)
+		log.Fatal(err)
 	}
 
-	// 步骤2：加载发送方私钥
+	// 步骤2：加载发送方私钥并推导地址
 	// 注意：实际使用时需要将"账户私钥"替换为真实的私钥
 	// 私钥格式：64位十六进制字符串（不包含0x前缀）
-	privateKey, err := crypto.HexToECDSA("账户私钥")
-	if err != nil {
-		log.Fatal("私钥解析失败:", err)
-	}
-
-	// 步骤3：从私钥推导公钥和地址
-	// 获取公钥
-	publicKey := privateKey.Public()
-	// 将公钥转换为ECDSA公钥类型
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		log.Fatal("公钥类型转换失败: 无法转换为*ecdsa.PublicKey类型")
-	}
-
-	// 从公钥推导出发送方地址
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	fmt.Printf("发送方地址: %s\n", fromAddress.Hex())
-
-	// 步骤4：获取交易nonce
-	// nonce是账户的交易序号，防止重复交易
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		log.Fatal("获取nonce失败:", err)
-	}
-	fmt.Printf("当前nonce: %d\n", nonce)
-
-	// 步骤5：设置交易参数
-	// 代币转账不需要发送ETH，所以value设为0
-	value := big.NewInt(0) // 0 ETH（因为我们转账的是代币，不是ETH）
-
-	// 获取当前网络建议的Gas价格
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	signer, err := backend.SignerFromHex("账户私钥")
 	if err != nil {
-		log.Fatal("获取Gas价格失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("Gas价格: %s wei\n", gasPrice.String())
+	fmt.Printf("发送方地址: %s\n", signer.Address.Hex())
 
-	// 步骤6：设置转账目标和代币合约地址
+	// 步骤3：设置转账目标和代币合约地址
 	// 接收代币的地址
 	toAddress := common.HexToAddress("0x4592d8f8d7b001e72cb26a73e4fa1806a51ac79d")
 	// ERC20代币合约地址
 	tokenAddress := common.HexToAddress("0x28b149020d2152179873ec60bed6bf7cd705775d")
-
 	fmt.Printf("接收地址: %s\n", toAddress.Hex())
 	fmt.Printf("代币合约地址: %s\n", tokenAddress.Hex())
 
-	// 步骤7：构建智能合约调用数据
-	// ERC20的transfer函数签名：transfer(address,uint256)
-	transferFnSignature := []byte("transfer(address,uint256)")
-
-	// 计算函数选择器（前4字节的Keccak256哈希）
-	hash := sha3.NewLegacyKeccak256()
-	hash.Write(transferFnSignature)
-	methodID := hash.Sum(nil)[:4]
-	fmt.Printf("函数选择器: %s\n", hexutil.Encode(methodID)) // 0xa9059cbb
-
-	// 将接收地址填充为32字节（左填充0）
-	paddedAddress := common.LeftPadBytes(toAddress.Bytes(), 32)
-	fmt.Printf("填充后的地址: %s\n", hexutil.Encode(paddedAddress))
-
 	// 设置转账数量（这里是1000个代币，假设代币有18位小数）
 	amount := new(big.Int)
 	amount.SetString("1000000000000000000000", 10) // 1000 * 10^18 = 1000个代币
-	// 将数量填充为32字节
-	paddedAmount := common.LeftPadBytes(amount.Bytes(), 32)
-	fmt.Printf("填充后的数量: %s\n", hexutil.Encode(paddedAmount))
-
-	// 组装完整的调用数据：函数选择器 + 参数
-	var data []byte
-	data = append(data, methodID...)      // 函数选择器
-	data = append(data, paddedAddress...) // 接收地址参数
-	data = append(data, paddedAmount...)  // 转账数量参数
 
-	// 步骤8：估算Gas消耗
-	// 注意：这里To地址应该是代币合约地址，不是接收方地址
-	gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
-		From: fromAddress,
-		To:   &tokenAddress, // 修正：应该是代币合约地址
-		Data: data,
-	})
+	// 步骤4：构建智能合约调用数据
+	// 调用数据 = transfer(address,uint256) 的函数选择器 + 32字节对齐的参数
+	fmt.Printf("函数选择器: %s\n", hexutil.Encode(transfer.TransferSelector)) // 0xa9059cbb
+	fmt.Printf("调用数据: %s\n", hexutil.Encode(transfer.EncodeTransfer(toAddress, amount)))
+
+	// 步骤5：估算Gas、创建交易、模拟执行并确认，再签名并广播
+	// 注意：交易的To地址是代币合约地址，不是接收方地址；value为0
+	// 余额不足等原因导致调用回滚时，Gas估算就会失败，交易不会被发送；
+	// 错误中带有 revert 数据，解码出 require 的消息或 OpenZeppelin 5.x 的自定义错误
+	// 签名前的模拟会显示双方的代币余额变化、Transfer 事件和手续费
+	sim, err := simulate.New(client)
 	if err != nil {
-		log.Fatal("估算Gas失败:", err)
+		log.Fatal(err)
 	}
-	fmt.Printf("估算Gas消耗: %d\n", gasLimit)
-
-	// 步骤9：创建交易
-	// 注意：To地址是代币合约地址，不是接收方地址
-	tx := types.NewTransaction(nonce, tokenAddress, value, gasLimit, gasPrice, data)
-
-	// 步骤10：获取网络ID并签名交易
-	// 获取链ID（网络标识符）
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		log.Fatal("获取网络ID失败:", err)
+	var in io.Reader // -yes 时为 nil，不询问
+	if !*yes {
+		in = os.Stdin
 	}
-	fmt.Printf("网络ID: %s\n", chainID.String())
-
-	// 使用EIP155签名器签名交易
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		log.Fatal("交易签名失败:", err)
+	opts := &backend.TxOptions{Confirm: sim.Confirm(*dryRun, *force, in, os.Stdout)}
+	result, err := transfer.SendToken(context.Background(), client, signer, tokenAddress, toAddress, amount, opts)
+	if errors.Is(err, simulate.ErrDryRun) || errors.Is(err, simulate.ErrCancelled) {
+		fmt.Println(err)
+		return
+	}
+	if errors.Is(err, simulate.ErrWillFail) {
+		log.Fatalf("%v（仍要发送请加 -force）", err)
 	}
-
-	// 步骤11：广播交易到网络
-	err = client.SendTransaction(context.Background(), signedTx)
 	if err != nil {
-		log.Fatal("发送交易失败:", err)
+		if reason := revert.NewDecoder(calldata.DefaultSignatures()).FromError(err); reason != nil {
+			log.Fatalf("转账会失败，交易未发送: %s", reason)
+		}
+		log.Fatal(err)
 	}
 
 	// 输出交易结果
 	fmt.Printf("\n=== 交易发送成功 ===\n")
-	fmt.Printf("交易哈希: %s\n", signedTx.Hash().Hex())
-	fmt.Printf("发送方: %s\n", fromAddress.Hex())
-	fmt.Printf("接收方: %s\n", toAddress.Hex())
-	fmt.Printf("代币合约: %s\n", tokenAddress.Hex())
-	fmt.Printf("转账数量: %s (最小单位)\n", amount.String())
+	fmt.Printf("交易哈希: %s\n", result.Tx.Hash().Hex())
+	fmt.Printf("发送方: %s\n", result.From.Hex())
+	fmt.Printf("接收方: %s\n", result.To.Hex())
+	fmt.Printf("代币合约: %s\n", result.Token.Hex())
+	fmt.Printf("转账数量: %s (最小单位)\n", result.Amount.String())
+	fmt.Printf("Nonce: %d, Gas限制: %d, Gas价格: %s wei\n", result.Nonce, result.GasLimit, result.GasPrice.String())
 
 	// 小白说明：
 	// 1. ERC20代币转账实际上是调用智能合约的transfer函数
@@ -156,4 +109,5 @@ func main() {
 	// 4. 交易的To地址是代币合约地址，不是接收方地址
 	// 5. value为0因为我们转账的是代币，不是ETH
 	// 6. Gas费用仍然用ETH支付，即使转账的是代币
-}
\ No newline at end of file
+	// 7. 发送前会先模拟执行，确认余额变化符合预期再输入 y 发送；加 -dry-run 只看模拟结果
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/ledger"
+	"github.com/duanyu/new-eth-project/pkg/portfolio"
+	"github.com/duanyu/new-eth-project/pkg/query"
+)
+
+// holders 持有人分析：从部署区块回放 Transfer 日志到目标区块，重建全部持有人的余额，
+// 抽样用 balanceOf 核对后输出排名、集中度、资金流向和铸造/销毁总额
+func holders(args []string) {
+	fs := flag.NewFlagSet("token holders", flag.ExitOnError)
+	// 注意：请替换<API_KEY>为您的实际API密钥
+	rpcURL := fs.String("rpc", "https://eth-mainnet.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	tokenHex := fs.String("token", "", "ERC20 代币合约地址")
+	var from, to blocktag.Selector
+	to.Kind = blocktag.Latest
+	fs.Var(&from, "from", "开始回放的区块，默认自动查找合约的部署区块")
+	fs.Var(&to, "to", "回放到的区块（含）：区块号、safe、finalized、-N 或 ISO 时间，默认最新区块")
+	top := fs.Int("top", 10, "显示前几大持有人和资金流向")
+	sample := fs.Int("sample", 20, "用 balanceOf 抽样核对的持有人数，0 表示不核对")
+	fs.Parse(args)
+	ctx := context.Background()
+
+	if !common.IsHexAddress(*tokenHex) {
+		log.Fatal("请用 -token 指定代币合约地址")
+	}
+	token := common.HexToAddress(*tokenHex)
+
+	fmt.Println("=== 代币持有人分析 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：确定回放范围 =====
+	end, err := resolveBlock(ctx, client, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var start uint64
+	if from.Kind == blocktag.Latest {
+		// 二分查找合约代码第一次出现的区块，大约 log2(区块数) 次 eth_getCode
+		if start, err = ledger.DeploymentBlock(ctx, client, token, end); err != nil {
+			log.Fatalf("查找部署区块失败（可用 -from 手动指定）: %v", err)
+		}
+		fmt.Printf("✓ 合约部署于区块 %d\n", start)
+	} else if start, err = resolveBlock(ctx, client, from); err != nil {
+		log.Fatal(err)
+	}
+	if end < start {
+		log.Fatalf("结束区块 %d 早于开始区块 %d", end, start)
+	}
+
+	info, err := query.TokenBalance(ctx, client, token, token)
+	if err != nil {
+		log.Fatal(err)
+	}
+	amount := func(v *big.Int) string {
+		return fmt.Sprintf("%s %s", query.ToUnit(v, int(info.Decimals)).Text('f', -1), info.Symbol)
+	}
+	fmt.Printf("代币: %s (%s)，回放区块 %d - %d\n", info.Name, info.Symbol, start, end)
+
+	// ===== 第3步：回放 Transfer 日志 =====
+	// 范围超过服务商的 eth_getLogs 限制时会自动拆分区间
+	l, err := ledger.Replay(ctx, client, token, start, end)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("✓ 回放了 %d 个 Transfer 事件\n", l.Transfers)
+
+	// ===== 第4步：铸造、销毁与总供应量 =====
+	fmt.Println("\n=== 铸造与销毁 ===")
+	fmt.Printf("铸造: %s（%d 次）\n", amount(l.Minted), l.Mints)
+	fmt.Printf("销毁: %s（%d 次）\n", amount(l.Burned), l.Burns)
+	fmt.Printf("回放得到的供应量: %s\n", amount(l.Supply()))
+	instance, err := mytoken.NewMyToken(token, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	supply, err := instance.TotalSupply(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(end)})
+	switch {
+	case err != nil:
+		fmt.Printf("⚠ 读取区块 %d 的 totalSupply 失败: %v\n", end, err)
+	case supply.Cmp(l.Supply()) == 0:
+		fmt.Printf("✓ 与 totalSupply 一致\n")
+	default:
+		fmt.Printf("⚠ 与 totalSupply（%s）不一致，相差 %s\n", amount(supply), amount(new(big.Int).Sub(supply, l.Supply())))
+	}
+
+	// ===== 第5步：前几大持有人与集中度 =====
+	c := l.Concentration(*top)
+	fmt.Printf("\n=== 前 %d 大持有人（共 %d 个持有人）===\n", c.TopN, c.Holders)
+	supplyF := new(big.Float).SetInt(l.Supply())
+	for i, h := range l.Top(*top) {
+		share := 0.0
+		if l.Supply().Sign() > 0 {
+			share, _ = new(big.Float).Quo(new(big.Float).SetInt(h.Balance), supplyF).Float64()
+		}
+		fmt.Printf("%3d. %s  %s  %.2f%%\n", i+1, h.Address.Hex(), amount(h.Balance), share*100)
+	}
+	fmt.Println("\n=== 持仓集中度 ===")
+	fmt.Printf("前 %d 名占比: %.2f%%\n", c.TopN, c.TopShare*100)
+	fmt.Printf("HHI 指数: %.4f\n", c.HHI)
+	fmt.Printf("基尼系数: %.4f\n", c.Gini)
+
+	// ===== 第6步：地址之间的资金流向 =====
+	flows := l.Flows(*top)
+	fmt.Printf("\n=== 资金流向（前 %d 组）===\n", len(flows))
+	for _, f := range flows {
+		fmt.Printf("%s → %s  %s（%d 笔）\n", f.From.Hex(), f.To.Hex(), amount(f.Amount), f.Count)
+	}
+
+	// ===== 第7步：抽样核对 =====
+	if *sample > 0 {
+		fmt.Println("\n=== balanceOf 抽样核对 ===")
+		checks, err := l.Verify(ctx, client, *sample)
+		if errors.Is(err, portfolio.ErrNoArchive) {
+			fmt.Printf("⚠ 节点没有区块 %d 的历史状态，跳过核对（需要归档节点，或把 -to 设为最新区块）\n", end)
+		} else if err != nil {
+			log.Fatal(err)
+		} else {
+			mismatched := 0
+			for _, check := range checks {
+				if !check.OK() {
+					mismatched++
+					fmt.Printf("✗ %s: 回放 %s，链上 %s\n", check.Holder.Hex(), amount(check.Replayed), amount(check.OnChain))
+				}
+			}
+			if mismatched == 0 {
+				fmt.Printf("✓ %d 个地址全部一致\n", len(checks))
+			} else {
+				fmt.Printf("⚠ %d / %d 个地址不一致，回放结果不可信（代币可能有不发事件的余额变化）\n", mismatched, len(checks))
+			}
+		}
+	}
+	if len(l.Negative) > 0 {
+		fmt.Printf("⚠ %d 个地址的回放余额曾经为负，-from 可能晚于部署区块\n", len(l.Negative))
+	}
+
+	// 小白说明：
+	// 1. 代币合约只记录每个地址当前的余额，没有“持有人列表”这样的接口
+	// 2. 每次转账都会发出 Transfer 事件，从合约部署开始按顺序重放就能算出每个地址的余额
+	// 3. 从零地址转出是铸造（新发行），转入零地址是销毁；铸造减销毁应等于总供应量
+	// 4. 前几名占比越高、基尼系数越接近 1，代币越集中在少数地址手里
+	//
+	// 技术说明：
+	// 1. 部署区块通过对 eth_getCode 做二分查找得到，查询历史区块的代码需要归档节点，否则请用 -from 指定
+	// 2. 日志按区间查询，超过服务商限制时自动二分，主网上热门代币的完整回放仍可能需要较长时间
+	// 3. 抽样核对包括前几大持有人、按排名均匀抽取的其他持有人和余额曾为负的地址，
+	//    通过 Multicall3 在目标区块上一次查询
+	// 4. rebase、手续费代币等不发 Transfer 事件就改变余额的代币，回放结果会与 balanceOf 不一致
+}
+
+// resolveBlock 把区块选择器固定为具体的区块号，日志回放不支持 pending
+func resolveBlock(ctx context.Context, client backend.EthBackend, sel blocktag.Selector) (uint64, error) {
+	if sel.IsPending() {
+		return 0, errors.New("不支持 pending 区块")
+	}
+	header, err := sel.Resolve(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
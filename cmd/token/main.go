@@ -0,0 +1,32 @@
+// 代币分析工具
+// 第一个参数为子命令：
+//
+//	holders  回放 Transfer 日志重建持有人余额，输出前几大持有人、持仓集中度、资金流向和铸造/销毁总额
+//
+//	go run ./cmd/token holders -token 0x... -top 20
+//	go run ./cmd/token holders -token 0x... -from 19000000 -to 2024-06-01
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "holders":
+		holders(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法: token <子命令> [参数]")
+	fmt.Fprintln(os.Stderr, "  holders  回放 Transfer 日志，分析持有人、集中度、资金流向和铸造/销毁")
+	fmt.Fprintln(os.Stderr, "使用 token <子命令> -h 查看子命令的参数")
+	os.Exit(2)
+}
@@ -0,0 +1,163 @@
+// 交易追踪工具
+// 本程序调用节点的 debug_traceTransaction，展示交易执行过程中合约之间的内部调用，
+// 以及交易造成的状态变化（余额、nonce、代码、存储槽）
+//
+//	go run ./cmd/trace -tx 0x交易哈希 -state
+//	go run ./cmd/trace -tx 0x交易哈希 -abi 0x代币地址=MyToken:token.abi.json -json
+//
+// 节点没有开放 debug 命名空间时，退回到收据 + 在父区块上重放交易，只显示最外层调用和失败原因
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/trace"
+)
+
+func main() {
+	// 注意：请替换<API_KEY>为您的实际API密钥；公共节点和多数服务商的免费套餐不提供 debug 接口
+	rpcURL := flag.String("rpc", "https://eth-sepolia.g.alchemy.com/v2/<API_KEY>", "节点地址")
+	txHex := flag.String("tx", "", "要追踪的交易哈希")
+	showState := flag.Bool("state", false, "同时显示交易造成的状态变化")
+	asJSON := flag.Bool("json", false, "输出节点返回的原始 JSON，而不是调用树文本")
+	abis := flag.String("abi", "", "已知合约的 ABI，格式为 地址=名称:ABI文件，多个用逗号分隔")
+	flag.Parse()
+	if len(*txHex) != 66 {
+		log.Fatal("请用 -tx 指定 0x 开头的交易哈希")
+	}
+	hash := common.HexToHash(*txHex)
+	ctx := context.Background()
+
+	fmt.Println("=== 以太坊交易追踪工具 ===")
+
+	// ===== 第1步：连接以太坊网络 =====
+	client, err := backend.Dial(*rpcURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("✓ 成功连接到以太坊网络")
+
+	// ===== 第2步：准备解码器 =====
+	// 调用树的每一层都按 ABI 或 4 字节签名库解码方法名和参数，失败的调用解码回滚原因；
+	// 未登记的合约按通用 ERC20 ABI 尝试解码
+	db := calldata.DefaultSignatures()
+	calls := calldata.NewDecoder(db)
+	reverts := revert.NewDecoder(db)
+	if err := calls.AddABI("ERC20", mytoken.MyTokenMetaData.ABI); err != nil {
+		log.Fatal(err)
+	}
+	if *abis != "" {
+		for _, item := range strings.Split(*abis, ",") {
+			addr, rest, ok1 := strings.Cut(item, "=")
+			name, path, ok2 := strings.Cut(rest, ":")
+			if !ok1 || !ok2 || !common.IsHexAddress(addr) {
+				log.Fatalf("无效的 -abi 参数 %q，格式为 地址=名称:ABI文件", item)
+			}
+			abiJSON, err := os.ReadFile(path)
+			if err != nil {
+				log.Fatal("读取 ABI 文件失败:", err)
+			}
+			if err := calls.AddContract(common.HexToAddress(addr), name, string(abiJSON)); err != nil {
+				log.Fatal(err)
+			}
+			// 合约 ABI 中的自定义错误用于解码回滚原因
+			if err := reverts.AddABI(name, string(abiJSON)); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	tracer := trace.New(client.Client(), trace.WithCalls(calls), trace.WithReverts(reverts))
+
+	// ===== 第3步：追踪调用树 =====
+	// callTracer 在节点上重新执行交易，记录每一次 CALL、DELEGATECALL、STATICCALL、CREATE
+	fmt.Printf("\n=== 调用树 %s ===\n", hash.Hex())
+	root, err := tracer.CallTree(ctx, hash)
+	if errors.Is(err, trace.ErrNotSupported) {
+		fmt.Println("⚠️ 节点不支持 debug_traceTransaction，只能显示最外层调用和失败原因")
+		fallback(ctx, client, calls, reverts, hash)
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *asJSON {
+		printJSON(root)
+	} else {
+		fmt.Print(root)
+	}
+
+	// ===== 第4步：状态变化 =====
+	// prestateTracer 的 diffMode 只返回被修改的账户和字段
+	if *showState {
+		fmt.Println("\n=== 状态变化 ===")
+		diff, err := tracer.StateDiff(ctx, hash)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *asJSON {
+			printJSON(diff)
+		} else {
+			fmt.Print(diff)
+		}
+	}
+
+	// 小白说明：
+	// 1. 一笔交易可能调用多个合约，合约之间的调用叫“内部调用”，不会出现在收据里
+	// 2. 调用树中 ✗ 回滚点 标出最初出错的那一层，失败会沿调用链向上传递导致整笔交易回滚
+	// 3. Gas 一栏是“本层实际消耗/本层可用”，可以看出 Gas 主要花在哪里
+	// 4. 存储槽的键是 keccak256 等方式计算出的位置，配合合约的存储布局才能知道对应哪个变量
+	//
+	// 技术说明：
+	// 1. debug_traceTransaction 需要节点保留交易所在区块的父区块状态，较早的交易需要归档节点
+	// 2. 追踪在节点上重新执行交易，复杂交易可能超过默认 5 秒的超时，可用 trace.WithTimeout 调整
+	// 3. 没有 debug 接口时只能用 eth_call 在父区块上重放，看不到内部调用
+}
+
+// fallback 节点不支持追踪时，用收据和 eth_call 重放给出最外层调用和失败原因
+func fallback(ctx context.Context, client backend.EthBackend, calls *calldata.Decoder, reverts *revert.Decoder, hash common.Hash) {
+	tx, _, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		log.Fatal(err)
+	}
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if call := calls.Decode(tx.To(), tx.Data()); call != nil {
+		fmt.Print(call)
+	}
+	fmt.Printf("Gas: %d/%d\n", receipt.GasUsed, tx.Gas())
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Println("交易状态: 成功执行")
+		return
+	}
+	fmt.Println("交易状态: 执行失败")
+	if reason, err := reverts.Replay(ctx, client, hash); err != nil {
+		fmt.Printf("失败原因: 无法取得（%v）\n", err)
+	} else {
+		fmt.Printf("失败原因: %s\n", reason)
+	}
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,201 @@
+// Package backend 定义各功能库共用的以太坊后端接口
+//
+// pkg 下的查询、转账、部署、合约调用和事件库都只依赖 EthBackend，
+// 因此既可以传入 *ethclient.Client 连接真实节点，也可以传入
+// simchain.Chain 在进程内运行，或者在测试中传入自定义的模拟实现。
+package backend
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// EthBackend 功能库所需的以太坊客户端能力，是 ethclient.Client 的子集
+type EthBackend interface {
+	bind.ContractBackend
+	ethereum.ChainReader
+	ethereum.TransactionReader
+	ethereum.ChainStateReader
+
+	// ChainID 返回用于 EIP-155 签名的链 ID
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// BlockReceiptsReader 支持 eth_getBlockReceipts 的后端可以实现此接口，
+// 未实现时按交易逐个查询收据
+type BlockReceiptsReader interface {
+	BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+}
+
+// PendingBalanceReader 支持查询待处理余额的后端可以实现此接口
+type PendingBalanceReader interface {
+	PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error)
+}
+
+// FeeHistoryReader 支持 eth_feeHistory 的后端可以实现此接口，
+// 未实现时 pkg/gas 逐个读取区块头，只能得到基础费和 Gas 使用率
+type FeeHistoryReader interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// RPCClient 底层是 JSON-RPC 连接的后端可以实现此接口，功能库借此发送批量请求
+// 包装其他后端的实现（如 pkg/cache）在被包装的后端没有连接时返回 nil
+type RPCClient interface {
+	Client() *rpc.Client
+}
+
+// RPC 返回后端底层的 JSON-RPC 连接，后端没有实现 RPCClient 或连接为 nil 时返回 false
+func RPC(b EthBackend) (*rpc.Client, bool) {
+	r, ok := b.(RPCClient)
+	if !ok {
+		return nil, false
+	}
+	client := r.Client()
+	return client, client != nil
+}
+
+var (
+	_ EthBackend           = (*ethclient.Client)(nil)
+	_ BlockReceiptsReader  = (*ethclient.Client)(nil)
+	_ PendingBalanceReader = (*ethclient.Client)(nil)
+	_ FeeHistoryReader     = (*ethclient.Client)(nil)
+	_ RPCClient            = (*ethclient.Client)(nil)
+)
+
+// ErrTxFailed 交易已打包但执行失败（收据状态为 0）
+var ErrTxFailed = errors.New("交易执行失败")
+
+// Dial 连接以太坊节点，返回的客户端满足 EthBackend
+func Dial(rawurl string) (*ethclient.Client, error) {
+	client, err := ethclient.Dial(rawurl)
+	if err != nil {
+		return nil, fmt.Errorf("连接以太坊网络失败: %w", err)
+	}
+	return client, nil
+}
+
+// TxOptions 构造交易时的可选参数，零值表示从链上自动获取
+type TxOptions struct {
+	Nonce    *uint64  // 交易 nonce，为空时使用 PendingNonceAt
+	GasPrice *big.Int // Gas 价格，为空时使用 SuggestGasPrice
+	GasLimit uint64   // Gas 上限，为 0 时调用 EstimateGas 估算
+
+	// Confirm 交易构造完成、签名之前调用，返回错误时放弃发送；
+	// 用于发送前模拟执行、展示预期结果并请用户确认（见 pkg/simulate）。
+	// GasLimit 为 0 时先以 Gas 上限为 0 的交易调用 Confirm，再估算 Gas，
+	// 这样会回滚的交易也能先看到模拟结果，而不是在估算 Gas 时直接失败
+	Confirm func(ctx context.Context, from common.Address, tx *types.Transaction) error
+}
+
+// Signer 持有私钥的交易发送方
+type Signer struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+}
+
+// NewSigner 由私钥创建发送方
+func NewSigner(key *ecdsa.PrivateKey) *Signer {
+	return &Signer{Key: key, Address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// SignerFromHex 由十六进制私钥（不含 0x 前缀）创建发送方
+func SignerFromHex(hexKey string) (*Signer, error) {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("私钥解析失败: %w", err)
+	}
+	return NewSigner(key), nil
+}
+
+// BuildTx 按 opts 补全 nonce、Gas 价格和 Gas 上限，构造一笔 legacy 交易
+// to 为 nil 时构造合约创建交易；设置了 opts.Confirm 时在估算 Gas 之前调用，Confirm 的错误原样返回
+func BuildTx(ctx context.Context, b EthBackend, from common.Address, to *common.Address, value *big.Int, data []byte, opts *TxOptions) (*types.Transaction, error) {
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = *opts.Nonce
+	} else {
+		n, err := b.PendingNonceAt(ctx, from)
+		if err != nil {
+			return nil, fmt.Errorf("获取nonce失败: %w", err)
+		}
+		nonce = n
+	}
+
+	gasPrice := opts.GasPrice
+	if gasPrice == nil {
+		price, err := b.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取Gas价格失败: %w", err)
+		}
+		gasPrice = price
+	}
+
+	newTx := func(gasLimit uint64) *types.Transaction {
+		if to == nil {
+			return types.NewContractCreation(nonce, value, gasLimit, gasPrice, data)
+		}
+		return types.NewTransaction(nonce, *to, value, gasLimit, gasPrice, data)
+	}
+	// 先模拟再估算：会回滚的交易估算 Gas 时就会失败，看不到模拟结果
+	if opts.Confirm != nil {
+		if err := opts.Confirm(ctx, from, newTx(opts.GasLimit)); err != nil {
+			return nil, err
+		}
+	}
+
+	gasLimit := opts.GasLimit
+	if gasLimit == 0 {
+		estimated, err := b.EstimateGas(ctx, ethereum.CallMsg{From: from, To: to, Value: value, Data: data})
+		if err != nil {
+			return nil, fmt.Errorf("估算Gas失败（强制发送会失败的交易需要指定 Gas 上限）: %w", err)
+		}
+		gasLimit = estimated
+	}
+	return newTx(gasLimit), nil
+}
+
+// SignAndSend 使用链 ID 对交易做 EIP-155 签名并广播
+func SignAndSend(ctx context.Context, b EthBackend, signer *Signer, tx *types.Transaction) (*types.Transaction, error) {
+	chainID, err := b.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取链ID失败: %w", err)
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), signer.Key)
+	if err != nil {
+		return nil, fmt.Errorf("交易签名失败: %w", err)
+	}
+	if err := b.SendTransaction(ctx, signed); err != nil {
+		return nil, fmt.Errorf("发送交易失败: %w", err)
+	}
+	return signed, nil
+}
+
+// WaitMined 等待交易被打包并返回收据；交易执行失败时同时返回收据和 ErrTxFailed
+func WaitMined(ctx context.Context, b EthBackend, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, b, tx)
+	if err != nil {
+		return nil, fmt.Errorf("等待交易 %s 确认失败: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("%w: %s", ErrTxFailed, tx.Hash().Hex())
+	}
+	return receipt, nil
+}
@@ -0,0 +1,104 @@
+package backend_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+func TestBuildTx(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	// 未指定参数时从链上获取 nonce、Gas 价格并估算 Gas
+	tx, err := backend.BuildTx(ctx, chain, alice.Address, &bob.Address, big.NewInt(1), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, _ := chain.SuggestGasPrice(ctx)
+	if tx.Nonce() != 0 || tx.Gas() != 21000 || tx.GasPrice().Cmp(price) != 0 {
+		t.Fatalf("nonce=%d gas=%d gasPrice=%s", tx.Nonce(), tx.Gas(), tx.GasPrice())
+	}
+
+	nonce := uint64(7)
+	tx, err = backend.BuildTx(ctx, chain, alice.Address, nil, nil, []byte{0x00}, &backend.TxOptions{
+		Nonce: &nonce, GasPrice: big.NewInt(5), GasLimit: 100_000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.To() != nil || tx.Nonce() != 7 || tx.Gas() != 100_000 || tx.GasPrice().Int64() != 5 {
+		t.Fatalf("explicit options not applied: to=%v nonce=%d gas=%d", tx.To(), tx.Nonce(), tx.Gas())
+	}
+
+	// Confirm 在估算 Gas 之前拿到构造好的交易，返回的错误原样传出
+	stop := errors.New("stop")
+	var confirmed *types.Transaction
+	_, err = backend.BuildTx(ctx, chain, alice.Address, &bob.Address, big.NewInt(1), nil, &backend.TxOptions{
+		Confirm: func(_ context.Context, from common.Address, tx *types.Transaction) error {
+			if from != alice.Address {
+				t.Errorf("confirm from = %s", from.Hex())
+			}
+			confirmed = tx
+			return stop
+		},
+	})
+	if err != stop || confirmed == nil || *confirmed.To() != bob.Address || confirmed.Gas() != 0 {
+		t.Fatalf("err = %v, confirmed = %v", err, confirmed)
+	}
+}
+
+func TestSignAndWait(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	signer := backend.NewSigner(alice.Key)
+	if signer.Address != alice.Address {
+		t.Fatalf("signer address = %s", signer.Address.Hex())
+	}
+
+	tx, _ := backend.BuildTx(ctx, chain, alice.Address, &bob.Address, big.NewInt(1), nil, nil)
+	signed, err := backend.SignAndSend(ctx, chain, signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := backend.WaitMined(ctx, chain, signed)
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("WaitMined = %v, %v", receipt, err)
+	}
+
+	// 指定 Gas 上限跳过估算，让一笔必然 revert 的代币转账上链
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1))
+	data := transfer.EncodeTransfer(alice.Address, big.NewInt(1))
+	tx, _ = backend.BuildTx(ctx, chain, bob.Address, &tokenAddr, nil, data, &backend.TxOptions{GasLimit: 100_000})
+	signed, err = backend.SignAndSend(ctx, chain, bob.Signer(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err = backend.WaitMined(ctx, chain, signed)
+	if !errors.Is(err, backend.ErrTxFailed) || receipt == nil || receipt.Status != types.ReceiptStatusFailed {
+		t.Fatalf("WaitMined on reverted tx = %v, %v; want ErrTxFailed", receipt, err)
+	}
+}
+
+func TestSignerFromHex(t *testing.T) {
+	if _, err := backend.SignerFromHex("not-a-key"); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+	signer, err := backend.SignerFromHex("fad9c8855b740a0b7ed4c221dbad0f33a83a49cad6b3fe8d5817ac83d38b6a19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := signer.Address.Hex(); got != "0x96216849c49358B10257cb55b28eA603c874b05E" {
+		t.Fatalf("address = %s", got)
+	}
+}
@@ -0,0 +1,207 @@
+// Package batch 把大量同类查询合并成 JSON-RPC 批量请求
+//
+// query-transaction 原来为区块中的每笔交易单独请求一次收据，query-balance 也是逐个地址查询。
+// 这里用 rpc.Client.BatchCallContext 把 eth_getBalance、eth_getTransactionReceipt、
+// eth_getBlockByNumber、eth_call 等请求按批次大小分组发送：一批请求只占用一次网络往返，
+// 每一项单独返回结果或错误，某一项失败不影响同批其他项。
+//
+//	batcher, ok := batch.FromBackend(client)
+//	if ok {
+//		results, err := batcher.Balances(ctx, accounts, nil)
+//		for i, r := range results {
+//			if r.Err != nil { ... }
+//			fmt.Println(accounts[i], r.Value)
+//		}
+//	}
+package batch
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// DefaultSize 默认每批请求数量；多数服务商限制单批 100～1000 个请求
+const DefaultSize = 100
+
+// Caller 能发送批量请求的 RPC 客户端，*rpc.Client 满足此接口
+type Caller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// Result 批量请求中单项的结果
+type Result[T any] struct {
+	Value T
+	Err   error // 该项自身的错误（如参数无效、数据不存在），不影响其他项
+}
+
+// Batcher 按批次大小拆分并发送批量请求
+type Batcher struct {
+	caller Caller
+	size   int
+}
+
+// New 创建批量请求器，size <= 0 时使用 DefaultSize
+func New(caller Caller, size int) *Batcher {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Batcher{caller: caller, size: size}
+}
+
+// FromBackend 后端实现了 backend.RPCClient 时返回对应的批量请求器
+func FromBackend(b backend.EthBackend) (*Batcher, bool) {
+	client, ok := backend.RPC(b)
+	if !ok {
+		return nil, false
+	}
+	return New(client, DefaultSize), true
+}
+
+// Size 返回每批请求数量
+func (b *Batcher) Size() int { return b.size }
+
+// Do 按批次大小依次发送 elems，单项的错误写入各自的 Error 字段
+// 只有整批请求失败（网络错误、服务商拒绝批量请求等）时才返回错误
+func (b *Batcher) Do(ctx context.Context, elems []rpc.BatchElem) error {
+	for start := 0; start < len(elems); start += b.size {
+		end := start + b.size
+		if end > len(elems) {
+			end = len(elems)
+		}
+		if err := b.caller.BatchCallContext(ctx, elems[start:end]); err != nil {
+			return fmt.Errorf("批量请求第 %d-%d 项失败: %w", start, end-1, err)
+		}
+	}
+	return nil
+}
+
+// Balances 批量查询账户余额，blockNumber 为 nil 时查询最新区块
+func (b *Batcher) Balances(ctx context.Context, accounts []common.Address, blockNumber *big.Int) ([]Result[*big.Int], error) {
+	out := make([]*hexutil.Big, len(accounts))
+	elems := make([]rpc.BatchElem, len(accounts))
+	for i, account := range accounts {
+		elems[i] = rpc.BatchElem{Method: "eth_getBalance", Args: []interface{}{account, BlockArg(blockNumber)}, Result: &out[i]}
+	}
+	if err := b.Do(ctx, elems); err != nil {
+		return nil, err
+	}
+	return collect(elems, out, func(v *hexutil.Big) *big.Int { return (*big.Int)(v) }), nil
+}
+
+// Nonces 批量查询账户 nonce，blockNumber 为 nil 时查询最新区块
+func (b *Batcher) Nonces(ctx context.Context, accounts []common.Address, blockNumber *big.Int) ([]Result[uint64], error) {
+	out := make([]*hexutil.Uint64, len(accounts))
+	elems := make([]rpc.BatchElem, len(accounts))
+	for i, account := range accounts {
+		elems[i] = rpc.BatchElem{Method: "eth_getTransactionCount", Args: []interface{}{account, BlockArg(blockNumber)}, Result: &out[i]}
+	}
+	if err := b.Do(ctx, elems); err != nil {
+		return nil, err
+	}
+	return collect(elems, out, func(v *hexutil.Uint64) uint64 { return uint64(*v) }), nil
+}
+
+// Receipts 批量查询交易收据，交易不存在或尚未打包的项返回 ethereum.NotFound
+func (b *Batcher) Receipts(ctx context.Context, hashes []common.Hash) ([]Result[*types.Receipt], error) {
+	out := make([]*types.Receipt, len(hashes))
+	elems := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{hash}, Result: &out[i]}
+	}
+	if err := b.Do(ctx, elems); err != nil {
+		return nil, err
+	}
+	return collect(elems, out, func(v *types.Receipt) *types.Receipt { return v }), nil
+}
+
+// Headers 批量查询区块头，区块不存在的项返回 ethereum.NotFound
+func (b *Batcher) Headers(ctx context.Context, numbers []*big.Int) ([]Result[*types.Header], error) {
+	out := make([]*types.Header, len(numbers))
+	elems := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		elems[i] = rpc.BatchElem{Method: "eth_getBlockByNumber", Args: []interface{}{BlockArg(number), false}, Result: &out[i]}
+	}
+	if err := b.Do(ctx, elems); err != nil {
+		return nil, err
+	}
+	return collect(elems, out, func(v *types.Header) *types.Header { return v }), nil
+}
+
+// Calls 批量执行只读合约调用，blockNumber 为 nil 时在最新区块上执行
+// 合约回滚等执行错误只影响对应的项
+func (b *Batcher) Calls(ctx context.Context, msgs []ethereum.CallMsg, blockNumber *big.Int) ([]Result[[]byte], error) {
+	out := make([]*hexutil.Bytes, len(msgs))
+	elems := make([]rpc.BatchElem, len(msgs))
+	for i, msg := range msgs {
+		elems[i] = rpc.BatchElem{Method: "eth_call", Args: []interface{}{CallArg(msg), BlockArg(blockNumber)}, Result: &out[i]}
+	}
+	if err := b.Do(ctx, elems); err != nil {
+		return nil, err
+	}
+	return collect(elems, out, func(v *hexutil.Bytes) []byte { return *v }), nil
+}
+
+// collect 把批量请求的原始结果转换为 Result，结果为 null 的项视为 ethereum.NotFound
+func collect[R any, T any](elems []rpc.BatchElem, raw []*R, convert func(*R) T) []Result[T] {
+	results := make([]Result[T], len(elems))
+	for i, elem := range elems {
+		switch {
+		case elem.Error != nil:
+			results[i].Err = elem.Error
+		case raw[i] == nil:
+			results[i].Err = ethereum.NotFound
+		default:
+			results[i].Value = convert(raw[i])
+		}
+	}
+	return results
+}
+
+// BlockArg 把区块号转换为 JSON-RPC 参数，nil 表示 "latest"；
+// 负数表示 rpc 区块标签（pending、safe、finalized），与 ethclient 的约定一致。
+// 区块号不能表示区块哈希：调用方按哈希选择区块时（见 blocktag.Selector），读取后要用 Verify 确认没有发生重组
+func BlockArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	if number.Sign() >= 0 {
+		return hexutil.EncodeBig(number)
+	}
+	return rpc.BlockNumber(number.Int64()).String()
+}
+
+// CallArg 把 CallMsg 转换为 eth_call / eth_estimateGas 的参数对象（与 ethclient 的编码一致）
+func CallArg(msg ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	return arg
+}
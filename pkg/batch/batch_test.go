@@ -0,0 +1,114 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/batch"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// countingCaller 统计实际发出的批量请求次数
+type countingCaller struct {
+	batch.Caller
+	batches []int
+}
+
+func (c *countingCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c.batches = append(c.batches, len(b))
+	return c.Caller.BatchCallContext(ctx, b)
+}
+
+func TestBalancesAndNonces(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAccounts(5), simchain.WithAutoMine())
+	ctx := context.Background()
+	chain.TransferETH(chain.Accounts[0], chain.Accounts[1].Address, big.NewInt(1e18))
+
+	accounts := make([]common.Address, len(chain.Accounts))
+	for i, acc := range chain.Accounts {
+		accounts[i] = acc.Address
+	}
+	caller := &countingCaller{Caller: chain.Client()}
+	batcher := batch.New(caller, 2)
+
+	results, err := batcher.Balances(ctx, accounts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(caller.batches) != 3 || caller.batches[2] != 1 {
+		t.Fatalf("batches = %v, want [2 2 1]", caller.batches)
+	}
+	for i, r := range results {
+		if r.Err != nil || r.Value.Cmp(chain.Balance(accounts[i])) != 0 {
+			t.Fatalf("balance %d = %v, %v", i, r.Value, r.Err)
+		}
+	}
+
+	// 指定历史区块
+	genesis, err := batcher.Balances(ctx, accounts[:2], big.NewInt(0))
+	if err != nil || genesis[0].Value.Cmp(genesis[1].Value) != 0 {
+		t.Fatalf("genesis balances = %+v, %v", genesis, err)
+	}
+
+	nonces, err := batcher.Nonces(ctx, accounts[:2], nil)
+	if err != nil || nonces[0].Value != 1 || nonces[1].Value != 0 {
+		t.Fatalf("nonces = %+v, %v", nonces, err)
+	}
+}
+
+func TestPerItemErrors(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tx := chain.TransferETH(alice, bob.Address, big.NewInt(1))
+	batcher, ok := batch.FromBackend(chain)
+	if !ok {
+		t.Fatal("simchain should support batch requests")
+	}
+
+	// 不存在的交易只影响对应的项
+	receipts, err := batcher.Receipts(ctx, []common.Hash{tx.Hash(), {0x01}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipts[0].Err != nil || receipts[0].Value.TxHash != tx.Hash() {
+		t.Fatalf("receipt 0 = %+v", receipts[0])
+	}
+	if !errors.Is(receipts[1].Err, ethereum.NotFound) {
+		t.Fatalf("receipt 1 err = %v, want NotFound", receipts[1].Err)
+	}
+
+	headers, err := batcher.Headers(ctx, []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(99), nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers[1].Value.Number.Uint64() != 1 || !errors.Is(headers[2].Err, ethereum.NotFound) || headers[3].Value.Number.Uint64() != 1 {
+		t.Fatalf("headers = %+v", headers)
+	}
+
+	// 合约回滚的调用返回错误，其他调用正常返回
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	parsed, _ := mytoken.MyTokenMetaData.GetAbi()
+	balanceOf, _ := parsed.Pack("balanceOf", alice.Address)
+	transfer, _ := parsed.Pack("transfer", alice.Address, big.NewInt(1))
+	calls, err := batcher.Calls(ctx, []ethereum.CallMsg{
+		{To: &tokenAddr, Data: balanceOf},
+		{From: bob.Address, To: &tokenAddr, Data: transfer},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls[0].Err != nil || new(big.Int).SetBytes(calls[0].Value).Cmp(new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))) != 0 {
+		t.Fatalf("balanceOf = %x, %v", calls[0].Value, calls[0].Err)
+	}
+	if calls[1].Err == nil {
+		t.Fatal("expected revert for transfer exceeding balance")
+	}
+}
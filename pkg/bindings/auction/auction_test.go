@@ -0,0 +1,92 @@
+package auction_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/auction"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestAuction(t *testing.T) {
+	chain := simchain.New(t)
+	alice, bob := chain.Accounts[0].Opts(), chain.Accounts[1].Opts()
+	beneficiary := common.HexToAddress("0x00000000000000000000000000000000000000be")
+	ctx := context.Background()
+
+	_, _, auc, err := auction.DeployAuction(alice, chain, big.NewInt(3600), beneficiary)
+	if err != nil {
+		t.Fatal("部署 Auction 失败:", err)
+	}
+	chain.Mine()
+
+	bid := func(from *bind.TransactOpts, value *big.Int) error {
+		opts := *from
+		opts.Value = value
+		_, err := auc.Bid(&opts)
+		chain.Mine()
+		return err
+	}
+	if err := bid(alice, ether(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := bid(bob, ether(1)); err == nil || !strings.Contains(err.Error(), "Bid not high enough") {
+		t.Fatalf("low bid: err = %v", err)
+	}
+	if err := bid(bob, ether(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if bidder, _ := auc.HighestBidder(nil); bidder != bob.From {
+		t.Fatalf("highestBidder = %s, want %s", bidder.Hex(), bob.From.Hex())
+	}
+	if pending, _ := auc.PendingReturns(nil, alice.From); pending.Cmp(ether(1)) != 0 {
+		t.Fatalf("pendingReturns(alice) = %s, want 1 ether", pending)
+	}
+
+	// 被超越的出价人可以取回资金
+	before, _ := chain.BalanceAt(ctx, alice.From, nil)
+	tx, err := auc.Withdraw(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	receipt, _ := chain.TransactionReceipt(ctx, tx.Hash())
+	after, _ := chain.BalanceAt(ctx, alice.From, nil)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	if diff := new(big.Int).Sub(new(big.Int).Add(after, fee), before); diff.Cmp(ether(1)) != 0 {
+		t.Fatalf("withdraw returned %s wei, want 1 ether", diff)
+	}
+
+	if _, err := auc.AuctionEnd(alice); err == nil || !strings.Contains(err.Error(), "Too early") {
+		t.Fatalf("auctionEnd before end time: err = %v", err)
+	}
+	chain.Warp(2 * time.Hour)
+	if err := bid(alice, ether(3)); err == nil || !strings.Contains(err.Error(), "Too late") {
+		t.Fatalf("bid after end time: err = %v", err)
+	}
+	if _, err := auc.AuctionEnd(alice); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if ended, _ := auc.Ended(nil); !ended {
+		t.Fatal("auction should be ended")
+	}
+	if bal, _ := chain.BalanceAt(ctx, beneficiary, nil); bal.Cmp(ether(2)) != 0 {
+		t.Fatalf("beneficiary balance = %s, want 2 ether", bal)
+	}
+	if _, err := auc.AuctionEnd(alice); err == nil || !strings.Contains(err.Error(), "Auction already ended") {
+		t.Fatalf("second auctionEnd: err = %v", err)
+	}
+}
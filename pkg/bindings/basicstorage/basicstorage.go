@@ -0,0 +1,440 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package basicstorage
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// BasicStorageMetaData contains all meta data concerning the BasicStorage contract.
+var BasicStorageMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newValue\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"by\",\"type\":\"address\"}],\"name\":\"DataStored\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"get\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"x\",\"type\":\"uint256\"}],\"name\":\"set\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"6d4ce63c": "get()",
+		"8da5cb5b": "owner()",
+		"60fe47b1": "set(uint256)",
+	},
+	Bin: "0x608060405234801561001057600080fd5b50600180546001600160a01b03191633179055610176806100326000396000f3fe608060405234801561001057600080fd5b50600436106100415760003560e01c806360fe47b1146100465780636d4ce63c1461005b5780638da5cb5b14610071575b600080fd5b610059610054366004610127565b61009c565b005b6000546040519081526020015b60405180910390f35b600154610084906001600160a01b031681565b6040516001600160a01b039091168152602001610068565b6001546001600160a01b031633146100ea5760405162461bcd60e51b815260206004820152600d60248201526c2737ba103a34329037bbb732b960991b604482015260640160405180910390fd5b600081905560405181815233907fad78bd60223e723bf7bdc74c7dfda6b62da93a84d5765178bc8a8ec6a365376e9060200160405180910390a250565b60006020828403121561013957600080fd5b503591905056fea2646970667358221220e4a3da68fe4f7be86ad0996309e4cf5884583709aaf7754d0ffdc5319d297ec764736f6c63430008150033",
+}
+
+// BasicStorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use BasicStorageMetaData.ABI instead.
+var BasicStorageABI = BasicStorageMetaData.ABI
+
+// Deprecated: Use BasicStorageMetaData.Sigs instead.
+// BasicStorageFuncSigs maps the 4-byte function signature to its string representation.
+var BasicStorageFuncSigs = BasicStorageMetaData.Sigs
+
+// BasicStorageBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BasicStorageMetaData.Bin instead.
+var BasicStorageBin = BasicStorageMetaData.Bin
+
+// DeployBasicStorage deploys a new Ethereum contract, binding an instance of BasicStorage to it.
+func DeployBasicStorage(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *BasicStorage, error) {
+	parsed, err := BasicStorageMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BasicStorageBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &BasicStorage{BasicStorageCaller: BasicStorageCaller{contract: contract}, BasicStorageTransactor: BasicStorageTransactor{contract: contract}, BasicStorageFilterer: BasicStorageFilterer{contract: contract}}, nil
+}
+
+// BasicStorage is an auto generated Go binding around an Ethereum contract.
+type BasicStorage struct {
+	BasicStorageCaller     // Read-only binding to the contract
+	BasicStorageTransactor // Write-only binding to the contract
+	BasicStorageFilterer   // Log filterer for contract events
+}
+
+// BasicStorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type BasicStorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BasicStorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type BasicStorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BasicStorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type BasicStorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// BasicStorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type BasicStorageSession struct {
+	Contract     *BasicStorage     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// BasicStorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type BasicStorageCallerSession struct {
+	Contract *BasicStorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// BasicStorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type BasicStorageTransactorSession struct {
+	Contract     *BasicStorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// BasicStorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type BasicStorageRaw struct {
+	Contract *BasicStorage // Generic contract binding to access the raw methods on
+}
+
+// BasicStorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type BasicStorageCallerRaw struct {
+	Contract *BasicStorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// BasicStorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type BasicStorageTransactorRaw struct {
+	Contract *BasicStorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewBasicStorage creates a new instance of BasicStorage, bound to a specific deployed contract.
+func NewBasicStorage(address common.Address, backend bind.ContractBackend) (*BasicStorage, error) {
+	contract, err := bindBasicStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BasicStorage{BasicStorageCaller: BasicStorageCaller{contract: contract}, BasicStorageTransactor: BasicStorageTransactor{contract: contract}, BasicStorageFilterer: BasicStorageFilterer{contract: contract}}, nil
+}
+
+// NewBasicStorageCaller creates a new read-only instance of BasicStorage, bound to a specific deployed contract.
+func NewBasicStorageCaller(address common.Address, caller bind.ContractCaller) (*BasicStorageCaller, error) {
+	contract, err := bindBasicStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BasicStorageCaller{contract: contract}, nil
+}
+
+// NewBasicStorageTransactor creates a new write-only instance of BasicStorage, bound to a specific deployed contract.
+func NewBasicStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*BasicStorageTransactor, error) {
+	contract, err := bindBasicStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BasicStorageTransactor{contract: contract}, nil
+}
+
+// NewBasicStorageFilterer creates a new log filterer instance of BasicStorage, bound to a specific deployed contract.
+func NewBasicStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*BasicStorageFilterer, error) {
+	contract, err := bindBasicStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &BasicStorageFilterer{contract: contract}, nil
+}
+
+// bindBasicStorage binds a generic wrapper to an already deployed contract.
+func bindBasicStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := BasicStorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BasicStorage *BasicStorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BasicStorage.Contract.BasicStorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BasicStorage *BasicStorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BasicStorage.Contract.BasicStorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BasicStorage *BasicStorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BasicStorage.Contract.BasicStorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_BasicStorage *BasicStorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _BasicStorage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_BasicStorage *BasicStorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _BasicStorage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_BasicStorage *BasicStorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _BasicStorage.Contract.contract.Transact(opts, method, params...)
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_BasicStorage *BasicStorageCaller) Get(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _BasicStorage.contract.Call(opts, &out, "get")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_BasicStorage *BasicStorageSession) Get() (*big.Int, error) {
+	return _BasicStorage.Contract.Get(&_BasicStorage.CallOpts)
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_BasicStorage *BasicStorageCallerSession) Get() (*big.Int, error) {
+	return _BasicStorage.Contract.Get(&_BasicStorage.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BasicStorage *BasicStorageCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _BasicStorage.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BasicStorage *BasicStorageSession) Owner() (common.Address, error) {
+	return _BasicStorage.Contract.Owner(&_BasicStorage.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_BasicStorage *BasicStorageCallerSession) Owner() (common.Address, error) {
+	return _BasicStorage.Contract.Owner(&_BasicStorage.CallOpts)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_BasicStorage *BasicStorageTransactor) Set(opts *bind.TransactOpts, x *big.Int) (*types.Transaction, error) {
+	return _BasicStorage.contract.Transact(opts, "set", x)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_BasicStorage *BasicStorageSession) Set(x *big.Int) (*types.Transaction, error) {
+	return _BasicStorage.Contract.Set(&_BasicStorage.TransactOpts, x)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_BasicStorage *BasicStorageTransactorSession) Set(x *big.Int) (*types.Transaction, error) {
+	return _BasicStorage.Contract.Set(&_BasicStorage.TransactOpts, x)
+}
+
+// BasicStorageDataStoredIterator is returned from FilterDataStored and is used to iterate over the raw logs and unpacked data for DataStored events raised by the BasicStorage contract.
+type BasicStorageDataStoredIterator struct {
+	Event *BasicStorageDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BasicStorageDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BasicStorageDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BasicStorageDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BasicStorageDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BasicStorageDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BasicStorageDataStored represents a DataStored event raised by the BasicStorage contract.
+type BasicStorageDataStored struct {
+	NewValue *big.Int
+	By       common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataStored is a free log retrieval operation binding the contract event 0xad78bd60223e723bf7bdc74c7dfda6b62da93a84d5765178bc8a8ec6a365376e.
+//
+// Solidity: event DataStored(uint256 newValue, address indexed by)
+func (_BasicStorage *BasicStorageFilterer) FilterDataStored(opts *bind.FilterOpts, by []common.Address) (*BasicStorageDataStoredIterator, error) {
+
+	var byRule []interface{}
+	for _, byItem := range by {
+		byRule = append(byRule, byItem)
+	}
+
+	logs, sub, err := _BasicStorage.contract.FilterLogs(opts, "DataStored", byRule)
+	if err != nil {
+		return nil, err
+	}
+	return &BasicStorageDataStoredIterator{contract: _BasicStorage.contract, event: "DataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchDataStored is a free log subscription operation binding the contract event 0xad78bd60223e723bf7bdc74c7dfda6b62da93a84d5765178bc8a8ec6a365376e.
+//
+// Solidity: event DataStored(uint256 newValue, address indexed by)
+func (_BasicStorage *BasicStorageFilterer) WatchDataStored(opts *bind.WatchOpts, sink chan<- *BasicStorageDataStored, by []common.Address) (event.Subscription, error) {
+
+	var byRule []interface{}
+	for _, byItem := range by {
+		byRule = append(byRule, byItem)
+	}
+
+	logs, sub, err := _BasicStorage.contract.WatchLogs(opts, "DataStored", byRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BasicStorageDataStored)
+				if err := _BasicStorage.contract.UnpackLog(event, "DataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataStored is a log parse operation binding the contract event 0xad78bd60223e723bf7bdc74c7dfda6b62da93a84d5765178bc8a8ec6a365376e.
+//
+// Solidity: event DataStored(uint256 newValue, address indexed by)
+func (_BasicStorage *BasicStorageFilterer) ParseDataStored(log types.Log) (*BasicStorageDataStored, error) {
+	event := new(BasicStorageDataStored)
+	if err := _BasicStorage.contract.UnpackLog(event, "DataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
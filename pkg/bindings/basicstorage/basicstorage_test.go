@@ -0,0 +1,46 @@
+package basicstorage_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/basicstorage"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestBasicStorage(t *testing.T) {
+	chain := simchain.New(t)
+	owner, other := chain.Accounts[0].Opts(), chain.Accounts[1].Opts()
+
+	_, _, store, err := basicstorage.DeployBasicStorage(owner, chain)
+	if err != nil {
+		t.Fatal("部署 BasicStorage 失败:", err)
+	}
+	chain.Mine()
+
+	if got, _ := store.Owner(nil); got != owner.From {
+		t.Fatalf("owner = %s, want %s", got.Hex(), owner.From.Hex())
+	}
+	if _, err := store.Set(owner, big.NewInt(7)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if got, _ := store.Get(nil); got.Int64() != 7 {
+		t.Fatalf("get() = %s, want 7", got)
+	}
+
+	it, err := store.FilterDataStored(nil, []common.Address{owner.From})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Event.NewValue.Int64() != 7 {
+		t.Fatal("missing DataStored event")
+	}
+
+	if _, err := store.Set(other, big.NewInt(8)); err == nil || !strings.Contains(err.Error(), "Not the owner") {
+		t.Fatalf("set by non-owner: err = %v", err)
+	}
+}
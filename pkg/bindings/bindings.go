@@ -0,0 +1,12 @@
+// Package bindings 存放由 contracts/ 下的 Solidity 源文件生成的 Go 合约绑定
+//
+// 每个合约对应一个子包，包名为合约名的小写形式（例如 MyToken -> mytoken）。
+// 子包中的代码均为自动生成，请勿手动修改；修改合约后执行：
+//
+//	go generate ./pkg/bindings/...
+//
+// 找到 solc 0.8.21（PATH 上的原生 solc，或环境变量 SOLJSON 指向的 soljson 构建）时
+// 会先重新编译合约并更新 contracts/build 中的产物，否则直接使用已提交的产物生成绑定。
+package bindings
+
+//go:generate go run ../../cmd/gen-bindings -contracts ../../contracts -out . -soljson "$SOLJSON"
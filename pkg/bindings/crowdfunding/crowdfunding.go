@@ -0,0 +1,1216 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package crowdfunding
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// CrowdfundingMetaData contains all meta data concerning the Crowdfunding contract.
+var CrowdfundingMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"creator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"goal\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"startAt\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"endAt\",\"type\":\"uint256\"}],\"name\":\"CampaignCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"Claimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"caller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Pledged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"caller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Refunded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"caller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Unpledged\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"campaignCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"campaigns\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"creator\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"goal\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pledged\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"startAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"claimed\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"goal\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"startAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endAt\",\"type\":\"uint256\"}],\"name\":\"createCampaign\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"pledge\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"pledgedAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"refund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"unpledge\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"7274e30d": "campaignCount()",
+		"141961bc": "campaigns(uint256)",
+		"379607f5": "claim(uint256)",
+		"f69d3158": "createCampaign(uint256,uint256,uint256)",
+		"7326c9c0": "pledge(uint256)",
+		"aa4fb63a": "pledgedAmount(uint256,address)",
+		"278ecde1": "refund(uint256)",
+		"711853ab": "unpledge(uint256,uint256)",
+	},
+	Bin: "0x608060405234801561001057600080fd5b50610be9806100206000396000f3fe60806040526004361061007b5760003560e01c80637274e30d1161004e5780637274e30d146101905780637326c9c0146101b4578063aa4fb63a146101c7578063f69d3158146101ff57600080fd5b8063141961bc14610080578063278ecde11461012e578063379607f514610150578063711853ab14610170575b600080fd5b34801561008c57600080fd5b506100e661009b366004610a7e565b6000602081905290815260409020805460018201546002830154600384015460048501546005909501546001600160a01b039094169492939192909160ff8082169161010090041687565b604080516001600160a01b03909816885260208801969096529486019390935260608501919091526080840152151560a0830152151560c082015260e0015b60405180910390f35b34801561013a57600080fd5b5061014e610149366004610a7e565b61021f565b005b34801561015c57600080fd5b5061014e61016b366004610a7e565b610382565b34801561017c57600080fd5b5061014e61018b366004610a97565b610587565b34801561019c57600080fd5b506101a660025481565b604051908152602001610125565b61014e6101c2366004610a7e565b610719565b3480156101d357600080fd5b506101a66101e2366004610ab9565b600160209081526000928352604080842090915290825290205481565b34801561020b57600080fd5b5061014e61021a366004610af5565b61086f565b60008181526020819052604090206005810154610100900460ff1661025f5760405162461bcd60e51b815260040161025690610b21565b60405180910390fd5b806004015442116102ab5760405162461bcd60e51b815260206004820152601660248201527510d85b5c185a59db881a185cc81b9bdd08195b99195960521b6044820152606401610256565b80600101548160020154106102fa5760405162461bcd60e51b815260206004820152601560248201527410d85b5c185a59db881c995858da19590819dbd85b605a1b6044820152606401610256565b6000828152600160209081526040808320338085529252808320805490849055905190926108fc841502918491818181858888f19350505050158015610344573d6000803e3d6000fd5b50604051818152339084907f7ca5472b7ea78c2c0141c5a12ee6d170cf4ce8ed06be3d22c8252ddfc7a6a2c4906020015b60405180910390a3505050565b60008181526020819052604090206005810154610100900460ff166103b95760405162461bcd60e51b815260040161025690610b21565b80546001600160a01b031633146104125760405162461bcd60e51b815260206004820152601860248201527f4e6f74207468652063616d706169676e2063726561746f7200000000000000006044820152606401610256565b8060040154421161045e5760405162461bcd60e51b815260206004820152601660248201527510d85b5c185a59db881a185cc81b9bdd08195b99195960521b6044820152606401610256565b8060010154816002015410156104b65760405162461bcd60e51b815260206004820152601b60248201527f43616d706169676e20646964206e6f7420726561636820676f616c00000000006044820152606401610256565b600581015460ff161561050b5760405162461bcd60e51b815260206004820152601860248201527f43616d706169676e20616c726561647920636c61696d656400000000000000006044820152606401610256565b60058101805460ff19166001179055805460028201546040516001600160a01b039092169181156108fc0291906000818181858888f19350505050158015610557573d6000803e3d6000fd5b5060405182907f7a355715549cfe7c1cba26304350343fbddc4b4f72d3ce3e7c27117dd20b5cb890600090a25050565b60008281526020819052604090206005810154610100900460ff166105be5760405162461bcd60e51b815260040161025690610b21565b80600401544211156106075760405162461bcd60e51b815260206004820152601260248201527110d85b5c185a59db881a185cc8195b99195960721b6044820152606401610256565b60008381526001602090815260408083203384529091529020548211156106705760405162461bcd60e51b815260206004820152601b60248201527f496e73756666696369656e7420706c656467656420616d6f756e7400000000006044820152606401610256565b818160020160008282546106849190610b6e565b90915550506000838152600160209081526040808320338452909152812080548492906106b2908490610b6e565b9091555050604051339083156108fc029084906000818181858888f193505050501580156106e4573d6000803e3d6000fd5b50604051828152339084907ffcd29b1632c6748a9a4bb9b4cd5c6486c3c84a8550dce2368f83fef3969d968590602001610375565b60008181526020819052604090206005810154610100900460ff166107505760405162461bcd60e51b815260040161025690610b21565b80600301544210156107a45760405162461bcd60e51b815260206004820152601860248201527f43616d706169676e20686173206e6f74207374617274656400000000000000006044820152606401610256565b80600401544211156107ed5760405162461bcd60e51b815260206004820152601260248201527110d85b5c185a59db881a185cc8195b99195960721b6044820152606401610256565b348160020160008282546108019190610b87565b909155505060008281526001602090815260408083203384529091528120805434929061082f908490610b87565b9091555050604051348152339083907f2757ac6a40883f4491cc56930ae964df9034e343e660d5179eb01e198336756b9060200160405180910390a35050565b428210156108bf5760405162461bcd60e51b815260206004820152601960248201527f53746172742074696d6520697320696e207468652070617374000000000000006044820152606401610256565b8181101561090f5760405162461bcd60e51b815260206004820152601d60248201527f456e642074696d65206973206265666f72652073746172742074696d650000006044820152606401610256565b61091c426276a700610b87565b8111156109755760405162461bcd60e51b815260206004820152602160248201527f456e642074696d6520697320746f6f2066617220696e207468652066757475726044820152606560f81b6064820152608401610256565b6040805160e081018252338082526020808301878152600084860181815260608087018a8152608088018a815260a08901858152600160c08b01818152600280548952888b52978d90209b518c546001600160a01b0319166001600160a01b03909116178c559751908b015593518986015590516003890155516004880155905160059096018054935161ffff1990941696151561ff001916969096176101009315159390930292909217909455925484518881529182018790529381018590529092917f532ce22d86c3d75e9e14507fdc84d53517c2726edb5c97af3c34fb362233b071910160405180910390a360028054906000610a7483610b9a565b9190505550505050565b600060208284031215610a9057600080fd5b5035919050565b60008060408385031215610aaa57600080fd5b50508035926020909101359150565b60008060408385031215610acc57600080fd5b8235915060208301356001600160a01b0381168114610aea57600080fd5b809150509250929050565b600080600060608486031215610b0a57600080fd5b505081359360208301359350604090920135919050565b60208082526017908201527f43616d706169676e20646f6573206e6f74206578697374000000000000000000604082015260600190565b634e487b7160e01b600052601160045260246000fd5b81810381811115610b8157610b81610b58565b92915050565b80820180821115610b8157610b81610b58565b600060018201610bac57610bac610b58565b506001019056fea26469706673582212201af1cdaa4c500e47dbc7dca13f2cc18cd88b2a9c2a60040b89655509558fa85664736f6c63430008150033",
+}
+
+// CrowdfundingABI is the input ABI used to generate the binding from.
+// Deprecated: Use CrowdfundingMetaData.ABI instead.
+var CrowdfundingABI = CrowdfundingMetaData.ABI
+
+// Deprecated: Use CrowdfundingMetaData.Sigs instead.
+// CrowdfundingFuncSigs maps the 4-byte function signature to its string representation.
+var CrowdfundingFuncSigs = CrowdfundingMetaData.Sigs
+
+// CrowdfundingBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use CrowdfundingMetaData.Bin instead.
+var CrowdfundingBin = CrowdfundingMetaData.Bin
+
+// DeployCrowdfunding deploys a new Ethereum contract, binding an instance of Crowdfunding to it.
+func DeployCrowdfunding(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Crowdfunding, error) {
+	parsed, err := CrowdfundingMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(CrowdfundingBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Crowdfunding{CrowdfundingCaller: CrowdfundingCaller{contract: contract}, CrowdfundingTransactor: CrowdfundingTransactor{contract: contract}, CrowdfundingFilterer: CrowdfundingFilterer{contract: contract}}, nil
+}
+
+// Crowdfunding is an auto generated Go binding around an Ethereum contract.
+type Crowdfunding struct {
+	CrowdfundingCaller     // Read-only binding to the contract
+	CrowdfundingTransactor // Write-only binding to the contract
+	CrowdfundingFilterer   // Log filterer for contract events
+}
+
+// CrowdfundingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CrowdfundingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrowdfundingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CrowdfundingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrowdfundingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CrowdfundingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrowdfundingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CrowdfundingSession struct {
+	Contract     *Crowdfunding     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// CrowdfundingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CrowdfundingCallerSession struct {
+	Contract *CrowdfundingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// CrowdfundingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CrowdfundingTransactorSession struct {
+	Contract     *CrowdfundingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// CrowdfundingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CrowdfundingRaw struct {
+	Contract *Crowdfunding // Generic contract binding to access the raw methods on
+}
+
+// CrowdfundingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CrowdfundingCallerRaw struct {
+	Contract *CrowdfundingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CrowdfundingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CrowdfundingTransactorRaw struct {
+	Contract *CrowdfundingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCrowdfunding creates a new instance of Crowdfunding, bound to a specific deployed contract.
+func NewCrowdfunding(address common.Address, backend bind.ContractBackend) (*Crowdfunding, error) {
+	contract, err := bindCrowdfunding(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Crowdfunding{CrowdfundingCaller: CrowdfundingCaller{contract: contract}, CrowdfundingTransactor: CrowdfundingTransactor{contract: contract}, CrowdfundingFilterer: CrowdfundingFilterer{contract: contract}}, nil
+}
+
+// NewCrowdfundingCaller creates a new read-only instance of Crowdfunding, bound to a specific deployed contract.
+func NewCrowdfundingCaller(address common.Address, caller bind.ContractCaller) (*CrowdfundingCaller, error) {
+	contract, err := bindCrowdfunding(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingCaller{contract: contract}, nil
+}
+
+// NewCrowdfundingTransactor creates a new write-only instance of Crowdfunding, bound to a specific deployed contract.
+func NewCrowdfundingTransactor(address common.Address, transactor bind.ContractTransactor) (*CrowdfundingTransactor, error) {
+	contract, err := bindCrowdfunding(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingTransactor{contract: contract}, nil
+}
+
+// NewCrowdfundingFilterer creates a new log filterer instance of Crowdfunding, bound to a specific deployed contract.
+func NewCrowdfundingFilterer(address common.Address, filterer bind.ContractFilterer) (*CrowdfundingFilterer, error) {
+	contract, err := bindCrowdfunding(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingFilterer{contract: contract}, nil
+}
+
+// bindCrowdfunding binds a generic wrapper to an already deployed contract.
+func bindCrowdfunding(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CrowdfundingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Crowdfunding *CrowdfundingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Crowdfunding.Contract.CrowdfundingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Crowdfunding *CrowdfundingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.CrowdfundingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Crowdfunding *CrowdfundingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.CrowdfundingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Crowdfunding *CrowdfundingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Crowdfunding.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Crowdfunding *CrowdfundingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Crowdfunding *CrowdfundingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.contract.Transact(opts, method, params...)
+}
+
+// CampaignCount is a free data retrieval call binding the contract method 0x7274e30d.
+//
+// Solidity: function campaignCount() view returns(uint256)
+func (_Crowdfunding *CrowdfundingCaller) CampaignCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Crowdfunding.contract.Call(opts, &out, "campaignCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CampaignCount is a free data retrieval call binding the contract method 0x7274e30d.
+//
+// Solidity: function campaignCount() view returns(uint256)
+func (_Crowdfunding *CrowdfundingSession) CampaignCount() (*big.Int, error) {
+	return _Crowdfunding.Contract.CampaignCount(&_Crowdfunding.CallOpts)
+}
+
+// CampaignCount is a free data retrieval call binding the contract method 0x7274e30d.
+//
+// Solidity: function campaignCount() view returns(uint256)
+func (_Crowdfunding *CrowdfundingCallerSession) CampaignCount() (*big.Int, error) {
+	return _Crowdfunding.Contract.CampaignCount(&_Crowdfunding.CallOpts)
+}
+
+// Campaigns is a free data retrieval call binding the contract method 0x141961bc.
+//
+// Solidity: function campaigns(uint256 ) view returns(address creator, uint256 goal, uint256 pledged, uint256 startAt, uint256 endAt, bool claimed, bool exists)
+func (_Crowdfunding *CrowdfundingCaller) Campaigns(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Creator common.Address
+	Goal    *big.Int
+	Pledged *big.Int
+	StartAt *big.Int
+	EndAt   *big.Int
+	Claimed bool
+	Exists  bool
+}, error) {
+	var out []interface{}
+	err := _Crowdfunding.contract.Call(opts, &out, "campaigns", arg0)
+
+	outstruct := new(struct {
+		Creator common.Address
+		Goal    *big.Int
+		Pledged *big.Int
+		StartAt *big.Int
+		EndAt   *big.Int
+		Claimed bool
+		Exists  bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Creator = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Goal = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Pledged = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.StartAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.EndAt = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Claimed = *abi.ConvertType(out[5], new(bool)).(*bool)
+	outstruct.Exists = *abi.ConvertType(out[6], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Campaigns is a free data retrieval call binding the contract method 0x141961bc.
+//
+// Solidity: function campaigns(uint256 ) view returns(address creator, uint256 goal, uint256 pledged, uint256 startAt, uint256 endAt, bool claimed, bool exists)
+func (_Crowdfunding *CrowdfundingSession) Campaigns(arg0 *big.Int) (struct {
+	Creator common.Address
+	Goal    *big.Int
+	Pledged *big.Int
+	StartAt *big.Int
+	EndAt   *big.Int
+	Claimed bool
+	Exists  bool
+}, error) {
+	return _Crowdfunding.Contract.Campaigns(&_Crowdfunding.CallOpts, arg0)
+}
+
+// Campaigns is a free data retrieval call binding the contract method 0x141961bc.
+//
+// Solidity: function campaigns(uint256 ) view returns(address creator, uint256 goal, uint256 pledged, uint256 startAt, uint256 endAt, bool claimed, bool exists)
+func (_Crowdfunding *CrowdfundingCallerSession) Campaigns(arg0 *big.Int) (struct {
+	Creator common.Address
+	Goal    *big.Int
+	Pledged *big.Int
+	StartAt *big.Int
+	EndAt   *big.Int
+	Claimed bool
+	Exists  bool
+}, error) {
+	return _Crowdfunding.Contract.Campaigns(&_Crowdfunding.CallOpts, arg0)
+}
+
+// PledgedAmount is a free data retrieval call binding the contract method 0xaa4fb63a.
+//
+// Solidity: function pledgedAmount(uint256 , address ) view returns(uint256)
+func (_Crowdfunding *CrowdfundingCaller) PledgedAmount(opts *bind.CallOpts, arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Crowdfunding.contract.Call(opts, &out, "pledgedAmount", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PledgedAmount is a free data retrieval call binding the contract method 0xaa4fb63a.
+//
+// Solidity: function pledgedAmount(uint256 , address ) view returns(uint256)
+func (_Crowdfunding *CrowdfundingSession) PledgedAmount(arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	return _Crowdfunding.Contract.PledgedAmount(&_Crowdfunding.CallOpts, arg0, arg1)
+}
+
+// PledgedAmount is a free data retrieval call binding the contract method 0xaa4fb63a.
+//
+// Solidity: function pledgedAmount(uint256 , address ) view returns(uint256)
+func (_Crowdfunding *CrowdfundingCallerSession) PledgedAmount(arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	return _Crowdfunding.Contract.PledgedAmount(&_Crowdfunding.CallOpts, arg0, arg1)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x379607f5.
+//
+// Solidity: function claim(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingTransactor) Claim(opts *bind.TransactOpts, id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.contract.Transact(opts, "claim", id)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x379607f5.
+//
+// Solidity: function claim(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingSession) Claim(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Claim(&_Crowdfunding.TransactOpts, id)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x379607f5.
+//
+// Solidity: function claim(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingTransactorSession) Claim(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Claim(&_Crowdfunding.TransactOpts, id)
+}
+
+// CreateCampaign is a paid mutator transaction binding the contract method 0xf69d3158.
+//
+// Solidity: function createCampaign(uint256 goal, uint256 startAt, uint256 endAt) returns()
+func (_Crowdfunding *CrowdfundingTransactor) CreateCampaign(opts *bind.TransactOpts, goal *big.Int, startAt *big.Int, endAt *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.contract.Transact(opts, "createCampaign", goal, startAt, endAt)
+}
+
+// CreateCampaign is a paid mutator transaction binding the contract method 0xf69d3158.
+//
+// Solidity: function createCampaign(uint256 goal, uint256 startAt, uint256 endAt) returns()
+func (_Crowdfunding *CrowdfundingSession) CreateCampaign(goal *big.Int, startAt *big.Int, endAt *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.CreateCampaign(&_Crowdfunding.TransactOpts, goal, startAt, endAt)
+}
+
+// CreateCampaign is a paid mutator transaction binding the contract method 0xf69d3158.
+//
+// Solidity: function createCampaign(uint256 goal, uint256 startAt, uint256 endAt) returns()
+func (_Crowdfunding *CrowdfundingTransactorSession) CreateCampaign(goal *big.Int, startAt *big.Int, endAt *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.CreateCampaign(&_Crowdfunding.TransactOpts, goal, startAt, endAt)
+}
+
+// Pledge is a paid mutator transaction binding the contract method 0x7326c9c0.
+//
+// Solidity: function pledge(uint256 id) payable returns()
+func (_Crowdfunding *CrowdfundingTransactor) Pledge(opts *bind.TransactOpts, id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.contract.Transact(opts, "pledge", id)
+}
+
+// Pledge is a paid mutator transaction binding the contract method 0x7326c9c0.
+//
+// Solidity: function pledge(uint256 id) payable returns()
+func (_Crowdfunding *CrowdfundingSession) Pledge(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Pledge(&_Crowdfunding.TransactOpts, id)
+}
+
+// Pledge is a paid mutator transaction binding the contract method 0x7326c9c0.
+//
+// Solidity: function pledge(uint256 id) payable returns()
+func (_Crowdfunding *CrowdfundingTransactorSession) Pledge(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Pledge(&_Crowdfunding.TransactOpts, id)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0x278ecde1.
+//
+// Solidity: function refund(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingTransactor) Refund(opts *bind.TransactOpts, id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.contract.Transact(opts, "refund", id)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0x278ecde1.
+//
+// Solidity: function refund(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingSession) Refund(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Refund(&_Crowdfunding.TransactOpts, id)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0x278ecde1.
+//
+// Solidity: function refund(uint256 id) returns()
+func (_Crowdfunding *CrowdfundingTransactorSession) Refund(id *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Refund(&_Crowdfunding.TransactOpts, id)
+}
+
+// Unpledge is a paid mutator transaction binding the contract method 0x711853ab.
+//
+// Solidity: function unpledge(uint256 id, uint256 amount) returns()
+func (_Crowdfunding *CrowdfundingTransactor) Unpledge(opts *bind.TransactOpts, id *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.contract.Transact(opts, "unpledge", id, amount)
+}
+
+// Unpledge is a paid mutator transaction binding the contract method 0x711853ab.
+//
+// Solidity: function unpledge(uint256 id, uint256 amount) returns()
+func (_Crowdfunding *CrowdfundingSession) Unpledge(id *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Unpledge(&_Crowdfunding.TransactOpts, id, amount)
+}
+
+// Unpledge is a paid mutator transaction binding the contract method 0x711853ab.
+//
+// Solidity: function unpledge(uint256 id, uint256 amount) returns()
+func (_Crowdfunding *CrowdfundingTransactorSession) Unpledge(id *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _Crowdfunding.Contract.Unpledge(&_Crowdfunding.TransactOpts, id, amount)
+}
+
+// CrowdfundingCampaignCreatedIterator is returned from FilterCampaignCreated and is used to iterate over the raw logs and unpacked data for CampaignCreated events raised by the Crowdfunding contract.
+type CrowdfundingCampaignCreatedIterator struct {
+	Event *CrowdfundingCampaignCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrowdfundingCampaignCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrowdfundingCampaignCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrowdfundingCampaignCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrowdfundingCampaignCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrowdfundingCampaignCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrowdfundingCampaignCreated represents a CampaignCreated event raised by the Crowdfunding contract.
+type CrowdfundingCampaignCreated struct {
+	Id      *big.Int
+	Creator common.Address
+	Goal    *big.Int
+	StartAt *big.Int
+	EndAt   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterCampaignCreated is a free log retrieval operation binding the contract event 0x532ce22d86c3d75e9e14507fdc84d53517c2726edb5c97af3c34fb362233b071.
+//
+// Solidity: event CampaignCreated(uint256 indexed id, address indexed creator, uint256 goal, uint256 startAt, uint256 endAt)
+func (_Crowdfunding *CrowdfundingFilterer) FilterCampaignCreated(opts *bind.FilterOpts, id []*big.Int, creator []common.Address) (*CrowdfundingCampaignCreatedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var creatorRule []interface{}
+	for _, creatorItem := range creator {
+		creatorRule = append(creatorRule, creatorItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.FilterLogs(opts, "CampaignCreated", idRule, creatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingCampaignCreatedIterator{contract: _Crowdfunding.contract, event: "CampaignCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchCampaignCreated is a free log subscription operation binding the contract event 0x532ce22d86c3d75e9e14507fdc84d53517c2726edb5c97af3c34fb362233b071.
+//
+// Solidity: event CampaignCreated(uint256 indexed id, address indexed creator, uint256 goal, uint256 startAt, uint256 endAt)
+func (_Crowdfunding *CrowdfundingFilterer) WatchCampaignCreated(opts *bind.WatchOpts, sink chan<- *CrowdfundingCampaignCreated, id []*big.Int, creator []common.Address) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var creatorRule []interface{}
+	for _, creatorItem := range creator {
+		creatorRule = append(creatorRule, creatorItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.WatchLogs(opts, "CampaignCreated", idRule, creatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrowdfundingCampaignCreated)
+				if err := _Crowdfunding.contract.UnpackLog(event, "CampaignCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCampaignCreated is a log parse operation binding the contract event 0x532ce22d86c3d75e9e14507fdc84d53517c2726edb5c97af3c34fb362233b071.
+//
+// Solidity: event CampaignCreated(uint256 indexed id, address indexed creator, uint256 goal, uint256 startAt, uint256 endAt)
+func (_Crowdfunding *CrowdfundingFilterer) ParseCampaignCreated(log types.Log) (*CrowdfundingCampaignCreated, error) {
+	event := new(CrowdfundingCampaignCreated)
+	if err := _Crowdfunding.contract.UnpackLog(event, "CampaignCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrowdfundingClaimedIterator is returned from FilterClaimed and is used to iterate over the raw logs and unpacked data for Claimed events raised by the Crowdfunding contract.
+type CrowdfundingClaimedIterator struct {
+	Event *CrowdfundingClaimed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrowdfundingClaimedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrowdfundingClaimed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrowdfundingClaimed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrowdfundingClaimedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrowdfundingClaimedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrowdfundingClaimed represents a Claimed event raised by the Crowdfunding contract.
+type CrowdfundingClaimed struct {
+	Id  *big.Int
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterClaimed is a free log retrieval operation binding the contract event 0x7a355715549cfe7c1cba26304350343fbddc4b4f72d3ce3e7c27117dd20b5cb8.
+//
+// Solidity: event Claimed(uint256 indexed id)
+func (_Crowdfunding *CrowdfundingFilterer) FilterClaimed(opts *bind.FilterOpts, id []*big.Int) (*CrowdfundingClaimedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.FilterLogs(opts, "Claimed", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingClaimedIterator{contract: _Crowdfunding.contract, event: "Claimed", logs: logs, sub: sub}, nil
+}
+
+// WatchClaimed is a free log subscription operation binding the contract event 0x7a355715549cfe7c1cba26304350343fbddc4b4f72d3ce3e7c27117dd20b5cb8.
+//
+// Solidity: event Claimed(uint256 indexed id)
+func (_Crowdfunding *CrowdfundingFilterer) WatchClaimed(opts *bind.WatchOpts, sink chan<- *CrowdfundingClaimed, id []*big.Int) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.WatchLogs(opts, "Claimed", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrowdfundingClaimed)
+				if err := _Crowdfunding.contract.UnpackLog(event, "Claimed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseClaimed is a log parse operation binding the contract event 0x7a355715549cfe7c1cba26304350343fbddc4b4f72d3ce3e7c27117dd20b5cb8.
+//
+// Solidity: event Claimed(uint256 indexed id)
+func (_Crowdfunding *CrowdfundingFilterer) ParseClaimed(log types.Log) (*CrowdfundingClaimed, error) {
+	event := new(CrowdfundingClaimed)
+	if err := _Crowdfunding.contract.UnpackLog(event, "Claimed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrowdfundingPledgedIterator is returned from FilterPledged and is used to iterate over the raw logs and unpacked data for Pledged events raised by the Crowdfunding contract.
+type CrowdfundingPledgedIterator struct {
+	Event *CrowdfundingPledged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrowdfundingPledgedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrowdfundingPledged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrowdfundingPledged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrowdfundingPledgedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrowdfundingPledgedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrowdfundingPledged represents a Pledged event raised by the Crowdfunding contract.
+type CrowdfundingPledged struct {
+	Id     *big.Int
+	Caller common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPledged is a free log retrieval operation binding the contract event 0x2757ac6a40883f4491cc56930ae964df9034e343e660d5179eb01e198336756b.
+//
+// Solidity: event Pledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) FilterPledged(opts *bind.FilterOpts, id []*big.Int, caller []common.Address) (*CrowdfundingPledgedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.FilterLogs(opts, "Pledged", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingPledgedIterator{contract: _Crowdfunding.contract, event: "Pledged", logs: logs, sub: sub}, nil
+}
+
+// WatchPledged is a free log subscription operation binding the contract event 0x2757ac6a40883f4491cc56930ae964df9034e343e660d5179eb01e198336756b.
+//
+// Solidity: event Pledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) WatchPledged(opts *bind.WatchOpts, sink chan<- *CrowdfundingPledged, id []*big.Int, caller []common.Address) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.WatchLogs(opts, "Pledged", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrowdfundingPledged)
+				if err := _Crowdfunding.contract.UnpackLog(event, "Pledged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePledged is a log parse operation binding the contract event 0x2757ac6a40883f4491cc56930ae964df9034e343e660d5179eb01e198336756b.
+//
+// Solidity: event Pledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) ParsePledged(log types.Log) (*CrowdfundingPledged, error) {
+	event := new(CrowdfundingPledged)
+	if err := _Crowdfunding.contract.UnpackLog(event, "Pledged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrowdfundingRefundedIterator is returned from FilterRefunded and is used to iterate over the raw logs and unpacked data for Refunded events raised by the Crowdfunding contract.
+type CrowdfundingRefundedIterator struct {
+	Event *CrowdfundingRefunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrowdfundingRefundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrowdfundingRefunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrowdfundingRefunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrowdfundingRefundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrowdfundingRefundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrowdfundingRefunded represents a Refunded event raised by the Crowdfunding contract.
+type CrowdfundingRefunded struct {
+	Id     *big.Int
+	Caller common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterRefunded is a free log retrieval operation binding the contract event 0x7ca5472b7ea78c2c0141c5a12ee6d170cf4ce8ed06be3d22c8252ddfc7a6a2c4.
+//
+// Solidity: event Refunded(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) FilterRefunded(opts *bind.FilterOpts, id []*big.Int, caller []common.Address) (*CrowdfundingRefundedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.FilterLogs(opts, "Refunded", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingRefundedIterator{contract: _Crowdfunding.contract, event: "Refunded", logs: logs, sub: sub}, nil
+}
+
+// WatchRefunded is a free log subscription operation binding the contract event 0x7ca5472b7ea78c2c0141c5a12ee6d170cf4ce8ed06be3d22c8252ddfc7a6a2c4.
+//
+// Solidity: event Refunded(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) WatchRefunded(opts *bind.WatchOpts, sink chan<- *CrowdfundingRefunded, id []*big.Int, caller []common.Address) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.WatchLogs(opts, "Refunded", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrowdfundingRefunded)
+				if err := _Crowdfunding.contract.UnpackLog(event, "Refunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRefunded is a log parse operation binding the contract event 0x7ca5472b7ea78c2c0141c5a12ee6d170cf4ce8ed06be3d22c8252ddfc7a6a2c4.
+//
+// Solidity: event Refunded(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) ParseRefunded(log types.Log) (*CrowdfundingRefunded, error) {
+	event := new(CrowdfundingRefunded)
+	if err := _Crowdfunding.contract.UnpackLog(event, "Refunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrowdfundingUnpledgedIterator is returned from FilterUnpledged and is used to iterate over the raw logs and unpacked data for Unpledged events raised by the Crowdfunding contract.
+type CrowdfundingUnpledgedIterator struct {
+	Event *CrowdfundingUnpledged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrowdfundingUnpledgedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrowdfundingUnpledged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrowdfundingUnpledged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrowdfundingUnpledgedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrowdfundingUnpledgedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrowdfundingUnpledged represents a Unpledged event raised by the Crowdfunding contract.
+type CrowdfundingUnpledged struct {
+	Id     *big.Int
+	Caller common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpledged is a free log retrieval operation binding the contract event 0xfcd29b1632c6748a9a4bb9b4cd5c6486c3c84a8550dce2368f83fef3969d9685.
+//
+// Solidity: event Unpledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) FilterUnpledged(opts *bind.FilterOpts, id []*big.Int, caller []common.Address) (*CrowdfundingUnpledgedIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.FilterLogs(opts, "Unpledged", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrowdfundingUnpledgedIterator{contract: _Crowdfunding.contract, event: "Unpledged", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpledged is a free log subscription operation binding the contract event 0xfcd29b1632c6748a9a4bb9b4cd5c6486c3c84a8550dce2368f83fef3969d9685.
+//
+// Solidity: event Unpledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) WatchUnpledged(opts *bind.WatchOpts, sink chan<- *CrowdfundingUnpledged, id []*big.Int, caller []common.Address) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+	var callerRule []interface{}
+	for _, callerItem := range caller {
+		callerRule = append(callerRule, callerItem)
+	}
+
+	logs, sub, err := _Crowdfunding.contract.WatchLogs(opts, "Unpledged", idRule, callerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrowdfundingUnpledged)
+				if err := _Crowdfunding.contract.UnpackLog(event, "Unpledged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpledged is a log parse operation binding the contract event 0xfcd29b1632c6748a9a4bb9b4cd5c6486c3c84a8550dce2368f83fef3969d9685.
+//
+// Solidity: event Unpledged(uint256 indexed id, address indexed caller, uint256 amount)
+func (_Crowdfunding *CrowdfundingFilterer) ParseUnpledged(log types.Log) (*CrowdfundingUnpledged, error) {
+	event := new(CrowdfundingUnpledged)
+	if err := _Crowdfunding.contract.UnpackLog(event, "Unpledged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,118 @@
+package crowdfunding_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/crowdfunding"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestCrowdfunding(t *testing.T) {
+	chain := simchain.New(t)
+	creator, alice, bob := chain.Accounts[0].Opts(), chain.Accounts[1].Opts(), chain.Accounts[2].Opts()
+	ctx := context.Background()
+
+	_, _, cf, err := crowdfunding.DeployCrowdfunding(creator, chain)
+	if err != nil {
+		t.Fatal("部署 Crowdfunding 失败:", err)
+	}
+	chain.Mine()
+
+	head, _ := chain.HeaderByNumber(ctx, nil)
+	start := new(big.Int).SetUint64(head.Time + 100)
+	end := new(big.Int).SetUint64(head.Time + 100 + 86400)
+
+	// 活动 0 会达成目标，活动 1 不会
+	if _, err := cf.CreateCampaign(creator, ether(3), start, end); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cf.CreateCampaign(creator, ether(10), start, end); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if _, err := cf.CreateCampaign(creator, ether(1), big.NewInt(1), end); err == nil || !strings.Contains(err.Error(), "Start time is in the past") {
+		t.Fatalf("campaign in the past: err = %v", err)
+	}
+
+	pledge := func(from *bind.TransactOpts, id int64, value *big.Int) error {
+		opts := *from
+		opts.Value = value
+		_, err := cf.Pledge(&opts, big.NewInt(id))
+		chain.Mine()
+		return err
+	}
+	if err := pledge(alice, 0, ether(1)); err == nil || !strings.Contains(err.Error(), "Campaign has not started") {
+		t.Fatalf("early pledge: err = %v", err)
+	}
+	chain.Warp(200 * time.Second)
+
+	for _, p := range []struct {
+		from  *bind.TransactOpts
+		id    int64
+		value *big.Int
+	}{{alice, 0, ether(2)}, {bob, 0, ether(2)}, {alice, 1, ether(1)}} {
+		if err := pledge(p.from, p.id, p.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cf.Unpledge(bob, big.NewInt(0), ether(1)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if amount, _ := cf.PledgedAmount(nil, big.NewInt(0), bob.From); amount.Cmp(ether(1)) != 0 {
+		t.Fatalf("pledgedAmount(0, bob) = %s, want 1 ether", amount)
+	}
+
+	if _, err := cf.Claim(creator, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Campaign has not ended") {
+		t.Fatalf("early claim: err = %v", err)
+	}
+	chain.Warp(48 * time.Hour)
+
+	before, _ := chain.BalanceAt(ctx, creator.From, nil)
+	tx, err := cf.Claim(creator, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	receipt, _ := chain.TransactionReceipt(ctx, tx.Hash())
+	after, _ := chain.BalanceAt(ctx, creator.From, nil)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	if diff := new(big.Int).Sub(new(big.Int).Add(after, fee), before); diff.Cmp(ether(3)) != 0 {
+		t.Fatalf("claim paid %s wei, want 3 ether", diff)
+	}
+	campaign, err := cf.Campaigns(nil, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !campaign.Claimed || !campaign.Exists || campaign.Creator != creator.From || campaign.Pledged.Cmp(ether(3)) != 0 {
+		t.Fatalf("campaign 0 = %+v", campaign)
+	}
+
+	if _, err := cf.Refund(alice, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Campaign reached goal") {
+		t.Fatalf("refund of successful campaign: err = %v", err)
+	}
+	if _, err := cf.Refund(alice, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if amount, _ := cf.PledgedAmount(nil, big.NewInt(1), alice.From); amount.Sign() != 0 {
+		t.Fatalf("pledgedAmount after refund = %s", amount)
+	}
+	refunds, err := cf.FilterRefunded(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refunds.Next() || refunds.Event.Amount.Cmp(ether(1)) != 0 {
+		t.Fatal("missing Refunded event")
+	}
+}
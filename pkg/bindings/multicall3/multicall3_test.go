@@ -0,0 +1,56 @@
+package multicall3_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestMulticall3(t *testing.T) {
+	chain := simchain.New(t)
+	auth := chain.Accounts[0].Opts()
+
+	addr, _, mc, err := multicall3.DeployMulticall3(auth, chain)
+	if err != nil {
+		t.Fatal("部署 Multicall3 失败:", err)
+	}
+	chain.Mine()
+
+	if id, err := mc.GetChainId(nil); err != nil || id.Int64() != 1337 {
+		t.Fatalf("getChainId() = %v, %v", id, err)
+	}
+	if n, err := mc.GetBlockNumber(nil); err != nil || n.Int64() != 1 {
+		t.Fatalf("getBlockNumber() = %v, %v", n, err)
+	}
+
+	// aggregate3 是 payable 函数，通过底层合约以只读方式调用
+	parsed, _ := multicall3.Multicall3MetaData.GetAbi()
+	getBalance, _ := parsed.Pack("getEthBalance", auth.From)
+	calls := []multicall3.Multicall3Call3{
+		{Target: addr, CallData: getBalance},
+		{Target: addr, AllowFailure: true, CallData: []byte{0xde, 0xad, 0xbe, 0xef}},
+	}
+	var out []interface{}
+	raw := &multicall3.Multicall3Raw{Contract: mc}
+	if err := raw.Call(nil, &out, "aggregate3", calls); err != nil {
+		t.Fatal(err)
+	}
+	results := *abi.ConvertType(out[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if len(results) != 2 || !results[0].Success || results[1].Success {
+		t.Fatalf("aggregate3 = %+v", results)
+	}
+	balance, _ := mc.GetEthBalance(nil, auth.From)
+	if new(big.Int).SetBytes(results[0].ReturnData).Cmp(balance) != 0 {
+		t.Fatalf("getEthBalance via aggregate3 = %x, want %s", results[0].ReturnData, balance)
+	}
+
+	// 不允许失败的调用回滚时整体回滚
+	calls[1].AllowFailure = false
+	if err := raw.Call(nil, &out, "aggregate3", calls); err == nil {
+		t.Fatal("expected aggregate3 to revert")
+	}
+}
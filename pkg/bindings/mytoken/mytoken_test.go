@@ -0,0 +1,138 @@
+package mytoken_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// deployToken 在测试链上部署 MyToken，owner 持有全部初始供应量
+func deployToken(t *testing.T, name string) (*simchain.Chain, *bind.TransactOpts, *bind.TransactOpts, *mytoken.MyToken) {
+	t.Helper()
+	chain := simchain.New(t)
+	_, token := chain.DeployMyToken(chain.Accounts[0], name, "MTK", 18, big.NewInt(1_000_000))
+	return chain, chain.Accounts[0].Opts(), chain.Accounts[1].Opts(), token
+}
+
+func TestMetadata(t *testing.T) {
+	longName := "My Token With A Name Longer Than Thirty-Two Bytes"
+	for _, name := range []string{"My Token", longName} {
+		_, owner, _, token := deployToken(t, name)
+
+		got, err := token.Name(nil)
+		if err != nil || got != name {
+			t.Fatalf("name = %q, %v; want %q", got, err, name)
+		}
+		if symbol, _ := token.Symbol(nil); symbol != "MTK" {
+			t.Errorf("symbol = %q", symbol)
+		}
+		if decimals, _ := token.Decimals(nil); decimals != 18 {
+			t.Errorf("decimals = %d", decimals)
+		}
+		if addr, _ := token.Owner(nil); addr != owner.From {
+			t.Errorf("owner = %s, want %s", addr.Hex(), owner.From.Hex())
+		}
+		want, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+		if supply, _ := token.TotalSupply(nil); supply.Cmp(want) != 0 {
+			t.Errorf("totalSupply = %s, want %s", supply, want)
+		}
+		if bal, _ := token.BalanceOf(nil, owner.From); bal.Cmp(want) != 0 {
+			t.Errorf("owner balance = %s, want %s", bal, want)
+		}
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	chain, owner, other, token := deployToken(t, "My Token")
+
+	amount := big.NewInt(12345)
+	if _, err := token.Transfer(owner, other.From, amount); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if bal, _ := token.BalanceOf(nil, other.From); bal.Cmp(amount) != 0 {
+		t.Fatalf("recipient balance = %s, want %s", bal, amount)
+	}
+	it, err := token.FilterTransfer(&bind.FilterOpts{Start: 0}, []common.Address{owner.From}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events int
+	for it.Next() {
+		if it.Event.To == other.From && it.Event.Value.Cmp(amount) == 0 {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Fatalf("got %d matching Transfer events, want 1", events)
+	}
+
+	// 余额不足时应返回 require 中的错误信息
+	_, err = token.Transfer(other, owner.From, big.NewInt(1_000_000))
+	if err == nil || !strings.Contains(err.Error(), "ERC20: transfer amount exceeds balance") {
+		t.Fatalf("transfer over balance: err = %v", err)
+	}
+}
+
+func TestApproveAndTransferFrom(t *testing.T) {
+	chain, owner, other, token := deployToken(t, "My Token")
+
+	if _, err := token.Approve(owner, other.From, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if allowance, _ := token.Allowance(nil, owner.From, other.From); allowance.Int64() != 100 {
+		t.Fatalf("allowance = %s, want 100", allowance)
+	}
+
+	if _, err := token.TransferFrom(other, owner.From, other.From, big.NewInt(60)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if allowance, _ := token.Allowance(nil, owner.From, other.From); allowance.Int64() != 40 {
+		t.Fatalf("allowance after transferFrom = %s, want 40", allowance)
+	}
+	if bal, _ := token.BalanceOf(nil, other.From); bal.Int64() != 60 {
+		t.Fatalf("balance = %s, want 60", bal)
+	}
+
+	_, err := token.TransferFrom(other, owner.From, other.From, big.NewInt(41))
+	if err == nil || !strings.Contains(err.Error(), "ERC20: transfer amount exceeds allowance") {
+		t.Fatalf("transferFrom over allowance: err = %v", err)
+	}
+}
+
+func TestMintAndBurn(t *testing.T) {
+	chain, owner, other, token := deployToken(t, "My Token")
+	supply, _ := token.TotalSupply(nil)
+
+	if _, err := token.Mint(other, other.From, big.NewInt(1)); err == nil || !strings.Contains(err.Error(), "Not the owner") {
+		t.Fatalf("mint by non-owner: err = %v", err)
+	}
+	if _, err := token.Mint(owner, other.From, big.NewInt(500)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if _, err := token.Burn(other, big.NewInt(200)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if bal, _ := token.BalanceOf(nil, other.From); bal.Int64() != 300 {
+		t.Fatalf("balance = %s, want 300", bal)
+	}
+	want := new(big.Int).Add(supply, big.NewInt(300))
+	if got, _ := token.TotalSupply(nil); got.Cmp(want) != 0 {
+		t.Fatalf("totalSupply = %s, want %s", got, want)
+	}
+	if _, err := token.Burn(other, big.NewInt(301)); err == nil || !strings.Contains(err.Error(), "ERC20: burn amount exceeds balance") {
+		t.Fatalf("burn over balance: err = %v", err)
+	}
+}
@@ -0,0 +1,1153 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package simpledao
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SimpleDAOMetaData contains all meta data concerning the SimpleDAO contract.
+var SimpleDAOMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"}],\"name\":\"ProposalCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"}],\"name\":\"ProposalExecuted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"SharesIssued\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"Voted\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"QUORUM\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOTING_PERIOD\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"addresspayable\",\"name\":\"recipient\",\"type\":\"address\"}],\"name\":\"createProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"}],\"name\":\"executeProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"issueShares\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"proposer\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"addresspayable\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"votes\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"executed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"shares\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalShares\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Sigs: map[string]string{
+		"2e80d9b6": "QUORUM()",
+		"b1610d7e": "VOTING_PERIOD()",
+		"6ac1e01f": "createProposal(string,uint256,address)",
+		"0d61b519": "executeProposal(uint256)",
+		"efc7a8ee": "issueShares(address,uint256)",
+		"da35c664": "proposalCount()",
+		"013cf08b": "proposals(uint256)",
+		"ce7c2ac2": "shares(address)",
+		"3a98ef39": "totalShares()",
+		"0121b93f": "vote(uint256)",
+	},
+	Bin: "0x608060405234801561001057600080fd5b50610c03806100206000396000f3fe6080604052600436106100955760003560e01c80636ac1e01f116100595780636ac1e01f14610159578063b1610d7e14610179578063ce7c2ac214610190578063da35c664146101bd578063efc7a8ee146101d357600080fd5b80630121b93f146100a1578063013cf08b146100c35780630d61b519146101005780632e80d9b6146101205780633a98ef391461014357600080fd5b3661009c57005b600080fd5b3480156100ad57600080fd5b506100c16100bc3660046107dc565b6101f3565b005b3480156100cf57600080fd5b506100e36100de3660046107dc565b61037e565b6040516100f798979695949392919061083b565b60405180910390f35b34801561010c57600080fd5b506100c161011b3660046107dc565b61045f565b34801561012c57600080fd5b50610135603381565b6040519081526020016100f7565b34801561014f57600080fd5b5061013560025481565b34801561016557600080fd5b506100c16101743660046108d7565b610640565b34801561018557600080fd5b5061013562093a8081565b34801561019c57600080fd5b506101356101ab3660046109a2565b60016020526000908152604090205481565b3480156101c957600080fd5b5061013560035481565b3480156101df57600080fd5b506100c16101ee3660046109c6565b610754565b336000908152600160205260409020546102435760405162461bcd60e51b815260206004820152600c60248201526b2737ba10309036b2b6b132b960a11b60448201526064015b60405180910390fd5b60008181526020819052604090206006810154421061029a5760405162461bcd60e51b8152602060048201526013602482015272159bdd1a5b99c81c195c9a5bd908195b991959606a1b604482015260640161023a565b33600090815260088201602052604090205460ff16156102ec5760405162461bcd60e51b815260206004820152600d60248201526c105b1c9958591e481d9bdd1959609a1b604482015260640161023a565b3360009081526008820160209081526040808320805460ff1916600190811790915590915281205460058301805491929091610329908490610a08565b9091555050336000818152600160205260409081902054905184917f1abe610cf2bf87e57dcc1181fcf5ac0934e843d8344ab9eed6e86c799f62585e9161037291815260200190565b60405180910390a35050565b60006020819052908152604090208054600182015460028301805492936001600160a01b03909216926103b090610a21565b80601f01602080910402602001604051908101604052809291908181526020018280546103dc90610a21565b80156104295780601f106103fe57610100808354040283529160200191610429565b820191906000526020600020905b81548152906001019060200180831161040c57829003601f168201915b5050506003840154600485015460058601546006870154600790970154959692956001600160a01b039092169450925060ff1688565b600081815260208190526040902060068101544210156104c15760405162461bcd60e51b815260206004820152601760248201527f566f74696e6720706572696f64206e6f7420656e646564000000000000000000604482015260640161023a565b600781015460ff16156105165760405162461bcd60e51b815260206004820152601960248201527f50726f706f73616c20616c726561647920657865637574656400000000000000604482015260640161023a565b60336002546105259190610a5b565b6005820154610535906064610a5b565b10156105785760405162461bcd60e51b8152602060048201526012602482015271145d5bdc9d5b481b9bdd081c995858da195960721b604482015260640161023a565b80600301544710156105c15760405162461bcd60e51b8152602060048201526012602482015271496e73756666696369656e742066756e647360701b604482015260640161023a565b60078101805460ff19166001179055600481015460038201546040516001600160a01b039092169181156108fc0291906000818181858888f19350505050158015610610573d6000803e3d6000fd5b5060405182907f712ae1383f79ac853f8d882153778e0260ef8f03b504e2866e0593e04d2b291f90600090a25050565b3360009081526001602052604090205461068b5760405162461bcd60e51b815260206004820152600c60248201526b2737ba10309036b2b6b132b960a11b604482015260640161023a565b600380546000918261069c83610a72565b9091555060008181526020819052604090208181556001810180546001600160a01b03191633179055909150600281016106d68682610ada565b50600381018490556004810180546001600160a01b0319166001600160a01b03851617905561070862093a8042610a08565b6006820155604051339083907f681515850d74456af78685d0714fbe16c2aae31c969f57374add5e8340018d219061074590899089908990610b9a565b60405180910390a35050505050565b6001600160a01b0382166000908152600160205260408120805483929061077c908490610a08565b9250508190555080600260008282546107959190610a08565b90915550506040518181526001600160a01b038316907f3abf6d97fde3541bb582f72fa6fb75093b8bb699577fbc722ac25de9f6fbc4ed9060200160405180910390a25050565b6000602082840312156107ee57600080fd5b5035919050565b6000815180845260005b8181101561081b576020818501810151868301820152016107ff565b506000602082860101526020601f19601f83011685010191505092915050565b8881526001600160a01b038881166020830152610100604083018190526000916108678483018b6107f5565b60608501999099529690961660808301525060a081019390935260c0830191909152151560e090910152509392505050565b634e487b7160e01b600052604160045260246000fd5b6001600160a01b03811681146108c457600080fd5b50565b80356108d2816108af565b919050565b6000806000606084860312156108ec57600080fd5b833567ffffffffffffffff8082111561090457600080fd5b818601915086601f83011261091857600080fd5b81358181111561092a5761092a610899565b604051601f8201601f19908116603f0116810190838211818310171561095257610952610899565b8160405282815289602084870101111561096b57600080fd5b82602086016020830137600060208483010152809750505050505060208401359150610999604085016108c7565b90509250925092565b6000602082840312156109b457600080fd5b81356109bf816108af565b9392505050565b600080604083850312156109d957600080fd5b82356109e4816108af565b946020939093013593505050565b634e487b7160e01b600052601160045260246000fd5b80820180821115610a1b57610a1b6109f2565b92915050565b600181811c90821680610a3557607f821691505b602082108103610a5557634e487b7160e01b600052602260045260246000fd5b50919050565b8082028115828204841417610a1b57610a1b6109f2565b600060018201610a8457610a846109f2565b5060010190565b601f821115610ad557600081815260208120601f850160051c81016020861015610ab25750805b601f850160051c820191505b81811015610ad157828155600101610abe565b5050505b505050565b815167ffffffffffffffff811115610af457610af4610899565b610b0881610b028454610a21565b84610a8b565b602080601f831160018114610b3d5760008415610b255750858301515b600019600386901b1c1916600185901b178555610ad1565b600085815260208120601f198616915b82811015610b6c57888601518255948401946001909101908401610b4d565b5085821015610b8a5787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b606081526000610bad60608301866107f5565b6020830194909452506001600160a01b039190911660409091015291905056fea2646970667358221220dc0c7f27db2387e4ffc78a9ca8cd5d932fb8240c20d3b1746bcd73d3364e4ab364736f6c63430008150033",
+}
+
+// SimpleDAOABI is the input ABI used to generate the binding from.
+// Deprecated: Use SimpleDAOMetaData.ABI instead.
+var SimpleDAOABI = SimpleDAOMetaData.ABI
+
+// Deprecated: Use SimpleDAOMetaData.Sigs instead.
+// SimpleDAOFuncSigs maps the 4-byte function signature to its string representation.
+var SimpleDAOFuncSigs = SimpleDAOMetaData.Sigs
+
+// SimpleDAOBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use SimpleDAOMetaData.Bin instead.
+var SimpleDAOBin = SimpleDAOMetaData.Bin
+
+// DeploySimpleDAO deploys a new Ethereum contract, binding an instance of SimpleDAO to it.
+func DeploySimpleDAO(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *SimpleDAO, error) {
+	parsed, err := SimpleDAOMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(SimpleDAOBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &SimpleDAO{SimpleDAOCaller: SimpleDAOCaller{contract: contract}, SimpleDAOTransactor: SimpleDAOTransactor{contract: contract}, SimpleDAOFilterer: SimpleDAOFilterer{contract: contract}}, nil
+}
+
+// SimpleDAO is an auto generated Go binding around an Ethereum contract.
+type SimpleDAO struct {
+	SimpleDAOCaller     // Read-only binding to the contract
+	SimpleDAOTransactor // Write-only binding to the contract
+	SimpleDAOFilterer   // Log filterer for contract events
+}
+
+// SimpleDAOCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SimpleDAOCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleDAOTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SimpleDAOTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleDAOFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SimpleDAOFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleDAOSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SimpleDAOSession struct {
+	Contract     *SimpleDAO        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SimpleDAOCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SimpleDAOCallerSession struct {
+	Contract *SimpleDAOCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// SimpleDAOTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SimpleDAOTransactorSession struct {
+	Contract     *SimpleDAOTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// SimpleDAORaw is an auto generated low-level Go binding around an Ethereum contract.
+type SimpleDAORaw struct {
+	Contract *SimpleDAO // Generic contract binding to access the raw methods on
+}
+
+// SimpleDAOCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SimpleDAOCallerRaw struct {
+	Contract *SimpleDAOCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SimpleDAOTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SimpleDAOTransactorRaw struct {
+	Contract *SimpleDAOTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSimpleDAO creates a new instance of SimpleDAO, bound to a specific deployed contract.
+func NewSimpleDAO(address common.Address, backend bind.ContractBackend) (*SimpleDAO, error) {
+	contract, err := bindSimpleDAO(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAO{SimpleDAOCaller: SimpleDAOCaller{contract: contract}, SimpleDAOTransactor: SimpleDAOTransactor{contract: contract}, SimpleDAOFilterer: SimpleDAOFilterer{contract: contract}}, nil
+}
+
+// NewSimpleDAOCaller creates a new read-only instance of SimpleDAO, bound to a specific deployed contract.
+func NewSimpleDAOCaller(address common.Address, caller bind.ContractCaller) (*SimpleDAOCaller, error) {
+	contract, err := bindSimpleDAO(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOCaller{contract: contract}, nil
+}
+
+// NewSimpleDAOTransactor creates a new write-only instance of SimpleDAO, bound to a specific deployed contract.
+func NewSimpleDAOTransactor(address common.Address, transactor bind.ContractTransactor) (*SimpleDAOTransactor, error) {
+	contract, err := bindSimpleDAO(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOTransactor{contract: contract}, nil
+}
+
+// NewSimpleDAOFilterer creates a new log filterer instance of SimpleDAO, bound to a specific deployed contract.
+func NewSimpleDAOFilterer(address common.Address, filterer bind.ContractFilterer) (*SimpleDAOFilterer, error) {
+	contract, err := bindSimpleDAO(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOFilterer{contract: contract}, nil
+}
+
+// bindSimpleDAO binds a generic wrapper to an already deployed contract.
+func bindSimpleDAO(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SimpleDAOMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleDAO *SimpleDAORaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleDAO.Contract.SimpleDAOCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleDAO *SimpleDAORaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.SimpleDAOTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleDAO *SimpleDAORaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.SimpleDAOTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleDAO *SimpleDAOCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleDAO.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleDAO *SimpleDAOTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleDAO *SimpleDAOTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.contract.Transact(opts, method, params...)
+}
+
+// QUORUM is a free data retrieval call binding the contract method 0x2e80d9b6.
+//
+// Solidity: function QUORUM() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCaller) QUORUM(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "QUORUM")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// QUORUM is a free data retrieval call binding the contract method 0x2e80d9b6.
+//
+// Solidity: function QUORUM() view returns(uint256)
+func (_SimpleDAO *SimpleDAOSession) QUORUM() (*big.Int, error) {
+	return _SimpleDAO.Contract.QUORUM(&_SimpleDAO.CallOpts)
+}
+
+// QUORUM is a free data retrieval call binding the contract method 0x2e80d9b6.
+//
+// Solidity: function QUORUM() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCallerSession) QUORUM() (*big.Int, error) {
+	return _SimpleDAO.Contract.QUORUM(&_SimpleDAO.CallOpts)
+}
+
+// VOTINGPERIOD is a free data retrieval call binding the contract method 0xb1610d7e.
+//
+// Solidity: function VOTING_PERIOD() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCaller) VOTINGPERIOD(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "VOTING_PERIOD")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VOTINGPERIOD is a free data retrieval call binding the contract method 0xb1610d7e.
+//
+// Solidity: function VOTING_PERIOD() view returns(uint256)
+func (_SimpleDAO *SimpleDAOSession) VOTINGPERIOD() (*big.Int, error) {
+	return _SimpleDAO.Contract.VOTINGPERIOD(&_SimpleDAO.CallOpts)
+}
+
+// VOTINGPERIOD is a free data retrieval call binding the contract method 0xb1610d7e.
+//
+// Solidity: function VOTING_PERIOD() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCallerSession) VOTINGPERIOD() (*big.Int, error) {
+	return _SimpleDAO.Contract.VOTINGPERIOD(&_SimpleDAO.CallOpts)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCaller) ProposalCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "proposalCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_SimpleDAO *SimpleDAOSession) ProposalCount() (*big.Int, error) {
+	return _SimpleDAO.Contract.ProposalCount(&_SimpleDAO.CallOpts)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCallerSession) ProposalCount() (*big.Int, error) {
+	return _SimpleDAO.Contract.ProposalCount(&_SimpleDAO.CallOpts)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(uint256 id, address proposer, string description, uint256 amount, address recipient, uint256 votes, uint256 deadline, bool executed)
+func (_SimpleDAO *SimpleDAOCaller) Proposals(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Id          *big.Int
+	Proposer    common.Address
+	Description string
+	Amount      *big.Int
+	Recipient   common.Address
+	Votes       *big.Int
+	Deadline    *big.Int
+	Executed    bool
+}, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "proposals", arg0)
+
+	outstruct := new(struct {
+		Id          *big.Int
+		Proposer    common.Address
+		Description string
+		Amount      *big.Int
+		Recipient   common.Address
+		Votes       *big.Int
+		Deadline    *big.Int
+		Executed    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Id = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Proposer = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Description = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Amount = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Recipient = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Votes = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.Deadline = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.Executed = *abi.ConvertType(out[7], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(uint256 id, address proposer, string description, uint256 amount, address recipient, uint256 votes, uint256 deadline, bool executed)
+func (_SimpleDAO *SimpleDAOSession) Proposals(arg0 *big.Int) (struct {
+	Id          *big.Int
+	Proposer    common.Address
+	Description string
+	Amount      *big.Int
+	Recipient   common.Address
+	Votes       *big.Int
+	Deadline    *big.Int
+	Executed    bool
+}, error) {
+	return _SimpleDAO.Contract.Proposals(&_SimpleDAO.CallOpts, arg0)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(uint256 id, address proposer, string description, uint256 amount, address recipient, uint256 votes, uint256 deadline, bool executed)
+func (_SimpleDAO *SimpleDAOCallerSession) Proposals(arg0 *big.Int) (struct {
+	Id          *big.Int
+	Proposer    common.Address
+	Description string
+	Amount      *big.Int
+	Recipient   common.Address
+	Votes       *big.Int
+	Deadline    *big.Int
+	Executed    bool
+}, error) {
+	return _SimpleDAO.Contract.Proposals(&_SimpleDAO.CallOpts, arg0)
+}
+
+// Shares is a free data retrieval call binding the contract method 0xce7c2ac2.
+//
+// Solidity: function shares(address ) view returns(uint256)
+func (_SimpleDAO *SimpleDAOCaller) Shares(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "shares", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Shares is a free data retrieval call binding the contract method 0xce7c2ac2.
+//
+// Solidity: function shares(address ) view returns(uint256)
+func (_SimpleDAO *SimpleDAOSession) Shares(arg0 common.Address) (*big.Int, error) {
+	return _SimpleDAO.Contract.Shares(&_SimpleDAO.CallOpts, arg0)
+}
+
+// Shares is a free data retrieval call binding the contract method 0xce7c2ac2.
+//
+// Solidity: function shares(address ) view returns(uint256)
+func (_SimpleDAO *SimpleDAOCallerSession) Shares(arg0 common.Address) (*big.Int, error) {
+	return _SimpleDAO.Contract.Shares(&_SimpleDAO.CallOpts, arg0)
+}
+
+// TotalShares is a free data retrieval call binding the contract method 0x3a98ef39.
+//
+// Solidity: function totalShares() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCaller) TotalShares(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleDAO.contract.Call(opts, &out, "totalShares")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalShares is a free data retrieval call binding the contract method 0x3a98ef39.
+//
+// Solidity: function totalShares() view returns(uint256)
+func (_SimpleDAO *SimpleDAOSession) TotalShares() (*big.Int, error) {
+	return _SimpleDAO.Contract.TotalShares(&_SimpleDAO.CallOpts)
+}
+
+// TotalShares is a free data retrieval call binding the contract method 0x3a98ef39.
+//
+// Solidity: function totalShares() view returns(uint256)
+func (_SimpleDAO *SimpleDAOCallerSession) TotalShares() (*big.Int, error) {
+	return _SimpleDAO.Contract.TotalShares(&_SimpleDAO.CallOpts)
+}
+
+// CreateProposal is a paid mutator transaction binding the contract method 0x6ac1e01f.
+//
+// Solidity: function createProposal(string description, uint256 amount, address recipient) returns()
+func (_SimpleDAO *SimpleDAOTransactor) CreateProposal(opts *bind.TransactOpts, description string, amount *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _SimpleDAO.contract.Transact(opts, "createProposal", description, amount, recipient)
+}
+
+// CreateProposal is a paid mutator transaction binding the contract method 0x6ac1e01f.
+//
+// Solidity: function createProposal(string description, uint256 amount, address recipient) returns()
+func (_SimpleDAO *SimpleDAOSession) CreateProposal(description string, amount *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.CreateProposal(&_SimpleDAO.TransactOpts, description, amount, recipient)
+}
+
+// CreateProposal is a paid mutator transaction binding the contract method 0x6ac1e01f.
+//
+// Solidity: function createProposal(string description, uint256 amount, address recipient) returns()
+func (_SimpleDAO *SimpleDAOTransactorSession) CreateProposal(description string, amount *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.CreateProposal(&_SimpleDAO.TransactOpts, description, amount, recipient)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x0d61b519.
+//
+// Solidity: function executeProposal(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOTransactor) ExecuteProposal(opts *bind.TransactOpts, proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.contract.Transact(opts, "executeProposal", proposalId)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x0d61b519.
+//
+// Solidity: function executeProposal(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOSession) ExecuteProposal(proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.ExecuteProposal(&_SimpleDAO.TransactOpts, proposalId)
+}
+
+// ExecuteProposal is a paid mutator transaction binding the contract method 0x0d61b519.
+//
+// Solidity: function executeProposal(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOTransactorSession) ExecuteProposal(proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.ExecuteProposal(&_SimpleDAO.TransactOpts, proposalId)
+}
+
+// IssueShares is a paid mutator transaction binding the contract method 0xefc7a8ee.
+//
+// Solidity: function issueShares(address to, uint256 amount) returns()
+func (_SimpleDAO *SimpleDAOTransactor) IssueShares(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.contract.Transact(opts, "issueShares", to, amount)
+}
+
+// IssueShares is a paid mutator transaction binding the contract method 0xefc7a8ee.
+//
+// Solidity: function issueShares(address to, uint256 amount) returns()
+func (_SimpleDAO *SimpleDAOSession) IssueShares(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.IssueShares(&_SimpleDAO.TransactOpts, to, amount)
+}
+
+// IssueShares is a paid mutator transaction binding the contract method 0xefc7a8ee.
+//
+// Solidity: function issueShares(address to, uint256 amount) returns()
+func (_SimpleDAO *SimpleDAOTransactorSession) IssueShares(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.IssueShares(&_SimpleDAO.TransactOpts, to, amount)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOTransactor) Vote(opts *bind.TransactOpts, proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.contract.Transact(opts, "vote", proposalId)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOSession) Vote(proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.Vote(&_SimpleDAO.TransactOpts, proposalId)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_SimpleDAO *SimpleDAOTransactorSession) Vote(proposalId *big.Int) (*types.Transaction, error) {
+	return _SimpleDAO.Contract.Vote(&_SimpleDAO.TransactOpts, proposalId)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SimpleDAO *SimpleDAOTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleDAO.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SimpleDAO *SimpleDAOSession) Receive() (*types.Transaction, error) {
+	return _SimpleDAO.Contract.Receive(&_SimpleDAO.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_SimpleDAO *SimpleDAOTransactorSession) Receive() (*types.Transaction, error) {
+	return _SimpleDAO.Contract.Receive(&_SimpleDAO.TransactOpts)
+}
+
+// SimpleDAOProposalCreatedIterator is returned from FilterProposalCreated and is used to iterate over the raw logs and unpacked data for ProposalCreated events raised by the SimpleDAO contract.
+type SimpleDAOProposalCreatedIterator struct {
+	Event *SimpleDAOProposalCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleDAOProposalCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleDAOProposalCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleDAOProposalCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleDAOProposalCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleDAOProposalCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleDAOProposalCreated represents a ProposalCreated event raised by the SimpleDAO contract.
+type SimpleDAOProposalCreated struct {
+	ProposalId  *big.Int
+	Proposer    common.Address
+	Description string
+	Amount      *big.Int
+	Recipient   common.Address
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterProposalCreated is a free log retrieval operation binding the contract event 0x681515850d74456af78685d0714fbe16c2aae31c969f57374add5e8340018d21.
+//
+// Solidity: event ProposalCreated(uint256 indexed proposalId, address indexed proposer, string description, uint256 amount, address recipient)
+func (_SimpleDAO *SimpleDAOFilterer) FilterProposalCreated(opts *bind.FilterOpts, proposalId []*big.Int, proposer []common.Address) (*SimpleDAOProposalCreatedIterator, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+	var proposerRule []interface{}
+	for _, proposerItem := range proposer {
+		proposerRule = append(proposerRule, proposerItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.FilterLogs(opts, "ProposalCreated", proposalIdRule, proposerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOProposalCreatedIterator{contract: _SimpleDAO.contract, event: "ProposalCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchProposalCreated is a free log subscription operation binding the contract event 0x681515850d74456af78685d0714fbe16c2aae31c969f57374add5e8340018d21.
+//
+// Solidity: event ProposalCreated(uint256 indexed proposalId, address indexed proposer, string description, uint256 amount, address recipient)
+func (_SimpleDAO *SimpleDAOFilterer) WatchProposalCreated(opts *bind.WatchOpts, sink chan<- *SimpleDAOProposalCreated, proposalId []*big.Int, proposer []common.Address) (event.Subscription, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+	var proposerRule []interface{}
+	for _, proposerItem := range proposer {
+		proposerRule = append(proposerRule, proposerItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.WatchLogs(opts, "ProposalCreated", proposalIdRule, proposerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleDAOProposalCreated)
+				if err := _SimpleDAO.contract.UnpackLog(event, "ProposalCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProposalCreated is a log parse operation binding the contract event 0x681515850d74456af78685d0714fbe16c2aae31c969f57374add5e8340018d21.
+//
+// Solidity: event ProposalCreated(uint256 indexed proposalId, address indexed proposer, string description, uint256 amount, address recipient)
+func (_SimpleDAO *SimpleDAOFilterer) ParseProposalCreated(log types.Log) (*SimpleDAOProposalCreated, error) {
+	event := new(SimpleDAOProposalCreated)
+	if err := _SimpleDAO.contract.UnpackLog(event, "ProposalCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleDAOProposalExecutedIterator is returned from FilterProposalExecuted and is used to iterate over the raw logs and unpacked data for ProposalExecuted events raised by the SimpleDAO contract.
+type SimpleDAOProposalExecutedIterator struct {
+	Event *SimpleDAOProposalExecuted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleDAOProposalExecutedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleDAOProposalExecuted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleDAOProposalExecuted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleDAOProposalExecutedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleDAOProposalExecutedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleDAOProposalExecuted represents a ProposalExecuted event raised by the SimpleDAO contract.
+type SimpleDAOProposalExecuted struct {
+	ProposalId *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterProposalExecuted is a free log retrieval operation binding the contract event 0x712ae1383f79ac853f8d882153778e0260ef8f03b504e2866e0593e04d2b291f.
+//
+// Solidity: event ProposalExecuted(uint256 indexed proposalId)
+func (_SimpleDAO *SimpleDAOFilterer) FilterProposalExecuted(opts *bind.FilterOpts, proposalId []*big.Int) (*SimpleDAOProposalExecutedIterator, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.FilterLogs(opts, "ProposalExecuted", proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOProposalExecutedIterator{contract: _SimpleDAO.contract, event: "ProposalExecuted", logs: logs, sub: sub}, nil
+}
+
+// WatchProposalExecuted is a free log subscription operation binding the contract event 0x712ae1383f79ac853f8d882153778e0260ef8f03b504e2866e0593e04d2b291f.
+//
+// Solidity: event ProposalExecuted(uint256 indexed proposalId)
+func (_SimpleDAO *SimpleDAOFilterer) WatchProposalExecuted(opts *bind.WatchOpts, sink chan<- *SimpleDAOProposalExecuted, proposalId []*big.Int) (event.Subscription, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.WatchLogs(opts, "ProposalExecuted", proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleDAOProposalExecuted)
+				if err := _SimpleDAO.contract.UnpackLog(event, "ProposalExecuted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProposalExecuted is a log parse operation binding the contract event 0x712ae1383f79ac853f8d882153778e0260ef8f03b504e2866e0593e04d2b291f.
+//
+// Solidity: event ProposalExecuted(uint256 indexed proposalId)
+func (_SimpleDAO *SimpleDAOFilterer) ParseProposalExecuted(log types.Log) (*SimpleDAOProposalExecuted, error) {
+	event := new(SimpleDAOProposalExecuted)
+	if err := _SimpleDAO.contract.UnpackLog(event, "ProposalExecuted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleDAOSharesIssuedIterator is returned from FilterSharesIssued and is used to iterate over the raw logs and unpacked data for SharesIssued events raised by the SimpleDAO contract.
+type SimpleDAOSharesIssuedIterator struct {
+	Event *SimpleDAOSharesIssued // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleDAOSharesIssuedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleDAOSharesIssued)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleDAOSharesIssued)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleDAOSharesIssuedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleDAOSharesIssuedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleDAOSharesIssued represents a SharesIssued event raised by the SimpleDAO contract.
+type SimpleDAOSharesIssued struct {
+	To     common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterSharesIssued is a free log retrieval operation binding the contract event 0x3abf6d97fde3541bb582f72fa6fb75093b8bb699577fbc722ac25de9f6fbc4ed.
+//
+// Solidity: event SharesIssued(address indexed to, uint256 amount)
+func (_SimpleDAO *SimpleDAOFilterer) FilterSharesIssued(opts *bind.FilterOpts, to []common.Address) (*SimpleDAOSharesIssuedIterator, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.FilterLogs(opts, "SharesIssued", toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOSharesIssuedIterator{contract: _SimpleDAO.contract, event: "SharesIssued", logs: logs, sub: sub}, nil
+}
+
+// WatchSharesIssued is a free log subscription operation binding the contract event 0x3abf6d97fde3541bb582f72fa6fb75093b8bb699577fbc722ac25de9f6fbc4ed.
+//
+// Solidity: event SharesIssued(address indexed to, uint256 amount)
+func (_SimpleDAO *SimpleDAOFilterer) WatchSharesIssued(opts *bind.WatchOpts, sink chan<- *SimpleDAOSharesIssued, to []common.Address) (event.Subscription, error) {
+
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.WatchLogs(opts, "SharesIssued", toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleDAOSharesIssued)
+				if err := _SimpleDAO.contract.UnpackLog(event, "SharesIssued", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSharesIssued is a log parse operation binding the contract event 0x3abf6d97fde3541bb582f72fa6fb75093b8bb699577fbc722ac25de9f6fbc4ed.
+//
+// Solidity: event SharesIssued(address indexed to, uint256 amount)
+func (_SimpleDAO *SimpleDAOFilterer) ParseSharesIssued(log types.Log) (*SimpleDAOSharesIssued, error) {
+	event := new(SimpleDAOSharesIssued)
+	if err := _SimpleDAO.contract.UnpackLog(event, "SharesIssued", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleDAOVotedIterator is returned from FilterVoted and is used to iterate over the raw logs and unpacked data for Voted events raised by the SimpleDAO contract.
+type SimpleDAOVotedIterator struct {
+	Event *SimpleDAOVoted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleDAOVotedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleDAOVoted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleDAOVoted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleDAOVotedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleDAOVotedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleDAOVoted represents a Voted event raised by the SimpleDAO contract.
+type SimpleDAOVoted struct {
+	ProposalId *big.Int
+	Voter      common.Address
+	Shares     *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterVoted is a free log retrieval operation binding the contract event 0x1abe610cf2bf87e57dcc1181fcf5ac0934e843d8344ab9eed6e86c799f62585e.
+//
+// Solidity: event Voted(uint256 indexed proposalId, address indexed voter, uint256 shares)
+func (_SimpleDAO *SimpleDAOFilterer) FilterVoted(opts *bind.FilterOpts, proposalId []*big.Int, voter []common.Address) (*SimpleDAOVotedIterator, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.FilterLogs(opts, "Voted", proposalIdRule, voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleDAOVotedIterator{contract: _SimpleDAO.contract, event: "Voted", logs: logs, sub: sub}, nil
+}
+
+// WatchVoted is a free log subscription operation binding the contract event 0x1abe610cf2bf87e57dcc1181fcf5ac0934e843d8344ab9eed6e86c799f62585e.
+//
+// Solidity: event Voted(uint256 indexed proposalId, address indexed voter, uint256 shares)
+func (_SimpleDAO *SimpleDAOFilterer) WatchVoted(opts *bind.WatchOpts, sink chan<- *SimpleDAOVoted, proposalId []*big.Int, voter []common.Address) (event.Subscription, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _SimpleDAO.contract.WatchLogs(opts, "Voted", proposalIdRule, voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleDAOVoted)
+				if err := _SimpleDAO.contract.UnpackLog(event, "Voted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVoted is a log parse operation binding the contract event 0x1abe610cf2bf87e57dcc1181fcf5ac0934e843d8344ab9eed6e86c799f62585e.
+//
+// Solidity: event Voted(uint256 indexed proposalId, address indexed voter, uint256 shares)
+func (_SimpleDAO *SimpleDAOFilterer) ParseVoted(log types.Log) (*SimpleDAOVoted, error) {
+	event := new(SimpleDAOVoted)
+	if err := _SimpleDAO.contract.UnpackLog(event, "Voted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,96 @@
+package simpledao_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/simpledao"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestSimpleDAO(t *testing.T) {
+	chain := simchain.New(t)
+	alice, bob, carol := chain.Accounts[0].Opts(), chain.Accounts[1].Opts(), chain.Accounts[2].Opts()
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000dA0")
+	ctx := context.Background()
+
+	daoAddr, _, dao, err := simpledao.DeploySimpleDAO(alice, chain)
+	if err != nil {
+		t.Fatal("部署 SimpleDAO 失败:", err)
+	}
+	chain.Mine()
+
+	// 通过 receive() 向 DAO 注资
+	fund := *carol
+	fund.Value = ether(2)
+	if _, err := dao.Receive(&fund); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dao.IssueShares(alice, alice.From, big.NewInt(60)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dao.IssueShares(alice, bob.From, big.NewInt(40)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if bal, _ := chain.BalanceAt(ctx, daoAddr, nil); bal.Cmp(ether(2)) != 0 {
+		t.Fatalf("DAO balance = %s, want 2 ether", bal)
+	}
+	if total, _ := dao.TotalShares(nil); total.Int64() != 100 {
+		t.Fatalf("totalShares = %s, want 100", total)
+	}
+
+	description := "Pay one ether to the community treasury for Q3 infrastructure"
+	if _, err := dao.CreateProposal(carol, description, ether(1), recipient); err == nil || !strings.Contains(err.Error(), "Not a member") {
+		t.Fatalf("createProposal by non-member: err = %v", err)
+	}
+	if _, err := dao.CreateProposal(alice, description, ether(1), recipient); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	created, err := dao.FilterProposalCreated(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Next() || created.Event.Description != description || created.Event.Recipient != recipient {
+		t.Fatal("missing ProposalCreated event")
+	}
+
+	if _, err := dao.Vote(alice, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if _, err := dao.Vote(alice, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Already voted") {
+		t.Fatalf("double vote: err = %v", err)
+	}
+	if _, err := dao.ExecuteProposal(bob, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Voting period not ended") {
+		t.Fatalf("early execution: err = %v", err)
+	}
+
+	chain.Warp(8 * 24 * time.Hour)
+	if _, err := dao.ExecuteProposal(bob, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if bal, _ := chain.BalanceAt(ctx, recipient, nil); bal.Cmp(ether(1)) != 0 {
+		t.Fatalf("recipient balance = %s, want 1 ether", bal)
+	}
+	p, err := dao.Proposals(nil, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Description != description || p.Proposer != alice.From || p.Votes.Int64() != 60 || !p.Executed {
+		t.Fatalf("proposal = %+v", p)
+	}
+}
@@ -0,0 +1,1043 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package simplenft
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SimpleNFTMetaData contains all meta data concerning the SimpleNFT contract.
+var SimpleNFTMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_symbol\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"approved\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"getApproved\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ownerOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"095ea7b3": "approve(address,uint256)",
+		"70a08231": "balanceOf(address)",
+		"081812fc": "getApproved(uint256)",
+		"e985e9c5": "isApprovedForAll(address,address)",
+		"6a627842": "mint(address)",
+		"06fdde03": "name()",
+		"6352211e": "ownerOf(uint256)",
+		"42842e0e": "safeTransferFrom(address,address,uint256)",
+		"b88d4fde": "safeTransferFrom(address,address,uint256,bytes)",
+		"a22cb465": "setApprovalForAll(address,bool)",
+		"01ffc9a7": "supportsInterface(bytes4)",
+		"95d89b41": "symbol()",
+		"23b872dd": "transferFrom(address,address,uint256)",
+	},
+	Bin: "0x608060405260006006553480156200001657600080fd5b50604051620013d5380380620013d5833981016040819052620000399162000124565b60006200004783826200021d565b5060016200005682826200021d565b505050620002e9565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126200008757600080fd5b81516001600160401b0380821115620000a457620000a46200005f565b604051601f8301601f19908116603f01168101908282118183101715620000cf57620000cf6200005f565b81604052838152602092508683858801011115620000ec57600080fd5b600091505b83821015620001105785820183015181830184015290820190620000f1565b600093810190920192909252949350505050565b600080604083850312156200013857600080fd5b82516001600160401b03808211156200015057600080fd5b6200015e8683870162000075565b935060208501519150808211156200017557600080fd5b50620001848582860162000075565b9150509250929050565b600181811c90821680620001a357607f821691505b602082108103620001c457634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200021857600081815260208120601f850160051c81016020861015620001f35750805b601f850160051c820191505b818110156200021457828155600101620001ff565b5050505b505050565b81516001600160401b038111156200023957620002396200005f565b62000251816200024a84546200018e565b84620001ca565b602080601f831160018114620002895760008415620002705750858301515b600019600386901b1c1916600185901b17855562000214565b600085815260208120601f198616915b82811015620002ba5788860151825594840194600190910190840162000299565b5085821015620002d95787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b6110dc80620002f96000396000f3fe608060405234801561001057600080fd5b50600436106100cf5760003560e01c80636352211e1161008c57806395d89b411161006657806395d89b41146101be578063a22cb465146101c6578063b88d4fde146101d9578063e985e9c5146101ec57600080fd5b80636352211e146101775780636a6278421461018a57806370a08231146101ab57600080fd5b806301ffc9a7146100d457806306fdde03146100fc578063081812fc14610111578063095ea7b31461013c57806323b872dd1461015157806342842e0e14610164575b600080fd5b6100e76100e2366004610c86565b6101ff565b60405190151581526020015b60405180910390f35b610104610236565b6040516100f39190610cf0565b61012461011f366004610d03565b6102c4565b6040516001600160a01b0390911681526020016100f3565b61014f61014a366004610d38565b61035e565b005b61014f61015f366004610d62565b610473565b61014f610172366004610d62565b6104a4565b610124610185366004610d03565b6104bf565b61019d610198366004610d9e565b610536565b6040519081526020016100f3565b61019d6101b9366004610d9e565b610558565b6101046105df565b61014f6101d4366004610db9565b6105ec565b61014f6101e7366004610e0b565b6106b0565b6100e76101fa366004610ee7565b6106e8565b60006001600160e01b031982166380ac58cd60e01b148061023057506001600160e01b031982166301ffc9a760e01b145b92915050565b6000805461024390610f1a565b80601f016020809104026020016040519081016040528092919081815260200182805461026f90610f1a565b80156102bc5780601f10610291576101008083540402835291602001916102bc565b820191906000526020600020905b81548152906001019060200180831161029f57829003601f168201915b505050505081565b6000818152600260205260408120546001600160a01b03166103425760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a20617070726f76656420717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b60648201526084015b60405180910390fd5b506000908152600460205260409020546001600160a01b031690565b6000610369826104bf565b9050806001600160a01b0316836001600160a01b0316036103d65760405162461bcd60e51b815260206004820152602160248201527f4552433732313a20617070726f76616c20746f2063757272656e74206f776e656044820152603960f91b6064820152608401610339565b336001600160a01b03821614806103f257506103f281336106e8565b6104645760405162461bcd60e51b815260206004820152603860248201527f4552433732313a20617070726f76652063616c6c6572206973206e6f74206f7760448201527f6e6572206e6f7220617070726f76656420666f7220616c6c00000000000000006064820152608401610339565b61046e8383610716565b505050565b61047d3382610784565b6104995760405162461bcd60e51b815260040161033990610f54565b61046e83838361085b565b61046e838383604051806020016040528060008152506106b0565b6000818152600260205260408120546001600160a01b0316806102305760405162461bcd60e51b815260206004820152602960248201527f4552433732313a206f776e657220717565727920666f72206e6f6e657869737460448201526832b73a103a37b5b2b760b91b6064820152608401610339565b6006805460009181908361054983610fbb565b919050555061023083826109f7565b60006001600160a01b0382166105c35760405162461bcd60e51b815260206004820152602a60248201527f4552433732313a2062616c616e636520717565727920666f7220746865207a65604482015269726f206164647265737360b01b6064820152608401610339565b506001600160a01b031660009081526003602052604090205490565b6001805461024390610f1a565b336001600160a01b038316036106445760405162461bcd60e51b815260206004820152601960248201527f4552433732313a20617070726f766520746f2063616c6c6572000000000000006044820152606401610339565b3360008181526005602090815260408083206001600160a01b03871680855290835292819020805460ff191686151590811790915590519081529192917f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31910160405180910390a35050565b6106ba3383610784565b6106d65760405162461bcd60e51b815260040161033990610f54565b6106e284848484610b39565b50505050565b6001600160a01b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b600081815260046020526040902080546001600160a01b0319166001600160a01b038416908117909155819061074b826104bf565b6001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560405160405180910390a45050565b6000818152600260205260408120546001600160a01b03166107fd5760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a206f70657261746f7220717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b6064820152608401610339565b6000610808836104bf565b9050806001600160a01b0316846001600160a01b031614806108435750836001600160a01b0316610838846102c4565b6001600160a01b0316145b80610853575061085381856106e8565b949350505050565b826001600160a01b031661086e826104bf565b6001600160a01b0316146108d25760405162461bcd60e51b815260206004820152602560248201527f4552433732313a207472616e736665722066726f6d20696e636f72726563742060448201526437bbb732b960d91b6064820152608401610339565b6001600160a01b0382166109345760405162461bcd60e51b8152602060048201526024808201527f4552433732313a207472616e7366657220746f20746865207a65726f206164646044820152637265737360e01b6064820152608401610339565b61093f600082610716565b6001600160a01b0383166000908152600360205260408120805460019290610968908490610fd4565b90915550506001600160a01b0382166000908152600360205260408120805460019290610996908490610fe7565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b0386811691821790925591518493918716917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a4505050565b6001600160a01b038216610a4d5760405162461bcd60e51b815260206004820181905260248201527f4552433732313a206d696e7420746f20746865207a65726f20616464726573736044820152606401610339565b6000818152600260205260409020546001600160a01b031615610ab25760405162461bcd60e51b815260206004820152601c60248201527f4552433732313a20746f6b656e20616c7265616479206d696e746564000000006044820152606401610339565b6001600160a01b0382166000908152600360205260408120805460019290610adb908490610fe7565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b03861690811790915590518392907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef908290a45050565b610b4484848461085b565b610b5084848484610b6c565b6106e25760405162461bcd60e51b815260040161033990610ffa565b60006001600160a01b0384163b15610c6257604051630a85bd0160e11b81526001600160a01b0385169063150b7a0290610bb090339089908890889060040161104c565b6020604051808303816000875af1925050508015610beb575060408051601f3d908101601f19168201909252610be891810190611089565b60015b610c48573d808015610c19576040519150601f19603f3d011682016040523d82523d6000602084013e610c1e565b606091505b508051600003610c405760405162461bcd60e51b815260040161033990610ffa565b805181602001fd5b6001600160e01b031916630a85bd0160e11b149050610853565b506001949350505050565b6001600160e01b031981168114610c8357600080fd5b50565b600060208284031215610c9857600080fd5b8135610ca381610c6d565b9392505050565b6000815180845260005b81811015610cd057602081850181015186830182015201610cb4565b506000602082860101526020601f19601f83011685010191505092915050565b602081526000610ca36020830184610caa565b600060208284031215610d1557600080fd5b5035919050565b80356001600160a01b0381168114610d3357600080fd5b919050565b60008060408385031215610d4b57600080fd5b610d5483610d1c565b946020939093013593505050565b600080600060608486031215610d7757600080fd5b610d8084610d1c565b9250610d8e60208501610d1c565b9150604084013590509250925092565b600060208284031215610db057600080fd5b610ca382610d1c565b60008060408385031215610dcc57600080fd5b610dd583610d1c565b915060208301358015158114610dea57600080fd5b809150509250929050565b634e487b7160e01b600052604160045260246000fd5b60008060008060808587031215610e2157600080fd5b610e2a85610d1c565b9350610e3860208601610d1c565b925060408501359150606085013567ffffffffffffffff80821115610e5c57600080fd5b818701915087601f830112610e7057600080fd5b813581811115610e8257610e82610df5565b604051601f8201601f19908116603f01168101908382118183101715610eaa57610eaa610df5565b816040528281528a6020848701011115610ec357600080fd5b82602086016020830137600060208483010152809550505050505092959194509250565b60008060408385031215610efa57600080fd5b610f0383610d1c565b9150610f1160208401610d1c565b90509250929050565b600181811c90821680610f2e57607f821691505b602082108103610f4e57634e487b7160e01b600052602260045260246000fd5b50919050565b60208082526031908201527f4552433732313a207472616e736665722063616c6c6572206973206e6f74206f6040820152701ddb995c881b9bdc88185c1c1c9bdd9959607a1b606082015260800190565b634e487b7160e01b600052601160045260246000fd5b600060018201610fcd57610fcd610fa5565b5060010190565b8181038181111561023057610230610fa5565b8082018082111561023057610230610fa5565b60208082526032908201527f4552433732313a207472616e7366657220746f206e6f6e20455243373231526560408201527131b2b4bb32b91034b6b83632b6b2b73a32b960711b606082015260800190565b6001600160a01b038581168252841660208201526040810183905260806060820181905260009061107f90830184610caa565b9695505050505050565b60006020828403121561109b57600080fd5b8151610ca381610c6d56fea2646970667358221220f1f9f20ed8b2aca029bff168603c6b658b8a3d53e64844e04a79976b9e112a5c64736f6c63430008150033",
+}
+
+// SimpleNFTABI is the input ABI used to generate the binding from.
+// Deprecated: Use SimpleNFTMetaData.ABI instead.
+var SimpleNFTABI = SimpleNFTMetaData.ABI
+
+// Deprecated: Use SimpleNFTMetaData.Sigs instead.
+// SimpleNFTFuncSigs maps the 4-byte function signature to its string representation.
+var SimpleNFTFuncSigs = SimpleNFTMetaData.Sigs
+
+// SimpleNFTBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use SimpleNFTMetaData.Bin instead.
+var SimpleNFTBin = SimpleNFTMetaData.Bin
+
+// DeploySimpleNFT deploys a new Ethereum contract, binding an instance of SimpleNFT to it.
+func DeploySimpleNFT(auth *bind.TransactOpts, backend bind.ContractBackend, _name string, _symbol string) (common.Address, *types.Transaction, *SimpleNFT, error) {
+	parsed, err := SimpleNFTMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(SimpleNFTBin), backend, _name, _symbol)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &SimpleNFT{SimpleNFTCaller: SimpleNFTCaller{contract: contract}, SimpleNFTTransactor: SimpleNFTTransactor{contract: contract}, SimpleNFTFilterer: SimpleNFTFilterer{contract: contract}}, nil
+}
+
+// SimpleNFT is an auto generated Go binding around an Ethereum contract.
+type SimpleNFT struct {
+	SimpleNFTCaller     // Read-only binding to the contract
+	SimpleNFTTransactor // Write-only binding to the contract
+	SimpleNFTFilterer   // Log filterer for contract events
+}
+
+// SimpleNFTCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SimpleNFTCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleNFTTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SimpleNFTTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleNFTFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SimpleNFTFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleNFTSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SimpleNFTSession struct {
+	Contract     *SimpleNFT        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SimpleNFTCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SimpleNFTCallerSession struct {
+	Contract *SimpleNFTCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// SimpleNFTTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SimpleNFTTransactorSession struct {
+	Contract     *SimpleNFTTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// SimpleNFTRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SimpleNFTRaw struct {
+	Contract *SimpleNFT // Generic contract binding to access the raw methods on
+}
+
+// SimpleNFTCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SimpleNFTCallerRaw struct {
+	Contract *SimpleNFTCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SimpleNFTTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SimpleNFTTransactorRaw struct {
+	Contract *SimpleNFTTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSimpleNFT creates a new instance of SimpleNFT, bound to a specific deployed contract.
+func NewSimpleNFT(address common.Address, backend bind.ContractBackend) (*SimpleNFT, error) {
+	contract, err := bindSimpleNFT(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFT{SimpleNFTCaller: SimpleNFTCaller{contract: contract}, SimpleNFTTransactor: SimpleNFTTransactor{contract: contract}, SimpleNFTFilterer: SimpleNFTFilterer{contract: contract}}, nil
+}
+
+// NewSimpleNFTCaller creates a new read-only instance of SimpleNFT, bound to a specific deployed contract.
+func NewSimpleNFTCaller(address common.Address, caller bind.ContractCaller) (*SimpleNFTCaller, error) {
+	contract, err := bindSimpleNFT(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTCaller{contract: contract}, nil
+}
+
+// NewSimpleNFTTransactor creates a new write-only instance of SimpleNFT, bound to a specific deployed contract.
+func NewSimpleNFTTransactor(address common.Address, transactor bind.ContractTransactor) (*SimpleNFTTransactor, error) {
+	contract, err := bindSimpleNFT(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTTransactor{contract: contract}, nil
+}
+
+// NewSimpleNFTFilterer creates a new log filterer instance of SimpleNFT, bound to a specific deployed contract.
+func NewSimpleNFTFilterer(address common.Address, filterer bind.ContractFilterer) (*SimpleNFTFilterer, error) {
+	contract, err := bindSimpleNFT(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTFilterer{contract: contract}, nil
+}
+
+// bindSimpleNFT binds a generic wrapper to an already deployed contract.
+func bindSimpleNFT(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SimpleNFTMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleNFT *SimpleNFTRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleNFT.Contract.SimpleNFTCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleNFT *SimpleNFTRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SimpleNFTTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleNFT *SimpleNFTRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SimpleNFTTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleNFT *SimpleNFTCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleNFT.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleNFT *SimpleNFTTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleNFT *SimpleNFTTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.contract.Transact(opts, method, params...)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_SimpleNFT *SimpleNFTCaller) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "balanceOf", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_SimpleNFT *SimpleNFTSession) BalanceOf(owner common.Address) (*big.Int, error) {
+	return _SimpleNFT.Contract.BalanceOf(&_SimpleNFT.CallOpts, owner)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_SimpleNFT *SimpleNFTCallerSession) BalanceOf(owner common.Address) (*big.Int, error) {
+	return _SimpleNFT.Contract.BalanceOf(&_SimpleNFT.CallOpts, owner)
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTCaller) GetApproved(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "getApproved", tokenId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTSession) GetApproved(tokenId *big.Int) (common.Address, error) {
+	return _SimpleNFT.Contract.GetApproved(&_SimpleNFT.CallOpts, tokenId)
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTCallerSession) GetApproved(tokenId *big.Int) (common.Address, error) {
+	return _SimpleNFT.Contract.GetApproved(&_SimpleNFT.CallOpts, tokenId)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_SimpleNFT *SimpleNFTCaller) IsApprovedForAll(opts *bind.CallOpts, owner common.Address, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "isApprovedForAll", owner, operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_SimpleNFT *SimpleNFTSession) IsApprovedForAll(owner common.Address, operator common.Address) (bool, error) {
+	return _SimpleNFT.Contract.IsApprovedForAll(&_SimpleNFT.CallOpts, owner, operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_SimpleNFT *SimpleNFTCallerSession) IsApprovedForAll(owner common.Address, operator common.Address) (bool, error) {
+	return _SimpleNFT.Contract.IsApprovedForAll(&_SimpleNFT.CallOpts, owner, operator)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_SimpleNFT *SimpleNFTCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_SimpleNFT *SimpleNFTSession) Name() (string, error) {
+	return _SimpleNFT.Contract.Name(&_SimpleNFT.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_SimpleNFT *SimpleNFTCallerSession) Name() (string, error) {
+	return _SimpleNFT.Contract.Name(&_SimpleNFT.CallOpts)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTCaller) OwnerOf(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "ownerOf", tokenId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTSession) OwnerOf(tokenId *big.Int) (common.Address, error) {
+	return _SimpleNFT.Contract.OwnerOf(&_SimpleNFT.CallOpts, tokenId)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_SimpleNFT *SimpleNFTCallerSession) OwnerOf(tokenId *big.Int) (common.Address, error) {
+	return _SimpleNFT.Contract.OwnerOf(&_SimpleNFT.CallOpts, tokenId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_SimpleNFT *SimpleNFTCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_SimpleNFT *SimpleNFTSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _SimpleNFT.Contract.SupportsInterface(&_SimpleNFT.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_SimpleNFT *SimpleNFTCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _SimpleNFT.Contract.SupportsInterface(&_SimpleNFT.CallOpts, interfaceId)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_SimpleNFT *SimpleNFTCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _SimpleNFT.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_SimpleNFT *SimpleNFTSession) Symbol() (string, error) {
+	return _SimpleNFT.Contract.Symbol(&_SimpleNFT.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_SimpleNFT *SimpleNFTCallerSession) Symbol() (string, error) {
+	return _SimpleNFT.Contract.Symbol(&_SimpleNFT.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactor) Approve(opts *bind.TransactOpts, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "approve", to, tokenId)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTSession) Approve(to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.Approve(&_SimpleNFT.TransactOpts, to, tokenId)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactorSession) Approve(to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.Approve(&_SimpleNFT.TransactOpts, to, tokenId)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x6a627842.
+//
+// Solidity: function mint(address to) returns(uint256)
+func (_SimpleNFT *SimpleNFTTransactor) Mint(opts *bind.TransactOpts, to common.Address) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "mint", to)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x6a627842.
+//
+// Solidity: function mint(address to) returns(uint256)
+func (_SimpleNFT *SimpleNFTSession) Mint(to common.Address) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.Mint(&_SimpleNFT.TransactOpts, to)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x6a627842.
+//
+// Solidity: function mint(address to) returns(uint256)
+func (_SimpleNFT *SimpleNFTTransactorSession) Mint(to common.Address) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.Mint(&_SimpleNFT.TransactOpts, to)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactor) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "safeTransferFrom", from, to, tokenId)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTSession) SafeTransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SafeTransferFrom(&_SimpleNFT.TransactOpts, from, to, tokenId)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactorSession) SafeTransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SafeTransferFrom(&_SimpleNFT.TransactOpts, from, to, tokenId)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes _data) returns()
+func (_SimpleNFT *SimpleNFTTransactor) SafeTransferFrom0(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int, _data []byte) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "safeTransferFrom0", from, to, tokenId, _data)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes _data) returns()
+func (_SimpleNFT *SimpleNFTSession) SafeTransferFrom0(from common.Address, to common.Address, tokenId *big.Int, _data []byte) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SafeTransferFrom0(&_SimpleNFT.TransactOpts, from, to, tokenId, _data)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes _data) returns()
+func (_SimpleNFT *SimpleNFTTransactorSession) SafeTransferFrom0(from common.Address, to common.Address, tokenId *big.Int, _data []byte) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SafeTransferFrom0(&_SimpleNFT.TransactOpts, from, to, tokenId, _data)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_SimpleNFT *SimpleNFTTransactor) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_SimpleNFT *SimpleNFTSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SetApprovalForAll(&_SimpleNFT.TransactOpts, operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_SimpleNFT *SimpleNFTTransactorSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.SetApprovalForAll(&_SimpleNFT.TransactOpts, operator, approved)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.contract.Transact(opts, "transferFrom", from, to, tokenId)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTSession) TransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.TransferFrom(&_SimpleNFT.TransactOpts, from, to, tokenId)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_SimpleNFT *SimpleNFTTransactorSession) TransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _SimpleNFT.Contract.TransferFrom(&_SimpleNFT.TransactOpts, from, to, tokenId)
+}
+
+// SimpleNFTApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the SimpleNFT contract.
+type SimpleNFTApprovalIterator struct {
+	Event *SimpleNFTApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleNFTApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleNFTApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleNFTApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleNFTApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleNFTApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleNFTApproval represents a Approval event raised by the SimpleNFT contract.
+type SimpleNFTApproval struct {
+	Owner    common.Address
+	Approved common.Address
+	TokenId  *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, approved []common.Address, tokenId []*big.Int) (*SimpleNFTApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var approvedRule []interface{}
+	for _, approvedItem := range approved {
+		approvedRule = append(approvedRule, approvedItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.FilterLogs(opts, "Approval", ownerRule, approvedRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTApprovalIterator{contract: _SimpleNFT.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *SimpleNFTApproval, owner []common.Address, approved []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var approvedRule []interface{}
+	for _, approvedItem := range approved {
+		approvedRule = append(approvedRule, approvedItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.WatchLogs(opts, "Approval", ownerRule, approvedRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleNFTApproval)
+				if err := _SimpleNFT.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) ParseApproval(log types.Log) (*SimpleNFTApproval, error) {
+	event := new(SimpleNFTApproval)
+	if err := _SimpleNFT.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleNFTApprovalForAllIterator is returned from FilterApprovalForAll and is used to iterate over the raw logs and unpacked data for ApprovalForAll events raised by the SimpleNFT contract.
+type SimpleNFTApprovalForAllIterator struct {
+	Event *SimpleNFTApprovalForAll // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleNFTApprovalForAllIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleNFTApprovalForAll)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleNFTApprovalForAll)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleNFTApprovalForAllIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleNFTApprovalForAllIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleNFTApprovalForAll represents a ApprovalForAll event raised by the SimpleNFT contract.
+type SimpleNFTApprovalForAll struct {
+	Owner    common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApprovalForAll is a free log retrieval operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_SimpleNFT *SimpleNFTFilterer) FilterApprovalForAll(opts *bind.FilterOpts, owner []common.Address, operator []common.Address) (*SimpleNFTApprovalForAllIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.FilterLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTApprovalForAllIterator{contract: _SimpleNFT.contract, event: "ApprovalForAll", logs: logs, sub: sub}, nil
+}
+
+// WatchApprovalForAll is a free log subscription operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_SimpleNFT *SimpleNFTFilterer) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *SimpleNFTApprovalForAll, owner []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.WatchLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleNFTApprovalForAll)
+				if err := _SimpleNFT.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApprovalForAll is a log parse operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_SimpleNFT *SimpleNFTFilterer) ParseApprovalForAll(log types.Log) (*SimpleNFTApprovalForAll, error) {
+	event := new(SimpleNFTApprovalForAll)
+	if err := _SimpleNFT.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleNFTTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the SimpleNFT contract.
+type SimpleNFTTransferIterator struct {
+	Event *SimpleNFTTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleNFTTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleNFTTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleNFTTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleNFTTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleNFTTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleNFTTransfer represents a Transfer event raised by the SimpleNFT contract.
+type SimpleNFTTransfer struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address, tokenId []*big.Int) (*SimpleNFTTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.FilterLogs(opts, "Transfer", fromRule, toRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleNFTTransferIterator{contract: _SimpleNFT.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *SimpleNFTTransfer, from []common.Address, to []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _SimpleNFT.contract.WatchLogs(opts, "Transfer", fromRule, toRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleNFTTransfer)
+				if err := _SimpleNFT.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_SimpleNFT *SimpleNFTFilterer) ParseTransfer(log types.Log) (*SimpleNFTTransfer, error) {
+	event := new(SimpleNFTTransfer)
+	if err := _SimpleNFT.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
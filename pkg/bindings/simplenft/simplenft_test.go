@@ -0,0 +1,110 @@
+package simplenft_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplenft"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestSimpleNFT(t *testing.T) {
+	chain := simchain.New(t)
+	alice, bob, carol := chain.Accounts[0].Opts(), chain.Accounts[1].Opts(), chain.Accounts[2].Opts()
+
+	_, _, nft, err := simplenft.DeploySimpleNFT(alice, chain, "Simple NFT", "SNFT")
+	if err != nil {
+		t.Fatal("部署 SimpleNFT 失败:", err)
+	}
+	other, _, _, err := simplenft.DeploySimpleNFT(alice, chain, "Other", "OTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if name, _ := nft.Name(nil); name != "Simple NFT" {
+		t.Fatalf("name = %q", name)
+	}
+	for id, want := range map[[4]byte]bool{
+		{0x80, 0xac, 0x58, 0xcd}: true, // IERC721
+		{0x01, 0xff, 0xc9, 0xa7}: true, // IERC165
+		{0xff, 0xff, 0xff, 0xff}: false,
+	} {
+		if got, _ := nft.SupportsInterface(nil, id); got != want {
+			t.Errorf("supportsInterface(%x) = %v, want %v", id, got, want)
+		}
+	}
+
+	// 铸造 0、1 两个 token 给 alice
+	for i := 0; i < 2; i++ {
+		if _, err := nft.Mint(alice, alice.From); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain.Mine()
+	if bal, _ := nft.BalanceOf(nil, alice.From); bal.Int64() != 2 {
+		t.Fatalf("balanceOf(alice) = %s, want 2", bal)
+	}
+	if _, err := nft.OwnerOf(nil, big.NewInt(5)); err == nil || !strings.Contains(err.Error(), "ERC721: owner query for nonexistent token") {
+		t.Fatalf("ownerOf(nonexistent): err = %v", err)
+	}
+
+	// 授权 bob 转移 token 0
+	if _, err := nft.Approve(alice, bob.From, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if approved, _ := nft.GetApproved(nil, big.NewInt(0)); approved != bob.From {
+		t.Fatalf("getApproved(0) = %s", approved.Hex())
+	}
+	if _, err := nft.TransferFrom(carol, alice.From, carol.From, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "not owner nor approved") {
+		t.Fatalf("unauthorised transferFrom: err = %v", err)
+	}
+	if _, err := nft.TransferFrom(bob, alice.From, carol.From, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if owner, _ := nft.OwnerOf(nil, big.NewInt(0)); owner != carol.From {
+		t.Fatalf("ownerOf(0) = %s, want carol", owner.Hex())
+	}
+	if approved, _ := nft.GetApproved(nil, big.NewInt(0)); approved != (common.Address{}) {
+		t.Fatalf("approval not cleared: %s", approved.Hex())
+	}
+
+	// 操作员授权 + safeTransferFrom 到普通账户
+	if _, err := nft.SetApprovalForAll(alice, bob.From, true); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if ok, _ := nft.IsApprovedForAll(nil, alice.From, bob.From); !ok {
+		t.Fatal("isApprovedForAll(alice, bob) = false")
+	}
+	if _, err := nft.SafeTransferFrom(bob, alice.From, bob.From, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if owner, _ := nft.OwnerOf(nil, big.NewInt(1)); owner != bob.From {
+		t.Fatalf("ownerOf(1) = %s, want bob", owner.Hex())
+	}
+
+	// 目标合约未实现 onERC721Received 时必须回滚
+	_, err = nft.SafeTransferFrom0(bob, bob.From, other, big.NewInt(1), []byte("hello"))
+	if err == nil || !strings.Contains(err.Error(), "ERC721: transfer to non ERC721Receiver implementer") {
+		t.Fatalf("safeTransferFrom to non-receiver: err = %v", err)
+	}
+
+	it, err := nft.FilterTransfer(nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transfers int
+	for it.Next() {
+		transfers++
+	}
+	if transfers != 4 {
+		t.Fatalf("got %d Transfer events, want 4", transfers)
+	}
+}
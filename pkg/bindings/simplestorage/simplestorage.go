@@ -0,0 +1,307 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package simplestorage
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SimpleStorageMetaData contains all meta data concerning the SimpleStorage contract.
+var SimpleStorageMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"get\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"increment\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"reset\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"x\",\"type\":\"uint256\"}],\"name\":\"set\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"6d4ce63c": "get()",
+		"d09de08a": "increment()",
+		"d826f88f": "reset()",
+		"60fe47b1": "set(uint256)",
+	},
+	Bin: "0x608060405234801561001057600080fd5b5061010b806100206000396000f3fe6080604052348015600f57600080fd5b506004361060465760003560e01c806360fe47b114604b5780636d4ce63c14605d578063d09de08a146072578063d826f88f146078575b600080fd5b605b60563660046097565b600055565b005b60005460405190815260200160405180910390f35b605b6080565b605b60008055565b60016000808282546090919060af565b9091555050565b60006020828403121560a857600080fd5b5035919050565b8082018082111560cf57634e487b7160e01b600052601160045260246000fd5b9291505056fea26469706673582212208eb278b0e26b25f40e15b51011b5264dcae8cfb662b8d3cafaab65a7625cfc8f64736f6c63430008150033",
+}
+
+// SimpleStorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use SimpleStorageMetaData.ABI instead.
+var SimpleStorageABI = SimpleStorageMetaData.ABI
+
+// Deprecated: Use SimpleStorageMetaData.Sigs instead.
+// SimpleStorageFuncSigs maps the 4-byte function signature to its string representation.
+var SimpleStorageFuncSigs = SimpleStorageMetaData.Sigs
+
+// SimpleStorageBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use SimpleStorageMetaData.Bin instead.
+var SimpleStorageBin = SimpleStorageMetaData.Bin
+
+// DeploySimpleStorage deploys a new Ethereum contract, binding an instance of SimpleStorage to it.
+func DeploySimpleStorage(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *SimpleStorage, error) {
+	parsed, err := SimpleStorageMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(SimpleStorageBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &SimpleStorage{SimpleStorageCaller: SimpleStorageCaller{contract: contract}, SimpleStorageTransactor: SimpleStorageTransactor{contract: contract}, SimpleStorageFilterer: SimpleStorageFilterer{contract: contract}}, nil
+}
+
+// SimpleStorage is an auto generated Go binding around an Ethereum contract.
+type SimpleStorage struct {
+	SimpleStorageCaller     // Read-only binding to the contract
+	SimpleStorageTransactor // Write-only binding to the contract
+	SimpleStorageFilterer   // Log filterer for contract events
+}
+
+// SimpleStorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SimpleStorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SimpleStorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SimpleStorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SimpleStorageSession struct {
+	Contract     *SimpleStorage    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SimpleStorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SimpleStorageCallerSession struct {
+	Contract *SimpleStorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// SimpleStorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SimpleStorageTransactorSession struct {
+	Contract     *SimpleStorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// SimpleStorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SimpleStorageRaw struct {
+	Contract *SimpleStorage // Generic contract binding to access the raw methods on
+}
+
+// SimpleStorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SimpleStorageCallerRaw struct {
+	Contract *SimpleStorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SimpleStorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SimpleStorageTransactorRaw struct {
+	Contract *SimpleStorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSimpleStorage creates a new instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorage(address common.Address, backend bind.ContractBackend) (*SimpleStorage, error) {
+	contract, err := bindSimpleStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorage{SimpleStorageCaller: SimpleStorageCaller{contract: contract}, SimpleStorageTransactor: SimpleStorageTransactor{contract: contract}, SimpleStorageFilterer: SimpleStorageFilterer{contract: contract}}, nil
+}
+
+// NewSimpleStorageCaller creates a new read-only instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageCaller(address common.Address, caller bind.ContractCaller) (*SimpleStorageCaller, error) {
+	contract, err := bindSimpleStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageCaller{contract: contract}, nil
+}
+
+// NewSimpleStorageTransactor creates a new write-only instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*SimpleStorageTransactor, error) {
+	contract, err := bindSimpleStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageTransactor{contract: contract}, nil
+}
+
+// NewSimpleStorageFilterer creates a new log filterer instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*SimpleStorageFilterer, error) {
+	contract, err := bindSimpleStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageFilterer{contract: contract}, nil
+}
+
+// bindSimpleStorage binds a generic wrapper to an already deployed contract.
+func bindSimpleStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SimpleStorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleStorage *SimpleStorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleStorage.Contract.SimpleStorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleStorage *SimpleStorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.SimpleStorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleStorage *SimpleStorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.SimpleStorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleStorage *SimpleStorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleStorage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleStorage *SimpleStorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleStorage *SimpleStorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.contract.Transact(opts, method, params...)
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_SimpleStorage *SimpleStorageCaller) Get(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "get")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_SimpleStorage *SimpleStorageSession) Get() (*big.Int, error) {
+	return _SimpleStorage.Contract.Get(&_SimpleStorage.CallOpts)
+}
+
+// Get is a free data retrieval call binding the contract method 0x6d4ce63c.
+//
+// Solidity: function get() view returns(uint256)
+func (_SimpleStorage *SimpleStorageCallerSession) Get() (*big.Int, error) {
+	return _SimpleStorage.Contract.Get(&_SimpleStorage.CallOpts)
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_SimpleStorage *SimpleStorageTransactor) Increment(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "increment")
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_SimpleStorage *SimpleStorageSession) Increment() (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Increment(&_SimpleStorage.TransactOpts)
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) Increment() (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Increment(&_SimpleStorage.TransactOpts)
+}
+
+// Reset is a paid mutator transaction binding the contract method 0xd826f88f.
+//
+// Solidity: function reset() returns()
+func (_SimpleStorage *SimpleStorageTransactor) Reset(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "reset")
+}
+
+// Reset is a paid mutator transaction binding the contract method 0xd826f88f.
+//
+// Solidity: function reset() returns()
+func (_SimpleStorage *SimpleStorageSession) Reset() (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Reset(&_SimpleStorage.TransactOpts)
+}
+
+// Reset is a paid mutator transaction binding the contract method 0xd826f88f.
+//
+// Solidity: function reset() returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) Reset() (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Reset(&_SimpleStorage.TransactOpts)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_SimpleStorage *SimpleStorageTransactor) Set(opts *bind.TransactOpts, x *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "set", x)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_SimpleStorage *SimpleStorageSession) Set(x *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Set(&_SimpleStorage.TransactOpts, x)
+}
+
+// Set is a paid mutator transaction binding the contract method 0x60fe47b1.
+//
+// Solidity: function set(uint256 x) returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) Set(x *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.Set(&_SimpleStorage.TransactOpts, x)
+}
@@ -0,0 +1,50 @@
+package simplestorage_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplestorage"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestSimpleStorage(t *testing.T) {
+	chain := simchain.New(t)
+	auth := chain.Accounts[0].Opts()
+
+	_, _, store, err := simplestorage.DeploySimpleStorage(auth, chain)
+	if err != nil {
+		t.Fatal("部署 SimpleStorage 失败:", err)
+	}
+	chain.Mine()
+
+	check := func(want int64) {
+		t.Helper()
+		got, err := store.Get(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Int64() != want {
+			t.Fatalf("get() = %s, want %d", got, want)
+		}
+	}
+
+	check(0)
+	if _, err := store.Set(auth, big.NewInt(41)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	check(41)
+
+	if _, err := store.Increment(auth); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	check(42)
+
+	if _, err := store.Reset(auth); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	check(0)
+}
@@ -0,0 +1,1084 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package tokenstaking
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TokenStakingMetaData contains all meta data concerning the TokenStaking contract.
+var TokenStakingMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_stakingToken\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_rewardToken\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"reward\",\"type\":\"uint256\"}],\"name\":\"RewardPaid\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Staked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Withdrawn\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balances\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"earned\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"exit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getReward\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastUpdateTime\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardPerToken\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardPerTokenStored\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardRate\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardToken\",\"outputs\":[{\"internalType\":\"contractIERC20Simple\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"rewards\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"stake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"stakingToken\",\"outputs\":[{\"internalType\":\"contractIERC20Simple\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userRewardPerTokenPaid\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"27e235e3": "balances(address)",
+		"008cc262": "earned(address)",
+		"e9fad8ee": "exit()",
+		"3d18b912": "getReward()",
+		"c8f33c91": "lastUpdateTime()",
+		"cd3daf9d": "rewardPerToken()",
+		"df136d65": "rewardPerTokenStored()",
+		"7b0a47ee": "rewardRate()",
+		"f7c618c1": "rewardToken()",
+		"0700037d": "rewards(address)",
+		"a694fc3a": "stake(uint256)",
+		"72f702f3": "stakingToken()",
+		"18160ddd": "totalSupply()",
+		"8b876347": "userRewardPerTokenPaid(address)",
+		"2e1a7d4d": "withdraw(uint256)",
+	},
+	Bin: "0x6080604052606460025534801561001557600080fd5b5060405161097d38038061097d83398101604081905261003491610081565b600080546001600160a01b039384166001600160a01b031991821617909155600180549290931691161790556100b4565b80516001600160a01b038116811461007c57600080fd5b919050565b6000806040838503121561009457600080fd5b61009d83610065565b91506100ab60208401610065565b90509250929050565b6108ba806100c36000396000f3fe608060405234801561001057600080fd5b50600436106100f45760003560e01c80637b0a47ee11610097578063cd3daf9d11610066578063cd3daf9d146101f5578063df136d65146101fd578063e9fad8ee14610206578063f7c618c11461020e57600080fd5b80637b0a47ee146101b05780638b876347146101b9578063a694fc3a146101d9578063c8f33c91146101ec57600080fd5b806327e235e3116100d357806327e235e3146101485780632e1a7d4d146101685780633d18b9121461017d57806372f702f31461018557600080fd5b80628cc262146100f95780630700037d1461011f57806318160ddd1461013f575b600080fd5b61010c6101073660046107a4565b610221565b6040519081526020015b60405180910390f35b61010c61012d3660046107a4565b60066020526000908152604090205481565b61010c60085481565b61010c6101563660046107a4565b60076020526000908152604090205481565b61017b6101763660046107d4565b61029e565b005b61017b61047c565b600054610198906001600160a01b031681565b6040516001600160a01b039091168152602001610116565b61010c60025481565b61010c6101c73660046107a4565b60056020526000908152604090205481565b61017b6101e73660046107d4565b6105a5565b61010c60035481565b61010c610723565b61010c60045481565b61017b610781565b600154610198906001600160a01b031681565b6001600160a01b0381166000908152600660209081526040808320546005909252822054670de0b6b3a764000090610257610723565b6102619190610803565b6001600160a01b0385166000908152600760205260409020546102849190610816565b61028e919061082d565b610298919061084f565b92915050565b336102a7610723565b600455426003556001600160a01b038116156102f2576102c681610221565b6001600160a01b0382166000908152600660209081526040808320939093556004546005909152919020555b6000821161033b5760405162461bcd60e51b8152602060048201526011602482015270043616e6e6f74207769746864726177203607c1b60448201526064015b60405180910390fd5b336000908152600760205260409020548211156103915760405162461bcd60e51b8152602060048201526014602482015273496e73756666696369656e742062616c616e636560601b6044820152606401610332565b81600860008282546103a39190610803565b909155505033600090815260076020526040812080548492906103c7908490610803565b909155505060005460405163a9059cbb60e01b8152336004820152602481018490526001600160a01b039091169063a9059cbb906044016020604051808303816000875af115801561041d573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906104419190610862565b5060405182815233907f7084f5476618d8e60b11ef0d7d3f06914655adb8793e28ff7f018d4c76d505d5906020015b60405180910390a25050565b33610485610723565b600455426003556001600160a01b038116156104d0576104a481610221565b6001600160a01b0382166000908152600660209081526040808320939093556004546005909152919020555b3360009081526006602052604090205480156105a1573360008181526006602052604080822091909155600154905163a9059cbb60e01b81526004810192909252602482018390526001600160a01b03169063a9059cbb906044016020604051808303816000875af115801561054a573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061056e9190610862565b5060405181815233907fe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e048690602001610470565b5050565b336105ae610723565b600455426003556001600160a01b038116156105f9576105cd81610221565b6001600160a01b0382166000908152600660209081526040808320939093556004546005909152919020555b6000821161063a5760405162461bcd60e51b815260206004820152600e60248201526d043616e6e6f74207374616b6520360941b6044820152606401610332565b816008600082825461064c919061084f565b9091555050336000908152600760205260408120805484929061067090849061084f565b90915550506000546040516323b872dd60e01b8152336004820152306024820152604481018490526001600160a01b03909116906323b872dd906064016020604051808303816000875af11580156106cc573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906106f09190610862565b5060405182815233907f9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d90602001610470565b6000600854600003610736575060045490565b6008546002546003546107499042610803565b6107539190610816565b61076590670de0b6b3a7640000610816565b61076f919061082d565b60045461077c919061084f565b905090565b3360009081526007602052604090205461079a9061029e565b6107a261047c565b565b6000602082840312156107b657600080fd5b81356001600160a01b03811681146107cd57600080fd5b9392505050565b6000602082840312156107e657600080fd5b5035919050565b634e487b7160e01b600052601160045260246000fd5b81810381811115610298576102986107ed565b8082028115828204841417610298576102986107ed565b60008261084a57634e487b7160e01b600052601260045260246000fd5b500490565b80820180821115610298576102986107ed565b60006020828403121561087457600080fd5b815180151581146107cd57600080fdfea2646970667358221220a69b4e925069e1f65816e714880132855e3cef1084bc5630184a2f364bf08a7b64736f6c63430008150033",
+}
+
+// TokenStakingABI is the input ABI used to generate the binding from.
+// Deprecated: Use TokenStakingMetaData.ABI instead.
+var TokenStakingABI = TokenStakingMetaData.ABI
+
+// Deprecated: Use TokenStakingMetaData.Sigs instead.
+// TokenStakingFuncSigs maps the 4-byte function signature to its string representation.
+var TokenStakingFuncSigs = TokenStakingMetaData.Sigs
+
+// TokenStakingBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use TokenStakingMetaData.Bin instead.
+var TokenStakingBin = TokenStakingMetaData.Bin
+
+// DeployTokenStaking deploys a new Ethereum contract, binding an instance of TokenStaking to it.
+func DeployTokenStaking(auth *bind.TransactOpts, backend bind.ContractBackend, _stakingToken common.Address, _rewardToken common.Address) (common.Address, *types.Transaction, *TokenStaking, error) {
+	parsed, err := TokenStakingMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TokenStakingBin), backend, _stakingToken, _rewardToken)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &TokenStaking{TokenStakingCaller: TokenStakingCaller{contract: contract}, TokenStakingTransactor: TokenStakingTransactor{contract: contract}, TokenStakingFilterer: TokenStakingFilterer{contract: contract}}, nil
+}
+
+// TokenStaking is an auto generated Go binding around an Ethereum contract.
+type TokenStaking struct {
+	TokenStakingCaller     // Read-only binding to the contract
+	TokenStakingTransactor // Write-only binding to the contract
+	TokenStakingFilterer   // Log filterer for contract events
+}
+
+// TokenStakingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TokenStakingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenStakingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TokenStakingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenStakingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TokenStakingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenStakingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TokenStakingSession struct {
+	Contract     *TokenStaking     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// TokenStakingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TokenStakingCallerSession struct {
+	Contract *TokenStakingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// TokenStakingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TokenStakingTransactorSession struct {
+	Contract     *TokenStakingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// TokenStakingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TokenStakingRaw struct {
+	Contract *TokenStaking // Generic contract binding to access the raw methods on
+}
+
+// TokenStakingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TokenStakingCallerRaw struct {
+	Contract *TokenStakingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TokenStakingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TokenStakingTransactorRaw struct {
+	Contract *TokenStakingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTokenStaking creates a new instance of TokenStaking, bound to a specific deployed contract.
+func NewTokenStaking(address common.Address, backend bind.ContractBackend) (*TokenStaking, error) {
+	contract, err := bindTokenStaking(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStaking{TokenStakingCaller: TokenStakingCaller{contract: contract}, TokenStakingTransactor: TokenStakingTransactor{contract: contract}, TokenStakingFilterer: TokenStakingFilterer{contract: contract}}, nil
+}
+
+// NewTokenStakingCaller creates a new read-only instance of TokenStaking, bound to a specific deployed contract.
+func NewTokenStakingCaller(address common.Address, caller bind.ContractCaller) (*TokenStakingCaller, error) {
+	contract, err := bindTokenStaking(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingCaller{contract: contract}, nil
+}
+
+// NewTokenStakingTransactor creates a new write-only instance of TokenStaking, bound to a specific deployed contract.
+func NewTokenStakingTransactor(address common.Address, transactor bind.ContractTransactor) (*TokenStakingTransactor, error) {
+	contract, err := bindTokenStaking(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingTransactor{contract: contract}, nil
+}
+
+// NewTokenStakingFilterer creates a new log filterer instance of TokenStaking, bound to a specific deployed contract.
+func NewTokenStakingFilterer(address common.Address, filterer bind.ContractFilterer) (*TokenStakingFilterer, error) {
+	contract, err := bindTokenStaking(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingFilterer{contract: contract}, nil
+}
+
+// bindTokenStaking binds a generic wrapper to an already deployed contract.
+func bindTokenStaking(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TokenStakingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenStaking *TokenStakingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenStaking.Contract.TokenStakingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenStaking *TokenStakingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenStaking.Contract.TokenStakingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenStaking *TokenStakingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenStaking.Contract.TokenStakingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenStaking *TokenStakingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenStaking.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenStaking *TokenStakingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenStaking.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenStaking *TokenStakingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenStaking.Contract.contract.Transact(opts, method, params...)
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) Balances(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "balances", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingSession) Balances(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Balances(&_TokenStaking.CallOpts, arg0)
+}
+
+// Balances is a free data retrieval call binding the contract method 0x27e235e3.
+//
+// Solidity: function balances(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) Balances(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Balances(&_TokenStaking.CallOpts, arg0)
+}
+
+// Earned is a free data retrieval call binding the contract method 0x008cc262.
+//
+// Solidity: function earned(address account) view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) Earned(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "earned", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Earned is a free data retrieval call binding the contract method 0x008cc262.
+//
+// Solidity: function earned(address account) view returns(uint256)
+func (_TokenStaking *TokenStakingSession) Earned(account common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Earned(&_TokenStaking.CallOpts, account)
+}
+
+// Earned is a free data retrieval call binding the contract method 0x008cc262.
+//
+// Solidity: function earned(address account) view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) Earned(account common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Earned(&_TokenStaking.CallOpts, account)
+}
+
+// LastUpdateTime is a free data retrieval call binding the contract method 0xc8f33c91.
+//
+// Solidity: function lastUpdateTime() view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) LastUpdateTime(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "lastUpdateTime")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LastUpdateTime is a free data retrieval call binding the contract method 0xc8f33c91.
+//
+// Solidity: function lastUpdateTime() view returns(uint256)
+func (_TokenStaking *TokenStakingSession) LastUpdateTime() (*big.Int, error) {
+	return _TokenStaking.Contract.LastUpdateTime(&_TokenStaking.CallOpts)
+}
+
+// LastUpdateTime is a free data retrieval call binding the contract method 0xc8f33c91.
+//
+// Solidity: function lastUpdateTime() view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) LastUpdateTime() (*big.Int, error) {
+	return _TokenStaking.Contract.LastUpdateTime(&_TokenStaking.CallOpts)
+}
+
+// RewardPerToken is a free data retrieval call binding the contract method 0xcd3daf9d.
+//
+// Solidity: function rewardPerToken() view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) RewardPerToken(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "rewardPerToken")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RewardPerToken is a free data retrieval call binding the contract method 0xcd3daf9d.
+//
+// Solidity: function rewardPerToken() view returns(uint256)
+func (_TokenStaking *TokenStakingSession) RewardPerToken() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardPerToken(&_TokenStaking.CallOpts)
+}
+
+// RewardPerToken is a free data retrieval call binding the contract method 0xcd3daf9d.
+//
+// Solidity: function rewardPerToken() view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) RewardPerToken() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardPerToken(&_TokenStaking.CallOpts)
+}
+
+// RewardPerTokenStored is a free data retrieval call binding the contract method 0xdf136d65.
+//
+// Solidity: function rewardPerTokenStored() view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) RewardPerTokenStored(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "rewardPerTokenStored")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RewardPerTokenStored is a free data retrieval call binding the contract method 0xdf136d65.
+//
+// Solidity: function rewardPerTokenStored() view returns(uint256)
+func (_TokenStaking *TokenStakingSession) RewardPerTokenStored() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardPerTokenStored(&_TokenStaking.CallOpts)
+}
+
+// RewardPerTokenStored is a free data retrieval call binding the contract method 0xdf136d65.
+//
+// Solidity: function rewardPerTokenStored() view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) RewardPerTokenStored() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardPerTokenStored(&_TokenStaking.CallOpts)
+}
+
+// RewardRate is a free data retrieval call binding the contract method 0x7b0a47ee.
+//
+// Solidity: function rewardRate() view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) RewardRate(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "rewardRate")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RewardRate is a free data retrieval call binding the contract method 0x7b0a47ee.
+//
+// Solidity: function rewardRate() view returns(uint256)
+func (_TokenStaking *TokenStakingSession) RewardRate() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardRate(&_TokenStaking.CallOpts)
+}
+
+// RewardRate is a free data retrieval call binding the contract method 0x7b0a47ee.
+//
+// Solidity: function rewardRate() view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) RewardRate() (*big.Int, error) {
+	return _TokenStaking.Contract.RewardRate(&_TokenStaking.CallOpts)
+}
+
+// RewardToken is a free data retrieval call binding the contract method 0xf7c618c1.
+//
+// Solidity: function rewardToken() view returns(address)
+func (_TokenStaking *TokenStakingCaller) RewardToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "rewardToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// RewardToken is a free data retrieval call binding the contract method 0xf7c618c1.
+//
+// Solidity: function rewardToken() view returns(address)
+func (_TokenStaking *TokenStakingSession) RewardToken() (common.Address, error) {
+	return _TokenStaking.Contract.RewardToken(&_TokenStaking.CallOpts)
+}
+
+// RewardToken is a free data retrieval call binding the contract method 0xf7c618c1.
+//
+// Solidity: function rewardToken() view returns(address)
+func (_TokenStaking *TokenStakingCallerSession) RewardToken() (common.Address, error) {
+	return _TokenStaking.Contract.RewardToken(&_TokenStaking.CallOpts)
+}
+
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
+//
+// Solidity: function rewards(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) Rewards(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "rewards", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
+//
+// Solidity: function rewards(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingSession) Rewards(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Rewards(&_TokenStaking.CallOpts, arg0)
+}
+
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
+//
+// Solidity: function rewards(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) Rewards(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.Rewards(&_TokenStaking.CallOpts, arg0)
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_TokenStaking *TokenStakingCaller) StakingToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "stakingToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_TokenStaking *TokenStakingSession) StakingToken() (common.Address, error) {
+	return _TokenStaking.Contract.StakingToken(&_TokenStaking.CallOpts)
+}
+
+// StakingToken is a free data retrieval call binding the contract method 0x72f702f3.
+//
+// Solidity: function stakingToken() view returns(address)
+func (_TokenStaking *TokenStakingCallerSession) StakingToken() (common.Address, error) {
+	return _TokenStaking.Contract.StakingToken(&_TokenStaking.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenStaking *TokenStakingSession) TotalSupply() (*big.Int, error) {
+	return _TokenStaking.Contract.TotalSupply(&_TokenStaking.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) TotalSupply() (*big.Int, error) {
+	return _TokenStaking.Contract.TotalSupply(&_TokenStaking.CallOpts)
+}
+
+// UserRewardPerTokenPaid is a free data retrieval call binding the contract method 0x8b876347.
+//
+// Solidity: function userRewardPerTokenPaid(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCaller) UserRewardPerTokenPaid(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenStaking.contract.Call(opts, &out, "userRewardPerTokenPaid", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserRewardPerTokenPaid is a free data retrieval call binding the contract method 0x8b876347.
+//
+// Solidity: function userRewardPerTokenPaid(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingSession) UserRewardPerTokenPaid(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.UserRewardPerTokenPaid(&_TokenStaking.CallOpts, arg0)
+}
+
+// UserRewardPerTokenPaid is a free data retrieval call binding the contract method 0x8b876347.
+//
+// Solidity: function userRewardPerTokenPaid(address ) view returns(uint256)
+func (_TokenStaking *TokenStakingCallerSession) UserRewardPerTokenPaid(arg0 common.Address) (*big.Int, error) {
+	return _TokenStaking.Contract.UserRewardPerTokenPaid(&_TokenStaking.CallOpts, arg0)
+}
+
+// Exit is a paid mutator transaction binding the contract method 0xe9fad8ee.
+//
+// Solidity: function exit() returns()
+func (_TokenStaking *TokenStakingTransactor) Exit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenStaking.contract.Transact(opts, "exit")
+}
+
+// Exit is a paid mutator transaction binding the contract method 0xe9fad8ee.
+//
+// Solidity: function exit() returns()
+func (_TokenStaking *TokenStakingSession) Exit() (*types.Transaction, error) {
+	return _TokenStaking.Contract.Exit(&_TokenStaking.TransactOpts)
+}
+
+// Exit is a paid mutator transaction binding the contract method 0xe9fad8ee.
+//
+// Solidity: function exit() returns()
+func (_TokenStaking *TokenStakingTransactorSession) Exit() (*types.Transaction, error) {
+	return _TokenStaking.Contract.Exit(&_TokenStaking.TransactOpts)
+}
+
+// GetReward is a paid mutator transaction binding the contract method 0x3d18b912.
+//
+// Solidity: function getReward() returns()
+func (_TokenStaking *TokenStakingTransactor) GetReward(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenStaking.contract.Transact(opts, "getReward")
+}
+
+// GetReward is a paid mutator transaction binding the contract method 0x3d18b912.
+//
+// Solidity: function getReward() returns()
+func (_TokenStaking *TokenStakingSession) GetReward() (*types.Transaction, error) {
+	return _TokenStaking.Contract.GetReward(&_TokenStaking.TransactOpts)
+}
+
+// GetReward is a paid mutator transaction binding the contract method 0x3d18b912.
+//
+// Solidity: function getReward() returns()
+func (_TokenStaking *TokenStakingTransactorSession) GetReward() (*types.Transaction, error) {
+	return _TokenStaking.Contract.GetReward(&_TokenStaking.TransactOpts)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_TokenStaking *TokenStakingTransactor) Stake(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.contract.Transact(opts, "stake", amount)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_TokenStaking *TokenStakingSession) Stake(amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.Contract.Stake(&_TokenStaking.TransactOpts, amount)
+}
+
+// Stake is a paid mutator transaction binding the contract method 0xa694fc3a.
+//
+// Solidity: function stake(uint256 amount) returns()
+func (_TokenStaking *TokenStakingTransactorSession) Stake(amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.Contract.Stake(&_TokenStaking.TransactOpts, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 amount) returns()
+func (_TokenStaking *TokenStakingTransactor) Withdraw(opts *bind.TransactOpts, amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.contract.Transact(opts, "withdraw", amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 amount) returns()
+func (_TokenStaking *TokenStakingSession) Withdraw(amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.Contract.Withdraw(&_TokenStaking.TransactOpts, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 amount) returns()
+func (_TokenStaking *TokenStakingTransactorSession) Withdraw(amount *big.Int) (*types.Transaction, error) {
+	return _TokenStaking.Contract.Withdraw(&_TokenStaking.TransactOpts, amount)
+}
+
+// TokenStakingRewardPaidIterator is returned from FilterRewardPaid and is used to iterate over the raw logs and unpacked data for RewardPaid events raised by the TokenStaking contract.
+type TokenStakingRewardPaidIterator struct {
+	Event *TokenStakingRewardPaid // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenStakingRewardPaidIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenStakingRewardPaid)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenStakingRewardPaid)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenStakingRewardPaidIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenStakingRewardPaidIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenStakingRewardPaid represents a RewardPaid event raised by the TokenStaking contract.
+type TokenStakingRewardPaid struct {
+	User   common.Address
+	Reward *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterRewardPaid is a free log retrieval operation binding the contract event 0xe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e0486.
+//
+// Solidity: event RewardPaid(address indexed user, uint256 reward)
+func (_TokenStaking *TokenStakingFilterer) FilterRewardPaid(opts *bind.FilterOpts, user []common.Address) (*TokenStakingRewardPaidIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.FilterLogs(opts, "RewardPaid", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingRewardPaidIterator{contract: _TokenStaking.contract, event: "RewardPaid", logs: logs, sub: sub}, nil
+}
+
+// WatchRewardPaid is a free log subscription operation binding the contract event 0xe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e0486.
+//
+// Solidity: event RewardPaid(address indexed user, uint256 reward)
+func (_TokenStaking *TokenStakingFilterer) WatchRewardPaid(opts *bind.WatchOpts, sink chan<- *TokenStakingRewardPaid, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.WatchLogs(opts, "RewardPaid", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenStakingRewardPaid)
+				if err := _TokenStaking.contract.UnpackLog(event, "RewardPaid", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRewardPaid is a log parse operation binding the contract event 0xe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e0486.
+//
+// Solidity: event RewardPaid(address indexed user, uint256 reward)
+func (_TokenStaking *TokenStakingFilterer) ParseRewardPaid(log types.Log) (*TokenStakingRewardPaid, error) {
+	event := new(TokenStakingRewardPaid)
+	if err := _TokenStaking.contract.UnpackLog(event, "RewardPaid", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenStakingStakedIterator is returned from FilterStaked and is used to iterate over the raw logs and unpacked data for Staked events raised by the TokenStaking contract.
+type TokenStakingStakedIterator struct {
+	Event *TokenStakingStaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenStakingStakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenStakingStaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenStakingStaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenStakingStakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenStakingStakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenStakingStaked represents a Staked event raised by the TokenStaking contract.
+type TokenStakingStaked struct {
+	User   common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterStaked is a free log retrieval operation binding the contract event 0x9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d.
+//
+// Solidity: event Staked(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) FilterStaked(opts *bind.FilterOpts, user []common.Address) (*TokenStakingStakedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.FilterLogs(opts, "Staked", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingStakedIterator{contract: _TokenStaking.contract, event: "Staked", logs: logs, sub: sub}, nil
+}
+
+// WatchStaked is a free log subscription operation binding the contract event 0x9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d.
+//
+// Solidity: event Staked(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) WatchStaked(opts *bind.WatchOpts, sink chan<- *TokenStakingStaked, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.WatchLogs(opts, "Staked", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenStakingStaked)
+				if err := _TokenStaking.contract.UnpackLog(event, "Staked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStaked is a log parse operation binding the contract event 0x9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d.
+//
+// Solidity: event Staked(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) ParseStaked(log types.Log) (*TokenStakingStaked, error) {
+	event := new(TokenStakingStaked)
+	if err := _TokenStaking.contract.UnpackLog(event, "Staked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenStakingWithdrawnIterator is returned from FilterWithdrawn and is used to iterate over the raw logs and unpacked data for Withdrawn events raised by the TokenStaking contract.
+type TokenStakingWithdrawnIterator struct {
+	Event *TokenStakingWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenStakingWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenStakingWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenStakingWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenStakingWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenStakingWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenStakingWithdrawn represents a Withdrawn event raised by the TokenStaking contract.
+type TokenStakingWithdrawn struct {
+	User   common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawn is a free log retrieval operation binding the contract event 0x7084f5476618d8e60b11ef0d7d3f06914655adb8793e28ff7f018d4c76d505d5.
+//
+// Solidity: event Withdrawn(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) FilterWithdrawn(opts *bind.FilterOpts, user []common.Address) (*TokenStakingWithdrawnIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.FilterLogs(opts, "Withdrawn", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenStakingWithdrawnIterator{contract: _TokenStaking.contract, event: "Withdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawn is a free log subscription operation binding the contract event 0x7084f5476618d8e60b11ef0d7d3f06914655adb8793e28ff7f018d4c76d505d5.
+//
+// Solidity: event Withdrawn(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) WatchWithdrawn(opts *bind.WatchOpts, sink chan<- *TokenStakingWithdrawn, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _TokenStaking.contract.WatchLogs(opts, "Withdrawn", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenStakingWithdrawn)
+				if err := _TokenStaking.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawn is a log parse operation binding the contract event 0x7084f5476618d8e60b11ef0d7d3f06914655adb8793e28ff7f018d4c76d505d5.
+//
+// Solidity: event Withdrawn(address indexed user, uint256 amount)
+func (_TokenStaking *TokenStakingFilterer) ParseWithdrawn(log types.Log) (*TokenStakingWithdrawn, error) {
+	event := new(TokenStakingWithdrawn)
+	if err := _TokenStaking.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,96 @@
+package tokenstaking_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/bindings/tokenstaking"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestTokenStaking(t *testing.T) {
+	chain := simchain.New(t)
+	owner, alice := chain.Accounts[0].Opts(), chain.Accounts[1].Opts()
+
+	// 质押代币与奖励代币都使用 MyToken
+	stakingAddr, _, stakingToken, err := mytoken.DeployMyToken(owner, chain, "Stake", "STK", 0, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewardAddr, _, rewardToken, err := mytoken.DeployMyToken(owner, chain, "Reward", "RWD", 0, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	poolAddr, _, pool, err := tokenstaking.DeployTokenStaking(owner, chain, stakingAddr, rewardAddr)
+	if err != nil {
+		t.Fatal("部署 TokenStaking 失败:", err)
+	}
+	chain.Mine()
+
+	if rate, _ := pool.RewardRate(nil); rate.Int64() != 100 {
+		t.Fatalf("rewardRate = %s, want 100", rate)
+	}
+	if addr, _ := pool.StakingToken(nil); addr != stakingAddr {
+		t.Fatalf("stakingToken = %s", addr.Hex())
+	}
+
+	// 为奖励池注资，并给 alice 一些质押代币
+	if _, err := rewardToken.Transfer(owner, poolAddr, big.NewInt(500_000)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stakingToken.Transfer(owner, alice.From, big.NewInt(1_000)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if _, err := pool.Stake(alice, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Cannot stake 0") {
+		t.Fatalf("stake 0: err = %v", err)
+	}
+	// 未授权时 transferFrom 的回滚原因会被透传
+	if _, err := pool.Stake(alice, big.NewInt(100)); err == nil || !strings.Contains(err.Error(), "ERC20: transfer amount exceeds allowance") {
+		t.Fatalf("stake without allowance: err = %v", err)
+	}
+	if _, err := stakingToken.Approve(alice, poolAddr, big.NewInt(1_000)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if _, err := pool.Stake(alice, big.NewInt(1_000)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	if total, _ := pool.TotalSupply(nil); total.Int64() != 1_000 {
+		t.Fatalf("totalSupply = %s, want 1000", total)
+	}
+
+	chain.Warp(100 * time.Second)
+	earned, err := pool.Earned(nil, alice.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if earned.Sign() <= 0 {
+		t.Fatalf("earned = %s, want > 0", earned)
+	}
+
+	if _, err := pool.Exit(alice); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if bal, _ := stakingToken.BalanceOf(nil, alice.From); bal.Int64() != 1_000 {
+		t.Fatalf("staking token balance after exit = %s, want 1000", bal)
+	}
+	reward, _ := rewardToken.BalanceOf(nil, alice.From)
+	if reward.Cmp(earned) < 0 {
+		t.Fatalf("reward = %s, want >= %s", reward, earned)
+	}
+	it, err := pool.FilterRewardPaid(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Event.Reward.Cmp(reward) != 0 {
+		t.Fatal("missing RewardPaid event")
+	}
+}
@@ -0,0 +1,1103 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package voting
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// VotingMetaData contains all meta data concerning the Voting contract.
+var VotingMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"name\":\"ProposalAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"}],\"name\":\"VoteCast\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"}],\"name\":\"VoterRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"winningProposalId\",\"type\":\"uint256\"}],\"name\":\"VotingClosed\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"name\":\"addProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"chairperson\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"closeVoting\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getWinningProposal\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"winningProposalId\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proposalCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"proposals\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"voteCount\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"voter\",\"type\":\"address\"}],\"name\":\"registerVoter\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"voters\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"hasVoted\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"proposalId\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isRegistered\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"votingOpen\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Sigs: map[string]string{
+		"2fdae3c5": "addProposal(string)",
+		"2e4176cf": "chairperson()",
+		"c631b292": "closeVoting()",
+		"a5134620": "getWinningProposal()",
+		"da35c664": "proposalCount()",
+		"013cf08b": "proposals(uint256)",
+		"38db6dd3": "registerVoter(address)",
+		"0121b93f": "vote(uint256)",
+		"a3ec138d": "voters(address)",
+		"a95824b4": "votingOpen()",
+	},
+	Bin: "0x608060405234801561001057600080fd5b50600080546001600160a01b031916331790556004805460ff19166001179055610a398061003f6000396000f3fe608060405234801561001057600080fd5b506004361061009e5760003560e01c8063a3ec138d11610066578063a3ec138d14610134578063a513462014610188578063a95824b41461019e578063c631b292146101bb578063da35c664146101c357600080fd5b80630121b93f146100a3578063013cf08b146100b85780632e4176cf146100e35780632fdae3c51461010e57806338db6dd314610121575b600080fd5b6100b66100b13660046106d3565b6101cc565b005b6100cb6100c63660046106d3565b610390565b6040516100da93929190610732565b60405180910390f35b6000546100f6906001600160a01b031681565b6040516001600160a01b0390911681526020016100da565b6100b661011c366004610772565b61043e565b6100b661012f366004610823565b610517565b610169610142366004610823565b600160208190526000918252604090912080549181015460029091015460ff928316921683565b60408051931515845260208401929092521515908201526060016100da565b6101906105ff565b6040519081526020016100da565b6004546101ab9060ff1681565b60405190151581526020016100da565b6100b6610657565b61019060035481565b3360009081526001602052604090206002015460ff1661022c5760405162461bcd60e51b81526020600482015260166024820152752737ba1030903932b3b4b9ba32b932b2103b37ba32b960511b60448201526064015b60405180910390fd5b60045460ff166102715760405162461bcd60e51b815260206004820152601060248201526f159bdd1a5b99c81a5cc818db1bdcd95960821b6044820152606401610223565b3360009081526001602052604090205460ff16156102c15760405162461bcd60e51b815260206004820152600d60248201526c105b1c9958591e481d9bdd1959609a1b6044820152606401610223565b6000818152600260208190526040909120015460ff166103235760405162461bcd60e51b815260206004820152601760248201527f50726f706f73616c20646f6573206e6f742065786973740000000000000000006044820152606401610223565b336000908152600160208181526040808420805460ff1916841781558301859055848452600290915282200180549161035b83610853565b9091555050604051819033907fa36cc2bebb74db33e9f88110a07ef56e1b31b24b4c4f51b54b1664266e29f45b90600090a350565b6002602052600090815260409020805481906103ab9061087a565b80601f01602080910402602001604051908101604052809291908181526020018280546103d79061087a565b80156104245780601f106103f957610100808354040283529160200191610424565b820191906000526020600020905b81548152906001019060200180831161040757829003601f168201915b50505050600183015460029093015491929160ff16905083565b6000546001600160a01b031633146104685760405162461bcd60e51b8152600401610223906108b4565b604080516060810182528281526000602080830182905260018385015260035482526002905291909120815181906104a09082610930565b50602082015160018201556040918201516002909101805460ff191691151591909117905560035490517ff970aa486598017b8116c2beb18c50d4584ecbc3c688817f59b26796725f31bf906104f79084906109f0565b60405180910390a26003805490600061050f83610853565b919050555050565b6000546001600160a01b031633146105415760405162461bcd60e51b8152600401610223906108b4565b6001600160a01b03811660009081526001602052604090206002015460ff16156105ad5760405162461bcd60e51b815260206004820152601860248201527f566f74657220616c7265616479207265676973746572656400000000000000006044820152606401610223565b6001600160a01b0381166000818152600160208190526040808320600201805460ff1916909217909155517fb6be2187d059cc2a55fe29e0e503b566e1e0f8c8780096e185429350acffd3dd9190a250565b600080805b60035481101561065257600081815260026020526040902060010154821015610640576000818152600260205260409020600101549092509050815b8061064a81610853565b915050610604565b505090565b6000546001600160a01b031633146106815760405162461bcd60e51b8152600401610223906108b4565b6004805460ff1916905560006106956105ff565b90507f8e9b46a70b3967bc9ea56ca61732c363a191fac88ae3ba011f4e030711c90117816040516106c891815260200190565b60405180910390a150565b6000602082840312156106e557600080fd5b5035919050565b6000815180845260005b81811015610712576020818501810151868301820152016106f6565b506000602082860101526020601f19601f83011685010191505092915050565b60608152600061074560608301866106ec565b602083019490945250901515604090910152919050565b634e487b7160e01b600052604160045260246000fd5b60006020828403121561078457600080fd5b813567ffffffffffffffff8082111561079c57600080fd5b818401915084601f8301126107b057600080fd5b8135818111156107c2576107c261075c565b604051601f8201601f19908116603f011681019083821181831017156107ea576107ea61075c565b8160405282815287602084870101111561080357600080fd5b826020860160208301376000928101602001929092525095945050505050565b60006020828403121561083557600080fd5b81356001600160a01b038116811461084c57600080fd5b9392505050565b60006001820161087357634e487b7160e01b600052601160045260246000fd5b5060010190565b600181811c9082168061088e57607f821691505b6020821081036108ae57634e487b7160e01b600052602260045260246000fd5b50919050565b6020808252601390820152722737ba103a34329031b430b4b93832b939b7b760691b604082015260600190565b601f82111561092b57600081815260208120601f850160051c810160208610156109085750805b601f850160051c820191505b8181101561092757828155600101610914565b5050505b505050565b815167ffffffffffffffff81111561094a5761094a61075c565b61095e81610958845461087a565b846108e1565b602080601f831160018114610993576000841561097b5750858301515b600019600386901b1c1916600185901b178555610927565b600085815260208120601f198616915b828110156109c2578886015182559484019460019091019084016109a3565b50858210156109e05787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b60208152600061084c60208301846106ec56fea26469706673582212207965cf6ce06a0b1a3ed9e5a5cce29f4ed9ea857dca27f80390b77be087592af264736f6c63430008150033",
+}
+
+// VotingABI is the input ABI used to generate the binding from.
+// Deprecated: Use VotingMetaData.ABI instead.
+var VotingABI = VotingMetaData.ABI
+
+// Deprecated: Use VotingMetaData.Sigs instead.
+// VotingFuncSigs maps the 4-byte function signature to its string representation.
+var VotingFuncSigs = VotingMetaData.Sigs
+
+// VotingBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use VotingMetaData.Bin instead.
+var VotingBin = VotingMetaData.Bin
+
+// DeployVoting deploys a new Ethereum contract, binding an instance of Voting to it.
+func DeployVoting(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Voting, error) {
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(VotingBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Voting{VotingCaller: VotingCaller{contract: contract}, VotingTransactor: VotingTransactor{contract: contract}, VotingFilterer: VotingFilterer{contract: contract}}, nil
+}
+
+// Voting is an auto generated Go binding around an Ethereum contract.
+type Voting struct {
+	VotingCaller     // Read-only binding to the contract
+	VotingTransactor // Write-only binding to the contract
+	VotingFilterer   // Log filterer for contract events
+}
+
+// VotingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type VotingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type VotingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type VotingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type VotingSession struct {
+	Contract     *Voting           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// VotingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type VotingCallerSession struct {
+	Contract *VotingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// VotingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type VotingTransactorSession struct {
+	Contract     *VotingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// VotingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type VotingRaw struct {
+	Contract *Voting // Generic contract binding to access the raw methods on
+}
+
+// VotingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type VotingCallerRaw struct {
+	Contract *VotingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// VotingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type VotingTransactorRaw struct {
+	Contract *VotingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewVoting creates a new instance of Voting, bound to a specific deployed contract.
+func NewVoting(address common.Address, backend bind.ContractBackend) (*Voting, error) {
+	contract, err := bindVoting(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Voting{VotingCaller: VotingCaller{contract: contract}, VotingTransactor: VotingTransactor{contract: contract}, VotingFilterer: VotingFilterer{contract: contract}}, nil
+}
+
+// NewVotingCaller creates a new read-only instance of Voting, bound to a specific deployed contract.
+func NewVotingCaller(address common.Address, caller bind.ContractCaller) (*VotingCaller, error) {
+	contract, err := bindVoting(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingCaller{contract: contract}, nil
+}
+
+// NewVotingTransactor creates a new write-only instance of Voting, bound to a specific deployed contract.
+func NewVotingTransactor(address common.Address, transactor bind.ContractTransactor) (*VotingTransactor, error) {
+	contract, err := bindVoting(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingTransactor{contract: contract}, nil
+}
+
+// NewVotingFilterer creates a new log filterer instance of Voting, bound to a specific deployed contract.
+func NewVotingFilterer(address common.Address, filterer bind.ContractFilterer) (*VotingFilterer, error) {
+	contract, err := bindVoting(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingFilterer{contract: contract}, nil
+}
+
+// bindVoting binds a generic wrapper to an already deployed contract.
+func bindVoting(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := VotingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Voting *VotingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Voting.Contract.VotingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Voting *VotingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Voting.Contract.VotingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Voting *VotingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Voting.Contract.VotingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Voting *VotingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Voting.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Voting *VotingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Voting.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Voting *VotingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Voting.Contract.contract.Transact(opts, method, params...)
+}
+
+// Chairperson is a free data retrieval call binding the contract method 0x2e4176cf.
+//
+// Solidity: function chairperson() view returns(address)
+func (_Voting *VotingCaller) Chairperson(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "chairperson")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Chairperson is a free data retrieval call binding the contract method 0x2e4176cf.
+//
+// Solidity: function chairperson() view returns(address)
+func (_Voting *VotingSession) Chairperson() (common.Address, error) {
+	return _Voting.Contract.Chairperson(&_Voting.CallOpts)
+}
+
+// Chairperson is a free data retrieval call binding the contract method 0x2e4176cf.
+//
+// Solidity: function chairperson() view returns(address)
+func (_Voting *VotingCallerSession) Chairperson() (common.Address, error) {
+	return _Voting.Contract.Chairperson(&_Voting.CallOpts)
+}
+
+// GetWinningProposal is a free data retrieval call binding the contract method 0xa5134620.
+//
+// Solidity: function getWinningProposal() view returns(uint256 winningProposalId)
+func (_Voting *VotingCaller) GetWinningProposal(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "getWinningProposal")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetWinningProposal is a free data retrieval call binding the contract method 0xa5134620.
+//
+// Solidity: function getWinningProposal() view returns(uint256 winningProposalId)
+func (_Voting *VotingSession) GetWinningProposal() (*big.Int, error) {
+	return _Voting.Contract.GetWinningProposal(&_Voting.CallOpts)
+}
+
+// GetWinningProposal is a free data retrieval call binding the contract method 0xa5134620.
+//
+// Solidity: function getWinningProposal() view returns(uint256 winningProposalId)
+func (_Voting *VotingCallerSession) GetWinningProposal() (*big.Int, error) {
+	return _Voting.Contract.GetWinningProposal(&_Voting.CallOpts)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_Voting *VotingCaller) ProposalCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "proposalCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_Voting *VotingSession) ProposalCount() (*big.Int, error) {
+	return _Voting.Contract.ProposalCount(&_Voting.CallOpts)
+}
+
+// ProposalCount is a free data retrieval call binding the contract method 0xda35c664.
+//
+// Solidity: function proposalCount() view returns(uint256)
+func (_Voting *VotingCallerSession) ProposalCount() (*big.Int, error) {
+	return _Voting.Contract.ProposalCount(&_Voting.CallOpts)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(string name, uint256 voteCount, bool exists)
+func (_Voting *VotingCaller) Proposals(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Name      string
+	VoteCount *big.Int
+	Exists    bool
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "proposals", arg0)
+
+	outstruct := new(struct {
+		Name      string
+		VoteCount *big.Int
+		Exists    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Name = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.VoteCount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Exists = *abi.ConvertType(out[2], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(string name, uint256 voteCount, bool exists)
+func (_Voting *VotingSession) Proposals(arg0 *big.Int) (struct {
+	Name      string
+	VoteCount *big.Int
+	Exists    bool
+}, error) {
+	return _Voting.Contract.Proposals(&_Voting.CallOpts, arg0)
+}
+
+// Proposals is a free data retrieval call binding the contract method 0x013cf08b.
+//
+// Solidity: function proposals(uint256 ) view returns(string name, uint256 voteCount, bool exists)
+func (_Voting *VotingCallerSession) Proposals(arg0 *big.Int) (struct {
+	Name      string
+	VoteCount *big.Int
+	Exists    bool
+}, error) {
+	return _Voting.Contract.Proposals(&_Voting.CallOpts, arg0)
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool hasVoted, uint256 proposalId, bool isRegistered)
+func (_Voting *VotingCaller) Voters(opts *bind.CallOpts, arg0 common.Address) (struct {
+	HasVoted     bool
+	ProposalId   *big.Int
+	IsRegistered bool
+}, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "voters", arg0)
+
+	outstruct := new(struct {
+		HasVoted     bool
+		ProposalId   *big.Int
+		IsRegistered bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.HasVoted = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.ProposalId = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.IsRegistered = *abi.ConvertType(out[2], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool hasVoted, uint256 proposalId, bool isRegistered)
+func (_Voting *VotingSession) Voters(arg0 common.Address) (struct {
+	HasVoted     bool
+	ProposalId   *big.Int
+	IsRegistered bool
+}, error) {
+	return _Voting.Contract.Voters(&_Voting.CallOpts, arg0)
+}
+
+// Voters is a free data retrieval call binding the contract method 0xa3ec138d.
+//
+// Solidity: function voters(address ) view returns(bool hasVoted, uint256 proposalId, bool isRegistered)
+func (_Voting *VotingCallerSession) Voters(arg0 common.Address) (struct {
+	HasVoted     bool
+	ProposalId   *big.Int
+	IsRegistered bool
+}, error) {
+	return _Voting.Contract.Voters(&_Voting.CallOpts, arg0)
+}
+
+// VotingOpen is a free data retrieval call binding the contract method 0xa95824b4.
+//
+// Solidity: function votingOpen() view returns(bool)
+func (_Voting *VotingCaller) VotingOpen(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Voting.contract.Call(opts, &out, "votingOpen")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// VotingOpen is a free data retrieval call binding the contract method 0xa95824b4.
+//
+// Solidity: function votingOpen() view returns(bool)
+func (_Voting *VotingSession) VotingOpen() (bool, error) {
+	return _Voting.Contract.VotingOpen(&_Voting.CallOpts)
+}
+
+// VotingOpen is a free data retrieval call binding the contract method 0xa95824b4.
+//
+// Solidity: function votingOpen() view returns(bool)
+func (_Voting *VotingCallerSession) VotingOpen() (bool, error) {
+	return _Voting.Contract.VotingOpen(&_Voting.CallOpts)
+}
+
+// AddProposal is a paid mutator transaction binding the contract method 0x2fdae3c5.
+//
+// Solidity: function addProposal(string name) returns()
+func (_Voting *VotingTransactor) AddProposal(opts *bind.TransactOpts, name string) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "addProposal", name)
+}
+
+// AddProposal is a paid mutator transaction binding the contract method 0x2fdae3c5.
+//
+// Solidity: function addProposal(string name) returns()
+func (_Voting *VotingSession) AddProposal(name string) (*types.Transaction, error) {
+	return _Voting.Contract.AddProposal(&_Voting.TransactOpts, name)
+}
+
+// AddProposal is a paid mutator transaction binding the contract method 0x2fdae3c5.
+//
+// Solidity: function addProposal(string name) returns()
+func (_Voting *VotingTransactorSession) AddProposal(name string) (*types.Transaction, error) {
+	return _Voting.Contract.AddProposal(&_Voting.TransactOpts, name)
+}
+
+// CloseVoting is a paid mutator transaction binding the contract method 0xc631b292.
+//
+// Solidity: function closeVoting() returns()
+func (_Voting *VotingTransactor) CloseVoting(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "closeVoting")
+}
+
+// CloseVoting is a paid mutator transaction binding the contract method 0xc631b292.
+//
+// Solidity: function closeVoting() returns()
+func (_Voting *VotingSession) CloseVoting() (*types.Transaction, error) {
+	return _Voting.Contract.CloseVoting(&_Voting.TransactOpts)
+}
+
+// CloseVoting is a paid mutator transaction binding the contract method 0xc631b292.
+//
+// Solidity: function closeVoting() returns()
+func (_Voting *VotingTransactorSession) CloseVoting() (*types.Transaction, error) {
+	return _Voting.Contract.CloseVoting(&_Voting.TransactOpts)
+}
+
+// RegisterVoter is a paid mutator transaction binding the contract method 0x38db6dd3.
+//
+// Solidity: function registerVoter(address voter) returns()
+func (_Voting *VotingTransactor) RegisterVoter(opts *bind.TransactOpts, voter common.Address) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "registerVoter", voter)
+}
+
+// RegisterVoter is a paid mutator transaction binding the contract method 0x38db6dd3.
+//
+// Solidity: function registerVoter(address voter) returns()
+func (_Voting *VotingSession) RegisterVoter(voter common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.RegisterVoter(&_Voting.TransactOpts, voter)
+}
+
+// RegisterVoter is a paid mutator transaction binding the contract method 0x38db6dd3.
+//
+// Solidity: function registerVoter(address voter) returns()
+func (_Voting *VotingTransactorSession) RegisterVoter(voter common.Address) (*types.Transaction, error) {
+	return _Voting.Contract.RegisterVoter(&_Voting.TransactOpts, voter)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_Voting *VotingTransactor) Vote(opts *bind.TransactOpts, proposalId *big.Int) (*types.Transaction, error) {
+	return _Voting.contract.Transact(opts, "vote", proposalId)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_Voting *VotingSession) Vote(proposalId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.Vote(&_Voting.TransactOpts, proposalId)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0x0121b93f.
+//
+// Solidity: function vote(uint256 proposalId) returns()
+func (_Voting *VotingTransactorSession) Vote(proposalId *big.Int) (*types.Transaction, error) {
+	return _Voting.Contract.Vote(&_Voting.TransactOpts, proposalId)
+}
+
+// VotingProposalAddedIterator is returned from FilterProposalAdded and is used to iterate over the raw logs and unpacked data for ProposalAdded events raised by the Voting contract.
+type VotingProposalAddedIterator struct {
+	Event *VotingProposalAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingProposalAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingProposalAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingProposalAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingProposalAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingProposalAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingProposalAdded represents a ProposalAdded event raised by the Voting contract.
+type VotingProposalAdded struct {
+	ProposalId *big.Int
+	Name       string
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterProposalAdded is a free log retrieval operation binding the contract event 0xf970aa486598017b8116c2beb18c50d4584ecbc3c688817f59b26796725f31bf.
+//
+// Solidity: event ProposalAdded(uint256 indexed proposalId, string name)
+func (_Voting *VotingFilterer) FilterProposalAdded(opts *bind.FilterOpts, proposalId []*big.Int) (*VotingProposalAddedIterator, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "ProposalAdded", proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingProposalAddedIterator{contract: _Voting.contract, event: "ProposalAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchProposalAdded is a free log subscription operation binding the contract event 0xf970aa486598017b8116c2beb18c50d4584ecbc3c688817f59b26796725f31bf.
+//
+// Solidity: event ProposalAdded(uint256 indexed proposalId, string name)
+func (_Voting *VotingFilterer) WatchProposalAdded(opts *bind.WatchOpts, sink chan<- *VotingProposalAdded, proposalId []*big.Int) (event.Subscription, error) {
+
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "ProposalAdded", proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingProposalAdded)
+				if err := _Voting.contract.UnpackLog(event, "ProposalAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProposalAdded is a log parse operation binding the contract event 0xf970aa486598017b8116c2beb18c50d4584ecbc3c688817f59b26796725f31bf.
+//
+// Solidity: event ProposalAdded(uint256 indexed proposalId, string name)
+func (_Voting *VotingFilterer) ParseProposalAdded(log types.Log) (*VotingProposalAdded, error) {
+	event := new(VotingProposalAdded)
+	if err := _Voting.contract.UnpackLog(event, "ProposalAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingVoteCastIterator is returned from FilterVoteCast and is used to iterate over the raw logs and unpacked data for VoteCast events raised by the Voting contract.
+type VotingVoteCastIterator struct {
+	Event *VotingVoteCast // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingVoteCastIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingVoteCast)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingVoteCast)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingVoteCastIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingVoteCastIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingVoteCast represents a VoteCast event raised by the Voting contract.
+type VotingVoteCast struct {
+	Voter      common.Address
+	ProposalId *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterVoteCast is a free log retrieval operation binding the contract event 0xa36cc2bebb74db33e9f88110a07ef56e1b31b24b4c4f51b54b1664266e29f45b.
+//
+// Solidity: event VoteCast(address indexed voter, uint256 indexed proposalId)
+func (_Voting *VotingFilterer) FilterVoteCast(opts *bind.FilterOpts, voter []common.Address, proposalId []*big.Int) (*VotingVoteCastIterator, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "VoteCast", voterRule, proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingVoteCastIterator{contract: _Voting.contract, event: "VoteCast", logs: logs, sub: sub}, nil
+}
+
+// WatchVoteCast is a free log subscription operation binding the contract event 0xa36cc2bebb74db33e9f88110a07ef56e1b31b24b4c4f51b54b1664266e29f45b.
+//
+// Solidity: event VoteCast(address indexed voter, uint256 indexed proposalId)
+func (_Voting *VotingFilterer) WatchVoteCast(opts *bind.WatchOpts, sink chan<- *VotingVoteCast, voter []common.Address, proposalId []*big.Int) (event.Subscription, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+	var proposalIdRule []interface{}
+	for _, proposalIdItem := range proposalId {
+		proposalIdRule = append(proposalIdRule, proposalIdItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "VoteCast", voterRule, proposalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingVoteCast)
+				if err := _Voting.contract.UnpackLog(event, "VoteCast", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVoteCast is a log parse operation binding the contract event 0xa36cc2bebb74db33e9f88110a07ef56e1b31b24b4c4f51b54b1664266e29f45b.
+//
+// Solidity: event VoteCast(address indexed voter, uint256 indexed proposalId)
+func (_Voting *VotingFilterer) ParseVoteCast(log types.Log) (*VotingVoteCast, error) {
+	event := new(VotingVoteCast)
+	if err := _Voting.contract.UnpackLog(event, "VoteCast", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingVoterRegisteredIterator is returned from FilterVoterRegistered and is used to iterate over the raw logs and unpacked data for VoterRegistered events raised by the Voting contract.
+type VotingVoterRegisteredIterator struct {
+	Event *VotingVoterRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingVoterRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingVoterRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingVoterRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingVoterRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingVoterRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingVoterRegistered represents a VoterRegistered event raised by the Voting contract.
+type VotingVoterRegistered struct {
+	Voter common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterVoterRegistered is a free log retrieval operation binding the contract event 0xb6be2187d059cc2a55fe29e0e503b566e1e0f8c8780096e185429350acffd3dd.
+//
+// Solidity: event VoterRegistered(address indexed voter)
+func (_Voting *VotingFilterer) FilterVoterRegistered(opts *bind.FilterOpts, voter []common.Address) (*VotingVoterRegisteredIterator, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "VoterRegistered", voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingVoterRegisteredIterator{contract: _Voting.contract, event: "VoterRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchVoterRegistered is a free log subscription operation binding the contract event 0xb6be2187d059cc2a55fe29e0e503b566e1e0f8c8780096e185429350acffd3dd.
+//
+// Solidity: event VoterRegistered(address indexed voter)
+func (_Voting *VotingFilterer) WatchVoterRegistered(opts *bind.WatchOpts, sink chan<- *VotingVoterRegistered, voter []common.Address) (event.Subscription, error) {
+
+	var voterRule []interface{}
+	for _, voterItem := range voter {
+		voterRule = append(voterRule, voterItem)
+	}
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "VoterRegistered", voterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingVoterRegistered)
+				if err := _Voting.contract.UnpackLog(event, "VoterRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVoterRegistered is a log parse operation binding the contract event 0xb6be2187d059cc2a55fe29e0e503b566e1e0f8c8780096e185429350acffd3dd.
+//
+// Solidity: event VoterRegistered(address indexed voter)
+func (_Voting *VotingFilterer) ParseVoterRegistered(log types.Log) (*VotingVoterRegistered, error) {
+	event := new(VotingVoterRegistered)
+	if err := _Voting.contract.UnpackLog(event, "VoterRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// VotingVotingClosedIterator is returned from FilterVotingClosed and is used to iterate over the raw logs and unpacked data for VotingClosed events raised by the Voting contract.
+type VotingVotingClosedIterator struct {
+	Event *VotingVotingClosed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *VotingVotingClosedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(VotingVotingClosed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(VotingVotingClosed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *VotingVotingClosedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *VotingVotingClosedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// VotingVotingClosed represents a VotingClosed event raised by the Voting contract.
+type VotingVotingClosed struct {
+	WinningProposalId *big.Int
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterVotingClosed is a free log retrieval operation binding the contract event 0x8e9b46a70b3967bc9ea56ca61732c363a191fac88ae3ba011f4e030711c90117.
+//
+// Solidity: event VotingClosed(uint256 winningProposalId)
+func (_Voting *VotingFilterer) FilterVotingClosed(opts *bind.FilterOpts) (*VotingVotingClosedIterator, error) {
+
+	logs, sub, err := _Voting.contract.FilterLogs(opts, "VotingClosed")
+	if err != nil {
+		return nil, err
+	}
+	return &VotingVotingClosedIterator{contract: _Voting.contract, event: "VotingClosed", logs: logs, sub: sub}, nil
+}
+
+// WatchVotingClosed is a free log subscription operation binding the contract event 0x8e9b46a70b3967bc9ea56ca61732c363a191fac88ae3ba011f4e030711c90117.
+//
+// Solidity: event VotingClosed(uint256 winningProposalId)
+func (_Voting *VotingFilterer) WatchVotingClosed(opts *bind.WatchOpts, sink chan<- *VotingVotingClosed) (event.Subscription, error) {
+
+	logs, sub, err := _Voting.contract.WatchLogs(opts, "VotingClosed")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(VotingVotingClosed)
+				if err := _Voting.contract.UnpackLog(event, "VotingClosed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseVotingClosed is a log parse operation binding the contract event 0x8e9b46a70b3967bc9ea56ca61732c363a191fac88ae3ba011f4e030711c90117.
+//
+// Solidity: event VotingClosed(uint256 winningProposalId)
+func (_Voting *VotingFilterer) ParseVotingClosed(log types.Log) (*VotingVotingClosed, error) {
+	event := new(VotingVotingClosed)
+	if err := _Voting.contract.UnpackLog(event, "VotingClosed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,103 @@
+package voting_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/voting"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestVoting(t *testing.T) {
+	chain := simchain.New(t)
+	chair, alice, bob := chain.Accounts[0].Opts(), chain.Accounts[1].Opts(), chain.Accounts[2].Opts()
+
+	_, _, vote, err := voting.DeployVoting(chair, chain)
+	if err != nil {
+		t.Fatal("部署 Voting 失败:", err)
+	}
+	chain.Mine()
+
+	if open, _ := vote.VotingOpen(nil); !open {
+		t.Fatal("voting should be open after deployment")
+	}
+	if _, err := vote.RegisterVoter(alice, bob.From); err == nil || !strings.Contains(err.Error(), "Not the chairperson") {
+		t.Fatalf("registerVoter by non-chairperson: err = %v", err)
+	}
+
+	// 注册投票人并添加两个提案（其中一个名称超过 32 字节）
+	for _, voter := range []*bind.TransactOpts{alice, bob} {
+		if _, err := vote.RegisterVoter(chair, voter.From); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names := []string{"Proposal A", "Proposal B with a rather long descriptive name"}
+	for _, name := range names {
+		if _, err := vote.AddProposal(chair, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain.Mine()
+
+	if count, _ := vote.ProposalCount(nil); count.Int64() != 2 {
+		t.Fatalf("proposalCount = %s, want 2", count)
+	}
+	for i, name := range names {
+		p, err := vote.Proposals(nil, big.NewInt(int64(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Name != name || !p.Exists {
+			t.Fatalf("proposal %d = %+v", i, p)
+		}
+	}
+	added, err := vote.FilterProposalAdded(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; added.Next(); i++ {
+		if added.Event.Name != names[i] || added.Event.ProposalId.Int64() != int64(i) {
+			t.Fatalf("ProposalAdded event %d = %+v", i, added.Event)
+		}
+	}
+
+	if _, err := vote.Vote(alice, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vote.Vote(bob, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	if _, err := vote.Vote(alice, big.NewInt(0)); err == nil || !strings.Contains(err.Error(), "Already voted") {
+		t.Fatalf("double vote: err = %v", err)
+	}
+	voter, err := vote.Voters(nil, alice.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !voter.HasVoted || !voter.IsRegistered || voter.ProposalId.Int64() != 1 {
+		t.Fatalf("voter = %+v", voter)
+	}
+	if winner, _ := vote.GetWinningProposal(nil); winner.Int64() != 1 {
+		t.Fatalf("winning proposal = %s, want 1", winner)
+	}
+
+	if _, err := vote.CloseVoting(chair); err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+	closed, err := vote.FilterVotingClosed(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closed.Next() || closed.Event.WinningProposalId.Int64() != 1 {
+		t.Fatal("missing VotingClosed event")
+	}
+	if open, _ := vote.VotingOpen(nil); open {
+		t.Fatal("voting should be closed")
+	}
+}
@@ -0,0 +1,274 @@
+// Package blocktag 解析命令行中的区块选择器，并把它固定为具体的区块
+//
+// 各查询命令的 -block 参数统一使用 Selector，支持以下写法：
+//   - latest（或留空）、pending、safe、finalized、earliest：区块标签，safe / finalized 是合并后共识层给出的确认区块
+//   - 19000000 或 0x121eac0：区块号
+//   - 0x 加 64 位十六进制：区块哈希
+//   - -10：最新区块之前第 10 个区块
+//   - 2024-01-01、2024-01-01T08:00:00Z 等 ISO 8601 时间，或 @1704067200（Unix 秒）：
+//     该时刻的状态，即时间戳不晚于该时刻的最后一个区块，由 pkg/blocktime 查找
+//
+// Resolve 把选择器固定为一个具体的区块头，同一次查询中的多个请求都使用这个区块，
+// 不会因为期间出了新区块而读到不同高度的状态。
+//
+//	sel, _ := blocktag.Parse("finalized")
+//	number, _ := sel.BlockNumber(ctx, client)
+//	balance, _ := client.BalanceAt(ctx, account, number)
+//	if err := sel.Verify(ctx, client, number); err != nil { ... } // 按哈希指定的区块被重组出规范链
+//
+// 能直接传递区块哈希的请求（ethclient 的 *AtHash 方法、EIP-1898 参数）应改用 BlockNumberOrHash，
+// 由节点保证读到的就是这个区块。
+package blocktag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+)
+
+var (
+	// ErrUnsupportedTag 节点不支持 safe / finalized 标签（合并前的链或旧版本节点），或还没有已确定的区块
+	ErrUnsupportedTag = errors.New("节点不支持该区块标签")
+	// ErrBeforeGenesis 指定的时间早于创世区块
+	ErrBeforeGenesis = blocktime.ErrBeforeGenesis
+	// ErrReorged 按哈希指定的区块不在（或读取期间已不在）规范链上
+	ErrReorged = errors.New("区块已不在规范链上")
+)
+
+// Kind 选择器的类型
+type Kind int
+
+const (
+	Latest    Kind = iota // 最新区块
+	Pending               // 待打包区块
+	Safe                  // 共识层认为不太可能被重组的区块
+	Finalized             // 已最终确定的区块
+	Earliest              // 创世区块
+	Number                // 指定区块号
+	Hash                  // 指定区块哈希
+	Relative              // 最新区块之前的第 N 个区块
+	Time                  // 指定时刻的状态
+)
+
+// tags 区块标签的名称
+var tags = map[string]Kind{
+	"latest": Latest, "pending": Pending, "safe": Safe, "finalized": Finalized, "earliest": Earliest,
+}
+
+// HeaderReader 解析选择器所需的后端能力，backend.EthBackend 和 ethclient.Client 都满足
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// Selector 区块选择器，零值表示最新区块。
+// 实现了 flag.Value，可以直接用 flag.Var 注册为命令行参数
+type Selector struct {
+	Kind   Kind
+	Number uint64      // Number：区块号；Relative：距离最新区块的区块数
+	Hash   common.Hash // Hash：区块哈希
+	Time   time.Time   // Time：时刻
+}
+
+// Parse 解析命令行中的区块选择器，写法见包文档
+func Parse(s string) (Selector, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Selector{}, nil
+	}
+	if kind, ok := tags[strings.ToLower(s)]; ok {
+		return Selector{Kind: kind}, nil
+	}
+	switch {
+	case strings.HasPrefix(s, "@"):
+		n, err := strconv.ParseInt(s[1:], 10, 64)
+		if err != nil {
+			return Selector{}, fmt.Errorf("无效的 Unix 时间 %q", s)
+		}
+		return Selector{Kind: Time, Time: time.Unix(n, 0).UTC()}, nil
+	case strings.HasPrefix(s, "-"):
+		n, err := strconv.ParseUint(s[1:], 10, 64)
+		if err != nil {
+			return Selector{}, fmt.Errorf("无效的相对区块 %q", s)
+		}
+		return Selector{Kind: Relative, Number: n}, nil
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		if len(s) == 66 {
+			b, err := hexutil.Decode(s)
+			if err != nil {
+				return Selector{}, fmt.Errorf("无效的区块哈希 %q", s)
+			}
+			return Selector{Kind: Hash, Hash: common.BytesToHash(b)}, nil
+		}
+		n, err := strconv.ParseUint(s[2:], 16, 64)
+		if err != nil {
+			return Selector{}, fmt.Errorf("无效的区块号 %q", s)
+		}
+		return Selector{Kind: Number, Number: n}, nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return Selector{Kind: Number, Number: n}, nil
+	}
+	if t, err := blocktime.ParseISO(s); err == nil {
+		return Selector{Kind: Time, Time: t}, nil
+	}
+	return Selector{}, fmt.Errorf("无法识别的区块 %q（可用 latest、pending、safe、finalized、区块号、区块哈希、-N、ISO 8601 时间或 @Unix 秒）", s)
+}
+
+// String 返回选择器的规范写法，Parse 可以解析回相同的选择器
+func (s Selector) String() string {
+	switch s.Kind {
+	case Number:
+		return strconv.FormatUint(s.Number, 10)
+	case Hash:
+		return s.Hash.Hex()
+	case Relative:
+		return "-" + strconv.FormatUint(s.Number, 10)
+	case Time:
+		return s.Time.UTC().Format(time.RFC3339)
+	}
+	for name, kind := range tags {
+		if kind == s.Kind {
+			return name
+		}
+	}
+	return fmt.Sprintf("<无效的选择器 %d>", s.Kind)
+}
+
+// Set 实现 flag.Value
+func (s *Selector) Set(v string) error {
+	sel, err := Parse(v)
+	if err != nil {
+		return err
+	}
+	*s = sel
+	return nil
+}
+
+// IsLatest 是否为最新区块
+func (s Selector) IsLatest() bool { return s.Kind == Latest }
+
+// IsPending 是否为待打包区块，余额等查询应改用 Pending* 方法
+func (s Selector) IsPending() bool { return s.Kind == Pending }
+
+// Resolve 把选择器固定为具体的区块头
+func (s Selector) Resolve(ctx context.Context, r HeaderReader) (*types.Header, error) {
+	var (
+		header *types.Header
+		err    error
+	)
+	switch s.Kind {
+	case Latest:
+		header, err = r.HeaderByNumber(ctx, nil)
+	case Pending:
+		header, err = r.HeaderByNumber(ctx, big.NewInt(int64(rpc.PendingBlockNumber)))
+	case Safe, Finalized:
+		tag := rpc.SafeBlockNumber
+		if s.Kind == Finalized {
+			tag = rpc.FinalizedBlockNumber
+		}
+		if header, err = r.HeaderByNumber(ctx, big.NewInt(int64(tag))); err != nil {
+			return nil, fmt.Errorf("%w %s: %v", ErrUnsupportedTag, s, err)
+		}
+		if header == nil {
+			return nil, fmt.Errorf("%w %s", ErrUnsupportedTag, s)
+		}
+	case Earliest:
+		header, err = r.HeaderByNumber(ctx, new(big.Int))
+	case Number:
+		header, err = r.HeaderByNumber(ctx, new(big.Int).SetUint64(s.Number))
+	case Hash:
+		header, err = r.HeaderByHash(ctx, s.Hash)
+	case Relative:
+		if header, err = r.HeaderByNumber(ctx, nil); err != nil {
+			break
+		}
+		head := header.Number.Uint64()
+		if s.Number > head {
+			return nil, fmt.Errorf("最新区块只有 %d，无法向前 %d 个区块", head, s.Number)
+		}
+		header, err = r.HeaderByNumber(ctx, new(big.Int).SetUint64(head-s.Number))
+	case Time:
+		header, err = blocktime.New(r).Before(ctx, s.Time)
+	default:
+		return nil, fmt.Errorf("无效的选择器 %d", s.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取区块 %s 失败: %w", s, err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("区块 %s 不存在", s)
+	}
+	return header, nil
+}
+
+// BlockNumber 返回传给 BalanceAt、CallContract、StorageAt、FilterQuery 等方法的区块号：
+// 最新区块为 nil，待打包区块为 rpc.PendingBlockNumber（ethclient 会转换为 "pending"），
+// 其余先用 Resolve 固定为具体的区块号。
+//
+// 区块号不能表示区块哈希：按哈希指定时，该区块必须在规范链上（否则返回 ErrReorged），
+// 按区块号读取之后还要用 Verify 确认期间没有发生重组
+func (s Selector) BlockNumber(ctx context.Context, r HeaderReader) (*big.Int, error) {
+	switch s.Kind {
+	case Latest:
+		return nil, nil
+	case Pending:
+		return big.NewInt(int64(rpc.PendingBlockNumber)), nil
+	}
+	header, err := s.Resolve(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Verify(ctx, r, header.Number); err != nil {
+		return nil, err
+	}
+	return header.Number, nil
+}
+
+// BlockNumberOrHash 返回 EIP-1898 形式的区块参数：最新、待打包区块为对应的标签，
+// 其余固定为具体区块的哈希并要求该区块在规范链上，读取期间发生重组时节点直接报错，不会返回其他区块的状态
+func (s Selector) BlockNumberOrHash(ctx context.Context, r HeaderReader) (rpc.BlockNumberOrHash, error) {
+	switch s.Kind {
+	case Latest:
+		return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil
+	case Pending:
+		return rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber), nil
+	case Hash:
+		return rpc.BlockNumberOrHashWithHash(s.Hash, true), nil
+	}
+	header, err := s.Resolve(ctx, r)
+	if err != nil {
+		return rpc.BlockNumberOrHash{}, err
+	}
+	return rpc.BlockNumberOrHashWithHash(header.Hash(), true), nil
+}
+
+// Verify 确认按区块号 number 读到的是选择器指定的区块：按哈希指定时重新读取该高度的区块头，
+// 哈希不同（区块已被重组出规范链）时返回 ErrReorged；其他写法本来就是按区块号查询，直接返回 nil
+func (s Selector) Verify(ctx context.Context, r HeaderReader, number *big.Int) error {
+	if s.Kind != Hash {
+		return nil
+	}
+	if number == nil || number.Sign() < 0 {
+		return fmt.Errorf("区块 %s 没有对应的区块号", s)
+	}
+	header, err := r.HeaderByNumber(ctx, number)
+	if err != nil {
+		return fmt.Errorf("确认区块 %s 失败: %w", s, err)
+	}
+	if header == nil || header.Hash() != s.Hash {
+		return fmt.Errorf("%w: %s（高度 %s）", ErrReorged, s, number)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package blocktag_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/blocktag"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestParse(t *testing.T) {
+	hash := common.HexToHash("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6")
+	tests := []struct {
+		in   string
+		want blocktag.Selector
+	}{
+		{"", blocktag.Selector{}},
+		{"latest", blocktag.Selector{Kind: blocktag.Latest}},
+		{"Finalized", blocktag.Selector{Kind: blocktag.Finalized}},
+		{"safe", blocktag.Selector{Kind: blocktag.Safe}},
+		{"pending", blocktag.Selector{Kind: blocktag.Pending}},
+		{"earliest", blocktag.Selector{Kind: blocktag.Earliest}},
+		{"19000000", blocktag.Selector{Kind: blocktag.Number, Number: 19000000}},
+		{"0x10", blocktag.Selector{Kind: blocktag.Number, Number: 16}},
+		{hash.Hex(), blocktag.Selector{Kind: blocktag.Hash, Hash: hash}},
+		{"-10", blocktag.Selector{Kind: blocktag.Relative, Number: 10}},
+		{"2024-01-01", blocktag.Selector{Kind: blocktag.Time, Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{"2024-01-01T08:00:00+08:00", blocktag.Selector{Kind: blocktag.Time, Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{"@1704067200", blocktag.Selector{Kind: blocktag.Time, Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		got, err := blocktag.Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got.Kind != tt.want.Kind || got.Number != tt.want.Number || got.Hash != tt.want.Hash || !got.Time.Equal(tt.want.Time) {
+			t.Errorf("Parse(%q) = %+v，期望 %+v", tt.in, got, tt.want)
+		}
+		// 规范写法可以解析回相同的选择器
+		if again, err := blocktag.Parse(got.String()); err != nil || again.Kind != got.Kind || again.Number != got.Number || !again.Time.Equal(got.Time) {
+			t.Errorf("Parse(%q).String() = %q 无法解析回原选择器", tt.in, got.String())
+		}
+	}
+	for _, bad := range []string{"soon", "-x", "0xzz", "2024-13-01", "0x" + "zz" + hash.Hex()[4:]} {
+		if _, err := blocktag.Parse(bad); err == nil {
+			t.Errorf("Parse(%q) 应该失败", bad)
+		}
+	}
+
+	// 作为命令行参数
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var sel blocktag.Selector
+	fs.Var(&sel, "block", "")
+	if err := fs.Parse([]string{"-block", "-5"}); err != nil {
+		t.Fatal(err)
+	}
+	if sel.Kind != blocktag.Relative || sel.Number != 5 {
+		t.Errorf("flag 解析结果 %+v", sel)
+	}
+	if err := fs.Parse([]string{"-block", "soon"}); err == nil {
+		t.Error("无效的 -block 应该报错")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	chain.MineBlocks(100)
+	head := chain.Head().Number.Uint64()
+
+	resolve := func(s string) uint64 {
+		t.Helper()
+		sel, err := blocktag.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		header, err := sel.Resolve(ctx, chain)
+		if err != nil {
+			t.Fatalf("Resolve(%q): %v", s, err)
+		}
+		return header.Number.Uint64()
+	}
+	for s, want := range map[string]uint64{
+		"latest":    head,
+		"safe":      head - simchain.SafeDepth,
+		"finalized": head - simchain.FinalizedDepth,
+		"earliest":  0,
+		"42":        42,
+		"-10":       head - 10,
+		chain.Blockchain().GetHeaderByNumber(7).Hash().Hex(): 7,
+	} {
+		if got := resolve(s); got != want {
+			t.Errorf("Resolve(%q) = %d，期望 %d", s, got, want)
+		}
+	}
+
+	// 时刻：时间戳不晚于该时刻的最后一个区块
+	h50 := chain.Blockchain().GetHeaderByNumber(50)
+	h51 := chain.Blockchain().GetHeaderByNumber(51)
+	for _, tc := range []struct {
+		at   uint64
+		want uint64
+	}{{h50.Time, 50}, {h51.Time - 1, 50}, {h51.Time, 51}, {chain.Head().Time + 3600, head}} {
+		sel := blocktag.Selector{Kind: blocktag.Time, Time: time.Unix(int64(tc.at), 0)}
+		header, err := sel.Resolve(ctx, chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Number.Uint64() != tc.want {
+			t.Errorf("时刻 %d 解析为区块 %d，期望 %d", tc.at, header.Number, tc.want)
+		}
+	}
+	genesis := chain.Blockchain().GetHeaderByNumber(0)
+	early := blocktag.Selector{Kind: blocktag.Time, Time: time.Unix(int64(genesis.Time)-1, 0)}
+	if _, err := early.Resolve(ctx, chain); !errors.Is(err, blocktag.ErrBeforeGenesis) {
+		t.Errorf("早于创世区块的时刻应返回 ErrBeforeGenesis，实际 %v", err)
+	}
+	if _, err := (blocktag.Selector{Kind: blocktag.Relative, Number: head + 1}).Resolve(ctx, chain); err == nil {
+		t.Error("超出链长度的相对区块应该报错")
+	}
+
+	// BlockNumber：latest 为 nil，pending 为 rpc 标签，其余为具体区块号
+	if n, err := (blocktag.Selector{}).BlockNumber(ctx, chain); err != nil || n != nil {
+		t.Errorf("latest 的区块号应为 nil，实际 %v %v", n, err)
+	}
+	if n, _ := (blocktag.Selector{Kind: blocktag.Pending}).BlockNumber(ctx, chain); n.Int64() != int64(rpc.PendingBlockNumber) {
+		t.Errorf("pending 的区块号应为 %d，实际 %v", rpc.PendingBlockNumber, n)
+	}
+	if n, _ := (blocktag.Selector{Kind: blocktag.Finalized}).BlockNumber(ctx, chain); n.Cmp(new(big.Int).SetUint64(head-simchain.FinalizedDepth)) != 0 {
+		t.Errorf("finalized 的区块号为 %v", n)
+	}
+
+	// 还没有已确定的区块时（如刚合并的链）返回 ErrUnsupportedTag
+	short := simchain.New(t)
+	short.MineBlocks(3)
+	if _, err := (blocktag.Selector{Kind: blocktag.Finalized}).Resolve(ctx, short); !errors.Is(err, blocktag.ErrUnsupportedTag) {
+		t.Errorf("短链的 finalized 应返回 ErrUnsupportedTag，实际 %v", err)
+	}
+}
+
+func TestHashSelectorReorg(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	chain.MineBlocks(5)
+	h4 := chain.Blockchain().GetHeaderByNumber(4)
+	sel := blocktag.Selector{Kind: blocktag.Hash, Hash: h4.Hash()}
+
+	number, err := sel.BlockNumber(ctx, chain)
+	if err != nil || number.Uint64() != 4 {
+		t.Fatalf("BlockNumber = %v, %v", number, err)
+	}
+	if ref, _ := sel.BlockNumberOrHash(ctx, chain); ref.BlockHash == nil || *ref.BlockHash != h4.Hash() || !ref.RequireCanonical {
+		t.Errorf("BlockNumberOrHash = %+v，应固定为区块 4 的哈希", ref)
+	}
+	finalized, _ := (blocktag.Selector{Kind: blocktag.Number, Number: 3}).BlockNumberOrHash(ctx, chain)
+	if finalized.BlockHash == nil || *finalized.BlockHash != chain.Blockchain().GetHeaderByNumber(3).Hash() {
+		t.Errorf("区块号应固定为对应区块的哈希，实际 %+v", finalized)
+	}
+
+	// 读取之后区块 4 被重组掉：按区块号读到的已是另一个区块的状态
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	chain.Reorg(2, func() { chain.TransferETH(alice, bob.Address, big.NewInt(1)) })
+	if err := sel.Verify(ctx, chain, number); !errors.Is(err, blocktag.ErrReorged) {
+		t.Errorf("重组后 Verify 应返回 ErrReorged，实际 %v", err)
+	}
+	if _, err := sel.BlockNumber(ctx, chain); !errors.Is(err, blocktag.ErrReorged) {
+		t.Errorf("不在规范链上的区块哈希应返回 ErrReorged，实际 %v", err)
+	}
+	if err := (blocktag.Selector{Kind: blocktag.Number, Number: 4}).Verify(ctx, chain, number); err != nil {
+		t.Errorf("按区块号指定时不需要确认，实际 %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+// Package blocktime 把时间戳解析为区块号
+//
+// 报表通常按日期定义，而节点接口只认区块号。Resolver 在区块头上做插值查找：
+// 出块间隔大致均匀时按时间比例直接猜测区块号，通常三四次请求就能命中；
+// 某一步没能把区间缩小一半时退回二分查找，最坏情况仍是 O(log n) 次请求。
+// 查询过的区块头保存在缓存中，后续查询先用缓存中离目标最近的区块头缩小初始区间，
+// 同一批报表中的多个时间点几乎不再请求节点。
+//
+//	r := blocktime.New(client)
+//	first, _ := r.After(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) // 2024 年的第一个区块
+//	from, to, _ := r.Range(ctx, since, until)                            // [since, until) 内的区块范围
+package blocktime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrBeforeGenesis 指定的时间早于创世区块
+	ErrBeforeGenesis = errors.New("指定的时间早于创世区块")
+	// ErrFuture 指定的时间晚于最新区块，还没有对应的区块
+	ErrFuture = errors.New("指定的时间晚于最新区块")
+	// ErrEmptyRange 时间范围内没有区块
+	ErrEmptyRange = errors.New("时间范围内没有区块")
+)
+
+// timeLayouts 支持的 ISO 8601 时间格式，不带时区的按 UTC 解析
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime 解析 Unix 秒或 ISO 8601 时间（如 2024-01-01、2024-01-01T08:00:00+08:00），不带时区的按 UTC 解析
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(n, 0).UTC(), nil
+	}
+	return ParseISO(s)
+}
+
+// ParseISO 只解析 ISO 8601 时间，纯数字由调用方按区块号等其他含义处理
+func ParseISO(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无法识别的时间 %q（可用 Unix 秒或 2024-01-01、2024-01-01T08:00:00Z 等格式）", s)
+}
+
+// HeaderReader 查找所需的后端能力，backend.EthBackend 和 ethclient.Client 都满足
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config 查找配置
+type Config struct {
+	CacheSize int           // 最多缓存多少个区块头
+	HeadTTL   time.Duration // 最新区块的缓存时间，0 表示每次查找都重新获取
+}
+
+// DefaultConfig 返回默认配置：缓存 4096 个区块头，最新区块缓存一个 slot（12 秒）
+func DefaultConfig() *Config {
+	return &Config{
+		CacheSize: 4096,
+		HeadTTL:   12 * time.Second,
+	}
+}
+
+// Option 修改查找配置
+type Option func(*Config)
+
+// WithCacheSize 设置区块头缓存的容量
+func WithCacheSize(n int) Option { return func(c *Config) { c.CacheSize = n } }
+
+// WithHeadTTL 设置最新区块的缓存时间
+func WithHeadTTL(d time.Duration) Option { return func(c *Config) { c.HeadTTL = d } }
+
+// Stats 查找统计
+type Stats struct {
+	Requests int // 发送给节点的区块头请求数
+	Hits     int // 命中缓存的区块头数
+}
+
+// Resolver 时间戳到区块号的查找器，可以并发使用
+type Resolver struct {
+	r   HeaderReader
+	cfg *Config
+
+	mu      sync.Mutex
+	headers map[uint64]*types.Header // 区块号 -> 区块头
+	head    *types.Header
+	headAt  time.Time
+	stats   Stats
+}
+
+// New 创建查找器
+func New(r HeaderReader, opts ...Option) *Resolver {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &Resolver{r: r, cfg: cfg, headers: make(map[uint64]*types.Header)}
+}
+
+// Stats 返回查找统计
+func (r *Resolver) Stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// After 返回时间戳不早于 t 的第一个区块，即 t 之后出的第一个区块；
+// t 早于创世区块时返回创世区块，晚于最新区块时返回 ErrFuture
+func (r *Resolver) After(ctx context.Context, t time.Time) (*types.Header, error) {
+	target := unix(t)
+	head, err := r.latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head.Time < target {
+		return nil, fmt.Errorf("%w（%s，最新区块 %d 的时间为 %s）", ErrFuture,
+			t.UTC().Format(time.RFC3339), head.Number, time.Unix(int64(head.Time), 0).UTC().Format(time.RFC3339))
+	}
+	genesis, err := r.header(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if genesis.Time >= target {
+		return genesis, nil
+	}
+
+	// 不变量：lo.Time < target <= hi.Time
+	lo, hi := r.bracket(genesis, head, target)
+	interpolate := true
+	for hi.Number.Uint64()-lo.Number.Uint64() > 1 {
+		l, h := lo.Number.Uint64(), hi.Number.Uint64()
+		mid := l + (h-l)/2
+		if interpolate && hi.Time > lo.Time {
+			// 按时间比例估计区块号，限制在区间内部
+			frac := float64(target-lo.Time) / float64(hi.Time-lo.Time)
+			mid = l + uint64(frac*float64(h-l))
+			if mid <= l {
+				mid = l + 1
+			} else if mid >= h {
+				mid = h - 1
+			}
+		}
+		header, err := r.header(ctx, mid)
+		if err != nil {
+			return nil, err
+		}
+		if header.Time < target {
+			lo = header
+		} else {
+			hi = header
+		}
+		// 这一步没能把区间缩小一半，说明出块间隔不均匀，下一步改用二分
+		interpolate = hi.Number.Uint64()-lo.Number.Uint64() <= (h-l)/2
+	}
+	return hi, nil
+}
+
+// Before 返回时间戳不晚于 t 的最后一个区块，即 t 时刻的链上状态所在的区块；
+// t 晚于最新区块时返回最新区块，早于创世区块时返回 ErrBeforeGenesis
+func (r *Resolver) Before(ctx context.Context, t time.Time) (*types.Header, error) {
+	if t.Unix() < 0 {
+		return nil, ErrBeforeGenesis
+	}
+	next, err := r.After(ctx, t.Add(time.Second))
+	if errors.Is(err, ErrFuture) {
+		return r.latest(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// next 是第一个晚于 t 的区块，它的前一个区块就是答案
+	if next.Number.Sign() == 0 {
+		return nil, ErrBeforeGenesis
+	}
+	return r.header(ctx, next.Number.Uint64()-1)
+}
+
+// Range 返回时间戳落在 [since, until) 内的区块范围（含两端）；
+// until 为零值或晚于最新区块时结束于最新区块
+func (r *Resolver) Range(ctx context.Context, since, until time.Time) (from, to uint64, err error) {
+	first, err := r.After(ctx, since)
+	if err != nil {
+		return 0, 0, err
+	}
+	from = first.Number.Uint64()
+	head, err := r.latest(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	to = head.Number.Uint64()
+	if !until.IsZero() {
+		if !until.After(since) {
+			return 0, 0, fmt.Errorf("%w：结束时间 %s 不晚于开始时间 %s", ErrEmptyRange, until.UTC().Format(time.RFC3339), since.UTC().Format(time.RFC3339))
+		}
+		end, err := r.After(ctx, until)
+		switch {
+		case errors.Is(err, ErrFuture):
+		case err != nil:
+			return 0, 0, err
+		case end.Number.Sign() == 0:
+			return 0, 0, ErrEmptyRange
+		default:
+			to = end.Number.Uint64() - 1
+		}
+	}
+	if to < from {
+		return 0, 0, ErrEmptyRange
+	}
+	return from, to, nil
+}
+
+// bracket 用缓存中离目标最近的区块头缩小初始区间
+func (r *Resolver) bracket(lo, hi *types.Header, target uint64) (*types.Header, *types.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range r.headers {
+		if h.Number.Cmp(hi.Number) > 0 {
+			continue
+		}
+		if h.Time < target && h.Number.Cmp(lo.Number) > 0 {
+			lo = h
+		} else if h.Time >= target && h.Number.Cmp(hi.Number) < 0 {
+			hi = h
+		}
+	}
+	return lo, hi
+}
+
+// latest 返回最新区块，HeadTTL 内复用上一次的结果
+func (r *Resolver) latest(ctx context.Context) (*types.Header, error) {
+	r.mu.Lock()
+	if r.head != nil && time.Since(r.headAt) < r.cfg.HeadTTL {
+		defer r.mu.Unlock()
+		return r.head, nil
+	}
+	r.stats.Requests++
+	r.mu.Unlock()
+
+	head, err := r.r.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取最新区块失败: %w", err)
+	}
+	r.mu.Lock()
+	r.head, r.headAt = head, time.Now()
+	r.mu.Unlock()
+	return head, nil
+}
+
+// header 返回指定区块号的区块头，优先使用缓存
+func (r *Resolver) header(ctx context.Context, number uint64) (*types.Header, error) {
+	r.mu.Lock()
+	if h, ok := r.headers[number]; ok {
+		r.stats.Hits++
+		r.mu.Unlock()
+		return h, nil
+	}
+	r.stats.Requests++
+	r.mu.Unlock()
+
+	h, err := r.r.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("获取区块头 %d 失败: %w", number, err)
+	}
+	if h == nil {
+		return nil, fmt.Errorf("区块 %d 不存在", number)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cfg.CacheSize > 0 {
+		if len(r.headers) >= r.cfg.CacheSize {
+			// 缓存已满时随机淘汰一个区块头，创世区块总是保留
+			for n := range r.headers {
+				if n != 0 {
+					delete(r.headers, n)
+					break
+				}
+			}
+		}
+		r.headers[number] = h
+	}
+	return h, nil
+}
+
+// unix 把时间转换为区块时间戳，早于 1970 年的按 0 处理
+func unix(t time.Time) uint64 {
+	if t.Unix() < 0 {
+		return 0
+	}
+	return uint64(t.Unix())
+}
@@ -0,0 +1,175 @@
+package blocktime_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// countingHeaders 统计发送给后端的区块头请求
+type countingHeaders struct {
+	*simchain.Chain
+	n atomic.Int64
+}
+
+func (c *countingHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.n.Add(1)
+	return c.Chain.HeaderByNumber(ctx, number)
+}
+
+// unevenChain 出块间隔不均匀的测试链：大多数区块间隔 10 秒，中间有几段长时间没有出块
+func unevenChain(t *testing.T) *simchain.Chain {
+	chain := simchain.New(t)
+	for i := 0; i < 6; i++ {
+		chain.MineBlocks(40)
+		chain.Warp(time.Duration(i+1) * time.Hour)
+		chain.Mine()
+	}
+	chain.MineBlocks(20)
+	return chain
+}
+
+// bruteAfter 逐个区块查找时间戳不早于 target 的第一个区块
+func bruteAfter(chain *simchain.Chain, target uint64) (uint64, bool) {
+	bc := chain.Blockchain()
+	for n := uint64(0); n <= chain.Head().Number.Uint64(); n++ {
+		if bc.GetHeaderByNumber(n).Time >= target {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func TestAfterBefore(t *testing.T) {
+	chain := unevenChain(t)
+	ctx := context.Background()
+	r := blocktime.New(chain)
+	bc := chain.Blockchain()
+	genesis, head := bc.GetHeaderByNumber(0), chain.Head()
+
+	// 每隔一段时间取一个时刻，与逐个区块查找的结果比较
+	for target := genesis.Time; target <= head.Time; target += 997 {
+		want, _ := bruteAfter(chain, target)
+		got, err := r.After(ctx, time.Unix(int64(target), 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Number.Uint64() != want {
+			t.Fatalf("After(%d) = %d，期望 %d", target, got.Number, want)
+		}
+
+		before, err := r.Before(ctx, time.Unix(int64(target), 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if before.Time > target || (before.Number.Uint64() < head.Number.Uint64() && bc.GetHeaderByNumber(before.Number.Uint64()+1).Time <= target) {
+			t.Fatalf("Before(%d) = %d（时间 %d），不是不晚于该时刻的最后一个区块", target, before.Number, before.Time)
+		}
+	}
+
+	// 恰好等于某个区块的时间戳
+	h := bc.GetHeaderByNumber(123)
+	if got, _ := r.After(ctx, time.Unix(int64(h.Time), 0)); got.Number.Uint64() != 123 {
+		t.Errorf("After(区块 123 的时间) = %d", got.Number)
+	}
+	if got, _ := r.Before(ctx, time.Unix(int64(h.Time), 0)); got.Number.Uint64() != 123 {
+		t.Errorf("Before(区块 123 的时间) = %d", got.Number)
+	}
+
+	// 边界：早于创世区块、晚于最新区块
+	if got, err := r.After(ctx, time.Unix(0, 0)); err != nil || got.Number.Sign() != 0 {
+		t.Errorf("After(0) = %v, %v，期望创世区块", got, err)
+	}
+	if _, err := r.After(ctx, time.Unix(int64(head.Time)+1, 0)); !errors.Is(err, blocktime.ErrFuture) {
+		t.Errorf("晚于最新区块应返回 ErrFuture，实际 %v", err)
+	}
+	if got, err := r.Before(ctx, time.Unix(int64(head.Time)+3600, 0)); err != nil || got.Number.Cmp(head.Number) != 0 {
+		t.Errorf("Before(未来) = %v, %v，期望最新区块", got, err)
+	}
+	if genesis.Time > 0 {
+		if _, err := r.Before(ctx, time.Unix(int64(genesis.Time)-1, 0)); !errors.Is(err, blocktime.ErrBeforeGenesis) {
+			t.Errorf("早于创世区块应返回 ErrBeforeGenesis，实际 %v", err)
+		}
+	}
+}
+
+func TestRequestsAndCache(t *testing.T) {
+	chain := simchain.New(t)
+	chain.MineBlocks(2000)
+	ctx := context.Background()
+	counting := &countingHeaders{Chain: chain}
+	r := blocktime.New(counting)
+	target := time.Unix(int64(chain.Blockchain().GetHeaderByNumber(1234).Time), 0)
+
+	// 出块间隔均匀时插值查找几次请求就能命中，远少于二分查找的 log2(2000) ≈ 11 次
+	got, err := r.After(ctx, target)
+	if err != nil || got.Number.Uint64() != 1234 {
+		t.Fatalf("After = %v, %v", got, err)
+	}
+	if n := counting.n.Load(); n > 6 {
+		t.Errorf("插值查找发送了 %d 个请求", n)
+	}
+
+	// 同一时刻再次查找完全使用缓存
+	before := counting.n.Load()
+	if got, _ := r.After(ctx, target); got.Number.Uint64() != 1234 {
+		t.Fatalf("第二次查找得到 %d", got.Number)
+	}
+	if n := counting.n.Load() - before; n != 0 {
+		t.Errorf("第二次查找发送了 %d 个请求", n)
+	}
+	if st := r.Stats(); st.Requests != int(counting.n.Load()) || st.Hits == 0 {
+		t.Errorf("统计 %+v，实际请求 %d", st, counting.n.Load())
+	}
+}
+
+func TestRange(t *testing.T) {
+	chain := unevenChain(t)
+	ctx := context.Background()
+	r := blocktime.New(chain)
+	bc := chain.Blockchain()
+	head := chain.Head().Number.Uint64()
+
+	since := time.Unix(int64(bc.GetHeaderByNumber(50).Time), 0)
+	until := time.Unix(int64(bc.GetHeaderByNumber(100).Time), 0)
+	from, to, err := r.Range(ctx, since, until)
+	if err != nil || from != 50 || to != 99 {
+		t.Errorf("Range = %d-%d, %v，期望 50-99", from, to, err)
+	}
+	// 不指定结束时间时到最新区块
+	if from, to, err = r.Range(ctx, since, time.Time{}); err != nil || from != 50 || to != head {
+		t.Errorf("Range(无结束) = %d-%d, %v", from, to, err)
+	}
+	// 长时间没有出块的空档中没有区块
+	gapStart := time.Unix(int64(bc.GetHeaderByNumber(40).Time)+60, 0)
+	if _, _, err := r.Range(ctx, gapStart, gapStart.Add(time.Minute)); !errors.Is(err, blocktime.ErrEmptyRange) {
+		t.Errorf("空档应返回 ErrEmptyRange，实际 %v", err)
+	}
+	if _, _, err := r.Range(ctx, until, since); !errors.Is(err, blocktime.ErrEmptyRange) {
+		t.Errorf("结束早于开始应返回 ErrEmptyRange，实际 %v", err)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range []string{"1704067200", "2024-01-01", "2024-01-01T00:00:00Z", "2024-01-01T08:00:00+08:00", "2024-01-01 00:00"} {
+		got, err := blocktime.ParseTime(s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := blocktime.ParseTime("yesterday"); err == nil {
+		t.Error("无效时间应该报错")
+	}
+	if _, err := blocktime.ParseISO("1704067200"); err == nil {
+		t.Error("ParseISO 不应接受纯数字")
+	}
+}
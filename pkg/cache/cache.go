@@ -0,0 +1,360 @@
+// Package cache 为不可变的链上数据（区块、区块头、交易、收据）提供响应缓存
+//
+// query-block、query-receipt、query-transaction 会反复请求早已确定、永远不会再变的区块和收据。
+// 用 Wrap 包装客户端后，这些请求先查内存 LRU，再查可选的磁盘存储，都未命中才访问节点：
+//   - 按哈希查询的区块、区块头和区块收据内容不可变，总是缓存
+//   - 按区块号查询的结果只在区块号不高于已确定（finalized）高度时缓存；
+//     节点不支持 finalized 标签时，以最新区块之前 Confirmations 个区块作为已确定高度
+//   - 按交易哈希查询的交易和收据可能随重组改变所在区块，尚未确定的条目只保存在内存中，
+//     发现重组（同一高度出现不同哈希的区块）时全部失效
+//   - 只有已确定的数据才写入磁盘存储，磁盘上的键按链 ID 区分
+//
+// 包装后的 Client 仍然实现 backend.EthBackend，其余请求直接转发给被包装的后端；
+// 链 ID 查询一次后缓存。被包装的后端有 JSON-RPC 连接时 Client 方法把它透传出来，
+// 功能库的批量请求照常可用（这些请求不经过缓存）：
+//
+//	store, _ := cache.NewDiskStore(filepath.Join(os.TempDir(), "eth-cache"))
+//	client := cache.Wrap(conn, cache.WithStore(store))
+//	info, err := query.Block(ctx, client, big.NewInt(5671744)) // 第二次查询直接命中缓存
+//	fmt.Printf("%+v\n", client.Stats())
+package cache
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// Config 缓存配置
+type Config struct {
+	Size          int           // 内存 LRU 最多保存的条目数
+	Store         Store         // 可选的磁盘存储，nil 表示只使用内存
+	Confirmations uint64        // 节点不支持 finalized 标签时，距最新区块多少个区块视为已确定
+	FinalizedTTL  time.Duration // 已确定高度和最新区块的刷新间隔
+}
+
+// DefaultConfig 返回默认配置：内存保存 4096 个条目，不使用磁盘，64 个确认，每 12 秒刷新一次已确定高度
+func DefaultConfig() *Config {
+	return &Config{
+		Size:          4096,
+		Confirmations: 64,
+		FinalizedTTL:  12 * time.Second,
+	}
+}
+
+// Option 修改缓存配置
+type Option func(*Config)
+
+// WithSize 设置内存 LRU 的条目数
+func WithSize(n int) Option { return func(c *Config) { c.Size = n } }
+
+// WithStore 启用磁盘存储（或其他实现了 Store 的持久化存储）
+func WithStore(s Store) Option { return func(c *Config) { c.Store = s } }
+
+// WithConfirmations 设置节点不支持 finalized 标签时使用的确认数
+func WithConfirmations(n uint64) Option { return func(c *Config) { c.Confirmations = n } }
+
+// WithFinalizedTTL 设置已确定高度的刷新间隔，0 表示每次都重新查询
+func WithFinalizedTTL(d time.Duration) Option { return func(c *Config) { c.FinalizedTTL = d } }
+
+// Stats 缓存命中统计
+type Stats struct {
+	Hits        uint64 // 内存命中次数
+	DiskHits    uint64 // 磁盘命中次数
+	Misses      uint64 // 未命中、需要请求节点的次数
+	Invalidated uint64 // 因重组失效的条目数
+	StoreErrors uint64 // 写入磁盘失败的次数（不影响查询结果）
+	Entries     int    // 当前内存中的条目数
+}
+
+// Client 带响应缓存的后端包装
+type Client struct {
+	backend backend.EthBackend
+	cfg     Config
+
+	mu          sync.Mutex
+	entries     lru.BasicLRU[string, any]
+	unfinalized map[string]uint64      // 尚未确定的条目 -> 所在（或写入时的最新）区块号
+	canonical   map[uint64]common.Hash // 见过的未确定区块号 -> 区块哈希，用于发现重组
+	head        uint64
+	finalized   uint64
+	refreshedAt time.Time
+	chainID     *big.Int // 第一次查询后缓存，同时作为磁盘键前缀
+	stats       Stats
+}
+
+// Client 仍可直接传给 pkg 下的各功能库
+var (
+	_ backend.EthBackend           = (*Client)(nil)
+	_ backend.BlockReceiptsReader  = (*Client)(nil)
+	_ backend.PendingBalanceReader = (*Client)(nil)
+	_ backend.RPCClient            = (*Client)(nil)
+)
+
+// Wrap 用响应缓存包装后端
+func Wrap(b backend.EthBackend, opts ...Option) *Client {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultConfig().Size
+	}
+	return &Client{
+		backend:     b,
+		cfg:         *cfg,
+		entries:     lru.NewBasicLRU[string, any](cfg.Size),
+		unfinalized: make(map[string]uint64),
+		canonical:   make(map[uint64]common.Hash),
+	}
+}
+
+// Unwrap 返回被包装的后端
+func (c *Client) Unwrap() backend.EthBackend { return c.backend }
+
+// Client 返回被包装后端的 JSON-RPC 连接，被包装的后端没有时返回 nil（见 backend.RPC）
+// 功能库借此发送的批量请求和原始调用直接发给节点，不经过缓存
+func (c *Client) Client() *rpc.Client {
+	if r, ok := c.backend.(backend.RPCClient); ok {
+		return r.Client()
+	}
+	return nil
+}
+
+// Close 关闭实现了 Close 方法的底层客户端
+func (c *Client) Close() {
+	if closer, ok := c.backend.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+// Stats 返回命中统计的快照
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.entries.Len()
+	return s
+}
+
+// Invalidate 使区块号不低于 from 的未确定条目失效
+// 调用方自行发现重组时（如 query.WatchBlocks 回调中看到父哈希不连续）可以主动调用
+func (c *Client) Invalidate(from uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate(from)
+}
+
+// invalidate 需持有 c.mu
+func (c *Client) invalidate(from uint64) {
+	for key, number := range c.unfinalized {
+		if number >= from {
+			c.entries.Remove(key)
+			delete(c.unfinalized, key)
+			c.stats.Invalidated++
+		}
+	}
+	for number := range c.canonical {
+		if number >= from {
+			delete(c.canonical, number)
+		}
+	}
+	if c.head > from {
+		c.head = from
+	}
+}
+
+// observe 记录按区块号查询到的区块头；同一高度的哈希变化或父哈希对不上时视为发生重组
+// 只凭这两个区块无法确定分叉点，重组时保守地使全部未确定条目失效
+func (c *Client) observe(header *types.Header) {
+	if header == nil || header.Number == nil {
+		return
+	}
+	number, hash := header.Number.Uint64(), header.Hash()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number <= c.finalized && c.finalized > 0 {
+		return
+	}
+	reorg := false
+	if prev, ok := c.canonical[number]; ok && prev != hash {
+		reorg = true
+	}
+	if parent, ok := c.canonical[number-1]; number > 0 && ok && parent != header.ParentHash {
+		reorg = true
+	}
+	if reorg {
+		c.invalidate(0)
+		c.head = number
+	} else if number > c.head {
+		c.head = number
+	}
+	c.canonical[number] = hash
+}
+
+// finalizedHeight 返回已确定高度，超过 FinalizedTTL 时重新查询
+// 同时刷新最新区块，借此发现重组
+func (c *Client) finalizedHeight(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	if !c.refreshedAt.IsZero() && time.Since(c.refreshedAt) < c.cfg.FinalizedTTL {
+		defer c.mu.Unlock()
+		return c.finalized, nil
+	}
+	last := c.head
+	_, known := c.canonical[last]
+	c.mu.Unlock()
+
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch number := head.Number.Uint64(); {
+	case known && number < last:
+		// 最新区块变低，说明切换到了更短的分叉
+		c.Invalidate(0)
+	case known && number > last+1:
+		// 中间跳过了若干区块，重新查询上次见到的最新区块，确认它仍在规范链上
+		if prev, err := c.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(last)); err == nil {
+			c.observe(prev)
+		}
+	}
+	c.observe(head)
+
+	var finalized uint64
+	tagged, err := c.backend.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err == nil && tagged != nil && tagged.Number != nil {
+		finalized = tagged.Number.Uint64()
+	} else if n := head.Number.Uint64(); n > c.cfg.Confirmations {
+		finalized = n - c.cfg.Confirmations
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if finalized > c.finalized {
+		c.finalized = finalized
+	}
+	c.refreshedAt = time.Now()
+	// 已确定的条目不会再因重组失效，不再跟踪
+	for key, number := range c.unfinalized {
+		if number <= c.finalized {
+			delete(c.unfinalized, key)
+		}
+	}
+	for number := range c.canonical {
+		if number < c.finalized {
+			delete(c.canonical, number)
+		}
+	}
+	return c.finalized, nil
+}
+
+// Finalized 返回已确定高度，按 FinalizedTTL 刷新
+func (c *Client) Finalized(ctx context.Context) (uint64, error) {
+	return c.finalizedHeight(ctx)
+}
+
+// GetRaw 查询以字节形式缓存的任意响应（如 pkg/proxy 转发的原始 JSON-RPC 结果）
+// key 会作为磁盘存储的路径，只能包含字母、数字、下划线和 "/"
+func (c *Client) GetRaw(ctx context.Context, key string) ([]byte, bool) {
+	return lookup(ctx, c, "raw/"+key, rawCodec)
+}
+
+// PutRaw 保存字节形式的响应；final 为 false 时只保存在内存中，发现重组时失效
+func (c *Client) PutRaw(ctx context.Context, key string, data []byte, number uint64, final bool) {
+	save(ctx, c, "raw/"+key, rawCodec, data, number, final)
+}
+
+// headNumber 返回最近见到的最新区块号
+func (c *Client) headNumber(ctx context.Context) (uint64, error) {
+	if _, err := c.finalizedHeight(ctx); err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, nil
+}
+
+// storeKey 磁盘上的键，以链 ID 为前缀，避免不同网络的数据混在一起
+func (c *Client) storeKey(ctx context.Context, key string) (string, bool) {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return "", false
+	}
+	return chainID.String() + "/" + key, true
+}
+
+// lookup 依次查询内存和磁盘，磁盘命中的条目会放回内存
+// 未确定的条目先按 FinalizedTTL 刷新一次最新区块，发现重组时该条目会在这里失效
+func lookup[T any](ctx context.Context, c *Client, key string, cd codec[T]) (T, bool) {
+	c.mu.Lock()
+	_, pending := c.unfinalized[key]
+	c.mu.Unlock()
+	if pending {
+		c.finalizedHeight(ctx)
+	}
+
+	c.mu.Lock()
+	if v, ok := c.entries.Get(key); ok {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return v.(T), true
+	}
+	c.mu.Unlock()
+
+	if c.cfg.Store != nil {
+		if skey, ok := c.storeKey(ctx, key); ok {
+			if data, ok := c.cfg.Store.Get(skey); ok {
+				if v, err := cd.decode(data); err == nil {
+					c.mu.Lock()
+					c.entries.Add(key, v)
+					c.stats.DiskHits++
+					c.mu.Unlock()
+					return v, true
+				}
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	var zero T
+	return zero, false
+}
+
+// save 保存查询结果；final 为 false 时只放在内存中，并记录所在区块号以便重组时失效
+func save[T any](ctx context.Context, c *Client, key string, cd codec[T], v T, number uint64, final bool) {
+	c.mu.Lock()
+	c.entries.Add(key, v)
+	if final {
+		delete(c.unfinalized, key)
+	} else {
+		c.unfinalized[key] = number
+	}
+	c.mu.Unlock()
+
+	if !final || c.cfg.Store == nil {
+		return
+	}
+	skey, ok := c.storeKey(ctx, key)
+	if !ok {
+		return
+	}
+	data, err := cd.encode(v)
+	if err == nil {
+		err = c.cfg.Store.Put(skey, data)
+	}
+	if err != nil {
+		c.mu.Lock()
+		c.stats.StoreErrors++
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,225 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/query"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// countingBackend 统计实际到达节点的区块、交易和收据请求
+type countingBackend struct {
+	backend.EthBackend
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newCounting(b backend.EthBackend) *countingBackend {
+	return &countingBackend{EthBackend: b, calls: map[string]int{}}
+}
+
+func (c *countingBackend) count(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[method]++
+}
+
+func (c *countingBackend) get(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+func (c *countingBackend) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	c.count("BlockByNumber")
+	return c.EthBackend.BlockByNumber(ctx, number)
+}
+
+func (c *countingBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	c.count("BlockByHash")
+	return c.EthBackend.BlockByHash(ctx, hash)
+}
+
+func (c *countingBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.count("TransactionReceipt")
+	return c.EthBackend.TransactionReceipt(ctx, hash)
+}
+
+func (c *countingBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	c.count("TransactionByHash")
+	return c.EthBackend.TransactionByHash(ctx, hash)
+}
+
+func (c *countingBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	c.count("ChainID")
+	return c.EthBackend.ChainID(ctx)
+}
+
+func TestChainIDAndRPCClient(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+
+	// 链 ID 只查询一次
+	cb := newCounting(chain)
+	client := cache.Wrap(cb)
+	for i := 0; i < 3; i++ {
+		if id, err := client.ChainID(ctx); err != nil || id.Cmp(simchain.ChainID) != 0 {
+			t.Fatalf("ChainID = %v, %v", id, err)
+		}
+	}
+	if n := cb.get("ChainID"); n != 1 {
+		t.Fatalf("ChainID reached the node %d times, want 1", n)
+	}
+
+	// 被包装的后端有 JSON-RPC 连接时透传，功能库可以继续发送批量请求
+	if rpcClient, ok := backend.RPC(cache.Wrap(chain)); !ok || rpcClient != chain.Client() {
+		t.Fatal("cache.Client should forward the wrapped backend's rpc.Client")
+	}
+	if _, ok := backend.RPC(client); ok {
+		t.Fatal("backend without rpc.Client should not report one through the cache")
+	}
+}
+
+func TestFinalizedEntriesCached(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	tx := chain.TransferETH(chain.Accounts[0], chain.Accounts[1].Address, big.NewInt(1))
+	chain.MineBlocks(5)
+
+	cb := newCounting(chain)
+	client := cache.Wrap(cb, cache.WithConfirmations(2), cache.WithFinalizedTTL(0))
+
+	// 已确定的区块第二次查询命中缓存，按哈希查询同一个区块也命中
+	for i := 0; i < 2; i++ {
+		info, err := query.Block(ctx, client, big.NewInt(1))
+		if err != nil || info.TxCount != 1 {
+			t.Fatalf("block 1 = %+v, %v", info, err)
+		}
+	}
+	block, err := client.BlockByHash(ctx, chain.Receipt(tx.Hash()).BlockHash)
+	if err != nil || block.NumberU64() != 1 {
+		t.Fatalf("block by hash = %v, %v", block, err)
+	}
+	if n := cb.get("BlockByNumber"); n != 1 {
+		t.Fatalf("BlockByNumber reached node %d times, want 1", n)
+	}
+	if n := cb.get("BlockByHash"); n != 0 {
+		t.Fatalf("BlockByHash reached node %d times, want 0", n)
+	}
+
+	// 未确定的区块每次都请求节点
+	head := chain.Head().Number
+	client.BlockByNumber(ctx, head)
+	client.BlockByNumber(ctx, head)
+	if n := cb.get("BlockByNumber"); n != 3 {
+		t.Fatalf("BlockByNumber reached node %d times, want 3", n)
+	}
+
+	for i := 0; i < 2; i++ {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil || receipt.TxHash != tx.Hash() {
+			t.Fatalf("receipt = %+v, %v", receipt, err)
+		}
+	}
+	if n := cb.get("TransactionReceipt"); n != 1 {
+		t.Fatalf("TransactionReceipt reached node %d times, want 1", n)
+	}
+
+	// 不存在的数据不缓存
+	if _, err := client.TransactionReceipt(ctx, common.Hash{0x01}); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+	st := client.Stats()
+	if st.Hits != 3 || st.Misses != 5 || st.Entries == 0 {
+		t.Fatalf("stats = %+v", st)
+	}
+}
+
+func TestReorgInvalidates(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	chain.MineBlocks(3)
+	tx := chain.TransferETH(alice, bob.Address, big.NewInt(1))
+
+	cb := newCounting(chain)
+	client := cache.Wrap(cb, cache.WithConfirmations(10), cache.WithFinalizedTTL(0))
+	if _, err := client.TransactionReceipt(ctx, tx.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.TransactionByHash(ctx, tx.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.TransactionReceipt(ctx, tx.Hash()); err != nil || cb.get("TransactionReceipt") != 1 {
+		t.Fatalf("receipt should be cached before reorg: %v", err)
+	}
+
+	// 交易所在的区块被重组掉，缓存的收据和交易随之失效
+	chain.Reorg(1, nil)
+	if _, err := client.TransactionReceipt(ctx, tx.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("err = %v, want NotFound after reorg", err)
+	}
+	if _, _, err := client.TransactionByHash(ctx, tx.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("tx err = %v, want NotFound after reorg", err)
+	}
+	if st := client.Stats(); st.Invalidated != 2 {
+		t.Fatalf("stats = %+v, want 2 invalidated entries", st)
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	tx := chain.TransferETH(chain.Accounts[0], chain.Accounts[1].Address, big.NewInt(1))
+	chain.MineBlocks(5)
+	store, err := cache.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := cache.Wrap(chain, cache.WithStore(store), cache.WithConfirmations(2))
+	block, err := first.BlockByNumber(ctx, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := first.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := first.HeaderByNumber(ctx, big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 新的客户端（如下一次运行命令）直接从磁盘读取
+	cb := newCounting(chain)
+	second := cache.Wrap(cb, cache.WithStore(store), cache.WithConfirmations(2))
+	cachedBlock, err := second.BlockByNumber(ctx, big.NewInt(1))
+	if err != nil || cachedBlock.Hash() != block.Hash() || len(cachedBlock.Transactions()) != 1 {
+		t.Fatalf("cached block = %v, %v", cachedBlock, err)
+	}
+	cachedReceipt, err := second.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || cachedReceipt.BlockHash != receipt.BlockHash || cachedReceipt.GasUsed != receipt.GasUsed {
+		t.Fatalf("cached receipt = %+v, %v", cachedReceipt, err)
+	}
+	cachedHeader, err := second.HeaderByNumber(ctx, big.NewInt(2))
+	if err != nil || cachedHeader.Hash() != header.Hash() {
+		t.Fatalf("cached header = %v, %v", cachedHeader, err)
+	}
+	if cb.get("BlockByNumber")+cb.get("TransactionReceipt") != 0 {
+		t.Fatalf("node calls = %v, want none", cb.calls)
+	}
+	if st := second.Stats(); st.DiskHits != 3 || st.Misses != 0 {
+		t.Fatalf("stats = %+v", st)
+	}
+}
@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ===== 链信息 =====
+
+// ChainID 链 ID 不会改变，第一次查询后缓存
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	c.mu.Lock()
+	chainID := c.chainID
+	c.mu.Unlock()
+	if chainID == nil {
+		var err error
+		if chainID, err = c.backend.ChainID(ctx); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.chainID = chainID
+		c.mu.Unlock()
+	}
+	return new(big.Int).Set(chainID), nil
+}
+
+// ===== 区块与交易（带缓存） =====
+
+// cacheable 判断按区块号的查询能否缓存：只有明确的、不高于已确定高度的区块号才可以
+func (c *Client) cacheable(ctx context.Context, number *big.Int) bool {
+	if number == nil || number.Sign() < 0 {
+		return false
+	}
+	finalized, err := c.finalizedHeight(ctx)
+	return err == nil && number.Uint64() <= finalized
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	key := "block/hash/" + hash.Hex()
+	if block, ok := lookup(ctx, c, key, blockCodec); ok {
+		return block, nil
+	}
+	block, err := c.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	save(ctx, c, key, blockCodec, block, block.NumberU64(), true)
+	return block, nil
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	key := "block/number/" + number.String()
+	if number != nil && number.Sign() >= 0 {
+		if block, ok := lookup(ctx, c, key, blockCodec); ok {
+			return block, nil
+		}
+	}
+	block, err := c.backend.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	c.observe(block.Header())
+	if c.cacheable(ctx, number) {
+		save(ctx, c, key, blockCodec, block, block.NumberU64(), true)
+		save(ctx, c, "block/hash/"+block.Hash().Hex(), blockCodec, block, block.NumberU64(), true)
+	}
+	return block, nil
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	key := "header/hash/" + hash.Hex()
+	if header, ok := lookup(ctx, c, key, headerCodec); ok {
+		return header, nil
+	}
+	header, err := c.backend.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		save(ctx, c, key, headerCodec, header, header.Number.Uint64(), true)
+	}
+	return header, nil
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	key := "header/number/" + number.String()
+	if number != nil && number.Sign() >= 0 {
+		if header, ok := lookup(ctx, c, key, headerCodec); ok {
+			return header, nil
+		}
+	}
+	header, err := c.backend.HeaderByNumber(ctx, number)
+	if err != nil || header == nil {
+		return header, err
+	}
+	c.observe(header)
+	if c.cacheable(ctx, number) {
+		save(ctx, c, key, headerCodec, header, header.Number.Uint64(), true)
+		save(ctx, c, "header/hash/"+header.Hash().Hex(), headerCodec, header, header.Number.Uint64(), true)
+	}
+	return header, nil
+}
+
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return c.backend.TransactionCount(ctx, blockHash)
+}
+
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return c.backend.TransactionInBlock(ctx, blockHash, index)
+}
+
+// TransactionByHash 只缓存已打包的交易；交易所在区块未知，按写入时的最新区块号跟踪，重组时保守地失效
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	key := "tx/" + hash.Hex()
+	if tx, ok := lookup(ctx, c, key, txCodec); ok {
+		return tx, false, nil
+	}
+	tx, isPending, err := c.backend.TransactionByHash(ctx, hash)
+	if err != nil || isPending {
+		return tx, isPending, err
+	}
+	if head, err := c.headNumber(ctx); err == nil {
+		save(ctx, c, key, txCodec, tx, head, false)
+	}
+	return tx, false, nil
+}
+
+// TransactionReceipt 收据所在区块已确定时写入磁盘，否则只在内存中缓存并随重组失效
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	key := "receipt/" + hash.Hex()
+	if receipt, ok := lookup(ctx, c, key, receiptCodec); ok {
+		return receipt, nil
+	}
+	receipt, err := c.backend.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if finalized, err := c.finalizedHeight(ctx); err == nil && receipt.BlockNumber != nil {
+		number := receipt.BlockNumber.Uint64()
+		save(ctx, c, key, receiptCodec, receipt, number, number <= finalized)
+	}
+	return receipt, nil
+}
+
+// BlockReceipts 被包装的后端未实现 backend.BlockReceiptsReader 时返回错误
+func (c *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	r, ok := c.backend.(backend.BlockReceiptsReader)
+	if !ok {
+		return nil, errors.New("后端不支持 eth_getBlockReceipts")
+	}
+	var key string
+	var number *big.Int
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		key = "receipts/hash/" + hash.Hex()
+	} else if n, ok := blockNrOrHash.Number(); ok && n >= 0 {
+		number = big.NewInt(n.Int64())
+		key = "receipts/number/" + number.String()
+	}
+	if key != "" {
+		if receipts, ok := lookup(ctx, c, key, receiptsCodec); ok {
+			return receipts, nil
+		}
+	}
+	receipts, err := r.BlockReceipts(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" || len(receipts) == 0 {
+		return receipts, nil
+	}
+	// 按哈希查询的收据不可变；按区块号查询的收据只在区块已确定时缓存
+	if number == nil || c.cacheable(ctx, number) {
+		save(ctx, c, key, receiptsCodec, receipts, receipts[0].BlockNumber.Uint64(), true)
+	}
+	return receipts, nil
+}
+
+// ===== 账户状态 =====
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return c.backend.BalanceAt(ctx, account, blockNumber)
+}
+
+// PendingBalanceAt 被包装的后端未实现 backend.PendingBalanceReader 时返回错误
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	r, ok := c.backend.(backend.PendingBalanceReader)
+	if !ok {
+		return nil, errors.New("后端不支持查询待处理余额")
+	}
+	return r.PendingBalanceAt(ctx, account)
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return c.backend.StorageAt(ctx, account, key, blockNumber)
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.backend.CodeAt(ctx, account, blockNumber)
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.backend.NonceAt(ctx, account, blockNumber)
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return c.backend.PendingCodeAt(ctx, account)
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.backend.PendingNonceAt(ctx, account)
+}
+
+// ===== 合约调用与 Gas =====
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.backend.CallContract(ctx, msg, blockNumber)
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return c.backend.EstimateGas(ctx, msg)
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return c.backend.SuggestGasPrice(ctx)
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return c.backend.SuggestGasTipCap(ctx)
+}
+
+// ===== 日志与订阅 =====
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return c.backend.FilterLogs(ctx, q)
+}
+
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.backend.SubscribeFilterLogs(ctx, q, ch)
+}
+
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return c.backend.SubscribeNewHead(ctx, ch)
+}
+
+// ===== 发送交易 =====
+
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.backend.SendTransaction(ctx, tx)
+}
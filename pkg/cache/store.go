@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Store 持久化存储，只保存已确定、不会再改变的数据
+// 读取失败按未命中处理；写入失败只计入 Stats.StoreErrors，不影响查询结果
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte) error
+}
+
+// DiskStore 以文件形式保存缓存条目，每个键一个文件
+type DiskStore struct {
+	dir string
+}
+
+// NewDiskStore 使用 dir 目录作为磁盘存储，目录不存在时自动创建
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// path 键由 "/" 分隔的链 ID、数据类型、区块号或十六进制哈希组成，直接映射为子目录
+func (s *DiskStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// Get 读取键对应的文件，不存在时返回 false
+func (s *DiskStore) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put 先写入临时文件再重命名，避免进程中断时留下不完整的文件
+func (s *DiskStore) Put(key string, value []byte) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// codec 缓存条目写入磁盘时的编码方式
+type codec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+// rlpCodec 区块和区块头使用 RLP 编码
+func rlpCodec[T any]() codec[*T] {
+	return codec[*T]{
+		encode: func(v *T) ([]byte, error) { return rlp.EncodeToBytes(v) },
+		decode: func(data []byte) (*T, error) {
+			v := new(T)
+			return v, rlp.DecodeBytes(data, v)
+		},
+	}
+}
+
+// jsonCodec 收据使用 JSON 编码，RLP 编码会丢失交易哈希、区块号等派生字段
+func jsonCodec[T any]() codec[T] {
+	return codec[T]{
+		encode: func(v T) ([]byte, error) { return json.Marshal(v) },
+		decode: func(data []byte) (T, error) {
+			var v T
+			return v, json.Unmarshal(data, &v)
+		},
+	}
+}
+
+var (
+	blockCodec    = rlpCodec[types.Block]()
+	headerCodec   = rlpCodec[types.Header]()
+	receiptCodec  = jsonCodec[*types.Receipt]()
+	receiptsCodec = jsonCodec[[]*types.Receipt]()
+	rawCodec      = codec[[]byte]{
+		encode: func(data []byte) ([]byte, error) { return data, nil },
+		decode: func(data []byte) ([]byte, error) { return data, nil },
+	}
+	txCodec = codec[*types.Transaction]{
+		encode: func(tx *types.Transaction) ([]byte, error) { return tx.MarshalBinary() },
+		decode: func(data []byte) (*types.Transaction, error) {
+			tx := new(types.Transaction)
+			return tx, tx.UnmarshalBinary(data)
+		},
+	}
+)
@@ -0,0 +1,368 @@
+// Package calldata 解码交易的输入数据（calldata）
+//
+// 输入数据的前 4 字节是方法选择器，Decoder 依次用以下来源匹配：
+//   - AddContract 登记在目标地址上的 ABI
+//   - AddABI 登记的通用 ABI（对任意地址都尝试）
+//   - 离线的 4 字节签名库 SignatureDB（内置常用签名，可导入 4byte.directory 等来源的数据）
+//
+// 选择器可能碰撞，所有能成功解码参数的签名都作为候选返回；重新编码后与原始数据完全一致的
+// 候选（Exact）排在前面。参数中的 bytes 如果本身也是一次调用（如 multicall(bytes[])、
+// Multicall3 的 aggregate3、Safe 的 execTransaction 和 multiSend），会递归解码。
+//
+//	d := calldata.NewDecoder(calldata.DefaultSignatures())
+//	d.AddABI("MyToken", mytoken.MyTokenMetaData.ABI)
+//	call := d.Decode(tx.To(), tx.Data())
+//	fmt.Print(call)
+package calldata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SourceSignatureDB 由签名库解码的候选的 Source
+const SourceSignatureDB = "4byte"
+
+// maxDepth 嵌套调用的最大解码深度
+const maxDepth = 4
+
+// Call 一段 calldata 的解码结果
+type Call struct {
+	Target     *common.Address // 调用的目标地址，未知时为 nil
+	Selector   Selector
+	Data       []byte
+	Candidates []*Candidate // 能成功解码的候选签名，最可能的排在最前；为空表示无法解码
+}
+
+// Best 返回最可能的候选，无法解码时返回 nil
+func (c *Call) Best() *Candidate {
+	if len(c.Candidates) == 0 {
+		return nil
+	}
+	return c.Candidates[0]
+}
+
+// Candidate 一个候选签名及按它解码出的参数
+type Candidate struct {
+	Source    string // ABI 名称，或签名库 SourceSignatureDB
+	Name      string
+	Signature string
+	Args      []Arg
+	Exact     bool      // 按该签名重新编码后与原始数据完全一致
+	Nested    []*Nested // 参数中嵌套的调用
+
+	inputs abi.Arguments
+}
+
+// Arg 解码出的参数；来自签名库的参数没有名称，依次命名为 arg0、arg1...
+type Arg struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// Nested 参数中嵌套的一次调用
+type Nested struct {
+	Path         string   // 参数路径，例如 "data"、"calls[1].field2"
+	Value        *big.Int // 附带的 ETH（仅 multiSend），否则为 nil
+	DelegateCall bool     // multiSend 中 operation 为 1
+	Call         *Call
+}
+
+// source 一个已解析的 ABI
+type source struct {
+	name string
+	abi  abi.ABI
+}
+
+// Decoder calldata 解码器，登记 ABI 之后并发使用是安全的
+type Decoder struct {
+	db        *SignatureDB
+	contracts map[common.Address][]*source
+	abis      []*source
+}
+
+// NewDecoder 创建解码器，db 为 nil 时只使用登记的 ABI
+func NewDecoder(db *SignatureDB) *Decoder {
+	return &Decoder{db: db, contracts: make(map[common.Address][]*source)}
+}
+
+// AddABI 登记对任意地址都尝试的 ABI
+func (d *Decoder) AddABI(name, abiJSON string) error {
+	src, err := parseSource(name, abiJSON)
+	if err != nil {
+		return err
+	}
+	d.abis = append(d.abis, src)
+	return nil
+}
+
+// AddContract 登记只用于 addr 的 ABI，优先于通用 ABI 和签名库
+func (d *Decoder) AddContract(addr common.Address, name, abiJSON string) error {
+	src, err := parseSource(name, abiJSON)
+	if err != nil {
+		return err
+	}
+	d.contracts[addr] = append(d.contracts[addr], src)
+	return nil
+}
+
+func parseSource(name, abiJSON string) (*source, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 的 ABI 失败: %w", name, err)
+	}
+	return &source{name: name, abi: parsed}, nil
+}
+
+// Decode 解码发往 to 的 calldata，to 为 nil 表示目标未知；不足 4 字节（如普通转账）时返回 nil
+func (d *Decoder) Decode(to *common.Address, input []byte) *Call {
+	return d.decode(to, input, 0)
+}
+
+func (d *Decoder) decode(to *common.Address, input []byte, depth int) *Call {
+	if len(input) < 4 {
+		return nil
+	}
+	call := &Call{Target: to, Data: input}
+	copy(call.Selector[:], input[:4])
+
+	seen := make(map[string]bool)
+	var sources []*source
+	if to != nil {
+		sources = append(sources, d.contracts[*to]...)
+	}
+	for _, src := range append(sources, d.abis...) {
+		method, err := src.abi.MethodById(input[:4])
+		if err != nil || seen[method.Sig] {
+			continue
+		}
+		if c := unpack(src.name, method.Name, method.Sig, method.Inputs, input); c != nil {
+			seen[method.Sig] = true
+			call.Candidates = append(call.Candidates, c)
+		}
+	}
+	if d.db != nil {
+		for _, sig := range d.db.Lookup(call.Selector) {
+			if seen[sig] {
+				continue
+			}
+			name, args, err := parseSignature(sig)
+			if err != nil {
+				continue
+			}
+			if c := unpack(SourceSignatureDB, name, sig, args, input); c != nil {
+				seen[sig] = true
+				call.Candidates = append(call.Candidates, c)
+			}
+		}
+	}
+	// 精确匹配优先，其次保持 ABI 在签名库之前的顺序
+	sort.SliceStable(call.Candidates, func(i, j int) bool {
+		return call.Candidates[i].Exact && !call.Candidates[j].Exact
+	})
+
+	if depth < maxDepth {
+		for _, c := range call.Candidates {
+			d.nested(c, to, depth)
+		}
+	}
+	return call
+}
+
+// unpack 按参数定义解码，数据与定义不符时返回 nil
+func unpack(src, name, sig string, inputs abi.Arguments, input []byte) *Candidate {
+	values, err := inputs.Unpack(input[4:])
+	if err != nil || len(values) != len(inputs) {
+		return nil
+	}
+	c := &Candidate{Source: src, Name: name, Signature: sig, Args: make([]Arg, len(inputs)), inputs: inputs}
+	for i, arg := range inputs {
+		c.Args[i] = Arg{Name: arg.Name, Type: arg.Type.String(), Value: values[i]}
+	}
+	if packed, err := inputs.Pack(values...); err == nil {
+		c.Exact = bytes.Equal(packed, input[4:])
+	}
+	return c
+}
+
+// nested 在候选的参数中查找嵌套调用
+// 嵌套调用的目标取同一层第一个 address 参数（如 execTransaction 的 to、aggregate3 的 target），
+// 同一层没有地址参数时（如 multicall(bytes[])）沿用外层的目标
+func (d *Decoder) nested(c *Candidate, to *common.Address, depth int) {
+	if c.Signature == "multiSend(bytes)" {
+		if packed, ok := c.Args[0].Value.([]byte); ok {
+			c.Nested = d.multiSend(c.Args[0].Name, packed, depth)
+		}
+		return
+	}
+	values := make([]interface{}, len(c.Args))
+	for i, arg := range c.Args {
+		values[i] = arg.Value
+	}
+	target := firstAddress(values, to)
+	for i, arg := range c.inputs {
+		d.walk(arg.Type, values[i], arg.Name, target, depth, &c.Nested)
+	}
+}
+
+// walk 按 ABI 类型递归遍历参数值，对 bytes 尝试按 calldata 解码
+func (d *Decoder) walk(t abi.Type, v interface{}, path string, target *common.Address, depth int, out *[]*Nested) {
+	switch t.T {
+	case abi.BytesTy:
+		b, _ := v.([]byte)
+		if call := d.decode(target, b, depth+1); call != nil && len(call.Candidates) > 0 {
+			*out = append(*out, &Nested{Path: path, Call: call})
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		rv := reflect.ValueOf(v)
+		for i := 0; i < rv.Len(); i++ {
+			d.walk(*t.Elem, rv.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i), target, depth, out)
+		}
+	case abi.TupleTy:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		fields := make([]interface{}, rv.NumField())
+		for i := range fields {
+			fields[i] = rv.Field(i).Interface()
+		}
+		tupleTarget := firstAddress(fields, target)
+		for i, elem := range t.TupleElems {
+			d.walk(*elem, fields[i], path+"."+t.TupleRawNames[i], tupleTarget, depth, out)
+		}
+	}
+}
+
+// firstAddress 返回值列表中第一个地址，没有时返回 fallback
+func firstAddress(values []interface{}, fallback *common.Address) *common.Address {
+	for _, v := range values {
+		if addr, ok := v.(common.Address); ok {
+			return &addr
+		}
+	}
+	return fallback
+}
+
+// multiSend 解析 Safe MultiSend 的紧凑编码：每笔交易依次为
+// operation(1 字节) + to(20 字节) + value(32 字节) + 数据长度(32 字节) + 数据
+func (d *Decoder) multiSend(name string, packed []byte, depth int) []*Nested {
+	var out []*Nested
+	for i := 0; len(packed) >= 85; i++ {
+		op := packed[0]
+		to := common.BytesToAddress(packed[1:21])
+		value := new(big.Int).SetBytes(packed[21:53])
+		length := new(big.Int).SetBytes(packed[53:85])
+		if !length.IsUint64() || length.Uint64() > uint64(len(packed)-85) {
+			break
+		}
+		data := packed[85 : 85+length.Uint64()]
+		packed = packed[85+length.Uint64():]
+
+		call := d.decode(&to, data, depth+1)
+		if call == nil {
+			call = &Call{Target: &to, Data: data} // 普通 ETH 转账
+		}
+		out = append(out, &Nested{Path: fmt.Sprintf("%s[%d]", name, i), Value: value, DelegateCall: op == 1, Call: call})
+	}
+	return out
+}
+
+// String 以缩进的文本形式展示解码结果，嵌套调用逐层缩进
+func (c *Call) String() string {
+	var buf bytes.Buffer
+	c.write(&buf, "")
+	return buf.String()
+}
+
+func (c *Call) write(w io.Writer, indent string) {
+	target := "未知"
+	if c.Target != nil {
+		target = c.Target.Hex()
+	}
+	fmt.Fprintf(w, "%s目标: %s，选择器: %s\n", indent, target, c.Selector.Hex())
+	if len(c.Data) < 4 {
+		fmt.Fprintf(w, "%s  （无调用数据）\n", indent)
+		return
+	}
+	if len(c.Candidates) == 0 {
+		fmt.Fprintf(w, "%s  无法解码：签名库和已登记的 ABI 中都没有这个选择器\n", indent)
+		return
+	}
+	if len(c.Candidates) > 1 {
+		fmt.Fprintf(w, "%s  发现 %d 个候选签名（选择器碰撞）\n", indent, len(c.Candidates))
+	}
+	for i, cand := range c.Candidates {
+		exact := ""
+		if cand.Exact {
+			exact = "，精确匹配"
+		}
+		fmt.Fprintf(w, "%s  [%d] %s（来源: %s%s）\n", indent, i+1, cand.Signature, cand.Source, exact)
+		for _, arg := range cand.Args {
+			fmt.Fprintf(w, "%s      %s %s = %s\n", indent, arg.Type, arg.Name, FormatValue(arg.Value))
+		}
+		for _, n := range cand.Nested {
+			extra := ""
+			if n.Value != nil && n.Value.Sign() > 0 {
+				extra += fmt.Sprintf("，附带 %s Wei", n.Value)
+			}
+			if n.DelegateCall {
+				extra += "，delegatecall"
+			}
+			fmt.Fprintf(w, "%s      ↳ %s 嵌套调用%s:\n", indent, n.Path, extra)
+			n.Call.write(w, indent+"        ")
+		}
+	}
+}
+
+// FormatValue 把 ABI 解码出的值格式化为字符串：地址、哈希和字节按十六进制，
+// 大整数按十进制，数组和元组逐项格式化
+func FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		items := make([]string, rv.NumField())
+		for i := range items {
+			items[i] = FormatValue(rv.Field(i).Interface())
+		}
+		return "(" + strings.Join(items, ", ") + ")"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,208 @@
+package calldata_test
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+)
+
+var (
+	alice = common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	token = common.HexToAddress("0x0000000000000000000000000000000000000701")
+)
+
+// safeABI 测试用的 Safe 方法定义
+const safeABI = `[
+ {"type":"function","name":"execTransaction","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}]},
+ {"type":"function","name":"multiSend","inputs":[{"name":"transactions","type":"bytes"}]}
+]`
+
+func mustPack(t *testing.T, abiJSON, method string, args ...interface{}) []byte {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func transferData(t *testing.T, amount int64) []byte {
+	return mustPack(t, mytoken.MyTokenMetaData.ABI, "transfer", alice, big.NewInt(amount))
+}
+
+func TestDecodeWithSignatureDB(t *testing.T) {
+	d := calldata.NewDecoder(calldata.DefaultSignatures())
+	call := d.Decode(&token, transferData(t, 250))
+	best := call.Best()
+	if best == nil || best.Source != calldata.SourceSignatureDB || best.Signature != "transfer(address,uint256)" || !best.Exact {
+		t.Fatalf("best = %+v", best)
+	}
+	if best.Args[0].Name != "arg0" || best.Args[0].Value != alice || best.Args[1].Value.(*big.Int).Int64() != 250 {
+		t.Fatalf("args = %+v", best.Args)
+	}
+
+	// 登记 ABI 后优先使用 ABI，参数带名称
+	if err := d.AddContract(token, "MyToken", mytoken.MyTokenMetaData.ABI); err != nil {
+		t.Fatal(err)
+	}
+	best = d.Decode(&token, transferData(t, 250)).Best()
+	if best.Source != "MyToken" || best.Args[0].Name != "recipient" || best.Args[1].Name != "amount" {
+		t.Fatalf("best = %+v", best)
+	}
+	if call := d.Decode(&token, []byte{0xde, 0xad, 0xbe, 0xef}); call.Best() != nil {
+		t.Fatalf("unknown selector decoded: %+v", call.Best())
+	}
+	if d.Decode(&token, nil) != nil {
+		t.Fatal("empty calldata should return nil")
+	}
+}
+
+func TestSelectorCollision(t *testing.T) {
+	if calldata.SelectorOf("burn(uint256)") != calldata.SelectorOf("collate_propagate_storage(bytes16)") {
+		t.Fatal("expected the bundled collision example to share a selector")
+	}
+	d := calldata.NewDecoder(calldata.DefaultSignatures())
+	data := append(calldata.SelectorOf("burn(uint256)").Bytes(), common.LeftPadBytes([]byte{1}, 32)...)
+	call := d.Decode(nil, data)
+	if len(call.Candidates) != 2 || call.Candidates[0].Signature != "burn(uint256)" || !call.Candidates[0].Exact || call.Candidates[1].Exact {
+		for _, c := range call.Candidates {
+			t.Logf("%+v", c)
+		}
+		t.Fatal("collision candidates not ranked by exact re-encoding")
+	}
+	if !strings.Contains(call.String(), "2 个候选签名") {
+		t.Fatalf("output:\n%s", call)
+	}
+}
+
+func TestNestedCalls(t *testing.T) {
+	d := calldata.NewDecoder(calldata.DefaultSignatures())
+	other := common.HexToAddress("0x0000000000000000000000000000000000000702")
+
+	// Multicall3.aggregate3：每个子调用的目标取元组中的 target
+	data := mustPack(t, multicall3.Multicall3MetaData.ABI, "aggregate3", []multicall3.Multicall3Call3{
+		{Target: token, AllowFailure: true, CallData: transferData(t, 1)},
+		{Target: other, CallData: transferData(t, 2)},
+	})
+	call := d.Decode(&multicallAddr, data)
+	best := call.Best()
+	if best == nil || best.Name != "aggregate3" || len(best.Nested) != 2 {
+		t.Fatalf("aggregate3 = %+v", best)
+	}
+	if n := best.Nested[1]; n.Path != "arg0[1].field2" || *n.Call.Target != other || n.Call.Best().Name != "transfer" {
+		t.Fatalf("nested = %+v, target = %v", n, n.Call.Target)
+	}
+
+	// multicall(bytes[])：子调用的目标沿用外层地址
+	data = mustPack(t, `[{"type":"function","name":"multicall","inputs":[{"name":"data","type":"bytes[]"}]}]`,
+		"multicall", [][]byte{transferData(t, 3)})
+	best = d.Decode(&token, data).Best()
+	if len(best.Nested) != 1 || *best.Nested[0].Call.Target != token {
+		t.Fatalf("multicall = %+v", best)
+	}
+
+	// Safe execTransaction -> delegatecall multiSend -> 一笔代币转账和一笔 ETH 转账
+	var packed []byte
+	for _, tx := range []struct {
+		to    common.Address
+		value int64
+		data  []byte
+	}{{token, 0, transferData(t, 4)}, {alice, 5, nil}} {
+		packed = append(packed, 0)
+		packed = append(packed, tx.to.Bytes()...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(tx.value).Bytes(), 32)...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(int64(len(tx.data))).Bytes(), 32)...)
+		packed = append(packed, tx.data...)
+	}
+	multiSendAddr := common.HexToAddress("0x40A2aCCbd92BCA938b02010E17A5b8929b49130D")
+	inner := mustPack(t, safeABI, "multiSend", packed)
+	data = mustPack(t, safeABI, "execTransaction", multiSendAddr, big.NewInt(0), inner, uint8(1),
+		big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, common.Address{}, []byte{})
+	safe := common.HexToAddress("0x0000000000000000000000000000000000005afe")
+	call = d.Decode(&safe, data)
+	best = call.Best()
+	if best == nil || best.Name != "execTransaction" || len(best.Nested) != 1 {
+		t.Fatalf("execTransaction = %+v", best)
+	}
+	ms := best.Nested[0].Call
+	if *ms.Target != multiSendAddr || ms.Best().Name != "multiSend" || len(ms.Best().Nested) != 2 {
+		t.Fatalf("multiSend = %+v", ms.Best())
+	}
+	txs := ms.Best().Nested
+	if txs[0].Call.Best().Name != "transfer" || *txs[1].Call.Target != alice || txs[1].Value.Int64() != 5 {
+		t.Fatalf("multiSend txs = %+v, %+v", txs[0], txs[1])
+	}
+	if out := call.String(); !strings.Contains(out, "multiSend(bytes)") || !strings.Contains(out, "附带 5 Wei") {
+		t.Fatalf("output:\n%s", out)
+	}
+}
+
+var multicallAddr = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+func TestImport(t *testing.T) {
+	db := calldata.NewSignatureDB()
+	// 4byte.directory 接口格式，第二条的选择器与签名不符，被跳过
+	page := `{"count":2,"results":[
+		{"id":1,"text_signature":"transfer(address,uint256)","hex_signature":"0xa9059cbb"},
+		{"id":2,"text_signature":"bogus(uint256)","hex_signature":"0x12345678"}]}`
+	if n, err := db.Import(strings.NewReader(page)); err != nil || n != 1 {
+		t.Fatalf("import page = %d, %v", n, err)
+	}
+	text := "# comment\n0x095ea7b3,approve(address,uint256)\n0x23b872dd transferFrom(address,address,uint256)\naggregate((address,bytes)[])\nnot a signature\n"
+	if n, err := db.Import(strings.NewReader(text)); err != nil || n != 3 {
+		t.Fatalf("import text = %d, %v", n, err)
+	}
+	byHex := `{"0x70a08231":["balanceOf(address)"],"0x18160ddd":"totalSupply()"}`
+	if n, err := db.Import(strings.NewReader(byHex)); err != nil || n != 2 {
+		t.Fatalf("import map = %d, %v", n, err)
+	}
+	if db.Len() != 6 {
+		t.Fatalf("len = %d, want 6", db.Len())
+	}
+	if sigs := db.Lookup(calldata.SelectorOf("approve(address,uint256)")); len(sigs) != 1 {
+		t.Fatalf("lookup = %v", sigs)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	data, err := calldata.Encode("transfer(address, uint256)", alice.Hex(), "0x64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := mustPack(t, mytoken.MyTokenMetaData.ABI, "transfer", alice, big.NewInt(100)); !bytes.Equal(data, want) {
+		t.Fatalf("encode = %x, want %x", data, want)
+	}
+
+	// 定长整数、定长字节、bool、string 按 Go 类型转换
+	if _, err := calldata.Encode("f(uint8,int64,bytes32,bool,string,bytes)", "255", "-1", "0x01", "true", "hi", "0xabcd"); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range [][]string{{"256"}, {"-1"}, {"x"}} {
+		if _, err := calldata.Encode("f(uint8)", bad...); err == nil {
+			t.Fatalf("Encode(f(uint8), %v) should fail", bad)
+		}
+	}
+	if _, err := calldata.Encode("f(address,uint256)", alice.Hex()); err == nil {
+		t.Fatal("wrong argument count should fail")
+	}
+
+	out, err := calldata.DecodeOutput("(address,uint256)", data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out[0].(common.Address) != alice || out[1].(*big.Int).Int64() != 100 {
+		t.Fatalf("decode output = %v", out)
+	}
+}
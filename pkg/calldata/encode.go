@@ -0,0 +1,111 @@
+package calldata
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Encode 按文本签名把命令行形式的参数编码为调用数据，如
+// Encode("transfer(address,uint256)", "0x4592...", "1000000")
+//
+// 支持基本类型：address、bool、string、bytes、bytesN（0x 十六进制）、uintN / intN（十进制或 0x 十六进制）；
+// 数组和元组请直接提供十六进制调用数据
+func Encode(signature string, args ...string) ([]byte, error) {
+	signature = strings.ReplaceAll(signature, " ", "")
+	_, inputs, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("%s 需要 %d 个参数，实际为 %d 个", signature, len(inputs), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range inputs {
+		if values[i], err = ParseValue(arg.Type, args[i]); err != nil {
+			return nil, fmt.Errorf("第 %d 个参数: %w", i+1, err)
+		}
+	}
+	packed, err := inputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return append(SelectorOf(signature).Bytes(), packed...), nil
+}
+
+// ParseValue 把文本解析为 ABI 编码所需的 Go 值
+func ParseValue(t abi.Type, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("无效的地址 %q", s)
+		}
+		return common.HexToAddress(s), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+	case abi.StringTy:
+		return s, nil
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > t.Size {
+			return nil, fmt.Errorf("%s 最多 %d 字节，实际为 %d 字节", t, t.Size, len(b))
+		}
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(common.RightPadBytes(b, t.Size)))
+		return v.Interface(), nil
+	case abi.UintTy, abi.IntTy:
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("无效的整数 %q", s)
+		}
+		if t.T == abi.UintTy && n.Sign() < 0 {
+			return nil, fmt.Errorf("%s 不能为负数", t)
+		}
+		// 有符号数的取值范围是 [-2^(size-1), 2^(size-1))：非负数和 -n-1 都要少于 size 位
+		bits := n.BitLen()
+		if t.T == abi.IntTy {
+			m := n
+			if n.Sign() < 0 {
+				m = new(big.Int).Sub(new(big.Int).Neg(n), big.NewInt(1))
+			}
+			bits = m.BitLen() + 1
+		}
+		if bits > t.Size {
+			return nil, fmt.Errorf("%s 超出 %s 的范围", s, t)
+		}
+		if t.Size > 64 {
+			return n, nil
+		}
+		// uint8..uint64、int8..int64 对应 Go 的定长整数类型
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(n.Uint64()).Convert(t.GetType()).Interface(), nil
+		}
+		return reflect.ValueOf(n.Int64()).Convert(t.GetType()).Interface(), nil
+	}
+	return nil, fmt.Errorf("不支持从文本解析 %s 类型", t)
+}
+
+// DecodeOutput 按类型列表解码返回数据，types 形如 "uint256" 或 "(uint256,address)"
+func DecodeOutput(types string, data []byte) ([]interface{}, error) {
+	types = strings.ReplaceAll(types, " ", "")
+	if !strings.HasPrefix(types, "(") {
+		types = "(" + types + ")"
+	}
+	_, outputs, err := parseSignature("f" + types)
+	if err != nil {
+		return nil, err
+	}
+	return outputs.Unpack(data)
+}
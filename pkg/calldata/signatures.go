@@ -0,0 +1,285 @@
+package calldata
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Selector 方法选择器：方法签名 keccak256 哈希的前 4 字节
+type Selector [4]byte
+
+// Hex 返回 0x 开头的十六进制形式
+func (s Selector) Hex() string { return hexutil.Encode(s[:]) }
+
+// Bytes 返回选择器的字节切片
+func (s Selector) Bytes() []byte { return s[:] }
+
+// SelectorOf 计算文本签名（如 "transfer(address,uint256)"）的选择器
+func SelectorOf(signature string) Selector {
+	var sel Selector
+	copy(sel[:], crypto.Keccak256([]byte(signature))[:4])
+	return sel
+}
+
+// SignatureDB 离线的 4 字节签名库：选择器到文本签名的映射
+// 不同签名可能得到相同的选择器（碰撞），因此一个选择器可以对应多个签名
+type SignatureDB struct {
+	mu   sync.RWMutex
+	sigs map[Selector][]string
+}
+
+// NewSignatureDB 创建空的签名库
+func NewSignatureDB() *SignatureDB {
+	return &SignatureDB{sigs: make(map[Selector][]string)}
+}
+
+//go:embed signatures.txt
+var bundledSignatures []byte
+
+var (
+	defaultOnce sync.Once
+	defaultDB   *SignatureDB
+)
+
+// DefaultSignatures 返回随项目打包的常用签名库（ERC20/721/1155、WETH、Uniswap、Multicall、Safe 等）
+// 返回的是新副本，可以放心继续导入
+func DefaultSignatures() *SignatureDB {
+	defaultOnce.Do(func() {
+		defaultDB = NewSignatureDB()
+		if _, err := defaultDB.Import(bytes.NewReader(bundledSignatures)); err != nil {
+			panic("解析内置签名库失败: " + err.Error())
+		}
+	})
+	db := NewSignatureDB()
+	defaultDB.mu.RLock()
+	defer defaultDB.mu.RUnlock()
+	for sel, sigs := range defaultDB.sigs {
+		db.sigs[sel] = append([]string(nil), sigs...)
+	}
+	return db
+}
+
+// Add 添加一个文本签名，签名格式不正确时返回错误，重复添加会被忽略
+func (db *SignatureDB) Add(signature string) error {
+	signature = strings.ReplaceAll(signature, " ", "")
+	if _, _, err := parseSignature(signature); err != nil {
+		return err
+	}
+	sel := SelectorOf(signature)
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, s := range db.sigs[sel] {
+		if s == signature {
+			return nil
+		}
+	}
+	db.sigs[sel] = append(db.sigs[sel], signature)
+	sort.Strings(db.sigs[sel])
+	return nil
+}
+
+// Lookup 返回选择器对应的全部文本签名，按字母顺序排列
+func (db *SignatureDB) Lookup(sel Selector) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return append([]string(nil), db.sigs[sel]...)
+}
+
+// Len 返回签名总数
+func (db *SignatureDB) Len() int {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	n := 0
+	for _, sigs := range db.sigs {
+		n += len(sigs)
+	}
+	return n
+}
+
+// Import 导入签名，返回成功导入的条数。自动识别以下格式：
+//   - 4byte.directory 接口返回的 JSON：{"results":[{"text_signature":"...","hex_signature":"0x..."}]}，
+//     或直接是 results 数组
+//   - 选择器到签名的 JSON 对象：{"0xa9059cbb":["transfer(address,uint256)"]}，值也可以是单个字符串
+//   - 文本：每行 "0x选择器,签名"、"0x选择器 签名" 或只有签名，# 开头的行为注释
+//
+// 提供了选择器时会与签名重新计算的结果核对，对不上的条目（如垃圾数据）被跳过
+func (db *SignatureDB) Import(r io.Reader) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return db.importJSON(trimmed)
+	}
+
+	n := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hex, sig := "", line
+		if strings.HasPrefix(line, "0x") {
+			if i := strings.IndexAny(line, ",\t "); i > 0 {
+				hex, sig = line[:i], strings.TrimSpace(line[i+1:])
+			}
+		}
+		if db.addChecked(hex, sig) {
+			n++
+		}
+	}
+	return n, scanner.Err()
+}
+
+// fourByteEntry 4byte.directory 接口中的一条签名
+type fourByteEntry struct {
+	TextSignature string `json:"text_signature"`
+	HexSignature  string `json:"hex_signature"`
+}
+
+func (db *SignatureDB) importJSON(data []byte) (int, error) {
+	var entries []fourByteEntry
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return 0, fmt.Errorf("解析签名 JSON 失败: %w", err)
+		}
+	} else {
+		var page struct {
+			Results []fourByteEntry `json:"results"`
+		}
+		if err := json.Unmarshal(data, &page); err == nil && page.Results != nil {
+			entries = page.Results
+		} else {
+			var byHex map[string]json.RawMessage
+			if err := json.Unmarshal(data, &byHex); err != nil {
+				return 0, fmt.Errorf("解析签名 JSON 失败: %w", err)
+			}
+			for hex, raw := range byHex {
+				var sigs []string
+				if err := json.Unmarshal(raw, &sigs); err != nil {
+					var sig string
+					if err := json.Unmarshal(raw, &sig); err != nil {
+						return 0, fmt.Errorf("解析 %s 的签名失败: %w", hex, err)
+					}
+					sigs = []string{sig}
+				}
+				for _, sig := range sigs {
+					entries = append(entries, fourByteEntry{TextSignature: sig, HexSignature: hex})
+				}
+			}
+		}
+	}
+	n := 0
+	for _, e := range entries {
+		if db.addChecked(e.HexSignature, e.TextSignature) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// addChecked hex 非空时先核对选择器，格式不正确的签名返回 false
+func (db *SignatureDB) addChecked(hex, sig string) bool {
+	sig = strings.ReplaceAll(sig, " ", "")
+	if hex != "" && !strings.EqualFold(hex, SelectorOf(sig).Hex()) {
+		return false
+	}
+	return db.Add(sig) == nil
+}
+
+// parseSignature 把文本签名解析为方法名和参数类型，参数依次命名为 arg0、arg1...
+// 支持元组 "(address,bytes)[]" 等嵌套类型
+func parseSignature(sig string) (string, abi.Arguments, error) {
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("无效的签名 %q", sig)
+	}
+	name := sig[:open]
+	types, err := splitTypes(sig[open+1 : len(sig)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("无效的签名 %q: %w", sig, err)
+	}
+	args := make(abi.Arguments, len(types))
+	for i, t := range types {
+		m, err := marshaling(t, fmt.Sprintf("arg%d", i))
+		if err != nil {
+			return "", nil, fmt.Errorf("无效的签名 %q: %w", sig, err)
+		}
+		typ, err := abi.NewType(m.Type, "", m.Components)
+		if err != nil {
+			return "", nil, fmt.Errorf("无效的签名 %q: %w", sig, err)
+		}
+		args[i] = abi.Argument{Name: m.Name, Type: typ}
+	}
+	return name, args, nil
+}
+
+// splitTypes 按最外层的逗号拆分参数类型列表
+func splitTypes(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var (
+		out   []string
+		depth int
+		start int
+	)
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("括号不匹配")
+			}
+		case ',':
+			if depth == 0 {
+				out = append(out, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("括号不匹配")
+	}
+	return append(out, list[start:]), nil
+}
+
+// marshaling 把类型字符串转换为 abi.NewType 需要的描述，元组的字段依次命名为 field0、field1...
+func marshaling(typ, name string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(typ, "(") {
+		if typ == "" {
+			return abi.ArgumentMarshaling{}, fmt.Errorf("空的参数类型")
+		}
+		return abi.ArgumentMarshaling{Name: name, Type: typ}, nil
+	}
+	end := strings.LastIndexByte(typ, ')')
+	fields, err := splitTypes(typ[1:end])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+	m := abi.ArgumentMarshaling{Name: name, Type: "tuple" + typ[end+1:]}
+	for i, f := range fields {
+		c, err := marshaling(f, fmt.Sprintf("field%d", i))
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		m.Components = append(m.Components, c)
+	}
+	return m, nil
+}
@@ -1,12 +1,15 @@
 package common
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
 )
 
 // NewEthClient 创建以太坊客户端连接
@@ -14,6 +17,11 @@ func NewEthClient(rpcURL string) (*ethclient.Client, error) {
 	return ethclient.Dial(rpcURL)
 }
 
+// NewMultiClient 连接同一网络的多个节点，读请求自动负载均衡和故障切换，交易广播给所有健康节点
+func NewMultiClient(ctx context.Context, rpcURLs []string, opts ...multiclient.Option) (*multiclient.Client, error) {
+	return multiclient.Dial(ctx, rpcURLs, opts...)
+}
+
 // IsValidAddress 验证以太坊地址是否有效
 func IsValidAddress(address string) bool {
 	return common.IsHexAddress(address)
@@ -0,0 +1,126 @@
+// Package contract 提供合约部署、加载和调用功能
+//
+// 对应 cmd/deploy-contract、cmd/load-contract 和 cmd/execute-contract 中的逻辑。
+// 这里直接使用 ABI 和字节码工作，适合没有生成 Go 绑定的合约；
+// contracts/ 中的合约请优先使用 pkg/bindings 下的类型化绑定。
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// DefaultDeployGas 部署合约时默认的 Gas 上限
+const DefaultDeployGas = 3_000_000
+
+// ErrNoCode 指定地址上没有部署合约
+var ErrNoCode = errors.New("指定地址没有部署合约")
+
+// Deployment 合约部署结果
+type Deployment struct {
+	Address common.Address // 由部署者地址和 nonce 计算出的合约地址
+	Tx      *types.Transaction
+	Receipt *types.Receipt // 仅在等待确认后才有值
+}
+
+// Deploy 发送合约创建交易，data 为创建字节码（含已编码的构造参数）
+// opts 为空时 Gas 上限使用 DefaultDeployGas；交易发送后立即返回，不等待打包
+func Deploy(ctx context.Context, b backend.EthBackend, signer *backend.Signer, data []byte, opts *backend.TxOptions) (*Deployment, error) {
+	o := backend.TxOptions{GasLimit: DefaultDeployGas}
+	if opts != nil {
+		o = *opts
+	}
+	tx, err := backend.BuildTx(ctx, b, signer.Address, nil, nil, data, &o)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := backend.SignAndSend(ctx, b, signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("部署合约失败: %w", err)
+	}
+	return &Deployment{Address: crypto.CreateAddress(signer.Address, signed.Nonce()), Tx: signed}, nil
+}
+
+// DeployAndWait 部署合约并等待交易确认，部署失败时返回 backend.ErrTxFailed
+func DeployAndWait(ctx context.Context, b backend.EthBackend, signer *backend.Signer, data []byte, opts *backend.TxOptions) (*Deployment, error) {
+	d, err := Deploy(ctx, b, signer, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.Receipt, err = backend.WaitMined(ctx, b, d.Tx)
+	if err != nil {
+		return d, err
+	}
+	d.Address = d.Receipt.ContractAddress
+	return d, nil
+}
+
+// Contract 已加载的合约：地址 + ABI
+type Contract struct {
+	Address common.Address
+	ABI     abi.ABI
+	backend backend.EthBackend
+}
+
+// Load 检查 addr 上确实有合约代码，并用 abiJSON 创建合约实例
+// 地址上没有代码时返回 ErrNoCode
+func Load(ctx context.Context, b backend.EthBackend, addr common.Address, abiJSON string) (*Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("解析合约ABI失败: %w", err)
+	}
+	code, err := b.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约字节码失败: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoCode, addr.Hex())
+	}
+	return &Contract{Address: addr, ABI: parsed, backend: b}, nil
+}
+
+// Call 执行只读调用并按 ABI 解码返回值，blockNumber 为 nil 时在最新区块上执行
+func (c *Contract) Call(ctx context.Context, blockNumber *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+	input, err := c.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("编码 %s 调用数据失败: %w", method, err)
+	}
+	result, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &c.Address, Data: input}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("调用 %s 失败: %w", method, err)
+	}
+	out, err := c.ABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 返回数据失败: %w", method, err)
+	}
+	return out, nil
+}
+
+// Transact 发送调用合约方法的交易，value 为随交易发送的 ETH（可为 nil）
+// 交易发送后立即返回，可用 backend.WaitMined 等待确认
+func (c *Contract) Transact(ctx context.Context, signer *backend.Signer, value *big.Int, opts *backend.TxOptions, method string, args ...interface{}) (*types.Transaction, error) {
+	input, err := c.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("编码 %s 调用数据失败: %w", method, err)
+	}
+	tx, err := backend.BuildTx(ctx, c.backend, signer.Address, &c.Address, value, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := backend.SignAndSend(ctx, c.backend, signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("调用 %s 失败: %w", method, err)
+	}
+	return signed, nil
+}
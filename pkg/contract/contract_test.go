@@ -0,0 +1,84 @@
+package contract_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/basicstorage"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplestorage"
+	"github.com/duanyu/new-eth-project/pkg/contract"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestDeployLoadCallTransact(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice := chain.Accounts[0]
+
+	d, err := contract.DeployAndWait(ctx, chain, alice.Signer(), common.FromHex(simplestorage.SimpleStorageMetaData.Bin), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Address != crypto.CreateAddress(alice.Address, 0) || d.Receipt == nil {
+		t.Fatalf("deployment = %+v", d)
+	}
+
+	store, err := contract.Load(ctx, chain, d.Address, simplestorage.SimpleStorageMetaData.ABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := store.Transact(ctx, alice.Signer(), nil, nil, "set", big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.WaitMined(ctx, chain, tx); err != nil {
+		t.Fatal(err)
+	}
+	out, err := store.Call(ctx, nil, "get")
+	if err != nil || out[0].(*big.Int).Int64() != 42 {
+		t.Fatalf("get() = %v, %v; want 42", out, err)
+	}
+	if _, err := store.Call(ctx, nil, "missing"); err == nil {
+		t.Fatal("expected error for unknown method")
+	}
+}
+
+func TestLoadWithoutCode(t *testing.T) {
+	chain := simchain.New(t)
+	_, err := contract.Load(context.Background(), chain, chain.Accounts[1].Address, simplestorage.SimpleStorageMetaData.ABI)
+	if !errors.Is(err, contract.ErrNoCode) {
+		t.Fatalf("err = %v, want ErrNoCode", err)
+	}
+}
+
+func TestTransactRevert(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	addr, _, _, err := basicstorage.DeployBasicStorage(chain.Accounts[0].Opts(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := contract.Load(ctx, chain, addr, basicstorage.BasicStorageMetaData.ABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 非 owner 调用 set：估算 Gas 阶段就会失败
+	if _, err := store.Transact(ctx, chain.Accounts[1].Signer(), nil, nil, "set", big.NewInt(1)); err == nil {
+		t.Fatal("expected error for non-owner set")
+	}
+	// 指定 Gas 上限跳过估算，交易上链但执行失败
+	tx, err := store.Transact(ctx, chain.Accounts[1].Signer(), nil, &backend.TxOptions{GasLimit: 100_000}, "set", big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.WaitMined(ctx, chain, tx); !errors.Is(err, backend.ErrTxFailed) {
+		t.Fatalf("err = %v, want ErrTxFailed", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package events 提供合约事件的历史查询、实时订阅和 ABI 解码功能
+//
+// 对应 cmd/query-history-events 和 cmd/contract-events 中的逻辑。
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/middleware"
+)
+
+// ErrUnknownEvent 日志的 Topics[0] 不对应 ABI 中的任何事件
+var ErrUnknownEvent = errors.New("未知的事件签名")
+
+// Event 解码后的合约事件
+type Event struct {
+	Name   string
+	Fields map[string]interface{} // indexed 与 non-indexed 参数合并后的字段
+	Log    types.Log
+}
+
+// Decoder 按合约 ABI 解码事件日志
+type Decoder struct {
+	abi abi.ABI
+}
+
+// NewDecoder 由 ABI JSON 创建解码器
+func NewDecoder(abiJSON string) (*Decoder, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("解析合约ABI失败: %w", err)
+	}
+	return &Decoder{abi: parsed}, nil
+}
+
+// Topic 返回事件签名哈希（即日志的 Topics[0]），事件不存在时返回零值
+func (d *Decoder) Topic(name string) common.Hash {
+	return d.abi.Events[name].ID
+}
+
+// Decode 解码单条日志：non-indexed 参数从 Data 解析，indexed 参数从 Topics[1:] 解析
+func (d *Decoder) Decode(vLog types.Log) (*Event, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, fmt.Errorf("%w: 日志没有 topics", ErrUnknownEvent)
+	}
+	event, err := d.abi.EventByID(vLog.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, vLog.Topics[0].Hex())
+	}
+
+	fields := make(map[string]interface{})
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, vLog.Data); err != nil {
+		return nil, fmt.Errorf("解析事件 %s 数据失败: %w", event.Name, err)
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, vLog.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("解析事件 %s 的 indexed 参数失败: %w", event.Name, err)
+	}
+	return &Event{Name: event.Name, Fields: fields, Log: vLog}, nil
+}
+
+// History 查询 query 范围内的历史日志并逐条解码
+// decoder 为 nil 时只返回原始日志（Name 和 Fields 为空）
+//
+// 服务商对 eth_getLogs 的区块范围或结果数量有限制，遇到这类错误时
+// 会把区间一分为二分别查询，直到每一段都在限制之内
+func History(ctx context.Context, b backend.EthBackend, query ethereum.FilterQuery, decoder *Decoder) ([]*Event, error) {
+	logs, err := filterLogs(ctx, b, query)
+	if err != nil {
+		return nil, fmt.Errorf("查询事件日志失败: %w", err)
+	}
+	out := make([]*Event, 0, len(logs))
+	for _, vLog := range logs {
+		if decoder == nil {
+			out = append(out, &Event{Log: vLog})
+			continue
+		}
+		event, err := decoder.Decode(vLog)
+		if err != nil {
+			return nil, fmt.Errorf("解码区块 %d 中的日志失败: %w", vLog.BlockNumber, err)
+		}
+		out = append(out, event)
+	}
+	return out, nil
+}
+
+// filterLogs 执行 FilterLogs，范围过大时二分区间递归查询
+func filterLogs(ctx context.Context, b backend.EthBackend, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := b.FilterLogs(ctx, query)
+	if err == nil || query.BlockHash != nil || middleware.Classify(err) != middleware.KindRangeTooLarge {
+		return logs, err
+	}
+
+	var from, to uint64
+	if query.FromBlock != nil {
+		from = query.FromBlock.Uint64()
+	}
+	if query.ToBlock != nil {
+		to = query.ToBlock.Uint64()
+	} else {
+		head, herr := b.HeaderByNumber(ctx, nil)
+		if herr != nil {
+			return nil, fmt.Errorf("获取最新区块失败: %w", herr)
+		}
+		to = head.Number.Uint64()
+	}
+	if to <= from {
+		// 单个区块仍然超限，无法再拆分
+		return nil, err
+	}
+
+	mid := from + (to-from)/2
+	left, right := query, query
+	left.FromBlock, left.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(mid)
+	right.FromBlock, right.ToBlock = new(big.Int).SetUint64(mid+1), new(big.Int).SetUint64(to)
+	first, err := filterLogs(ctx, b, left)
+	if err != nil {
+		return nil, err
+	}
+	second, err := filterLogs(ctx, b, right)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
+
+// Watch 订阅满足 query 的新日志，解码后交给 handle 处理
+// 直到 ctx 取消、订阅出错或 handle 返回错误为止；decoder 为 nil 时不解码
+func Watch(ctx context.Context, b backend.EthBackend, query ethereum.FilterQuery, decoder *Decoder, handle func(*Event) error) error {
+	logs := make(chan types.Log)
+	sub, err := b.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return fmt.Errorf("创建事件订阅失败: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return fmt.Errorf("事件订阅出错: %w", err)
+		case vLog := <-logs:
+			event := &Event{Log: vLog}
+			if decoder != nil {
+				if event, err = decoder.Decode(vLog); err != nil {
+					return err
+				}
+			}
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/basicstorage"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/events"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestHistory(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1_000_000))
+
+	for i := 1; i <= 3; i++ {
+		if _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(int64(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := token.Approve(alice.Opts(), bob.Address, big.NewInt(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	decoder, err := events.NewDecoder(mytoken.MyTokenMetaData.ABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := ethereum.FilterQuery{
+		FromBlock: big.NewInt(2),
+		Addresses: []common.Address{tokenAddr},
+		Topics:    [][]common.Hash{{decoder.Topic("Transfer")}},
+	}
+	got, err := events.History(ctx, chain, query, decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d Transfer events, want 3", len(got))
+	}
+	for i, ev := range got {
+		if ev.Name != "Transfer" || ev.Fields["from"] != alice.Address || ev.Fields["to"] != bob.Address {
+			t.Fatalf("event %d = %+v", i, ev.Fields)
+		}
+		if v := ev.Fields["value"].(*big.Int); v.Int64() != int64(i+1) {
+			t.Fatalf("event %d value = %s", i, v)
+		}
+	}
+
+	// 不带主题过滤时也会返回 Approval，且可以解码
+	query.Topics = nil
+	all, err := events.History(ctx, chain, query, decoder)
+	if err != nil || len(all) != 4 || all[3].Name != "Approval" {
+		t.Fatalf("all events = %d, %v", len(all), err)
+	}
+
+	// 用不匹配的 ABI 解码时返回 ErrUnknownEvent
+	other, _ := events.NewDecoder(basicstorage.BasicStorageMetaData.ABI)
+	if _, err := events.History(ctx, chain, query, other); !errors.Is(err, events.ErrUnknownEvent) {
+		t.Fatalf("err = %v, want ErrUnknownEvent", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	alice := chain.Accounts[0]
+	addr, _, store, err := basicstorage.DeployBasicStorage(alice.Opts(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder, _ := events.NewDecoder(basicstorage.BasicStorageMetaData.ABI)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	received := make(chan *events.Event, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- events.Watch(ctx, chain, ethereum.FilterQuery{Addresses: []common.Address{addr}}, decoder, func(ev *events.Event) error {
+			received <- ev
+			return errors.New("stop")
+		})
+	}()
+
+	// 订阅建立之前发出的事件不会被推送，因此重复写入直到收到事件
+	for {
+		if _, err := store.Set(alice.Opts(), big.NewInt(7)); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case ev := <-received:
+			if ev.Name != "DataStored" || ev.Fields["by"] != alice.Address || ev.Fields["newValue"].(*big.Int).Int64() != 7 {
+				t.Fatalf("event = %+v", ev.Fields)
+			}
+			if err := <-done; err == nil || err.Error() != "stop" {
+				t.Fatalf("Watch returned %v, want handler error", err)
+			}
+			return
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for event")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestDecodeRawLog(t *testing.T) {
+	decoder, _ := events.NewDecoder(basicstorage.BasicStorageMetaData.ABI)
+	if _, err := decoder.Decode(types.Log{}); !errors.Is(err, events.ErrUnknownEvent) {
+		t.Fatalf("err = %v, want ErrUnknownEvent", err)
+	}
+}
+
+// rangeLimitedBackend 模拟服务商限制：单次 eth_getLogs 最多查询 maxRange 个区块
+type rangeLimitedBackend struct {
+	*simchain.Chain
+	maxRange uint64
+	calls    int
+}
+
+func (r *rangeLimitedBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	r.calls++
+	to := r.Head().Number.Uint64()
+	if q.ToBlock != nil {
+		to = q.ToBlock.Uint64()
+	}
+	if to-q.FromBlock.Uint64()+1 > r.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	return r.Chain.FilterLogs(ctx, q)
+}
+
+func TestHistorySplitsRange(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1_000_000))
+	for i := 1; i <= 7; i++ {
+		if _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(int64(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	limited := &rangeLimitedBackend{Chain: chain, maxRange: 2}
+	decoder, _ := events.NewDecoder(mytoken.MyTokenMetaData.ABI)
+	got, err := events.History(context.Background(), limited, ethereum.FilterQuery{
+		FromBlock: big.NewInt(2),
+		Addresses: []common.Address{tokenAddr},
+	}, decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 7 || limited.calls < 4 {
+		t.Fatalf("got %d events in %d calls, want 7 events from split queries", len(got), limited.calls)
+	}
+	for i, ev := range got {
+		if v := ev.Fields["value"].(*big.Int); v.Int64() != int64(i+1) {
+			t.Fatalf("event %d value = %s, events out of order", i, v)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package explorer
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+)
+
+// Arg 解码后的参数，值统一格式化为字符串，避免大整数在 JSON 中丢失精度
+type Arg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// CallView 解码后的 calldata
+type CallView struct {
+	Selector     string        `json:"selector"`
+	Contract     string        `json:"contract,omitempty"`  // 解码所用 ABI 的名称，来自签名库时为 "4byte"
+	Method       string        `json:"method,omitempty"`    // 无法解码时为空
+	Signature    string        `json:"signature,omitempty"` // 例如 transfer(address,uint256)
+	Args         []Arg         `json:"args,omitempty"`
+	Alternatives []string      `json:"alternatives,omitempty"` // 选择器碰撞时的其他候选签名
+	Nested       []*NestedView `json:"nested,omitempty"`       // 参数中嵌套的调用
+}
+
+// NestedView 参数中嵌套的调用，如 multicall 的子调用
+type NestedView struct {
+	Path   string          `json:"path"`
+	Target *common.Address `json:"target"`
+	Call   *CallView       `json:"call"`
+}
+
+// LogView 事件日志，能按 ABI 解码时附带事件名和参数
+type LogView struct {
+	Index    uint           `json:"logIndex"`
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     string         `json:"data"`
+	Contract string         `json:"contract,omitempty"`
+	Event    string         `json:"event,omitempty"`
+	Args     []Arg          `json:"args,omitempty"`
+}
+
+// candidates 解码 addr 相关数据时依次尝试的 ABI：先登记的合约，再通用 ABI
+func (s *Server) candidates(addr *common.Address) []*parsedABI {
+	var out []*parsedABI
+	if addr != nil {
+		if c, ok := s.contracts[*addr]; ok {
+			out = append(out, c)
+		}
+	}
+	return append(out, s.fallback...)
+}
+
+// decodeCall 用 pkg/calldata 解码 calldata，取最可能的候选；不足 4 字节（如普通转账）时返回 nil
+func (s *Server) decodeCall(to *common.Address, input []byte) *CallView {
+	call := s.calls.Decode(to, input)
+	if call == nil {
+		return nil
+	}
+	return newCallView(call)
+}
+
+func newCallView(call *calldata.Call) *CallView {
+	view := &CallView{Selector: call.Selector.Hex()}
+	best := call.Best()
+	if best == nil {
+		return view
+	}
+	view.Contract, view.Method, view.Signature = best.Source, best.Name, best.Signature
+	for _, arg := range best.Args {
+		view.Args = append(view.Args, Arg{Name: arg.Name, Type: arg.Type, Value: calldata.FormatValue(arg.Value)})
+	}
+	for _, c := range call.Candidates[1:] {
+		view.Alternatives = append(view.Alternatives, c.Signature)
+	}
+	for _, n := range best.Nested {
+		view.Nested = append(view.Nested, &NestedView{Path: n.Path, Target: n.Call.Target, Call: newCallView(n.Call)})
+	}
+	return view
+}
+
+// decodeLog 按 Topics[0] 匹配 ABI 中的事件并解码参数，参数顺序与事件定义一致
+func (s *Server) decodeLog(l *types.Log) *LogView {
+	view := &LogView{Index: l.Index, Address: l.Address, Topics: l.Topics, Data: formatBytes(l.Data)}
+	if len(l.Topics) == 0 {
+		return view
+	}
+	addr := l.Address
+	for _, c := range s.candidates(&addr) {
+		event, err := c.abi.EventByID(l.Topics[0])
+		if err != nil {
+			continue
+		}
+		fields := make(map[string]interface{})
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, l.Data); err != nil {
+			continue
+		}
+		var indexed abi.Arguments
+		for _, arg := range event.Inputs {
+			if arg.Indexed {
+				indexed = append(indexed, arg)
+			}
+		}
+		// ERC20 与 ERC721 的 Transfer 签名相同但 indexed 参数个数不同，个数对不上时换下一个 ABI
+		if len(indexed) != len(l.Topics)-1 || abi.ParseTopicsIntoMap(fields, indexed, l.Topics[1:]) != nil {
+			continue
+		}
+		values := make([]interface{}, len(event.Inputs))
+		for i, arg := range event.Inputs {
+			values[i] = fields[arg.Name]
+		}
+		view.Contract, view.Event = c.name, event.Name
+		view.Args = formatArgs(event.Inputs, values)
+		break
+	}
+	return view
+}
+
+func formatArgs(args abi.Arguments, values []interface{}) []Arg {
+	out := make([]Arg, len(args))
+	for i, arg := range args {
+		out[i] = Arg{Name: arg.Name, Type: arg.Type.String(), Value: calldata.FormatValue(values[i])}
+	}
+	return out
+}
+
+func formatBytes(b []byte) string {
+	return hexutil.Encode(b)
+}
@@ -0,0 +1,238 @@
+// Package explorer 提供区块浏览器风格的 HTTP 服务：REST 接口和服务端渲染的简易网页
+//
+// 接口（同一路径按 Accept 头返回 JSON 或 HTML，也可用 ?format=json|html 指定）：
+//
+//	/block/{number|hash|latest}   区块及其交易列表
+//	/tx/{hash}                    交易、收据、解码后的 calldata 和事件日志
+//	/address/{addr}               余额、nonce、是否为合约（ERC20 合约附带代币信息）
+//	/token/{addr}?holder={addr}   ERC20 代币信息，可选查询某个地址的余额
+//	/search?q=...                 按输入的长度跳转到区块、交易或地址页面
+//
+// 数据全部通过 pkg/query 等功能库实时从节点查询，不建立索引，因此不提供地址的交易列表。
+// 用 WithContract 登记已知合约的 ABI 后，发往该合约的交易和它产生的日志会按 ABI 解码；
+// 其他合约按 Fallback 中的通用 ABI（默认为 ERC20）尝试解码，calldata 还会查询
+// pkg/calldata 的内置 4 字节签名库（可用 WithSignatures 替换）。
+//
+//	srv, _ := explorer.New(client, explorer.WithContract(addr, "MyToken", mytoken.MyTokenMetaData.ABI))
+//	http.ListenAndServe("127.0.0.1:8080", srv)
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+)
+
+// ContractABI 已知合约的名称和 ABI JSON
+type ContractABI struct {
+	Name string
+	ABI  string
+}
+
+// Config 浏览器配置
+type Config struct {
+	Contracts  map[common.Address]ContractABI // 按地址登记的合约 ABI
+	Fallback   []ContractABI                  // 地址未登记时依次尝试的通用 ABI
+	Timeout    time.Duration                  // 单个页面查询节点的超时时间
+	Signatures *calldata.SignatureDB          // 解码 calldata 的 4 字节签名库，nil 表示不使用
+}
+
+// DefaultConfig 返回默认配置：用 ERC20 ABI 解码未登记的合约，查询超时 30 秒
+// MyToken 的 ABI 只包含标准 ERC20 方法和事件，作为通用 ERC20 ABI 使用
+func DefaultConfig() *Config {
+	return &Config{
+		Contracts:  make(map[common.Address]ContractABI),
+		Fallback:   []ContractABI{{Name: "ERC20", ABI: mytoken.MyTokenMetaData.ABI}},
+		Timeout:    30 * time.Second,
+		Signatures: calldata.DefaultSignatures(),
+	}
+}
+
+// Option 修改浏览器配置
+type Option func(*Config)
+
+// WithContract 登记合约的名称和 ABI JSON
+func WithContract(addr common.Address, name, abiJSON string) Option {
+	return func(c *Config) { c.Contracts[addr] = ContractABI{Name: name, ABI: abiJSON} }
+}
+
+// WithFallback 替换地址未登记时使用的通用 ABI
+func WithFallback(abis ...ContractABI) Option { return func(c *Config) { c.Fallback = abis } }
+
+// WithSignatures 替换解码 calldata 使用的签名库，例如导入了 4byte.directory 数据的签名库
+func WithSignatures(db *calldata.SignatureDB) Option { return func(c *Config) { c.Signatures = db } }
+
+// WithTimeout 设置单个页面查询节点的超时时间
+func WithTimeout(d time.Duration) Option { return func(c *Config) { c.Timeout = d } }
+
+// parsedABI 解析后的 ABI
+type parsedABI struct {
+	name string
+	abi  abi.ABI
+}
+
+// Server 区块浏览器，实现 http.Handler
+type Server struct {
+	backend   backend.EthBackend
+	cfg       Config
+	contracts map[common.Address]*parsedABI // 解码日志使用
+	fallback  []*parsedABI
+	calls     *calldata.Decoder // 解码 calldata 使用
+}
+
+// New 创建浏览器，ABI 无法解析时返回错误
+func New(b backend.EthBackend, opts ...Option) (*Server, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	s := &Server{
+		backend:   b,
+		cfg:       *cfg,
+		contracts: make(map[common.Address]*parsedABI),
+		calls:     calldata.NewDecoder(cfg.Signatures),
+	}
+	for addr, c := range cfg.Contracts {
+		parsed, err := parseABI(c)
+		if err != nil {
+			return nil, err
+		}
+		s.contracts[addr] = parsed
+		s.calls.AddContract(addr, c.Name, c.ABI)
+	}
+	for _, c := range cfg.Fallback {
+		parsed, err := parseABI(c)
+		if err != nil {
+			return nil, err
+		}
+		s.fallback = append(s.fallback, parsed)
+		s.calls.AddABI(c.Name, c.ABI)
+	}
+	return s, nil
+}
+
+func parseABI(c ContractABI) (*parsedABI, error) {
+	parsed, err := abi.JSON(strings.NewReader(c.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 的 ABI 失败: %w", c.Name, err)
+	}
+	return &parsedABI{name: c.Name, abi: parsed}, nil
+}
+
+// errBadRequest 请求参数错误，返回 400
+var errBadRequest = errors.New("参数错误")
+
+// ServeHTTP 按路径的第一段分发请求
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "只支持 GET 请求", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.cfg.Timeout)
+	defer cancel()
+
+	kind, id, _ := strings.Cut(strings.Trim(r.URL.Path, "/"), "/")
+	var (
+		view interface{}
+		err  error
+	)
+	switch kind {
+	case "":
+		http.Redirect(w, r, "/block/latest", http.StatusFound)
+		return
+	case "search":
+		http.Redirect(w, r, s.searchTarget(ctx, strings.TrimSpace(r.URL.Query().Get("q"))), http.StatusFound)
+		return
+	case "block":
+		view, err = s.Block(ctx, id)
+	case "tx":
+		view, err = s.Transaction(ctx, id)
+	case "address":
+		view, err = s.Address(ctx, id)
+	case "token":
+		view, err = s.Token(ctx, id, r.URL.Query().Get("holder"))
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	status := http.StatusOK
+	if err != nil {
+		status = errorStatus(err)
+		view = &ErrorView{Status: status, Error: err.Error()}
+		kind = "error"
+	}
+	if wantsHTML(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		if err := pages.ExecuteTemplate(w, kind, view); err != nil {
+			fmt.Fprintf(w, "渲染页面失败: %v", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(view)
+}
+
+// ErrorView 错误响应
+type ErrorView struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+// errorStatus 参数错误返回 400，数据或合约不存在返回 404，其他（节点出错）返回 502
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ethereum.NotFound), errors.Is(err, bind.ErrNoCode):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// wantsHTML ?format= 优先，否则浏览器（Accept 包含 text/html）返回网页
+func wantsHTML(r *http.Request) bool {
+	switch r.URL.Query().Get("format") {
+	case "json":
+		return false
+	case "html":
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// searchTarget 按输入长度判断类型：66 个字符为交易或区块哈希，42 个字符为地址，其他按区块号处理
+func (s *Server) searchTarget(ctx context.Context, q string) string {
+	switch {
+	case len(q) == 66 && strings.HasPrefix(q, "0x"):
+		if _, _, err := s.backend.TransactionByHash(ctx, common.HexToHash(q)); errors.Is(err, ethereum.NotFound) {
+			return "/block/" + q
+		}
+		return "/tx/" + q
+	case common.IsHexAddress(q):
+		return "/address/" + q
+	case q == "":
+		return "/block/latest"
+	default:
+		return "/block/" + url.PathEscape(q)
+	}
+}
@@ -0,0 +1,144 @@
+package explorer_test
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/explorer"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// get 请求 path 并把 JSON 响应解码到 out，返回 HTTP 状态码
+func get(t *testing.T, base, path string, out interface{}) int {
+	t.Helper()
+	resp, err := http.Get(base + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func setup(t *testing.T) (*simchain.Chain, common.Address, common.Hash, string) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "Test Token", "TT", 18, big.NewInt(1000))
+	tx, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(250))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := explorer.New(chain, explorer.WithContract(tokenAddr, "MyToken", mytoken.MyTokenMetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return chain, tokenAddr, tx.Hash(), ts.URL
+}
+
+func TestTransactionDecoded(t *testing.T) {
+	chain, tokenAddr, txHash, url := setup(t)
+	bob := chain.Accounts[1]
+
+	var tx explorer.TxView
+	if code := get(t, url, "/tx/"+txHash.Hex(), &tx); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if tx.Status == nil || *tx.Status != 1 || tx.Call == nil || tx.Call.Contract != "MyToken" || tx.Call.Method != "transfer" {
+		t.Fatalf("tx = %+v, call = %+v", tx, tx.Call)
+	}
+	if len(tx.Call.Args) != 2 || tx.Call.Args[0].Value != bob.Address.Hex() || tx.Call.Args[1].Value != "250" {
+		t.Fatalf("args = %+v", tx.Call.Args)
+	}
+	if len(tx.Logs) != 1 || tx.Logs[0].Event != "Transfer" || tx.Logs[0].Address != tokenAddr {
+		t.Fatalf("logs = %+v", tx.Logs)
+	}
+	if args := tx.Logs[0].Args; len(args) != 3 || args[0].Name != "from" || args[1].Value != bob.Address.Hex() || args[2].Value != "250" {
+		t.Fatalf("log args = %+v", args)
+	}
+
+	// 区块页面列出交易和解码出的方法名
+	var block explorer.BlockView
+	if code := get(t, url, "/block/"+new(big.Int).SetUint64(*tx.BlockNumber).String(), &block); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(block.Transactions) != 1 || block.Transactions[0].Hash != txHash || block.Transactions[0].Method != "transfer" {
+		t.Fatalf("block = %+v", block)
+	}
+	var latest explorer.BlockView
+	if get(t, url, "/block/latest", &latest); latest.Hash != block.Hash {
+		t.Fatalf("latest = %s, want %s", latest.Hash.Hex(), block.Hash.Hex())
+	}
+}
+
+func TestAddressAndToken(t *testing.T) {
+	chain, tokenAddr, _, url := setup(t)
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	var eoa explorer.AddressView
+	get(t, url, "/address/"+alice.Address.Hex(), &eoa)
+	if eoa.IsContract || eoa.Nonce != 2 || eoa.Balance != chain.Balance(alice.Address).String() {
+		t.Fatalf("eoa = %+v", eoa)
+	}
+
+	var contract explorer.AddressView
+	get(t, url, "/address/"+tokenAddr.Hex(), &contract)
+	if !contract.IsContract || contract.Contract != "MyToken" || contract.Token == nil || contract.Token.Symbol != "TT" {
+		t.Fatalf("contract = %+v", contract)
+	}
+
+	var token explorer.TokenView
+	get(t, url, "/token/"+tokenAddr.Hex()+"?holder="+bob.Address.Hex(), &token)
+	instance, _ := mytoken.NewMyToken(tokenAddr, chain)
+	supply, _ := instance.TotalSupply(&bind.CallOpts{})
+	if token.TotalSupply != supply.String() || token.Holder == nil || token.Holder.Raw != "250" {
+		t.Fatalf("token = %+v, holder = %+v", token, token.Holder)
+	}
+}
+
+func TestErrorsAndHTML(t *testing.T) {
+	chain, _, txHash, url := setup(t)
+
+	var e explorer.ErrorView
+	if code := get(t, url, "/block/abc", &e); code != http.StatusBadRequest || e.Error == "" {
+		t.Fatalf("bad block: %d %+v", code, e)
+	}
+	if code := get(t, url, "/tx/"+common.Hash{0x01}.Hex(), &e); code != http.StatusNotFound {
+		t.Fatalf("missing tx: %d %+v", code, e)
+	}
+	if code := get(t, url, "/token/"+chain.Accounts[1].Address.Hex(), &e); code != http.StatusNotFound {
+		t.Fatalf("token without code: %d %+v", code, e)
+	}
+
+	// 浏览器请求返回网页，搜索交易哈希跳转到交易页面
+	req, _ := http.NewRequest(http.MethodGet, url+"/search?q="+txHash.Hex(), nil)
+	req.Header.Set("Accept", "text/html")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || resp.Request.URL.Path != "/tx/"+txHash.Hex() {
+		t.Fatalf("search: %d %s", resp.StatusCode, resp.Request.URL)
+	}
+	for _, want := range []string{"MyToken.transfer(address,uint256)", "MyToken.Transfer", "成功"} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("page missing %q:\n%s", want, body)
+		}
+	}
+}
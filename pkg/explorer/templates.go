@@ -0,0 +1,107 @@
+package explorer
+
+import "html/template"
+
+// pages 服务端渲染的网页模板，模板名与路径的第一段一致
+var pages = template.Must(template.New("explorer").Funcs(template.FuncMap{
+	"status": statusText,
+	"uint":   formatUint,
+}).Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="zh">
+<head>
+<meta charset="utf-8">
+<title>{{.}} - 区块浏览器</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 4px 8px; text-align: left; font-family: monospace; }
+th { background: #f4f4f4; }
+</style>
+</head>
+<body>
+<form action="/search"><a href="/block/latest">最新区块</a>
+<input name="q" size="70" placeholder="区块号 / 区块哈希 / 交易哈希 / 地址"> <button>搜索</button></form>
+<h2>{{.}}</h2>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "args"}}{{if .}}<table>{{range .}}<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Value}}</td></tr>{{end}}</table>{{end}}{{end}}
+
+{{define "call"}}<p>{{if .Method}}{{.Contract}}.{{.Signature}}{{else}}未知方法 {{.Selector}}{{end}}</p>
+{{with .Alternatives}}<p>其他候选签名（选择器碰撞）: {{range .}}{{.}} {{end}}</p>{{end}}
+{{template "args" .Args}}
+{{range .Nested}}<div style="margin-left: 2em"><p>↳ {{.Path}} 嵌套调用 {{with .Target}}{{.Hex}}{{end}}</p>{{template "call" .Call}}</div>{{end}}{{end}}
+
+{{define "block"}}{{template "header" "区块"}}
+<table>
+<tr><th>区块号</th><td>{{.Number}}</td></tr>
+<tr><th>哈希</th><td>{{.Hash.Hex}}</td></tr>
+<tr><th>父区块</th><td><a href="/block/{{.ParentHash.Hex}}">{{.ParentHash.Hex}}</a></td></tr>
+<tr><th>时间</th><td>{{.TimeUTC}}（{{.Time}}）</td></tr>
+<tr><th>出块地址</th><td><a href="/address/{{.Miner.Hex}}">{{.Miner.Hex}}</a></td></tr>
+<tr><th>Gas</th><td>{{.GasUsed}} / {{.GasLimit}}</td></tr>
+{{if .BaseFee}}<tr><th>Base Fee (Wei)</th><td>{{.BaseFee}}</td></tr>{{end}}
+</table>
+<h3>交易（{{len .Transactions}}）</h3>
+<table>
+<tr><th>哈希</th><th>发送方</th><th>接收方</th><th>金额 (Wei)</th><th>方法</th><th>状态</th></tr>
+{{range .Transactions}}<tr>
+<td><a href="/tx/{{.Hash.Hex}}">{{.Hash.Hex}}</a></td>
+<td><a href="/address/{{.From.Hex}}">{{.From.Hex}}</a></td>
+<td>{{with .To}}<a href="/address/{{.Hex}}">{{.Hex}}</a>{{else}}创建合约{{end}}</td>
+<td>{{.Value}}</td><td>{{.Method}}</td><td>{{status .Status}}</td>
+</tr>{{end}}
+</table>
+{{template "footer"}}{{end}}
+
+{{define "tx"}}{{template "header" "交易"}}
+<table>
+<tr><th>哈希</th><td>{{.Hash.Hex}}</td></tr>
+<tr><th>状态</th><td>{{if .Pending}}等待打包{{else}}{{with .Status}}{{status .}}{{end}}{{end}}</td></tr>
+{{with .BlockHash}}<tr><th>区块</th><td><a href="/block/{{.Hex}}">{{uint $.BlockNumber}}</a></td></tr>{{end}}
+<tr><th>发送方</th><td><a href="/address/{{.From.Hex}}">{{.From.Hex}}</a></td></tr>
+<tr><th>接收方</th><td>{{with .To}}<a href="/address/{{.Hex}}">{{.Hex}}</a>{{else}}创建合约 {{with $.ContractAddress}}<a href="/address/{{.Hex}}">{{.Hex}}</a>{{end}}{{end}}</td></tr>
+<tr><th>金额 (Wei)</th><td>{{.Value}}</td></tr>
+<tr><th>Nonce</th><td>{{.Nonce}}</td></tr>
+<tr><th>Gas</th><td>{{uint .GasUsed}} / {{.Gas}}</td></tr>
+<tr><th>Gas 价格 (Wei)</th><td>{{.GasPrice}}</td></tr>
+</table>
+<h3>输入数据</h3>
+{{with .Call}}{{template "call" .}}{{end}}
+<pre style="white-space: pre-wrap; word-break: break-all">{{.Input}}</pre>
+<h3>事件日志（{{len .Logs}}）</h3>
+{{range .Logs}}<p>#{{.Index}} <a href="/address/{{.Address.Hex}}">{{.Address.Hex}}</a> {{if .Event}}{{.Contract}}.{{.Event}}{{end}}</p>
+{{if .Event}}{{template "args" .Args}}{{else}}<table>{{range .Topics}}<tr><th>topic</th><td>{{.Hex}}</td></tr>{{end}}<tr><th>data</th><td>{{.Data}}</td></tr></table>{{end}}
+{{end}}
+{{template "footer"}}{{end}}
+
+{{define "address"}}{{template "header" "地址"}}
+<table>
+<tr><th>地址</th><td>{{.Address.Hex}}</td></tr>
+<tr><th>余额</th><td>{{.Ether}} ETH（{{.Balance}} Wei）</td></tr>
+<tr><th>Nonce</th><td>{{.Nonce}}</td></tr>
+<tr><th>类型</th><td>{{if .IsContract}}合约（代码 {{.CodeSize}} 字节）{{if .Contract}} {{.Contract}}{{end}}{{else}}外部账户{{end}}</td></tr>
+{{with .Token}}<tr><th>代币</th><td><a href="/token/{{.Address.Hex}}">{{.Name}} ({{.Symbol}})</a></td></tr>{{end}}
+</table>
+{{template "footer"}}{{end}}
+
+{{define "token"}}{{template "header" "代币"}}
+<table>
+<tr><th>地址</th><td><a href="/address/{{.Address.Hex}}">{{.Address.Hex}}</a></td></tr>
+<tr><th>名称</th><td>{{.Name}}</td></tr>
+<tr><th>符号</th><td>{{.Symbol}}</td></tr>
+<tr><th>精度</th><td>{{.Decimals}}</td></tr>
+<tr><th>总供应量</th><td>{{.Supply}}</td></tr>
+{{with .Holder}}<tr><th>{{.Address.Hex}} 的余额</th><td>{{.Value}}</td></tr>{{end}}
+</table>
+<form><input type="hidden" name="format" value="html"><input name="holder" size="50" placeholder="查询持有人余额"> <button>查询</button></form>
+{{template "footer"}}{{end}}
+
+{{define "error"}}{{template "header" "出错了"}}
+<p>{{.Status}}: {{.Error}}</p>
+{{template "footer"}}{{end}}
+`))
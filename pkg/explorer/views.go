@@ -0,0 +1,295 @@
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/query"
+)
+
+// BlockView 区块页面
+type BlockView struct {
+	Number       uint64         `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	Time         uint64         `json:"timestamp"`
+	TimeUTC      string         `json:"time"`
+	Miner        common.Address `json:"miner"`
+	GasUsed      uint64         `json:"gasUsed"`
+	GasLimit     uint64         `json:"gasLimit"`
+	BaseFee      string         `json:"baseFeePerGas,omitempty"` // Wei，伦敦升级之前的区块为空
+	Transactions []*TxSummary   `json:"transactions"`
+}
+
+// TxSummary 区块页面中的交易摘要
+type TxSummary struct {
+	Hash   common.Hash     `json:"hash"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`               // 创建合约时为 null
+	Value  string          `json:"value"`            // Wei
+	Method string          `json:"method,omitempty"` // 解码出的方法名，无法解码时为 4 字节选择器
+	Status uint64          `json:"status"`
+}
+
+// TxView 交易页面
+type TxView struct {
+	Hash            common.Hash     `json:"hash"`
+	Pending         bool            `json:"pending"`
+	BlockNumber     *uint64         `json:"blockNumber"`
+	BlockHash       *common.Hash    `json:"blockHash"`
+	Index           *uint           `json:"transactionIndex"`
+	From            common.Address  `json:"from"`
+	To              *common.Address `json:"to"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"` // 创建合约的交易
+	Value           string          `json:"value"`
+	Nonce           uint64          `json:"nonce"`
+	Gas             uint64          `json:"gas"`
+	GasPrice        string          `json:"gasPrice"`
+	GasUsed         *uint64         `json:"gasUsed"`
+	Status          *uint64         `json:"status"`
+	Input           string          `json:"input"`
+	Call            *CallView       `json:"call,omitempty"` // 按 ABI 解码的 calldata
+	Logs            []*LogView      `json:"logs"`
+}
+
+// AddressView 地址页面
+type AddressView struct {
+	Address    common.Address `json:"address"`
+	Balance    string         `json:"balance"` // Wei
+	Ether      string         `json:"ether"`
+	Nonce      uint64         `json:"nonce"`
+	IsContract bool           `json:"isContract"`
+	CodeSize   int            `json:"codeSize"`
+	Contract   string         `json:"contract,omitempty"` // 登记的合约名称
+	Token      *TokenView     `json:"token,omitempty"`    // 合约实现了 ERC20 元数据方法时
+}
+
+// TokenView 代币页面
+type TokenView struct {
+	Address     common.Address `json:"address"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Decimals    uint8          `json:"decimals"`
+	TotalSupply string         `json:"totalSupply"` // 最小单位
+	Supply      string         `json:"supply"`      // 按精度换算
+	Holder      *HolderView    `json:"holder,omitempty"`
+}
+
+// HolderView 某个地址持有的代币数量
+type HolderView struct {
+	Address common.Address `json:"address"`
+	Raw     string         `json:"raw"`   // 最小单位
+	Value   string         `json:"value"` // 按精度换算
+}
+
+// Block 查询区块，id 为十进制或 0x 开头的十六进制区块号、区块哈希或 "latest"
+func (s *Server) Block(ctx context.Context, id string) (*BlockView, error) {
+	var (
+		info *query.BlockInfo
+		err  error
+	)
+	switch {
+	case id == "" || id == "latest":
+		info, err = query.Block(ctx, s.backend, nil)
+	case len(id) == 66 && strings.HasPrefix(id, "0x"):
+		info, err = query.BlockByHash(ctx, s.backend, common.HexToHash(id))
+	default:
+		number, ok := new(big.Int).SetString(id, 0)
+		if !ok || number.Sign() < 0 {
+			return nil, fmt.Errorf("%w: 无效的区块号 %q", errBadRequest, id)
+		}
+		info, err = query.Block(ctx, s.backend, number)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	view := &BlockView{
+		Number:     info.Number,
+		Hash:       info.Hash,
+		ParentHash: info.ParentHash,
+		Time:       info.Time,
+		TimeUTC:    formatTime(info.Time),
+		Miner:      info.Coinbase,
+		GasUsed:    info.GasUsed,
+		GasLimit:   info.GasLimit,
+	}
+	if baseFee := info.Block.BaseFee(); baseFee != nil {
+		view.BaseFee = baseFee.String()
+	}
+	// 直接使用已取得的区块：交易不再逐笔查询，发送方共用一个签名器恢复，
+	// 收据优先走 eth_getBlockReceipts，其次是批量请求
+	txs, err := query.BlockTransactionsOf(ctx, s.backend, info.Block)
+	if err != nil {
+		return nil, err
+	}
+	view.Transactions = make([]*TxSummary, len(txs))
+	for i, tx := range txs {
+		summary := &TxSummary{Hash: tx.Tx.Hash(), From: tx.From, To: tx.Tx.To(), Value: tx.Tx.Value().String()}
+		if tx.Receipt != nil {
+			summary.Status = tx.Receipt.Status
+		}
+		if call := s.decodeCall(tx.Tx.To(), tx.Tx.Data()); call != nil {
+			summary.Method = call.Method
+			if summary.Method == "" {
+				summary.Method = call.Selector
+			}
+		}
+		view.Transactions[i] = summary
+	}
+	return view, nil
+}
+
+// Transaction 查询交易、收据，并解码 calldata 和日志
+func (s *Server) Transaction(ctx context.Context, id string) (*TxView, error) {
+	if len(id) != 66 || !strings.HasPrefix(id, "0x") {
+		return nil, fmt.Errorf("%w: 无效的交易哈希 %q", errBadRequest, id)
+	}
+	info, err := query.Transaction(ctx, s.backend, common.HexToHash(id))
+	if err != nil {
+		return nil, err
+	}
+	tx := info.Tx
+	view := &TxView{
+		Hash:     tx.Hash(),
+		Pending:  info.IsPending,
+		From:     info.From,
+		To:       tx.To(),
+		Value:    tx.Value().String(),
+		Nonce:    tx.Nonce(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice().String(),
+		Input:    formatBytes(tx.Data()),
+		Call:     s.decodeCall(tx.To(), tx.Data()),
+		Logs:     []*LogView{},
+	}
+	if r := info.Receipt; r != nil {
+		number, hash, index := r.BlockNumber.Uint64(), r.BlockHash, r.TransactionIndex
+		view.BlockNumber, view.BlockHash, view.Index = &number, &hash, &index
+		view.GasUsed, view.Status = &r.GasUsed, &r.Status
+		if r.EffectiveGasPrice != nil {
+			view.GasPrice = r.EffectiveGasPrice.String()
+		}
+		if tx.To() == nil {
+			view.ContractAddress = &r.ContractAddress
+		}
+		for _, l := range r.Logs {
+			view.Logs = append(view.Logs, s.decodeLog(l))
+		}
+	}
+	return view, nil
+}
+
+// Address 查询地址的余额、nonce 和代码；合约实现了 ERC20 元数据方法时附带代币信息
+func (s *Server) Address(ctx context.Context, id string) (*AddressView, error) {
+	if !common.IsHexAddress(id) {
+		return nil, fmt.Errorf("%w: 无效的地址 %q", errBadRequest, id)
+	}
+	addr := common.HexToAddress(id)
+	balance, err := query.Balance(ctx, s.backend, addr, nil)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := s.backend.NonceAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("查询 %s 的 nonce 失败: %w", addr.Hex(), err)
+	}
+	code, err := s.backend.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("查询 %s 的代码失败: %w", addr.Hex(), err)
+	}
+	view := &AddressView{
+		Address:    addr,
+		Balance:    balance.Wei.String(),
+		Ether:      balance.Ether().Text('f', 18),
+		Nonce:      nonce,
+		IsContract: len(code) > 0,
+		CodeSize:   len(code),
+	}
+	if c, ok := s.contracts[addr]; ok {
+		view.Contract = c.name
+	}
+	if view.IsContract {
+		// 不是 ERC20 合约时调用会失败，忽略即可
+		view.Token, _ = s.token(ctx, addr)
+	}
+	return view, nil
+}
+
+// Token 查询 ERC20 代币信息，holder 非空时同时查询该地址的余额
+func (s *Server) Token(ctx context.Context, id, holder string) (*TokenView, error) {
+	if !common.IsHexAddress(id) {
+		return nil, fmt.Errorf("%w: 无效的代币地址 %q", errBadRequest, id)
+	}
+	if holder != "" && !common.IsHexAddress(holder) {
+		return nil, fmt.Errorf("%w: 无效的持有人地址 %q", errBadRequest, holder)
+	}
+	view, err := s.token(ctx, common.HexToAddress(id))
+	if err != nil {
+		return nil, err
+	}
+	if holder != "" {
+		balance, err := query.TokenBalance(ctx, s.backend, view.Address, common.HexToAddress(holder))
+		if err != nil {
+			return nil, err
+		}
+		view.Holder = &HolderView{Address: balance.Holder, Raw: balance.Raw.String(), Value: balance.Value().Text('f', int(balance.Decimals))}
+	}
+	return view, nil
+}
+
+// token 查询代币的名称、符号、精度和总供应量
+func (s *Server) token(ctx context.Context, addr common.Address) (*TokenView, error) {
+	instance, err := mytoken.NewMyToken(addr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建代币合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	view := &TokenView{Address: addr}
+	if view.Name, err = instance.Name(opts); err != nil {
+		return nil, fmt.Errorf("查询代币名称失败: %w", err)
+	}
+	if view.Symbol, err = instance.Symbol(opts); err != nil {
+		return nil, fmt.Errorf("查询代币符号失败: %w", err)
+	}
+	if view.Decimals, err = instance.Decimals(opts); err != nil {
+		return nil, fmt.Errorf("查询代币小数位数失败: %w", err)
+	}
+	supply, err := instance.TotalSupply(opts)
+	if err != nil {
+		return nil, fmt.Errorf("查询代币总供应量失败: %w", err)
+	}
+	view.TotalSupply = supply.String()
+	view.Supply = query.ToUnit(supply, int(view.Decimals)).Text('f', int(view.Decimals))
+	return view, nil
+}
+
+// formatTime 区块时间戳转换为 UTC 时间
+func formatTime(ts uint64) string {
+	return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+}
+
+// statusText 收据状态的文字说明
+func statusText(status uint64) string {
+	if status == types.ReceiptStatusSuccessful {
+		return "成功"
+	}
+	return "失败"
+}
+
+// formatUint 供模板使用，nil 显示为 "-"
+func formatUint(v *uint64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatUint(*v, 10)
+}
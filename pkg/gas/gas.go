@@ -0,0 +1,416 @@
+// Package gas 基于 eth_feeHistory 生成 Gas 费用报告和短期预测
+//
+// EIP-1559 之后交易费用分为两部分：
+//   - 基础费（base fee）：由协议根据上一个区块的 Gas 使用率决定，高于目标（50%）时上涨、
+//     低于时下降，每个区块最多变化 12.5%，会被销毁
+//   - 优先费（priority fee / tip）：付给出块者的小费，出价越高越容易被优先打包
+//
+// Report 读取最近若干区块的基础费、Gas 使用率和各百分位的优先费，
+// 给出下一个区块的基础费（由协议规则精确算出）、按平均使用率外推的后续基础费，
+// 以及 slow / normal / fast 三档的 maxPriorityFeePerGas 和 maxFeePerGas；
+// Estimate 再为具体的调用估算 Gas 上限（含安全余量）和各档的总费用。
+//
+//	report, err := gas.NewReport(ctx, client)
+//	fmt.Print(report)
+//	est, err := report.Estimate(ctx, client, msg)
+//	fmt.Print(est)
+package gas
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/query"
+)
+
+// ErrNoBaseFee 区块没有基础费，链还没有启用 EIP-1559
+var ErrNoBaseFee = errors.New("区块没有基础费（链未启用 EIP-1559）")
+
+// 默认参数
+const (
+	DefaultBlocks   = 20  // 统计的区块数
+	DefaultMargin   = 0.2 // Gas 上限在估算值上增加的比例
+	DefaultForecast = 5   // 预测的区块数
+)
+
+// EIP-1559 参数：Gas 目标为上限的 1/2，基础费每个区块最多变化 1/8
+const (
+	elasticityMultiplier     = 2
+	baseFeeChangeDenominator = 8
+)
+
+// Tier 费用档位
+type Tier struct {
+	Name       string
+	Percentile float64 // 取最近区块优先费的哪个百分位
+	Blocks     int     // 期望在几个区块内被打包，maxFeePerGas 按这段时间内基础费的最大涨幅预留
+}
+
+// DefaultTiers 默认的三个档位
+var DefaultTiers = []Tier{
+	{Name: "slow", Percentile: 10, Blocks: 6},
+	{Name: "normal", Percentile: 50, Blocks: 3},
+	{Name: "fast", Percentile: 90, Blocks: 1},
+}
+
+// Config 报告配置
+type Config struct {
+	Blocks   uint64  // 统计最近多少个区块
+	Tiers    []Tier  // 费用档位
+	Margin   float64 // Estimate 在估算值上增加的比例
+	Forecast int     // 预测多少个区块的基础费
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() Config {
+	return Config{Blocks: DefaultBlocks, Tiers: DefaultTiers, Margin: DefaultMargin, Forecast: DefaultForecast}
+}
+
+// Option 修改 Config 的函数
+type Option func(*Config)
+
+// WithBlocks 设置统计的区块数
+func WithBlocks(n uint64) Option { return func(c *Config) { c.Blocks = n } }
+
+// WithTiers 使用自定义档位
+func WithTiers(tiers ...Tier) Option { return func(c *Config) { c.Tiers = tiers } }
+
+// WithMargin 设置 Gas 上限的安全余量，如 0.2 表示在估算值上增加 20%
+func WithMargin(m float64) Option { return func(c *Config) { c.Margin = m } }
+
+// WithForecast 设置预测的区块数
+func WithForecast(n int) Option { return func(c *Config) { c.Forecast = n } }
+
+// BlockFee 单个区块的费用数据
+type BlockFee struct {
+	Number       uint64
+	BaseFee      *big.Int
+	GasUsedRatio float64
+	Rewards      []*big.Int // 与 Config.Tiers 一一对应的优先费百分位；没有 eth_feeHistory 时为 nil
+}
+
+// TierFee 某个档位建议的 EIP-1559 费用参数
+type TierFee struct {
+	Tier
+	PriorityFee *big.Int // maxPriorityFeePerGas
+	MaxFee      *big.Int // maxFeePerGas：Blocks 个区块后基础费的上限 + 优先费
+	Expected    *big.Int // 预计实际单价：下一个区块的基础费 + 优先费
+}
+
+// Trend Gas 使用率的变化趋势
+type Trend string
+
+const (
+	TrendRising  Trend = "上升"
+	TrendFalling Trend = "下降"
+	TrendFlat    Trend = "平稳"
+)
+
+// Report Gas 费用报告
+type Report struct {
+	Latest      uint64
+	BaseFee     *big.Int // 最新区块的基础费
+	NextBaseFee *big.Int // 下一个区块的基础费，由协议规则确定
+	Blocks      []*BlockFee
+	AvgGasUsed  float64    // 平均 Gas 使用率
+	Trend       Trend      // 后一半区块相对前一半的使用率变化
+	Forecast    []*big.Int // 从下一个区块起的基础费预测，按平均使用率外推
+	Tiers       []*TierFee
+	FeeHistory  bool    // 优先费是否来自 eth_feeHistory；否则所有档位都使用 SuggestGasTipCap
+	Margin      float64 // 供 Estimate 使用
+}
+
+// NewReport 读取最近的区块费用数据并生成报告
+func NewReport(ctx context.Context, b backend.EthBackend, opts ...Option) (*Report, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Blocks == 0 {
+		cfg.Blocks = 1
+	}
+	r := &Report{Margin: cfg.Margin}
+
+	// ===== 读取区块费用数据 =====
+	// eth_feeHistory 要求百分位递增，这里排序后再映射回档位顺序
+	percentiles := make([]float64, len(cfg.Tiers))
+	for i, t := range cfg.Tiers {
+		percentiles[i] = t.Percentile
+	}
+	sorted := append([]float64(nil), percentiles...)
+	sort.Float64s(sorted)
+
+	if fh, ok := b.(backend.FeeHistoryReader); ok {
+		history, err := fh.FeeHistory(ctx, cfg.Blocks, nil, sorted)
+		if err != nil {
+			return nil, fmt.Errorf("获取 feeHistory 失败: %w", err)
+		}
+		if len(history.BaseFee) == 0 || history.BaseFee[0] == nil || history.BaseFee[0].Sign() == 0 {
+			return nil, ErrNoBaseFee
+		}
+		for i, ratio := range history.GasUsedRatio {
+			block := &BlockFee{
+				Number:       history.OldestBlock.Uint64() + uint64(i),
+				BaseFee:      history.BaseFee[i],
+				GasUsedRatio: ratio,
+			}
+			if i < len(history.Reward) {
+				block.Rewards = make([]*big.Int, len(percentiles))
+				for j, p := range percentiles {
+					block.Rewards[j] = history.Reward[i][sort.SearchFloat64s(sorted, p)]
+				}
+			}
+			r.Blocks = append(r.Blocks, block)
+		}
+		r.NextBaseFee = history.BaseFee[len(history.BaseFee)-1]
+		r.FeeHistory = len(history.Reward) > 0
+	} else {
+		if err := r.fromHeaders(ctx, b, cfg.Blocks); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.Blocks) == 0 {
+		return nil, errors.New("没有可统计的区块")
+	}
+	latest := r.Blocks[len(r.Blocks)-1]
+	r.Latest, r.BaseFee = latest.Number, latest.BaseFee
+
+	// ===== 使用率趋势与基础费预测 =====
+	var sum, first, second float64
+	half := len(r.Blocks) / 2
+	for i, block := range r.Blocks {
+		sum += block.GasUsedRatio
+		if i < half {
+			first += block.GasUsedRatio
+		} else {
+			second += block.GasUsedRatio
+		}
+	}
+	r.AvgGasUsed = sum / float64(len(r.Blocks))
+	r.Trend = TrendFlat
+	if half > 0 {
+		diff := second/float64(len(r.Blocks)-half) - first/float64(half)
+		switch {
+		case diff > 0.05:
+			r.Trend = TrendRising
+		case diff < -0.05:
+			r.Trend = TrendFalling
+		}
+	}
+	fee := r.NextBaseFee
+	for i := 0; i < cfg.Forecast; i++ {
+		r.Forecast = append(r.Forecast, fee)
+		fee = projectBaseFee(fee, r.AvgGasUsed)
+	}
+
+	// ===== 各档位的费用 =====
+	var fallbackTip *big.Int
+	for i, t := range cfg.Tiers {
+		tip := medianReward(r.Blocks, i)
+		if tip == nil {
+			if fallbackTip == nil {
+				suggested, err := b.SuggestGasTipCap(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("获取建议优先费失败: %w", err)
+				}
+				fallbackTip = suggested
+			}
+			tip = fallbackTip
+		}
+		// 每个区块基础费最多上涨 1/8，Blocks 个区块后的上限为 next × (9/8)^(Blocks-1)
+		ceiling := new(big.Int).Set(r.NextBaseFee)
+		for j := 1; j < t.Blocks; j++ {
+			ceiling.Add(ceiling, new(big.Int).Div(ceiling, big.NewInt(baseFeeChangeDenominator)))
+		}
+		r.Tiers = append(r.Tiers, &TierFee{
+			Tier:        t,
+			PriorityFee: tip,
+			MaxFee:      new(big.Int).Add(ceiling, tip),
+			Expected:    new(big.Int).Add(r.NextBaseFee, tip),
+		})
+	}
+	return r, nil
+}
+
+// fromHeaders 后端不支持 eth_feeHistory 时逐个读取区块头，没有优先费数据
+func (r *Report) fromHeaders(ctx context.Context, b backend.EthBackend, blocks uint64) error {
+	head, err := b.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("获取最新区块头失败: %w", err)
+	}
+	if head.BaseFee == nil {
+		return ErrNoBaseFee
+	}
+	latest := head.Number.Uint64()
+	if blocks > latest+1 {
+		blocks = latest + 1
+	}
+	for n := latest + 1 - blocks; n <= latest; n++ {
+		header := head
+		if n != latest {
+			if header, err = b.HeaderByNumber(ctx, new(big.Int).SetUint64(n)); err != nil {
+				return fmt.Errorf("获取区块 %d 失败: %w", n, err)
+			}
+		}
+		baseFee := header.BaseFee
+		if baseFee == nil {
+			baseFee = new(big.Int)
+		}
+		r.Blocks = append(r.Blocks, &BlockFee{
+			Number:       n,
+			BaseFee:      baseFee,
+			GasUsedRatio: float64(header.GasUsed) / float64(header.GasLimit),
+		})
+	}
+	r.NextBaseFee = nextBaseFee(head.BaseFee, head.GasUsed, head.GasLimit)
+	return nil
+}
+
+// nextBaseFee 按 EIP-1559 由父区块计算下一个区块的基础费
+func nextBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	target := gasLimit / elasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+	var delta big.Int
+	if gasUsed > target {
+		delta.SetUint64(gasUsed - target)
+	} else {
+		delta.SetUint64(target - gasUsed)
+	}
+	delta.Mul(&delta, baseFee)
+	delta.Div(&delta, new(big.Int).SetUint64(target))
+	delta.Div(&delta, big.NewInt(baseFeeChangeDenominator))
+	if gasUsed > target {
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(&delta, baseFee)
+	}
+	return delta.Sub(baseFee, &delta)
+}
+
+// projectBaseFee 假设区块使用率保持为 ratio，外推下一个区块的基础费
+func projectBaseFee(baseFee *big.Int, ratio float64) *big.Int {
+	const gasLimit = 30_000_000 // 只用于换算比例，具体数值不影响结果
+	return nextBaseFee(baseFee, uint64(math.Round(ratio*gasLimit)), gasLimit)
+}
+
+// medianReward 返回非空区块中第 i 档优先费的中位数，没有数据时返回 nil
+func medianReward(blocks []*BlockFee, i int) *big.Int {
+	var values []*big.Int
+	for _, b := range blocks {
+		// 空区块的优先费恒为 0，不代表市场行情
+		if b.Rewards == nil || b.GasUsedRatio == 0 {
+			continue
+		}
+		values = append(values, b.Rewards[i])
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Slice(values, func(a, b int) bool { return values[a].Cmp(values[b]) < 0 })
+	return values[len(values)/2]
+}
+
+// Cost 某个档位的交易费用
+type Cost struct {
+	Tier     string
+	Expected *big.Int // 估算 Gas × 预计单价
+	Max      *big.Int // Gas 上限 × maxFeePerGas，账户至少需要这么多 ETH
+}
+
+// Estimate 单笔调用的 Gas 估算
+type Estimate struct {
+	Gas   uint64 // EstimateGas 的结果
+	Limit uint64 // 建议的 Gas 上限
+	Costs []*Cost
+}
+
+// Estimate 估算 msg 的 Gas，按报告中的档位计算费用。
+// 向没有代码的地址转账 ETH 的 Gas 固定为 21000，不加余量；其他调用按 Margin 增加余量
+func (r *Report) Estimate(ctx context.Context, b backend.EthBackend, msg ethereum.CallMsg) (*Estimate, error) {
+	msg.Gas, msg.GasPrice, msg.GasFeeCap, msg.GasTipCap = 0, nil, nil, nil
+	gas, err := b.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("估算Gas失败: %w", err)
+	}
+	e := &Estimate{Gas: gas, Limit: gas}
+	plain := len(msg.Data) == 0 && msg.To != nil
+	if plain {
+		code, err := b.CodeAt(ctx, *msg.To, nil)
+		if err != nil {
+			return nil, fmt.Errorf("获取合约字节码失败: %w", err)
+		}
+		plain = len(code) == 0
+	}
+	if !plain {
+		e.Limit = uint64(math.Ceil(float64(gas) * (1 + r.Margin)))
+	}
+	for _, t := range r.Tiers {
+		e.Costs = append(e.Costs, &Cost{
+			Tier:     t.Name,
+			Expected: new(big.Int).Mul(new(big.Int).SetUint64(e.Gas), t.Expected),
+			Max:      new(big.Int).Mul(new(big.Int).SetUint64(e.Limit), t.MaxFee),
+		})
+	}
+	return e, nil
+}
+
+// gwei 把 Wei 格式化为 Gwei，保留 3 位小数
+func gwei(wei *big.Int) string {
+	return query.ToUnit(wei, 9).Text('f', 3)
+}
+
+// ether 把 Wei 格式化为 ETH，保留 8 位小数
+func ether(wei *big.Int) string {
+	return query.ToUnit(wei, 18).Text('f', 8)
+}
+
+// String 把报告渲染为文本
+func (r *Report) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "最新区块: %d\n", r.Latest)
+	fmt.Fprintf(&buf, "当前基础费: %s Gwei，下一区块: %s Gwei\n", gwei(r.BaseFee), gwei(r.NextBaseFee))
+	fmt.Fprintf(&buf, "最近 %d 个区块平均 Gas 使用率: %.1f%%（趋势: %s）\n", len(r.Blocks), r.AvgGasUsed*100, r.Trend)
+
+	fmt.Fprintln(&buf, "\n区块       基础费(Gwei)   使用率")
+	for _, b := range r.Blocks {
+		bar := int(math.Round(b.GasUsedRatio * 20))
+		fmt.Fprintf(&buf, "%-10d %12s   %5.1f%% %s\n", b.Number, gwei(b.BaseFee), b.GasUsedRatio*100, bytes.Repeat([]byte("█"), bar))
+	}
+
+	fmt.Fprintln(&buf, "\n基础费预测（按平均使用率外推）:")
+	for i, fee := range r.Forecast {
+		fmt.Fprintf(&buf, "  +%d 区块: %s Gwei\n", i+1, gwei(fee))
+	}
+
+	if r.FeeHistory {
+		fmt.Fprintln(&buf, "\n档位      百分位  优先费(Gwei)  最大费用(Gwei)  预计单价(Gwei)")
+	} else {
+		fmt.Fprintln(&buf, "\n节点不支持 eth_feeHistory，优先费使用 eth_maxPriorityFeePerGas 的建议值")
+		fmt.Fprintln(&buf, "档位      百分位  优先费(Gwei)  最大费用(Gwei)  预计单价(Gwei)")
+	}
+	for _, t := range r.Tiers {
+		fmt.Fprintf(&buf, "%-8s %6.0f%%  %12s  %14s  %14s\n", t.Name, t.Percentile, gwei(t.PriorityFee), gwei(t.MaxFee), gwei(t.Expected))
+	}
+	return buf.String()
+}
+
+// String 把估算结果渲染为文本
+func (e *Estimate) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "估算 Gas: %d，建议 Gas 上限: %d\n", e.Gas, e.Limit)
+	for _, c := range e.Costs {
+		fmt.Fprintf(&buf, "  %-8s 预计 %s ETH，最多 %s ETH\n", c.Tier, ether(c.Expected), ether(c.Max))
+	}
+	return buf.String()
+}
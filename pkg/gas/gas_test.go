@@ -0,0 +1,141 @@
+package gas_test
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/gas"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+// noFeeHistory 隐藏 simchain 的 FeeHistory，模拟不支持 eth_feeHistory 的后端
+type noFeeHistory struct{ backend.EthBackend }
+
+// sendTips 在一个区块中发送若干笔优先费不同的 EIP-1559 转账
+func sendTips(t *testing.T, chain *simchain.Chain, tips ...int64) {
+	t.Helper()
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	head := chain.Head()
+	signer := types.LatestSignerForChainID(simchain.ChainID)
+	for _, tip := range tips {
+		nonce, err := chain.PendingNonceAt(ctx, alice.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := types.MustSignNewTx(alice.Key, signer, &types.DynamicFeeTx{
+			ChainID:   simchain.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), big.NewInt(tip)),
+			Gas:       21000,
+			To:        &bob.Address,
+			Value:     big.NewInt(1),
+		})
+		if err := chain.SendTransaction(ctx, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chain.Mine()
+}
+
+func TestReport(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		sendTips(t, chain, 1e9, 2e9, 3e9)
+	}
+	chain.Mine() // 空区块不参与优先费统计
+
+	report, err := gas.NewReport(ctx, chain, gas.WithBlocks(5), gas.WithForecast(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.FeeHistory || len(report.Blocks) != 5 || len(report.Forecast) != 3 || report.Latest != chain.Head().Number.Uint64() {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.BaseFee.Cmp(chain.Head().BaseFee) != 0 {
+		t.Fatalf("base fee = %s, head = %s", report.BaseFee, chain.Head().BaseFee)
+	}
+	// 最新区块是空的，下一个区块的基础费下降 12.5%
+	want := new(big.Int).Sub(report.BaseFee, new(big.Int).Div(report.BaseFee, big.NewInt(8)))
+	if report.NextBaseFee.Cmp(want) != 0 {
+		t.Fatalf("next base fee = %s, want %s", report.NextBaseFee, want)
+	}
+	slow, normal, fast := report.Tiers[0], report.Tiers[1], report.Tiers[2]
+	if slow.PriorityFee.Int64() != 1e9 || normal.PriorityFee.Int64() != 2e9 || fast.PriorityFee.Int64() != 3e9 {
+		t.Fatalf("tips = %s / %s / %s", slow.PriorityFee, normal.PriorityFee, fast.PriorityFee)
+	}
+	// fast 期望下一个区块打包，最大费用不需要为基础费上涨留余量
+	if fast.MaxFee.Cmp(fast.Expected) != 0 || slow.MaxFee.Cmp(slow.Expected) <= 0 {
+		t.Fatalf("fast max=%s expected=%s, slow max=%s expected=%s", fast.MaxFee, fast.Expected, slow.MaxFee, slow.Expected)
+	}
+	out := report.String()
+	for _, s := range []string{"下一区块", "基础费预测", "normal"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("output missing %q:\n%s", s, out)
+		}
+	}
+
+	// 不支持 eth_feeHistory 时从区块头计算，下一个区块的基础费与协议一致
+	fallback, err := gas.NewReport(ctx, noFeeHistory{chain}, gas.WithBlocks(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fallback.FeeHistory || fallback.NextBaseFee.Cmp(report.NextBaseFee) != 0 || len(fallback.Blocks) != 5 {
+		t.Fatalf("fallback = %+v", fallback)
+	}
+	tip, _ := chain.SuggestGasTipCap(ctx)
+	if fallback.Tiers[0].PriorityFee.Cmp(tip) != 0 {
+		t.Fatalf("fallback tip = %s, want %s", fallback.Tiers[0].PriorityFee, tip)
+	}
+	for i := range report.Blocks {
+		if fallback.Blocks[i].BaseFee.Cmp(report.Blocks[i].BaseFee) != 0 || fallback.Blocks[i].GasUsedRatio != report.Blocks[i].GasUsedRatio {
+			t.Fatalf("block %d differs: %+v vs %+v", i, fallback.Blocks[i], report.Blocks[i])
+		}
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	report, err := gas.NewReport(ctx, chain, gas.WithMargin(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 普通转账的 Gas 是固定的，不加余量
+	est, err := report.Estimate(ctx, chain, ethereum.CallMsg{From: alice.Address, To: &bob.Address, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Gas != 21000 || est.Limit != 21000 || len(est.Costs) != 3 {
+		t.Fatalf("eth transfer estimate = %+v", est)
+	}
+
+	est, err = report.Estimate(ctx, chain, ethereum.CallMsg{From: alice.Address, To: &token, Data: transfer.EncodeTransfer(bob.Address, big.NewInt(1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Limit != uint64(math.Ceil(float64(est.Gas)*1.5)) {
+		t.Fatalf("gas = %d, limit = %d", est.Gas, est.Limit)
+	}
+	normal := est.Costs[1]
+	if normal.Expected.Cmp(new(big.Int).Mul(big.NewInt(int64(est.Gas)), report.Tiers[1].Expected)) != 0 ||
+		normal.Max.Cmp(new(big.Int).Mul(big.NewInt(int64(est.Limit)), report.Tiers[1].MaxFee)) != 0 {
+		t.Fatalf("normal cost = %+v", normal)
+	}
+	if !strings.Contains(est.String(), "建议 Gas 上限") {
+		t.Fatalf("output:\n%s", est)
+	}
+}
@@ -0,0 +1,347 @@
+// Package ledger 回放 ERC20 的 Transfer 日志，重建持有人余额并做持仓分析
+//
+// 代币合约只保存当前余额，不提供持有人列表。从部署区块开始按顺序回放 Transfer 事件，
+// 就能得到任意区块上的全部持有人、铸造和销毁总量以及地址之间的资金流向。
+// 回放结果依赖日志完整且代币没有"不发事件就改余额"的逻辑（如 rebase 代币），
+// Verify 抽样用 balanceOf 核对，发现不一致时说明回放结果不可信。
+//
+//	l, err := ledger.Replay(ctx, client, token, deployBlock, head)
+//	top := l.Top(10)
+//	checks, err := l.Verify(ctx, client, 20)
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/events"
+	"github.com/duanyu/new-eth-project/pkg/portfolio"
+)
+
+// ErrNotContract 地址在指定区块上没有合约代码
+var ErrNotContract = errors.New("地址上没有合约代码")
+
+// DeploymentBlock 在 [0, to] 内二分查找合约代码第一次出现的区块，需要节点能查询历史区块的代码
+func DeploymentBlock(ctx context.Context, b backend.EthBackend, token common.Address, to uint64) (uint64, error) {
+	hasCode := func(n uint64) (bool, error) {
+		code, err := b.CodeAt(ctx, token, new(big.Int).SetUint64(n))
+		if err != nil {
+			return false, fmt.Errorf("查询区块 %d 的合约代码失败: %w", n, err)
+		}
+		return len(code) > 0, nil
+	}
+	ok, err := hasCode(to)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s（区块 %d）", ErrNotContract, token.Hex(), to)
+	}
+	lo, hi := uint64(0), to // 不变量：hi 上有代码
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := hasCode(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, nil
+}
+
+// Holder 持有人及其余额
+type Holder struct {
+	Address common.Address
+	Balance *big.Int
+}
+
+// Flow 两个地址之间的转账汇总，不含铸造和销毁
+type Flow struct {
+	From   common.Address
+	To     common.Address
+	Amount *big.Int
+	Count  int
+}
+
+// Ledger 由 Transfer 日志重建的余额账本
+type Ledger struct {
+	Token     common.Address
+	From, To  uint64   // 回放的区块范围（含两端）
+	Transfers int      // 回放的 Transfer 事件数，包括铸造和销毁
+	Minted    *big.Int // from 为零地址的转账总额
+	Burned    *big.Int // to 为零地址的转账总额
+	Mints     int
+	Burns     int
+	// Negative 余额曾经变为负数的地址，说明日志不完整或代币有不发事件的余额变化
+	Negative []common.Address
+
+	balances map[common.Address]*big.Int
+	flows    map[[2]common.Address]*Flow
+}
+
+// New 创建空账本，通常由 Replay 创建；自己订阅事件时可以用 Apply 逐条记账
+func New(token common.Address) *Ledger {
+	return &Ledger{
+		Token:    token,
+		Minted:   new(big.Int),
+		Burned:   new(big.Int),
+		balances: make(map[common.Address]*big.Int),
+		flows:    make(map[[2]common.Address]*Flow),
+	}
+}
+
+// Replay 回放 token 在 [from, to] 区块内的全部 Transfer 事件；from 应不晚于部署区块，否则缺少早期的余额
+func Replay(ctx context.Context, b backend.EthBackend, token common.Address, from, to uint64) (*Ledger, error) {
+	decoder, err := events.NewDecoder(mytoken.MyTokenMetaData.ABI)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := events.History(ctx, b, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{token},
+		Topics:    [][]common.Hash{{decoder.Topic("Transfer")}},
+	}, decoder)
+	if err != nil {
+		return nil, err
+	}
+	l := New(token)
+	l.From, l.To = from, to
+	for _, ev := range logs {
+		src, _ := ev.Fields["from"].(common.Address)
+		dst, _ := ev.Fields["to"].(common.Address)
+		value, ok := ev.Fields["value"].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("区块 %d 中的 Transfer 事件没有 value 字段", ev.Log.BlockNumber)
+		}
+		l.Apply(src, dst, value)
+	}
+	return l, nil
+}
+
+// Apply 记一笔转账：from 为零地址表示铸造，to 为零地址表示销毁
+func (l *Ledger) Apply(from, to common.Address, value *big.Int) {
+	l.Transfers++
+	switch {
+	case from == (common.Address{}):
+		l.Minted.Add(l.Minted, value)
+		l.Mints++
+	case to == (common.Address{}):
+		l.Burned.Add(l.Burned, value)
+		l.Burns++
+	default:
+		key := [2]common.Address{from, to}
+		f, ok := l.flows[key]
+		if !ok {
+			f = &Flow{From: from, To: to, Amount: new(big.Int)}
+			l.flows[key] = f
+		}
+		f.Amount.Add(f.Amount, value)
+		f.Count++
+	}
+	if from != (common.Address{}) {
+		bal := l.balance(from)
+		bal.Sub(bal, value)
+		if bal.Sign() < 0 && !l.isNegative(from) {
+			l.Negative = append(l.Negative, from)
+		}
+	}
+	if to != (common.Address{}) {
+		bal := l.balance(to)
+		bal.Add(bal, value)
+	}
+}
+
+func (l *Ledger) balance(addr common.Address) *big.Int {
+	bal, ok := l.balances[addr]
+	if !ok {
+		bal = new(big.Int)
+		l.balances[addr] = bal
+	}
+	return bal
+}
+
+func (l *Ledger) isNegative(addr common.Address) bool {
+	for _, a := range l.Negative {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Balance 返回回放得到的余额
+func (l *Ledger) Balance(addr common.Address) *big.Int {
+	if bal, ok := l.balances[addr]; ok {
+		return new(big.Int).Set(bal)
+	}
+	return new(big.Int)
+}
+
+// Supply 铸造总额减去销毁总额，应等于 to 区块上的 totalSupply
+func (l *Ledger) Supply() *big.Int {
+	return new(big.Int).Sub(l.Minted, l.Burned)
+}
+
+// Holders 返回余额为正的全部持有人，按余额从大到小排列，余额相同时按地址排列
+func (l *Ledger) Holders() []Holder {
+	out := make([]Holder, 0, len(l.balances))
+	for addr, bal := range l.balances {
+		if bal.Sign() > 0 {
+			out = append(out, Holder{Address: addr, Balance: new(big.Int).Set(bal)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := out[i].Balance.Cmp(out[j].Balance); c != 0 {
+			return c > 0
+		}
+		return out[i].Address.Cmp(out[j].Address) < 0
+	})
+	return out
+}
+
+// Top 返回余额最大的 n 个持有人
+func (l *Ledger) Top(n int) []Holder {
+	holders := l.Holders()
+	if n < len(holders) {
+		holders = holders[:n]
+	}
+	return holders
+}
+
+// Flows 返回转账总额最大的 n 组地址对，n <= 0 时返回全部
+func (l *Ledger) Flows(n int) []Flow {
+	out := make([]Flow, 0, len(l.flows))
+	for _, f := range l.flows {
+		out = append(out, Flow{From: f.From, To: f.To, Amount: new(big.Int).Set(f.Amount), Count: f.Count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := out[i].Amount.Cmp(out[j].Amount); c != 0 {
+			return c > 0
+		}
+		if c := out[i].From.Cmp(out[j].From); c != 0 {
+			return c < 0
+		}
+		return out[i].To.Cmp(out[j].To) < 0
+	})
+	if n > 0 && n < len(out) {
+		out = out[:n]
+	}
+	return out
+}
+
+// Concentration 持仓集中度
+type Concentration struct {
+	Holders  int     // 余额为正的持有人数
+	TopN     int     // TopShare 统计的持有人数
+	TopShare float64 // 前 TopN 名持有的份额（0-1）
+	HHI      float64 // 赫芬达尔指数：各持有人份额的平方和，1 表示一个地址持有全部
+	Gini     float64 // 基尼系数：0 表示完全平均，接近 1 表示高度集中
+}
+
+// Concentration 计算持仓集中度，份额以全部正余额之和为分母
+func (l *Ledger) Concentration(topN int) Concentration {
+	holders := l.Holders()
+	c := Concentration{Holders: len(holders), TopN: min(topN, len(holders))}
+	if len(holders) == 0 {
+		return c
+	}
+	total := new(big.Int)
+	for _, h := range holders {
+		total.Add(total, h.Balance)
+	}
+	totalF := new(big.Float).SetInt(total)
+	shares := make([]float64, len(holders))
+	for i, h := range holders {
+		shares[i], _ = new(big.Float).Quo(new(big.Float).SetInt(h.Balance), totalF).Float64()
+		if i < c.TopN {
+			c.TopShare += shares[i]
+		}
+		c.HHI += shares[i] * shares[i]
+	}
+	// 按从小到大排第 r 名的权重为 n+1-r，G = (n + 1 - 2 * Σ (n+1-r) * share_r) / n；
+	// 这里份额从大到小排列，第 i 个（从 0 开始）的 r = n-i，权重为 i+1
+	n := float64(len(shares))
+	var weighted float64
+	for i, s := range shares {
+		weighted += float64(i+1) * s
+	}
+	c.Gini = math.Max(0, (n+1-2*weighted)/n)
+	return c
+}
+
+// Check 一个持有人的回放余额与链上 balanceOf 的对比
+type Check struct {
+	Holder   common.Address
+	Replayed *big.Int
+	OnChain  *big.Int
+}
+
+// OK 两个余额是否一致
+func (c Check) OK() bool { return c.Replayed.Cmp(c.OnChain) == 0 }
+
+// Verify 抽样 sample 个持有人，在 To 区块上用 balanceOf 核对回放的余额
+//
+// 样本包括余额最大的一半持有人和按排名均匀抽取的其余持有人，余额曾为负的地址总是核对；
+// 查询通过 portfolio 完成，优先用 Multicall3 合并成一次调用，查询历史区块需要归档节点
+func (l *Ledger) Verify(ctx context.Context, b backend.EthBackend, sample int) ([]Check, error) {
+	addrs := l.sample(sample)
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	snap, err := portfolio.New(b, addrs, portfolio.WithTokens(l.Token)).Snapshot(ctx, l.To)
+	if err != nil {
+		return nil, err
+	}
+	var out []Check
+	for _, bal := range snap.Balances {
+		if bal.Token == l.Token {
+			out = append(out, Check{Holder: bal.Holder, Replayed: l.Balance(bal.Holder), OnChain: bal.Raw})
+		}
+	}
+	return out, nil
+}
+
+// sample 选出要核对的地址
+func (l *Ledger) sample(n int) []common.Address {
+	holders := l.Holders()
+	var out []common.Address
+	seen := make(map[common.Address]bool)
+	add := func(addr common.Address) {
+		if !seen[addr] {
+			seen[addr] = true
+			out = append(out, addr)
+		}
+	}
+	for _, addr := range l.Negative {
+		add(addr)
+	}
+	if n >= len(holders) {
+		for _, h := range holders {
+			add(h.Address)
+		}
+		return out
+	}
+	top := (n + 1) / 2
+	for _, h := range holders[:top] {
+		add(h.Address)
+	}
+	rest, picks := holders[top:], n-top
+	for i := 0; i < picks; i++ {
+		add(rest[i*len(rest)/picks].Address)
+	}
+	return out
+}
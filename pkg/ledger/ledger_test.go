@@ -0,0 +1,152 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/ledger"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestReplay(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob, carol, dave := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2], chain.Accounts[3]
+	chain.MineBlocks(3)
+	deployed := chain.Head().Number.Uint64() + 1 // 部署交易所在的区块
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 0, big.NewInt(1000))
+
+	steps := []func() error{
+		func() error { _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(300)); return err },
+		func() error { _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(100)); return err },
+		func() error { _, err := token.Transfer(bob.Opts(), carol.Address, big.NewInt(50)); return err },
+		func() error { _, err := token.Mint(alice.Opts(), dave.Address, big.NewInt(500)); return err },
+		func() error { _, err := token.Burn(bob.Opts(), big.NewInt(20)); return err },
+		func() error { _, err := token.Approve(carol.Opts(), dave.Address, big.NewInt(50)); return err },
+		func() error {
+			_, err := token.TransferFrom(dave.Opts(), carol.Address, alice.Address, big.NewInt(50))
+			return err
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("第 %d 步: %v", i, err)
+		}
+	}
+	head := chain.Head().Number.Uint64()
+
+	from, err := ledger.DeploymentBlock(ctx, chain, tokenAddr, head)
+	if err != nil || from != deployed {
+		t.Fatalf("DeploymentBlock = %d, %v，期望 %d", from, err, deployed)
+	}
+	if _, err := ledger.DeploymentBlock(ctx, chain, bob.Address, head); !errors.Is(err, ledger.ErrNotContract) {
+		t.Errorf("普通账户应返回 ErrNotContract，实际 %v", err)
+	}
+
+	l, err := ledger.Replay(ctx, chain, tokenAddr, from, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 回放的余额与 balanceOf 一致，铸造减销毁等于 totalSupply
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(head)}
+	for _, acc := range []common.Address{alice.Address, bob.Address, carol.Address, dave.Address} {
+		want, _ := token.BalanceOf(opts, acc)
+		if got := l.Balance(acc); got.Cmp(want) != 0 {
+			t.Errorf("%s 的余额 = %s，期望 %s", acc.Hex(), got, want)
+		}
+	}
+	supply, _ := token.TotalSupply(opts)
+	if l.Supply().Cmp(supply) != 0 || l.Minted.Int64() != 1500 || l.Burned.Int64() != 20 || l.Mints != 2 || l.Burns != 1 {
+		t.Errorf("铸造 %s（%d 次），销毁 %s（%d 次），totalSupply %s", l.Minted, l.Mints, l.Burned, l.Burns, supply)
+	}
+	if l.Transfers != 7 || len(l.Negative) != 0 {
+		t.Errorf("Transfers = %d, Negative = %v", l.Transfers, l.Negative)
+	}
+
+	// 排名：alice 650、dave 500、bob 330、carol 0（不计入持有人）
+	top := l.Top(10)
+	if len(top) != 3 || top[0].Address != alice.Address || top[1].Address != dave.Address || top[2].Balance.Int64() != 330 {
+		t.Errorf("Top = %+v", top)
+	}
+	flows := l.Flows(0)
+	if len(flows) != 3 || flows[0].From != alice.Address || flows[0].To != bob.Address || flows[0].Amount.Int64() != 400 || flows[0].Count != 2 {
+		t.Errorf("Flows = %+v", flows)
+	}
+
+	checks, err := l.Verify(ctx, chain, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) != 3 {
+		t.Fatalf("核对了 %d 个地址", len(checks))
+	}
+	for _, c := range checks {
+		if !c.OK() {
+			t.Errorf("%s: 回放 %s，链上 %s", c.Holder.Hex(), c.Replayed, c.OnChain)
+		}
+	}
+
+	// 从部署之后开始回放会漏掉初始铸造：alice 的余额变为负数，核对时发现不一致
+	partial, err := ledger.Replay(ctx, chain, tokenAddr, from+1, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partial.Negative) != 1 || partial.Negative[0] != alice.Address {
+		t.Fatalf("Negative = %v", partial.Negative)
+	}
+	checks, err = partial.Verify(ctx, chain, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checks) == 0 || checks[0].Holder != alice.Address || checks[0].OK() {
+		t.Errorf("应该核对出 alice 的余额不一致: %+v", checks)
+	}
+}
+
+func TestConcentration(t *testing.T) {
+	var zero common.Address
+	addr := func(i byte) common.Address { return common.Address{19: i} }
+
+	even := ledger.New(addr(99))
+	for i := byte(1); i <= 4; i++ {
+		even.Apply(zero, addr(i), big.NewInt(25))
+	}
+	c := even.Concentration(1)
+	if c.Holders != 4 || c.TopN != 1 || !near(c.TopShare, 0.25) || !near(c.HHI, 0.25) || !near(c.Gini, 0) {
+		t.Errorf("平均分布: %+v", c)
+	}
+
+	whale := ledger.New(addr(99))
+	whale.Apply(zero, addr(1), big.NewInt(100))
+	for i := byte(2); i <= 4; i++ {
+		whale.Apply(addr(1), addr(i), big.NewInt(0))
+	}
+	whale.Apply(addr(1), addr(2), big.NewInt(1))
+	whale.Apply(addr(2), zero, big.NewInt(1))
+	c = whale.Concentration(3)
+	if c.Holders != 1 || c.TopN != 1 || !near(c.TopShare, 1) || !near(c.HHI, 1) || !near(c.Gini, 0) {
+		t.Errorf("一个地址持有全部: %+v", c)
+	}
+
+	skewed := ledger.New(addr(99))
+	skewed.Apply(zero, addr(1), big.NewInt(70))
+	skewed.Apply(zero, addr(2), big.NewInt(20))
+	skewed.Apply(zero, addr(3), big.NewInt(10))
+	c = skewed.Concentration(2)
+	// G = (n + 1 - 2 * (1*0.7 + 2*0.2 + 3*0.1)) / n = (4 - 2.8) / 3
+	if !near(c.TopShare, 0.9) || !near(c.HHI, 0.54) || !near(c.Gini, 0.4) {
+		t.Errorf("集中分布: %+v", c)
+	}
+
+	if c := ledger.New(addr(99)).Concentration(10); c.Holders != 0 || c.TopShare != 0 {
+		t.Errorf("空账本: %+v", c)
+	}
+}
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Kind RPC 错误的分类
+type Kind int
+
+const (
+	KindNone           Kind = iota // 没有错误
+	KindOther                      // 其他错误（参数错误、合约回滚等），重试无意义
+	KindRateLimit                  // 服务商限流（HTTP 429、-32005 等）
+	KindRangeTooLarge              // eth_getLogs 的区块范围或结果数量超过服务商限制
+	KindHeaderNotFound             // 节点尚未同步到请求的区块
+	KindTimeout                    // 请求超时
+	KindTransient                  // 连接中断、服务端 5xx 等临时故障
+	KindMissingState               // 节点已裁剪该区块的历史状态（非归档节点）
+)
+
+var kindNames = map[Kind]string{
+	KindNone:           "none",
+	KindOther:          "other",
+	KindRateLimit:      "rate-limit",
+	KindRangeTooLarge:  "range-too-large",
+	KindHeaderNotFound: "header-not-found",
+	KindTimeout:        "timeout",
+	KindTransient:      "transient",
+	KindMissingState:   "missing-state",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Retryable 该类错误换个时间重试是否可能成功
+func (k Kind) Retryable() bool {
+	switch k {
+	case KindRateLimit, KindHeaderNotFound, KindTimeout, KindTransient:
+		return true
+	}
+	return false
+}
+
+// 经过中间件的错误会包装对应的哨兵错误，可用 errors.Is 判断
+var (
+	ErrRateLimited    = errors.New("请求被服务商限流")
+	ErrRangeTooLarge  = errors.New("查询范围超过服务商限制")
+	ErrHeaderNotFound = errors.New("节点尚未同步到该区块")
+	ErrMissingState   = errors.New("节点没有该区块的历史状态（需要归档节点）")
+)
+
+// 各服务商返回的错误信息片段（统一转成小写比较）
+var (
+	rangeTooLargeMessages = []string{
+		"query returned more than", // Infura、Alchemy：结果超过 10000 条
+		"block range",              // "block range is too wide"、"exceed maximum block range"
+		"range too large",
+		"log response size exceeded", // Alchemy
+		"query timeout exceeded",     // 部分节点在范围过大时超时
+		"too many blocks",
+		"eth_getlogs is limited to", // QuickNode
+	}
+	rateLimitMessages = []string{
+		"rate limit",
+		"too many requests",
+		"exceeded its compute units",
+		"request limit",
+		"daily request count exceeded",
+		"capacity exceeded",
+	}
+	missingStateMessages = []string{
+		"missing trie node",            // geth（hash 模式）
+		"historical state",             // geth（path 模式）："historical state ... is not available"
+		"state not available",          // Erigon、Reth
+		"state is not available",       // Nethermind
+		"world state not available",    // Besu
+		"state histories haven't been", // Erigon 索引未完成
+	}
+	// prunedStatePattern 状态被裁剪的说法，如 "state at block #100 is pruned"、"state has been pruned"；
+	// 只出现 pruned 不够："pruned history unavailable" 等是区块、收据被裁剪，与是否归档节点无关
+	prunedStatePattern = regexp.MustCompile(`\bstate\b[^.;:]*\b(?:has been|is|was) pruned|\bpruned state\b`)
+
+	headerNotFoundMessages = []string{
+		"header not found",
+		"unknown block",
+		"block not found",
+	}
+)
+
+// Classify 根据 HTTP 状态码、JSON-RPC 错误码和错误信息对错误分类
+func Classify(err error) Kind {
+	if err == nil {
+		return KindNone
+	}
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return KindRateLimit
+	case errors.Is(err, ErrRangeTooLarge):
+		return KindRangeTooLarge
+	case errors.Is(err, ErrHeaderNotFound):
+		return KindHeaderNotFound
+	case errors.Is(err, ErrMissingState):
+		return KindMissingState
+	case errors.Is(err, context.Canceled):
+		return KindOther
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTimeout
+	}
+
+	// 范围过大的错误码与限流相同（-32005），所以先按信息判断
+	msg := strings.ToLower(err.Error())
+	if containsAny(msg, rangeTooLargeMessages) {
+		return KindRangeTooLarge
+	}
+	if containsAny(msg, missingStateMessages) || prunedStatePattern.MatchString(msg) {
+		return KindMissingState
+	}
+	if containsAny(msg, headerNotFoundMessages) {
+		return KindHeaderNotFound
+	}
+	if containsAny(msg, rateLimitMessages) {
+		return KindRateLimit
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == http.StatusTooManyRequests:
+			return KindRateLimit
+		case httpErr.StatusCode == http.StatusRequestTimeout || httpErr.StatusCode == http.StatusGatewayTimeout:
+			return KindTimeout
+		case httpErr.StatusCode >= 500:
+			return KindTransient
+		}
+		return KindOther
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		if rpcErr.ErrorCode() == -32005 {
+			return KindRateLimit
+		}
+		return KindOther
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return KindTimeout
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return KindTransient
+	}
+	return KindOther
+}
+
+// wrap 为可识别的错误加上对应的哨兵错误
+func wrap(kind Kind, method string, err error) error {
+	switch kind {
+	case KindRateLimit:
+		return fmt.Errorf("%s: %w: %w", method, ErrRateLimited, err)
+	case KindRangeTooLarge:
+		return fmt.Errorf("%s: %w: %w", method, ErrRangeTooLarge, err)
+	case KindHeaderNotFound:
+		return fmt.Errorf("%s: %w: %w", method, ErrHeaderNotFound, err)
+	case KindMissingState:
+		return fmt.Errorf("%s: %w: %w", method, ErrMissingState, err)
+	}
+	return err
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
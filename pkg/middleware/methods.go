@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ===== 链信息 =====
+
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, "eth_chainId", true, func(ctx context.Context) (*big.Int, error) {
+		return c.backend.ChainID(ctx)
+	})
+}
+
+// ===== 区块与交易 =====
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return call(ctx, c, "eth_getBlockByHash", true, func(ctx context.Context) (*types.Block, error) {
+		return c.backend.BlockByHash(ctx, hash)
+	})
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return call(ctx, c, "eth_getBlockByNumber", true, func(ctx context.Context) (*types.Block, error) {
+		return c.backend.BlockByNumber(ctx, number)
+	})
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return call(ctx, c, "eth_getBlockByHash", true, func(ctx context.Context) (*types.Header, error) {
+		return c.backend.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(ctx, c, "eth_getBlockByNumber", true, func(ctx context.Context) (*types.Header, error) {
+		return c.backend.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return call(ctx, c, "eth_getBlockTransactionCountByHash", true, func(ctx context.Context) (uint, error) {
+		return c.backend.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return call(ctx, c, "eth_getTransactionByBlockHashAndIndex", true, func(ctx context.Context) (*types.Transaction, error) {
+		return c.backend.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	r, err := call(ctx, c, "eth_getTransactionByHash", true, func(ctx context.Context) (result, error) {
+		tx, isPending, err := c.backend.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+	return r.tx, r.isPending, err
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return call(ctx, c, "eth_getTransactionReceipt", true, func(ctx context.Context) (*types.Receipt, error) {
+		return c.backend.TransactionReceipt(ctx, hash)
+	})
+}
+
+// BlockReceipts 被包装的后端未实现 backend.BlockReceiptsReader 时返回错误
+func (c *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	r, ok := c.backend.(backend.BlockReceiptsReader)
+	if !ok {
+		return nil, errors.New("后端不支持 eth_getBlockReceipts")
+	}
+	return call(ctx, c, "eth_getBlockReceipts", true, func(ctx context.Context) ([]*types.Receipt, error) {
+		return r.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+// ===== 账户状态 =====
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return call(ctx, c, "eth_getBalance", true, func(ctx context.Context) (*big.Int, error) {
+		return c.backend.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// PendingBalanceAt 被包装的后端未实现 backend.PendingBalanceReader 时返回错误
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	r, ok := c.backend.(backend.PendingBalanceReader)
+	if !ok {
+		return nil, errors.New("后端不支持查询待处理余额")
+	}
+	return call(ctx, c, "eth_getBalance", true, func(ctx context.Context) (*big.Int, error) {
+		return r.PendingBalanceAt(ctx, account)
+	})
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, "eth_getStorageAt", true, func(ctx context.Context) ([]byte, error) {
+		return c.backend.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, "eth_getCode", true, func(ctx context.Context) ([]byte, error) {
+		return c.backend.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(ctx, c, "eth_getTransactionCount", true, func(ctx context.Context) (uint64, error) {
+		return c.backend.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(ctx, c, "eth_getCode", true, func(ctx context.Context) ([]byte, error) {
+		return c.backend.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(ctx, c, "eth_getTransactionCount", true, func(ctx context.Context) (uint64, error) {
+		return c.backend.PendingNonceAt(ctx, account)
+	})
+}
+
+// ===== 合约调用与 Gas =====
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, "eth_call", true, func(ctx context.Context) ([]byte, error) {
+		return c.backend.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(ctx, c, "eth_estimateGas", true, func(ctx context.Context) (uint64, error) {
+		return c.backend.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, "eth_gasPrice", true, func(ctx context.Context) (*big.Int, error) {
+		return c.backend.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, "eth_maxPriorityFeePerGas", true, func(ctx context.Context) (*big.Int, error) {
+		return c.backend.SuggestGasTipCap(ctx)
+	})
+}
+
+// ===== 日志与订阅 =====
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return call(ctx, c, "eth_getLogs", true, func(ctx context.Context) ([]types.Log, error) {
+		return c.backend.FilterLogs(ctx, q)
+	})
+}
+
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return call(ctx, c, "eth_subscribe", false, func(ctx context.Context) (ethereum.Subscription, error) {
+		return c.backend.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return call(ctx, c, "eth_subscribe", false, func(ctx context.Context) (ethereum.Subscription, error) {
+		return c.backend.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// ===== 发送交易 =====
+
+// SendTransaction 只受限流约束，不重试：重试可能把同一笔交易重复广播，由调用方决定如何处理
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := call(ctx, c, "eth_sendRawTransaction", false, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.backend.SendTransaction(ctx, tx)
+	})
+	return err
+}
@@ -0,0 +1,199 @@
+// Package middleware 在 RPC 调用外层加上限流、重试、错误分类和调用统计
+//
+// 大范围回填（query-history-events、逐块扫描）很容易触发服务商的 429 限流，
+// 原来的命令遇到一次错误就 log.Fatal 退出。用 Wrap 包装客户端后：
+//   - 每个节点一个令牌桶，请求在本地排队，不会超过设定的速率
+//   - 只读（幂等）请求遇到限流、超时、节点未同步等临时错误时按带抖动的指数退避重试；
+//     发送交易不会重试，以免重复广播
+//   - 返回的错误按类型包装 ErrRateLimited、ErrRangeTooLarge、ErrHeaderNotFound，
+//     调用方可用 errors.Is 或 Classify 判断（例如 events.History 遇到范围过大时会自动二分区间）
+//   - 按 JSON-RPC 方法名统计调用、重试和失败次数
+//
+// 中间件只覆盖 backend.EthBackend（及 BlockReceiptsReader、PendingBalanceReader）的方法。
+// 包装后的 Client 有意不实现 backend.RPCClient：直接发送 JSON-RPC 的功能（batch 批量请求、
+// trace、proof、override、query.RawHeader）拿到的连接会绕过限流和重试，因此这些功能通过 Client 时
+// 退回逐个调用 EthBackend 方法，或者报告不支持；确实需要时可以用 Unwrap 取得底层后端，自行承担限流。
+//
+// 包装后的 Client 仍然实现 backend.EthBackend；与 multiclient 组合时，
+// 为每个节点分别包装即可获得按节点的限流：
+//
+//	ep := multiclient.NewEndpoint(url, middleware.Wrap(client, middleware.WithRateLimit(10, 20)))
+package middleware
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// Config 中间件配置
+type Config struct {
+	Rate       float64       // 每秒允许的请求数，<= 0 表示不限流
+	Burst      int           // 令牌桶容量，允许的瞬时并发请求数
+	MaxRetries int           // 幂等请求的最大重试次数
+	BaseDelay  time.Duration // 第一次重试前的基础等待时间，之后每次翻倍
+	MaxDelay   time.Duration // 单次重试等待时间上限
+}
+
+// DefaultConfig 返回默认配置：不限流，最多重试 5 次，退避从 200 毫秒开始、最长 10 秒
+func DefaultConfig() *Config {
+	return &Config{
+		Burst:      1,
+		MaxRetries: 5,
+		BaseDelay:  200 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+}
+
+// Option 修改中间件配置
+type Option func(*Config)
+
+// WithRateLimit 设置令牌桶速率（每秒请求数）和容量
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Config) { c.Rate, c.Burst = rate, burst }
+}
+
+// WithRetries 设置最大重试次数和退避时间
+func WithRetries(max int, base, maxDelay time.Duration) Option {
+	return func(c *Config) { c.MaxRetries, c.BaseDelay, c.MaxDelay = max, base, maxDelay }
+}
+
+// MethodStats 单个 JSON-RPC 方法的调用统计
+type MethodStats struct {
+	Calls       uint64 // 调用次数（不含重试）
+	Retries     uint64 // 重试次数
+	Failures    uint64 // 最终失败的次数
+	RateLimited uint64 // 收到限流响应的次数（含重试中的）
+}
+
+// Client 带限流、重试和统计的后端包装
+type Client struct {
+	backend backend.EthBackend
+	cfg     Config
+	limiter *Limiter
+
+	mu    sync.Mutex
+	stats map[string]*MethodStats
+	rand  *rand.Rand
+}
+
+// Client 仍可直接传给 pkg 下的各功能库
+var (
+	_ backend.EthBackend           = (*Client)(nil)
+	_ backend.BlockReceiptsReader  = (*Client)(nil)
+	_ backend.PendingBalanceReader = (*Client)(nil)
+)
+
+// Wrap 用限流、重试和统计包装后端
+func Wrap(b backend.EthBackend, opts ...Option) *Client {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &Client{
+		backend: b,
+		cfg:     *cfg,
+		limiter: NewLimiter(cfg.Rate, cfg.Burst),
+		stats:   make(map[string]*MethodStats),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Unwrap 返回被包装的后端
+func (c *Client) Unwrap() backend.EthBackend { return c.backend }
+
+// Close 关闭实现了 Close 方法的底层客户端
+func (c *Client) Close() {
+	if closer, ok := c.backend.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+// Stats 返回各方法调用统计的快照，键为 JSON-RPC 方法名
+func (c *Client) Stats() map[string]MethodStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]MethodStats, len(c.stats))
+	for method, s := range c.stats {
+		out[method] = *s
+	}
+	return out
+}
+
+// Methods 返回有调用记录的方法名，按字母排序，便于打印统计
+func (c *Client) Methods() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	methods := make([]string, 0, len(c.stats))
+	for method := range c.stats {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func (c *Client) record(method string, update func(*MethodStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.stats[method]
+	if !ok {
+		s = &MethodStats{}
+		c.stats[method] = s
+	}
+	update(s)
+}
+
+// backoff 第 attempt 次重试前的等待时间：指数增长，取 [d/2, d) 之间的随机值
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.cfg.BaseDelay << attempt
+	if d <= 0 || (c.cfg.MaxDelay > 0 && d > c.cfg.MaxDelay) {
+		d = c.cfg.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	c.mu.Lock()
+	jitter := time.Duration(c.rand.Int63n(int64(d/2) + 1))
+	c.mu.Unlock()
+	return d/2 + jitter
+}
+
+// call 执行一次 RPC 调用：先取令牌，失败后按错误类型决定是否重试
+// idempotent 为 false 的请求（发送交易、建立订阅）只执行一次
+func call[T any](ctx context.Context, c *Client, method string, idempotent bool, fn func(context.Context) (T, error)) (T, error) {
+	c.record(method, func(s *MethodStats) { s.Calls++ })
+	var zero T
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			c.record(method, func(s *MethodStats) { s.Failures++ })
+			return zero, err
+		}
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+
+		kind := Classify(err)
+		if kind == KindRateLimit {
+			c.record(method, func(s *MethodStats) { s.RateLimited++ })
+		}
+		if !idempotent || !kind.Retryable() || attempt >= c.cfg.MaxRetries || ctx.Err() != nil {
+			c.record(method, func(s *MethodStats) { s.Failures++ })
+			return zero, wrap(kind, method, err)
+		}
+
+		c.record(method, func(s *MethodStats) { s.Retries++ })
+		timer := time.NewTimer(c.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			c.record(method, func(s *MethodStats) { s.Failures++ })
+			return zero, wrap(kind, method, err)
+		}
+	}
+}
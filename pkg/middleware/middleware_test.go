@@ -0,0 +1,154 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/middleware"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// jsonError 模拟节点返回的 JSON-RPC 错误
+type jsonError struct {
+	code int
+	msg  string
+}
+
+func (e jsonError) Error() string  { return e.msg }
+func (e jsonError) ErrorCode() int { return e.code }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want middleware.Kind
+	}{
+		{nil, middleware.KindNone},
+		{rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, middleware.KindRateLimit},
+		{rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, middleware.KindTransient},
+		{jsonError{-32005, "daily request count exceeded, request rate limited"}, middleware.KindRateLimit},
+		{jsonError{-32005, "query returned more than 10000 results"}, middleware.KindRangeTooLarge},
+		{jsonError{-32602, "eth_getLogs is limited to a 10,000 range"}, middleware.KindRangeTooLarge},
+		{jsonError{-32000, "header not found"}, middleware.KindHeaderNotFound},
+		{jsonError{-32000, "missing trie node 3f2b1c (path ) state 0x1234 is not available"}, middleware.KindMissingState},
+		{jsonError{-32000, "historical state 0xabcd is not available"}, middleware.KindMissingState},
+		{jsonError{-32000, "state at block #100 is pruned"}, middleware.KindMissingState},
+		{jsonError{-32000, "pruned history unavailable"}, middleware.KindOther},
+		{jsonError{3, "execution reverted"}, middleware.KindOther},
+		{fmt.Errorf("查询失败: %w", context.DeadlineExceeded), middleware.KindTimeout},
+		{io.ErrUnexpectedEOF, middleware.KindTransient},
+		{ethereum.NotFound, middleware.KindOther},
+	}
+	for _, tt := range tests {
+		if got := middleware.Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+// failingBackend 前 fails 次 BalanceAt 和每次 FilterLogs、SendTransaction 都返回 err
+type failingBackend struct {
+	backend.EthBackend
+	err   error
+	fails int
+	calls int
+}
+
+func (f *failingBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	f.calls++
+	if f.calls <= f.fails {
+		return nil, f.err
+	}
+	return f.EthBackend.BalanceAt(ctx, account, blockNumber)
+}
+
+func (f *failingBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.calls++
+	return f.err
+}
+
+func TestRetry(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	account := chain.Accounts[0].Address
+	limited := rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}
+	fast := middleware.WithRetries(3, time.Millisecond, 5*time.Millisecond)
+
+	// 限流错误重试后成功
+	fb := &failingBackend{EthBackend: chain, err: limited, fails: 2}
+	client := middleware.Wrap(fb, fast)
+	balance, err := client.BalanceAt(ctx, account, nil)
+	if err != nil || balance.Cmp(chain.Balance(account)) != 0 {
+		t.Fatalf("BalanceAt = %v, %v", balance, err)
+	}
+	st := client.Stats()["eth_getBalance"]
+	if st.Calls != 1 || st.Retries != 2 || st.RateLimited != 2 || st.Failures != 0 {
+		t.Fatalf("stats = %+v", st)
+	}
+
+	// 超过最大重试次数后返回包装了 ErrRateLimited 的错误
+	fb = &failingBackend{EthBackend: chain, err: limited, fails: 10}
+	client = middleware.Wrap(fb, fast)
+	if _, err := client.BalanceAt(ctx, account, nil); !errors.Is(err, middleware.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if fb.calls != 4 {
+		t.Fatalf("calls = %d, want 1 + 3 retries", fb.calls)
+	}
+
+	// 范围过大不重试，交给调用方拆分区间
+	fb = &failingBackend{EthBackend: chain, err: jsonError{-32005, "query returned more than 10000 results"}}
+	client = middleware.Wrap(fb, fast)
+	if _, err := client.FilterLogs(ctx, ethereum.FilterQuery{}); !errors.Is(err, middleware.ErrRangeTooLarge) || fb.calls != 1 {
+		t.Fatalf("FilterLogs: err = %v, calls = %d", err, fb.calls)
+	}
+
+	// 发送交易不是幂等请求，即使被限流也不重试
+	fb = &failingBackend{EthBackend: chain, err: limited}
+	client = middleware.Wrap(fb, fast)
+	if err := client.SendTransaction(ctx, types.NewTx(&types.LegacyTx{})); !errors.Is(err, middleware.ErrRateLimited) || fb.calls != 1 {
+		t.Fatalf("SendTransaction: err = %v, calls = %d", err, fb.calls)
+	}
+	if got := client.Methods(); len(got) != 1 || got[0] != "eth_sendRawTransaction" {
+		t.Fatalf("methods = %v", got)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := middleware.NewLimiter(100, 2)
+
+	// 桶内的 2 个令牌立即可用，之后每 10 毫秒一个
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("6 requests took %s, want >= 40ms", elapsed)
+	}
+
+	slow := middleware.NewLimiter(0.1, 1)
+	slow.Wait(ctx)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := slow.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
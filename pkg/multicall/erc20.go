@@ -0,0 +1,98 @@
+package multicall
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/query"
+)
+
+// erc20ABI MyToken 的 ABI 包含全部标准 ERC20 方法，可用于任意 ERC20 合约
+func erc20ABI() *abi.ABI {
+	parsed, err := mytoken.MyTokenMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// metadataCalls 查询 name、symbol、decimals 的三个调用
+func metadataCalls(token common.Address, parsed *abi.ABI) []Call {
+	calls := make([]Call, 0, 3)
+	for _, method := range []string{"name", "symbol", "decimals"} {
+		call, _ := NewCall(token, parsed, method) // 无参数方法编码不会失败
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// tokenInfo 用 metadataCalls 的结果和 balanceOf 的结果填充 TokenBalanceInfo
+func tokenInfo(token, holder common.Address, meta []Result, balance Result) (*query.TokenBalanceInfo, error) {
+	for _, r := range []Result{meta[0], meta[1], meta[2], balance} {
+		if r.Err != nil {
+			return nil, fmt.Errorf("查询代币 %s 失败: %w", token.Hex(), r.Err)
+		}
+	}
+	return &query.TokenBalanceInfo{
+		Token:    token,
+		Holder:   holder,
+		Name:     meta[0].Values[0].(string),
+		Symbol:   meta[1].Values[0].(string),
+		Decimals: meta[2].Values[0].(uint8),
+		Raw:      balance.Values[0].(*big.Int),
+	}, nil
+}
+
+// TokenBalances 用一次 Multicall 查询同一个 ERC20 代币在多个地址上的余额，结果与 holders 一一对应
+func (c *Client) TokenBalances(ctx context.Context, token common.Address, holders []common.Address, blockNumber *big.Int) ([]*query.TokenBalanceInfo, error) {
+	parsed := erc20ABI()
+	calls := metadataCalls(token, parsed)
+	for _, holder := range holders {
+		call, err := NewCall(token, parsed, "balanceOf", holder)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	results, err := c.Aggregate(ctx, blockNumber, calls)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*query.TokenBalanceInfo, len(holders))
+	for i, holder := range holders {
+		if infos[i], err = tokenInfo(token, holder, results[:3], results[3+i]); err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
+
+// HolderTokens 用一次 Multicall 查询一个地址持有的多个 ERC20 代币，结果与 tokens 一一对应
+func (c *Client) HolderTokens(ctx context.Context, holder common.Address, tokens []common.Address, blockNumber *big.Int) ([]*query.TokenBalanceInfo, error) {
+	parsed := erc20ABI()
+	calls := make([]Call, 0, 4*len(tokens))
+	for _, token := range tokens {
+		call, err := NewCall(token, parsed, "balanceOf", holder)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(append(calls, metadataCalls(token, parsed)...), call)
+	}
+	results, err := c.Aggregate(ctx, blockNumber, calls)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*query.TokenBalanceInfo, len(tokens))
+	for i, token := range tokens {
+		r := results[4*i : 4*i+4]
+		if infos[i], err = tokenInfo(token, holder, r[:3], r[3]); err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
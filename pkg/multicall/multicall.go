@@ -0,0 +1,249 @@
+// Package multicall 通过 Multicall3 合约把多个只读合约调用合并成一次 eth_call
+//
+// 批量 JSON-RPC（pkg/batch）仍然让节点逐个执行 eth_call，而 Multicall3 的 aggregate3
+// 在一次 EVM 执行中依次调用各个目标合约，所有结果都来自同一个区块状态，服务商也只计一次请求。
+// 每个调用可以单独设置 AllowFailure：允许失败的调用回滚时只在对应结果中记录错误，
+// 不允许失败的调用回滚时整个 aggregate3 回滚。
+//
+//	mc := multicall.New(client)
+//	call, _ := multicall.NewCall(token, erc20ABI, "balanceOf", holder)
+//	results, err := mc.Aggregate(ctx, nil, []multicall.Call{call})
+//	balance := results[0].Values[0].(*big.Int)
+//
+// Multicall3 在主网和大部分公链上都部署在 Address；本地开发链上没有时，
+// 用 EnsureDeployed 部署一份并通过 WithAddress 指定新地址。
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/contract"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+// Address Multicall3 的标准部署地址，在各条链上都相同
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// DefaultMaxCalls 默认每次 eth_call 最多合并的调用数量
+const DefaultMaxCalls = 500
+
+var (
+	// ErrNotDeployed 目标地址上没有 Multicall3 合约
+	ErrNotDeployed = errors.New("指定地址没有部署 Multicall3 合约")
+	// ErrCallFailed 单个调用回滚（仅出现在 Result.Err 中）
+	ErrCallFailed = errors.New("调用失败")
+	// ErrNotDevChain 只在本地开发链上自动部署 Multicall3
+	ErrNotDevChain = errors.New("不是本地开发链，不会自动部署 Multicall3")
+)
+
+// DevChainIDs 视为本地开发链的链 ID：1337（geth --dev、Ganache）和 31337（Hardhat、Anvil）
+var DevChainIDs = []int64{1337, 31337}
+
+// Call 一个待合并的合约调用
+type Call struct {
+	Target       common.Address
+	Data         []byte // 已编码的调用数据（函数选择器 + 参数）
+	AllowFailure bool   // 为 true 时该调用回滚不影响其他调用
+
+	abi    *abi.ABI // 由 NewCall 创建时记录，用于解码返回值
+	method string
+}
+
+// NewCall 用 ABI 编码一个调用，返回值会在 Aggregate 中自动解码到 Result.Values
+// 创建的调用默认允许失败，需要整体回滚时把 AllowFailure 改为 false
+func NewCall(target common.Address, contractABI *abi.ABI, method string, args ...interface{}) (Call, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return Call{}, fmt.Errorf("编码 %s 调用失败: %w", method, err)
+	}
+	return Call{Target: target, Data: data, AllowFailure: true, abi: contractABI, method: method}, nil
+}
+
+// Result 单个调用的结果
+type Result struct {
+	Success    bool
+	ReturnData []byte
+	Values     []interface{} // 解码后的返回值，仅对 NewCall 创建的调用有值
+	Err        error         // 调用回滚（包装 ErrCallFailed）或返回值解码失败
+}
+
+// Config Multicall3 客户端配置
+type Config struct {
+	Address  common.Address // Multicall3 合约地址
+	MaxCalls int            // 每次 eth_call 最多合并的调用数量
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() Config {
+	return Config{Address: Address, MaxCalls: DefaultMaxCalls}
+}
+
+// Option 修改 Config 的函数
+type Option func(*Config)
+
+// WithAddress 使用指定地址上的 Multicall3（如本地链上用 EnsureDeployed 部署的合约）
+func WithAddress(addr common.Address) Option { return func(c *Config) { c.Address = addr } }
+
+// WithMaxCalls 设置每次 eth_call 最多合并的调用数量
+func WithMaxCalls(n int) Option { return func(c *Config) { c.MaxCalls = n } }
+
+// Client Multicall3 客户端
+type Client struct {
+	backend backend.EthBackend
+	cfg     Config
+	abi     *abi.ABI
+}
+
+// New 创建 Multicall3 客户端
+func New(b backend.EthBackend, opts ...Option) *Client {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.MaxCalls <= 0 {
+		cfg.MaxCalls = DefaultMaxCalls
+	}
+	parsed, err := multicall3.Multicall3MetaData.GetAbi()
+	if err != nil {
+		panic(err) // 绑定中的 ABI 是生成的，解析失败说明绑定已损坏
+	}
+	return &Client{backend: b, cfg: cfg, abi: parsed}
+}
+
+// Address 返回使用的 Multicall3 合约地址
+func (c *Client) Address() common.Address { return c.cfg.Address }
+
+// Aggregate 在 blockNumber 区块上执行 calls（nil 表示最新区块），结果与 calls 一一对应
+//
+// 调用数量超过 MaxCalls 时按 MaxCalls 分组发送；某一组执行失败（超出节点的 Gas 上限、不允许失败的调用回滚）时，
+// 对半拆分后重试，最终定位到回滚的单个调用并返回错误。网络错误等其他错误直接返回。
+func (c *Client) Aggregate(ctx context.Context, blockNumber *big.Int, calls []Call) ([]Result, error) {
+	results := make([]Result, 0, len(calls))
+	for start := 0; start < len(calls); start += c.cfg.MaxCalls {
+		end := start + c.cfg.MaxCalls
+		if end > len(calls) {
+			end = len(calls)
+		}
+		chunk, err := c.aggregate(ctx, blockNumber, calls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunk...)
+	}
+	return results, nil
+}
+
+// aggregate 用一次 aggregate3 执行 calls，执行失败时拆分重试
+func (c *Client) aggregate(ctx context.Context, blockNumber *big.Int, calls []Call) ([]Result, error) {
+	args := make([]multicall3.Multicall3Call3, len(calls))
+	for i, call := range calls {
+		args[i] = multicall3.Multicall3Call3{Target: call.Target, AllowFailure: call.AllowFailure, CallData: call.Data}
+	}
+	data, err := c.abi.Pack("aggregate3", args)
+	if err != nil {
+		return nil, fmt.Errorf("编码 aggregate3 失败: %w", err)
+	}
+	out, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &c.cfg.Address, Data: data}, blockNumber)
+	if err != nil {
+		// 只有执行失败（回滚、Gas 不足）才可能是一次合并的调用过多，拆分重试；
+		// 网络错误、取消等与调用数量无关，拆分只会成倍增加失败的请求
+		if len(calls) == 1 || ctx.Err() != nil || revert.NewDecoder(nil).FromError(err) == nil {
+			return nil, fmt.Errorf("执行 aggregate3 失败: %w", err)
+		}
+		mid := len(calls) / 2
+		left, err := c.aggregate(ctx, blockNumber, calls[:mid])
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.aggregate(ctx, blockNumber, calls[mid:])
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotDeployed, c.cfg.Address.Hex())
+	}
+
+	values, err := c.abi.Unpack("aggregate3", out)
+	if err != nil {
+		return nil, fmt.Errorf("解码 aggregate3 返回值失败: %w", err)
+	}
+	raw := *abi.ConvertType(values[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if len(raw) != len(calls) {
+		return nil, fmt.Errorf("aggregate3 返回 %d 个结果，期望 %d 个", len(raw), len(calls))
+	}
+	results := make([]Result, len(calls))
+	for i, r := range raw {
+		results[i] = decode(calls[i], r.Success, r.ReturnData)
+	}
+	return results, nil
+}
+
+// decode 把 aggregate3 中单个调用的结果转换为 Result
+func decode(call Call, success bool, data []byte) Result {
+	res := Result{Success: success, ReturnData: data}
+	if !success {
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			res.Err = fmt.Errorf("%w: %s", ErrCallFailed, reason)
+		} else {
+			res.Err = fmt.Errorf("%w: 返回数据 %x", ErrCallFailed, data)
+		}
+		return res
+	}
+	if call.abi != nil {
+		values, err := call.abi.Unpack(call.method, data)
+		if err != nil {
+			res.Err = fmt.Errorf("解码 %s 返回值失败: %w", call.method, err)
+		} else {
+			res.Values = values
+		}
+	}
+	return res
+}
+
+// EnsureDeployed 确保链上可以使用 Multicall3，返回合约地址
+//
+// 标准地址上已有合约时直接返回 Address；否则在本地开发链（DevChainIDs）上由 signer 部署一份，
+// 返回新合约地址（标准地址只能通过预签名的部署交易得到，本地部署的地址取决于 signer 的 nonce）。
+// 其他链上返回 ErrNotDevChain，避免误在公链上花费 Gas。
+func EnsureDeployed(ctx context.Context, b backend.EthBackend, signer *backend.Signer) (common.Address, error) {
+	code, err := b.CodeAt(ctx, Address, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("查询 Multicall3 合约代码失败: %w", err)
+	}
+	if len(code) > 0 {
+		return Address, nil
+	}
+	chainID, err := b.ChainID(ctx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("获取链 ID 失败: %w", err)
+	}
+	if !isDevChain(chainID) {
+		return common.Address{}, fmt.Errorf("%w（链 ID %s）", ErrNotDevChain, chainID)
+	}
+	d, err := contract.DeployAndWait(ctx, b, signer, common.FromHex(multicall3.Multicall3MetaData.Bin), nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("部署 Multicall3 失败: %w", err)
+	}
+	return d.Address, nil
+}
+
+// isDevChain 判断链 ID 是否属于 DevChainIDs
+func isDevChain(chainID *big.Int) bool {
+	for _, id := range DevChainIDs {
+		if chainID.Cmp(big.NewInt(id)) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package multicall_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/multicall"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// countingBackend 统计 eth_call 次数；调用数据超过 maxData 字节时模拟超出节点 Gas 上限的执行错误，
+// 设置了 err 时每次调用都返回它
+type countingBackend struct {
+	backend.EthBackend
+	calls   int
+	maxData int
+	err     error
+}
+
+func (c *countingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.maxData > 0 && len(msg.Data) > c.maxData {
+		return nil, errors.New("out of gas")
+	}
+	return c.EthBackend.CallContract(ctx, msg, blockNumber)
+}
+
+// setup 部署 Multicall3 和一个 MyToken，返回合约地址
+func setup(t *testing.T) (*simchain.Chain, common.Address, common.Address) {
+	t.Helper()
+	chain := simchain.New(t, simchain.WithAccounts(5), simchain.WithAutoMine())
+	ctx := context.Background()
+	addr, err := multicall.EnsureDeployed(ctx, chain, chain.Accounts[0].Signer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr == multicall.Address {
+		t.Fatal("simulated chain should not have Multicall3 at the canonical address")
+	}
+	tokenAddr, _ := chain.DeployMyToken(chain.Accounts[0], "My Token", "MTK", 18, big.NewInt(1000))
+	return chain, addr, tokenAddr
+}
+
+func TestAggregate(t *testing.T) {
+	chain, addr, tokenAddr := setup(t)
+	ctx := context.Background()
+	parsed, _ := mytoken.MyTokenMetaData.GetAbi()
+
+	var calls []multicall.Call
+	for _, acc := range chain.Accounts {
+		call, err := multicall.NewCall(tokenAddr, parsed, "balanceOf", acc.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, call)
+	}
+	// Multicall3 没有代币，转账会回滚，但允许失败
+	transfer, _ := multicall.NewCall(tokenAddr, parsed, "transfer", chain.Accounts[1].Address, big.NewInt(1))
+	calls = append(calls, transfer)
+
+	cb := &countingBackend{EthBackend: chain}
+	results, err := multicall.New(cb, multicall.WithAddress(addr), multicall.WithMaxCalls(4)).Aggregate(ctx, nil, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cb.calls != 2 {
+		t.Fatalf("eth_call count = %d, want 2", cb.calls)
+	}
+	supply := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	if got := results[0].Values[0].(*big.Int); got.Cmp(supply) != 0 {
+		t.Fatalf("balanceOf(owner) = %s, want %s", got, supply)
+	}
+	if got := results[1].Values[0].(*big.Int); got.Sign() != 0 {
+		t.Fatalf("balanceOf(other) = %s, want 0", got)
+	}
+	last := results[len(results)-1]
+	if last.Success || !errors.Is(last.Err, multicall.ErrCallFailed) {
+		t.Fatalf("transfer result = %+v, want ErrCallFailed", last)
+	}
+
+	// 不允许失败的调用回滚时整体失败
+	transfer.AllowFailure = false
+	if _, err := multicall.New(chain, multicall.WithAddress(addr)).Aggregate(ctx, nil, []multicall.Call{calls[0], transfer}); err == nil {
+		t.Fatal("expected aggregate3 to revert")
+	}
+
+	// 标准地址上没有合约
+	if _, err := multicall.New(chain).Aggregate(ctx, nil, calls[:1]); !errors.Is(err, multicall.ErrNotDeployed) {
+		t.Fatalf("err = %v, want ErrNotDeployed", err)
+	}
+}
+
+func TestAggregateSplitsOnFailure(t *testing.T) {
+	chain, addr, tokenAddr := setup(t)
+	ctx := context.Background()
+	parsed, _ := mytoken.MyTokenMetaData.GetAbi()
+
+	var calls []multicall.Call
+	for i := 0; i < 8; i++ {
+		call, _ := multicall.NewCall(tokenAddr, parsed, "balanceOf", chain.Accounts[i%len(chain.Accounts)].Address)
+		calls = append(calls, call)
+	}
+	// 超过 2 个调用的 aggregate3 都会失败，需要拆成 4 组
+	cb := &countingBackend{EthBackend: chain, maxData: 600}
+	results, err := multicall.New(cb, multicall.WithAddress(addr)).Aggregate(ctx, nil, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(calls) || cb.calls != 1+2+4 {
+		t.Fatalf("results = %d, eth_call count = %d", len(results), cb.calls)
+	}
+	// 网络错误与调用数量无关，不拆分
+	down := &countingBackend{EthBackend: chain, err: errors.New("connection refused")}
+	if _, err := multicall.New(down, multicall.WithAddress(addr)).Aggregate(ctx, nil, calls); err == nil || down.calls != 1 {
+		t.Fatalf("err = %v, eth_call count = %d", err, down.calls)
+	}
+
+	supply := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	for i, r := range results {
+		want := new(big.Int)
+		if i%len(chain.Accounts) == 0 {
+			want = supply
+		}
+		if r.Err != nil || r.Values[0].(*big.Int).Cmp(want) != 0 {
+			t.Fatalf("result %d = %+v, want %s", i, r, want)
+		}
+	}
+}
+
+func TestTokenHelpers(t *testing.T) {
+	chain, addr, tokenAddr := setup(t)
+	ctx := context.Background()
+	otherAddr, _ := chain.DeployMyToken(chain.Accounts[1], "Other", "OTH", 6, big.NewInt(5))
+	mc := multicall.New(chain, multicall.WithAddress(addr))
+
+	holders := []common.Address{chain.Accounts[0].Address, chain.Accounts[1].Address}
+	balances, err := mc.TokenBalances(ctx, tokenAddr, holders, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balances[0].Symbol != "MTK" || balances[0].Decimals != 18 || balances[0].Value().Cmp(big.NewFloat(1000)) != 0 {
+		t.Fatalf("balance 0 = %+v", balances[0])
+	}
+	if balances[1].Holder != holders[1] || balances[1].Raw.Sign() != 0 {
+		t.Fatalf("balance 1 = %+v", balances[1])
+	}
+
+	tokens, err := mc.HolderTokens(ctx, chain.Accounts[1].Address, []common.Address{tokenAddr, otherAddr}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens[0].Raw.Sign() != 0 || tokens[1].Name != "Other" || tokens[1].Raw.Cmp(big.NewInt(5_000_000)) != 0 {
+		t.Fatalf("tokens = %+v, %+v", tokens[0], tokens[1])
+	}
+
+	// 不是 ERC20 的地址返回错误
+	if _, err := mc.HolderTokens(ctx, holders[0], []common.Address{holders[1]}, nil); err == nil {
+		t.Fatal("expected error for an account without code")
+	}
+}
@@ -0,0 +1,248 @@
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// errUnsupported 节点不支持该可选方法，换下一个节点重试，但不把该节点标记为不健康
+var errUnsupported = errors.New("节点不支持该方法")
+
+// ===== 链信息 =====
+
+// ChainID 返回健康检查确认的链 ID，不发起网络请求
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	c.chainMu.RLock()
+	defer c.chainMu.RUnlock()
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// BlockNumber 返回最新区块号
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	header, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// ===== 区块与交易 =====
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Block, error) {
+		return b.BlockByHash(ctx, hash)
+	})
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Block, error) {
+		return b.BlockByNumber(ctx, number)
+	})
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Header, error) {
+		return b.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Header, error) {
+		return b.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (uint, error) {
+		return b.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Transaction, error) {
+		return b.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	r, err := call(ctx, c, func(ctx context.Context, b backend.EthBackend) (result, error) {
+		tx, isPending, err := b.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+	return r.tx, r.isPending, err
+}
+
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*types.Receipt, error) {
+		return b.TransactionReceipt(ctx, hash)
+	})
+}
+
+// BlockReceipts 批量查询区块收据，只发给实现了 backend.BlockReceiptsReader 的节点
+func (c *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]*types.Receipt, error) {
+		r, ok := b.(backend.BlockReceiptsReader)
+		if !ok {
+			return nil, errUnsupported
+		}
+		return r.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+// ===== 账户状态 =====
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*big.Int, error) {
+		return b.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// PendingBalanceAt 查询待处理余额，只发给实现了 backend.PendingBalanceReader 的节点
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*big.Int, error) {
+		r, ok := b.(backend.PendingBalanceReader)
+		if !ok {
+			return nil, errUnsupported
+		}
+		return r.PendingBalanceAt(ctx, account)
+	})
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]byte, error) {
+		return b.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]byte, error) {
+		return b.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (uint64, error) {
+		return b.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]byte, error) {
+		return b.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (uint64, error) {
+		return b.PendingNonceAt(ctx, account)
+	})
+}
+
+// ===== 合约调用与 Gas =====
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]byte, error) {
+		return b.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (uint64, error) {
+		return b.EstimateGas(ctx, msg)
+	})
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*big.Int, error) {
+		return b.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) (*big.Int, error) {
+		return b.SuggestGasTipCap(ctx)
+	})
+}
+
+// ===== 日志与订阅 =====
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return call(ctx, c, func(ctx context.Context, b backend.EthBackend) ([]types.Log, error) {
+		return b.FilterLogs(ctx, q)
+	})
+}
+
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return subscribe(ctx, c, func(ctx context.Context, b backend.EthBackend) (ethereum.Subscription, error) {
+		return b.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return subscribe(ctx, c, func(ctx context.Context, b backend.EthBackend) (ethereum.Subscription, error) {
+		return b.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// ===== 原始 JSON-RPC 调用 =====
+
+// CallContext 在节点上执行任意 JSON-RPC 方法（如 pkg/proxy 转发的请求），失败时与其他请求一样切换节点
+// 只使用实现了 backend.RPCClient 的节点，backend.Dial 返回的 *ethclient.Client 满足
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	_, err := call(ctx, c, func(ctx context.Context, b backend.EthBackend) (struct{}, error) {
+		client, ok := backend.RPC(b)
+		if !ok {
+			return struct{}{}, errUnsupported
+		}
+		return struct{}{}, client.CallContext(ctx, result, method, args...)
+	})
+	return err
+}
+
+// ===== 发送交易 =====
+
+// SendTransaction 把交易并发广播给所有健康节点（没有健康节点时广播给全部节点）
+// 任一节点接受即返回成功；节点返回“already known”说明交易已在其交易池中，同样视为接受
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	targets := c.candidates()
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, e := range targets {
+		wg.Add(1)
+		go func(i int, e *Endpoint) {
+			defer wg.Done()
+			attemptCtx, cancel := c.attemptContext(ctx)
+			defer cancel()
+			if err := e.Backend.SendTransaction(attemptCtx, tx); err != nil && !isAlreadyKnown(err) {
+				errs[i] = fmt.Errorf("%s: %w", e.Name, err)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("所有节点均拒绝交易 %s: %w", tx.Hash().Hex(), errors.Join(errs...))
+}
+
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
@@ -0,0 +1,430 @@
+// Package multiclient 提供同时连接多个以太坊节点的客户端
+//
+// 与 pkg/common.NewEthClient 只连接一个 URL 不同，这里的 Client 为同一网络接入多个节点：
+//   - 定期做健康检查：最新区块落后程度、响应延迟、链 ID 是否一致
+//   - 读请求按轮询或“区块最新优先”的策略分发给健康节点，出错或超时自动切换到下一个节点
+//   - 发送交易时广播给所有健康节点，任一节点接受即视为成功
+//
+// Client 实现了 backend.EthBackend，可以直接替换 *ethclient.Client 传给 pkg 下的各功能库：
+//
+//	client, err := multiclient.Dial(ctx, []string{
+//		"https://eth-sepolia.g.alchemy.com/v2/<API_KEY>",
+//		"https://sepolia.infura.io/v3/<API_KEY>",
+//	}, multiclient.WithStrategy(multiclient.Freshest))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer client.Close()
+//	balance, err := query.Balance(ctx, client, account, nil)
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ErrNoEndpoints 没有配置任何节点
+var ErrNoEndpoints = errors.New("没有可用的节点")
+
+// Strategy 读请求的路由策略
+type Strategy int
+
+const (
+	// RoundRobin 在健康节点之间轮询
+	RoundRobin Strategy = iota
+	// Freshest 优先选择最新区块最高的节点，高度相同时选择延迟最低的
+	Freshest
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case RoundRobin:
+		return "round-robin"
+	case Freshest:
+		return "freshest"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// Config 多节点客户端配置
+type Config struct {
+	Strategy       Strategy
+	ChainID        *big.Int      // 期望的链 ID，为 nil 时以第一个响应成功的节点为准
+	MaxHeadLag     uint64        // 最新区块落后最高节点超过该值的节点视为不健康
+	MaxLatency     time.Duration // 健康检查响应超过该值的节点视为不健康
+	RequestTimeout time.Duration // 单个节点单次请求的超时时间
+	CheckInterval  time.Duration // 后台健康检查间隔，为 0 时只在创建时检查一次
+}
+
+// DefaultConfig 返回默认配置：轮询，允许落后 3 个区块，延迟上限 2 秒，请求超时 10 秒，每 15 秒检查一次
+func DefaultConfig() *Config {
+	return &Config{
+		Strategy:       RoundRobin,
+		MaxHeadLag:     3,
+		MaxLatency:     2 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		CheckInterval:  15 * time.Second,
+	}
+}
+
+// Option 修改多节点客户端配置
+type Option func(*Config)
+
+// WithStrategy 设置读请求的路由策略
+func WithStrategy(s Strategy) Option { return func(c *Config) { c.Strategy = s } }
+
+// WithChainID 设置期望的链 ID，链 ID 不一致的节点不会被使用
+func WithChainID(id *big.Int) Option { return func(c *Config) { c.ChainID = id } }
+
+// WithMaxHeadLag 设置允许落后的最大区块数
+func WithMaxHeadLag(n uint64) Option { return func(c *Config) { c.MaxHeadLag = n } }
+
+// WithMaxLatency 设置健康检查允许的最大延迟
+func WithMaxLatency(d time.Duration) Option { return func(c *Config) { c.MaxLatency = d } }
+
+// WithRequestTimeout 设置单个节点单次请求的超时时间
+func WithRequestTimeout(d time.Duration) Option { return func(c *Config) { c.RequestTimeout = d } }
+
+// WithCheckInterval 设置后台健康检查间隔，0 表示关闭后台检查
+func WithCheckInterval(d time.Duration) Option { return func(c *Config) { c.CheckInterval = d } }
+
+// Endpoint 一个节点及其最近一次健康检查的结果
+type Endpoint struct {
+	Name    string
+	Backend backend.EthBackend
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// Status 节点健康状态
+type Status struct {
+	Name      string
+	Healthy   bool
+	Head      uint64        // 最新区块号
+	Latency   time.Duration // 健康检查中获取最新区块头的耗时
+	ChainID   *big.Int
+	Err       error // 最近一次检查或请求的错误
+	CheckedAt time.Time
+}
+
+// NewEndpoint 用已有的后端创建节点，name 用于日志和状态展示
+func NewEndpoint(name string, b backend.EthBackend) *Endpoint {
+	return &Endpoint{Name: name, Backend: b, status: Status{Name: name}}
+}
+
+// Status 返回节点当前的健康状态
+func (e *Endpoint) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status
+}
+
+func (e *Endpoint) healthy() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status.Healthy
+}
+
+// markFailed 请求失败后把节点标记为不健康，等待下一次健康检查恢复
+func (e *Endpoint) markFailed(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.Healthy = false
+	e.status.Err = err
+}
+
+// Client 多节点以太坊客户端
+type Client struct {
+	cfg       Config
+	endpoints []*Endpoint
+	next      atomic.Uint64
+
+	chainMu sync.RWMutex
+	chainID *big.Int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Client 可以直接传给 pkg 下的各功能库
+var (
+	_ backend.EthBackend           = (*Client)(nil)
+	_ backend.BlockReceiptsReader  = (*Client)(nil)
+	_ backend.PendingBalanceReader = (*Client)(nil)
+)
+
+// Dial 连接 urls 中的全部节点并完成首次健康检查
+// 个别节点连接失败不影响创建，只要有一个节点健康即可
+func Dial(ctx context.Context, urls []string, opts ...Option) (*Client, error) {
+	endpoints := make([]*Endpoint, 0, len(urls))
+	var errs []error
+	for _, url := range urls {
+		client, err := backend.Dial(url)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+			continue
+		}
+		endpoints = append(endpoints, NewEndpoint(url, client))
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.Join(append([]error{ErrNoEndpoints}, errs...)...)
+	}
+	return New(ctx, endpoints, opts...)
+}
+
+// New 用已创建的节点构造客户端并完成首次健康检查
+// 没有任何健康节点时返回错误；CheckInterval 大于 0 时启动后台健康检查，需调用 Close 停止
+func New(ctx context.Context, endpoints []*Endpoint, opts ...Option) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	c := &Client{cfg: *cfg, endpoints: endpoints, chainID: cfg.ChainID}
+	if c.CheckHealth(ctx) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoEndpoints, c.describeErrors())
+	}
+	if cfg.CheckInterval > 0 {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.healthLoop()
+	}
+	return c, nil
+}
+
+// Close 停止后台健康检查，并关闭实现了 Close 方法的底层客户端
+func (c *Client) Close() {
+	if c.stop != nil {
+		close(c.stop)
+		<-c.done
+		c.stop = nil
+	}
+	for _, e := range c.endpoints {
+		if closer, ok := e.Backend.(interface{ Close() }); ok {
+			closer.Close()
+		}
+	}
+}
+
+// Endpoints 返回全部节点的健康状态，顺序与创建时一致
+func (c *Client) Endpoints() []Status {
+	out := make([]Status, len(c.endpoints))
+	for i, e := range c.endpoints {
+		out[i] = e.Status()
+	}
+	return out
+}
+
+func (c *Client) healthLoop() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.CheckHealth(context.Background())
+		}
+	}
+}
+
+// CheckHealth 立即检查所有节点并更新健康状态，返回健康节点数量
+//
+// 节点满足以下条件才视为健康：
+//  1. 能在 RequestTimeout 内返回最新区块头和链 ID
+//  2. 链 ID 与期望值一致
+//  3. 最新区块落后所有节点中最高区块不超过 MaxHeadLag
+//  4. 获取区块头的延迟不超过 MaxLatency
+func (c *Client) CheckHealth(ctx context.Context) int {
+	results := make([]Status, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, e *Endpoint) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	// 未指定链 ID 时以配置顺序中第一个响应成功的节点为准，之后不再改变
+	c.chainMu.Lock()
+	if c.chainID == nil {
+		for _, r := range results {
+			if r.Err == nil {
+				c.chainID = r.ChainID
+				break
+			}
+		}
+	}
+	expected := c.chainID
+	c.chainMu.Unlock()
+
+	var maxHead uint64
+	for _, r := range results {
+		if r.Err == nil && r.ChainID.Cmp(expected) == 0 && r.Head > maxHead {
+			maxHead = r.Head
+		}
+	}
+
+	healthy := 0
+	for i, r := range results {
+		switch {
+		case r.Err != nil:
+		case r.ChainID.Cmp(expected) != 0:
+			r.Err = fmt.Errorf("链ID不一致: 期望 %s, 实际 %s", expected, r.ChainID)
+		case maxHead-r.Head > c.cfg.MaxHeadLag:
+			r.Err = fmt.Errorf("区块落后 %d 个（最新 %d, 本节点 %d）", maxHead-r.Head, maxHead, r.Head)
+		case c.cfg.MaxLatency > 0 && r.Latency > c.cfg.MaxLatency:
+			r.Err = fmt.Errorf("响应过慢: %s", r.Latency)
+		default:
+			r.Healthy = true
+			healthy++
+		}
+		e := c.endpoints[i]
+		e.mu.Lock()
+		e.status = r
+		e.mu.Unlock()
+	}
+	return healthy
+}
+
+// probe 查询单个节点的最新区块头和链 ID
+func (c *Client) probe(ctx context.Context, e *Endpoint) Status {
+	st := Status{Name: e.Name, CheckedAt: time.Now()}
+	ctx, cancel := c.attemptContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	header, err := e.Backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		st.Err = fmt.Errorf("获取最新区块头失败: %w", err)
+		return st
+	}
+	st.Latency = time.Since(start)
+	st.Head = header.Number.Uint64()
+	if st.ChainID, err = e.Backend.ChainID(ctx); err != nil {
+		st.Err = fmt.Errorf("获取链ID失败: %w", err)
+	}
+	return st
+}
+
+func (c *Client) describeErrors() string {
+	var msg string
+	for _, st := range c.Endpoints() {
+		if msg != "" {
+			msg += "; "
+		}
+		msg += fmt.Sprintf("%s: %v", st.Name, st.Err)
+	}
+	return msg
+}
+
+func (c *Client) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.cfg.RequestTimeout > 0 {
+		return context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// candidates 按路由策略排列健康节点；没有健康节点时退而使用全部节点
+func (c *Client) candidates() []*Endpoint {
+	var healthy []*Endpoint
+	for _, e := range c.endpoints {
+		if e.healthy() {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = append(healthy, c.endpoints...)
+	}
+
+	switch c.cfg.Strategy {
+	case Freshest:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			a, b := healthy[i].Status(), healthy[j].Status()
+			if a.Head != b.Head {
+				return a.Head > b.Head
+			}
+			return a.Latency < b.Latency
+		})
+	default:
+		start := int(c.next.Add(1)-1) % len(healthy)
+		rotated := make([]*Endpoint, 0, len(healthy))
+		healthy = append(append(rotated, healthy[start:]...), healthy[:start]...)
+	}
+	return healthy
+}
+
+// shouldFailover 判断请求失败后是否值得换一个节点重试
+// 数据不存在和合约执行回滚是确定性的结果，换节点也不会改变
+func shouldFailover(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var dataErr rpc.DataError
+	return !errors.As(err, &dataErr)
+}
+
+// call 按路由策略依次尝试各节点，直到请求成功、遇到确定性错误或 ctx 结束
+func call[T any](ctx context.Context, c *Client, fn func(context.Context, backend.EthBackend) (T, error)) (T, error) {
+	var (
+		zero T
+		errs []error
+	)
+	for _, e := range c.candidates() {
+		attemptCtx, cancel := c.attemptContext(ctx)
+		result, err := fn(attemptCtx, e.Backend)
+		cancel()
+		if err == nil {
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		if !shouldFailover(err) {
+			return zero, err
+		}
+		// 节点只是不支持该可选方法，并没有出故障，其他请求照常发给它
+		if !errors.Is(err, errUnsupported) {
+			e.markFailed(err)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+	}
+	return zero, fmt.Errorf("所有节点请求均失败: %w", errors.Join(errs...))
+}
+
+// subscribe 订阅只建立在一个节点上，建立失败时切换节点
+// 订阅不能套用单次请求超时，因此直接使用调用方的 ctx
+func subscribe(ctx context.Context, c *Client, fn func(context.Context, backend.EthBackend) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	var errs []error
+	for _, e := range c.candidates() {
+		sub, err := fn(ctx, e.Backend)
+		if err == nil {
+			return sub, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		e.markFailed(err)
+		errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+	}
+	return nil, fmt.Errorf("所有节点订阅均失败: %w", errors.Join(errs...))
+}
@@ -0,0 +1,294 @@
+package multiclient_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
+	"github.com/duanyu/new-eth-project/pkg/query"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+var errDown = errors.New("节点不可用")
+
+// flakyBackend 包装后端：统计 BalanceAt 调用次数，并可让读请求、发送交易失败或篡改链 ID
+type flakyBackend struct {
+	backend.EthBackend
+	calls     atomic.Int32
+	failReads bool
+	failSends bool
+	chainID   *big.Int
+}
+
+func (f *flakyBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	f.calls.Add(1)
+	if f.failReads {
+		return nil, errDown
+	}
+	return f.EthBackend.BalanceAt(ctx, account, blockNumber)
+}
+
+func (f *flakyBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if f.failSends {
+		return errDown
+	}
+	return f.EthBackend.SendTransaction(ctx, tx)
+}
+
+func (f *flakyBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	if f.chainID != nil {
+		return f.chainID, nil
+	}
+	return f.EthBackend.ChainID(ctx)
+}
+
+func newClient(t *testing.T, backends []backend.EthBackend, opts ...multiclient.Option) *multiclient.Client {
+	t.Helper()
+	endpoints := make([]*multiclient.Endpoint, len(backends))
+	for i, b := range backends {
+		endpoints[i] = multiclient.NewEndpoint(string(rune('a'+i)), b)
+	}
+	opts = append([]multiclient.Option{multiclient.WithCheckInterval(0)}, opts...)
+	client, err := multiclient.New(context.Background(), endpoints, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestHealthCheck(t *testing.T) {
+	behind, ahead, wrongChain := simchain.New(t), simchain.New(t), simchain.New(t)
+	ahead.MineBlocks(10)
+	wrongChain.MineBlocks(10)
+
+	client := newClient(t, []backend.EthBackend{
+		behind, ahead, &flakyBackend{EthBackend: wrongChain, chainID: big.NewInt(5)},
+	}, multiclient.WithChainID(simchain.ChainID), multiclient.WithStrategy(multiclient.Freshest))
+
+	status := client.Endpoints()
+	if status[0].Healthy || status[0].Err == nil {
+		t.Errorf("lagging endpoint should be unhealthy: %+v", status[0])
+	}
+	if !status[1].Healthy || status[1].Head != 10 {
+		t.Errorf("leading endpoint status = %+v", status[1])
+	}
+	if status[2].Healthy {
+		t.Errorf("endpoint with wrong chain ID should be unhealthy: %+v", status[2])
+	}
+
+	// 落后的节点追上后重新检查即恢复健康
+	behind.MineBlocks(9)
+	if n := client.CheckHealth(context.Background()); n != 2 {
+		t.Fatalf("healthy endpoints = %d, want 2", n)
+	}
+
+	ctx := context.Background()
+	if n, err := client.BlockNumber(ctx); err != nil || n != 10 {
+		t.Fatalf("freshest BlockNumber = %d, %v; want 10", n, err)
+	}
+	if _, err := multiclient.New(ctx, []*multiclient.Endpoint{
+		multiclient.NewEndpoint("x", &flakyBackend{EthBackend: behind, chainID: big.NewInt(5)}),
+	}, multiclient.WithChainID(simchain.ChainID), multiclient.WithCheckInterval(0)); !errors.Is(err, multiclient.ErrNoEndpoints) {
+		t.Fatalf("New without healthy endpoints: err = %v", err)
+	}
+}
+
+func TestRoundRobinAndFailover(t *testing.T) {
+	a := &flakyBackend{EthBackend: simchain.New(t)}
+	b := &flakyBackend{EthBackend: simchain.New(t)}
+	client := newClient(t, []backend.EthBackend{a, b})
+	ctx := context.Background()
+	account := simchain.New(t).Accounts[0].Address
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.BalanceAt(ctx, account, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.calls.Load() != 2 || b.calls.Load() != 2 {
+		t.Fatalf("round robin calls = %d/%d, want 2/2", a.calls.Load(), b.calls.Load())
+	}
+
+	// a 出错时自动切换到 b，并把 a 标记为不健康，之后的请求不再发给 a
+	a.failReads = true
+	for i := 0; i < 3; i++ {
+		if _, err := client.BalanceAt(ctx, account, nil); err != nil {
+			t.Fatalf("failover request %d: %v", i, err)
+		}
+	}
+	if a.calls.Load() != 3 || b.calls.Load() != 5 {
+		t.Fatalf("calls after failure = %d/%d, want 3/5", a.calls.Load(), b.calls.Load())
+	}
+	if st := client.Endpoints()[0]; st.Healthy || !errors.Is(st.Err, errDown) {
+		t.Fatalf("failed endpoint status = %+v", st)
+	}
+
+	// 全部节点失败时返回汇总错误
+	b.failReads = true
+	if _, err := client.BalanceAt(ctx, account, nil); !errors.Is(err, errDown) {
+		t.Fatalf("all endpoints down: err = %v", err)
+	}
+}
+
+func TestNotFoundDoesNotFailover(t *testing.T) {
+	client := newClient(t, []backend.EthBackend{simchain.New(t), simchain.New(t)})
+	_, err := client.TransactionReceipt(context.Background(), common.Hash{1})
+	if !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+	for _, st := range client.Endpoints() {
+		if !st.Healthy {
+			t.Fatalf("NotFound should not mark %s unhealthy", st.Name)
+		}
+	}
+}
+
+func TestUnsupportedDoesNotMarkFailed(t *testing.T) {
+	// 第一个节点只暴露 EthBackend，没有 eth_getBlockReceipts
+	plain := &flakyBackend{EthBackend: simchain.New(t)}
+	client := newClient(t, []backend.EthBackend{plain, simchain.New(t)})
+	ctx := context.Background()
+
+	if _, err := client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)); err != nil {
+		t.Fatalf("BlockReceipts should fail over to the endpoint that supports it: %v", err)
+	}
+	if st := client.Endpoints()[0]; !st.Healthy {
+		t.Fatalf("endpoint without an optional method should stay healthy: %+v", st)
+	}
+}
+
+func TestBroadcastSend(t *testing.T) {
+	a, b := simchain.New(t), simchain.New(t)
+	rejecting := &flakyBackend{EthBackend: simchain.New(t), failSends: true}
+	client := newClient(t, []backend.EthBackend{a, b, rejecting}, multiclient.WithStrategy(multiclient.Freshest))
+	ctx := context.Background()
+	alice, bob := a.Accounts[0], a.Accounts[1]
+
+	// 通过 pkg/query 等功能库使用，与单节点客户端没有区别
+	before, err := query.Balance(ctx, client, bob.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, _ := backend.BuildTx(ctx, client, alice.Address, &bob.Address, big.NewInt(1e18), nil, nil)
+	signed, err := backend.SignAndSend(ctx, client, alice.Signer(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Mine()
+	b.Mine()
+	for _, chain := range []*simchain.Chain{a, b} {
+		if chain.Receipt(signed.Hash()).Status != types.ReceiptStatusSuccessful {
+			t.Fatal("broadcast transaction failed")
+		}
+	}
+
+	// 未收到交易的节点落后一个区块，Freshest 策略会把读请求发给已打包交易的节点
+	client.CheckHealth(ctx)
+	after, _ := query.Balance(ctx, client, bob.Address, nil)
+	if diff := new(big.Int).Sub(after.Wei, before.Wei); diff.Cmp(big.NewInt(1e18)) != 0 {
+		t.Fatalf("balance diff = %s, want 1e18", diff)
+	}
+
+	// 所有节点都拒绝时返回错误
+	rejectAll := newClient(t, []backend.EthBackend{&flakyBackend{EthBackend: simchain.New(t), failSends: true}})
+	if err := rejectAll.SendTransaction(ctx, signed); !errors.Is(err, errDown) {
+		t.Fatalf("err = %v, want errDown", err)
+	}
+}
+
+// lyingBackend 返回错误的余额和 eth_call 结果
+type lyingBackend struct {
+	backend.EthBackend
+}
+
+func (l lyingBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	balance, err := l.EthBackend.BalanceAt(ctx, account, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return balance.Add(balance, big.NewInt(1)), nil
+}
+
+func (l lyingBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return common.LeftPadBytes([]byte{1}, 32), nil
+}
+
+func TestQuorum(t *testing.T) {
+	chains := []*simchain.Chain{simchain.New(t, simchain.WithAutoMine()), simchain.New(t, simchain.WithAutoMine()), simchain.New(t, simchain.WithAutoMine())}
+	var token common.Address
+	var tx *types.Transaction
+	for _, chain := range chains {
+		// 相同的确定性账户和交易，三条链的状态完全一致
+		token, _ = chain.DeployMyToken(chain.Accounts[0], "My Token", "MTK", 18, big.NewInt(1000))
+		tx = chain.TransferETH(chain.Accounts[0], chain.Accounts[1].Address, big.NewInt(1e18))
+	}
+	a, b, c := chains[0], chains[1], chains[2]
+	client := newClient(t, []backend.EthBackend{a, b, lyingBackend{c}})
+	ctx := context.Background()
+	bob := a.Accounts[1].Address
+
+	q, err := client.Quorum(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, report, err := q.BalanceAt(ctx, bob, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(a.Balance(bob)) != 0 || report.Agree < 2 || report.Block.Cmp(a.Head().Number) != 0 {
+		t.Fatalf("balance = %s\n%s", balance, report)
+	}
+
+	// 要求三个节点一致时，说谎的节点导致失败，报告中列出它的回答
+	all, _ := client.Quorum(3)
+	_, report, err = all.BalanceAt(ctx, bob, nil)
+	if !errors.Is(err, multiclient.ErrNoQuorum) {
+		t.Fatalf("err = %v", err)
+	}
+	if d := report.Divergent(); len(d) != 1 || d[0].Endpoint != "c" || report.Agree != 2 {
+		t.Fatalf("report:\n%s", report)
+	}
+
+	totalSupply := ethereum.CallMsg{To: &token, Data: common.FromHex("0x18160ddd")}
+	out, _, err := q.CallContract(ctx, totalSupply, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supply, _ := a.CallContract(ctx, totalSupply, nil); common.Bytes2Hex(out) != common.Bytes2Hex(supply) {
+		t.Fatalf("call = %x, want %x", out, supply)
+	}
+
+	receipt, report, err := all.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful || report.Agree != 3 {
+		t.Fatalf("receipt err = %v\n%s", err, report)
+	}
+	if _, report, err := all.TransactionReceipt(ctx, common.Hash{1}); !errors.Is(err, ethereum.NotFound) || report.Agree != 3 {
+		t.Fatalf("missing receipt err = %v", err)
+	}
+
+	// c 落后一个区块：固定到 a、b 都已到达的区块，c 的回答记为分歧
+	a.Mine()
+	b.Mine()
+	client.CheckHealth(ctx)
+	hash, report, err := q.BlockHash(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != a.Head().Hash() || report.Block.Cmp(a.Head().Number) != 0 {
+		t.Fatalf("hash = %s, head = %s\n%s", hash.Hex(), a.Head().Hash().Hex(), report)
+	}
+	if _, err := client.Quorum(4); err == nil {
+		t.Fatal("expected error for quorum larger than endpoint count")
+	}
+}
@@ -0,0 +1,282 @@
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ErrNoQuorum 给出相同结果的节点数量不足
+var ErrNoQuorum = errors.New("给出相同结果的节点数量不足")
+
+// Quorum 多节点一致性读取：同一个请求发给多个节点，在固定的区块上比较结果，
+// 至少 K 个节点给出相同结果时才返回，用于发现返回错误数据或明显落后的节点
+//
+//	q, _ := client.Quorum(2)
+//	balance, report, err := q.BalanceAt(ctx, account, nil)
+//	if errors.Is(err, multiclient.ErrNoQuorum) {
+//		fmt.Print(report) // 每个节点返回了什么
+//	}
+type Quorum struct {
+	c *Client
+	k int
+}
+
+// Quorum 返回要求至少 k 个节点一致的读取器，k 不能超过节点总数
+func (c *Client) Quorum(k int) (*Quorum, error) {
+	if k < 1 || k > len(c.endpoints) {
+		return nil, fmt.Errorf("无效的法定数 %d（共 %d 个节点）", k, len(c.endpoints))
+	}
+	return &Quorum{c: c, k: k}, nil
+}
+
+// Vote 单个节点的回答
+type Vote struct {
+	Endpoint string
+	Value    string // 结果的规范化表示，相同表示一致
+	Err      error
+	Agree    bool // 是否属于多数结果
+}
+
+// Report 一次一致性读取中各节点的回答
+type Report struct {
+	Method string
+	Block  *big.Int // 固定的区块号，与区块无关的请求（如收据）为 nil
+	Need   int
+	Agree  int
+	Votes  []Vote
+}
+
+// Divergent 返回与多数结果不一致（包括出错）的节点
+func (r *Report) Divergent() []Vote {
+	var out []Vote
+	for _, v := range r.Votes {
+		if !v.Agree {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// String 逐个显示节点的回答
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s", r.Method)
+	if r.Block != nil {
+		fmt.Fprintf(&sb, " @ 区块 %s", r.Block)
+	}
+	fmt.Fprintf(&sb, "：%d/%d 个节点一致（需要 %d）\n", r.Agree, len(r.Votes), r.Need)
+	for _, v := range r.Votes {
+		mark := "✓"
+		if !v.Agree {
+			mark = "✗"
+		}
+		if v.Err != nil {
+			fmt.Fprintf(&sb, "  %s %s: 错误 %v\n", mark, v.Endpoint, v.Err)
+		} else {
+			fmt.Fprintf(&sb, "  %s %s: %s\n", mark, v.Endpoint, v.Value)
+		}
+	}
+	return sb.String()
+}
+
+// Pin 选出至少 K 个节点已经到达的最高区块：按健康检查记录的最新区块从高到低排序，取第 K 个。
+// 所有节点在同一个区块上回答，结果才有可比性，刚出的新区块也不会被误判为分歧
+func (q *Quorum) Pin(ctx context.Context) (*big.Int, error) {
+	heads := make([]uint64, 0, len(q.c.endpoints))
+	for _, e := range q.c.endpoints {
+		if st := e.Status(); st.Err == nil {
+			heads = append(heads, st.Head)
+		}
+	}
+	if len(heads) < q.k {
+		// 健康检查结果不足时重新检查一次
+		q.c.CheckHealth(ctx)
+		heads = heads[:0]
+		for _, e := range q.c.endpoints {
+			if st := e.Status(); st.Err == nil {
+				heads = append(heads, st.Head)
+			}
+		}
+		if len(heads) < q.k {
+			return nil, fmt.Errorf("%w: 只有 %d 个节点可用，需要 %d 个", ErrNoQuorum, len(heads), q.k)
+		}
+	}
+	sort.Slice(heads, func(i, j int) bool { return heads[i] > heads[j] })
+	return new(big.Int).SetUint64(heads[q.k-1]), nil
+}
+
+// pin blockNumber 为 nil 时固定到 Pin 选出的区块
+func (q *Quorum) pin(ctx context.Context, blockNumber *big.Int) (*big.Int, error) {
+	if blockNumber != nil {
+		return blockNumber, nil
+	}
+	return q.Pin(ctx)
+}
+
+// BalanceAt 一致性读取余额，blockNumber 为 nil 时使用 Pin 选出的区块
+func (q *Quorum) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, *Report, error) {
+	block, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vote(ctx, q, "eth_getBalance "+account.Hex(), block, func(ctx context.Context, b backend.EthBackend) (*big.Int, error) {
+		return b.BalanceAt(ctx, account, block)
+	}, func(v *big.Int) string { return v.String() })
+}
+
+// BlockHash 一致性读取区块哈希（对区块头重新计算），number 为 nil 时使用 Pin 选出的区块
+func (q *Quorum) BlockHash(ctx context.Context, number *big.Int) (common.Hash, *Report, error) {
+	block, err := q.pin(ctx, number)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	header, report, err := vote(ctx, q, "区块哈希", block, func(ctx context.Context, b backend.EthBackend) (*types.Header, error) {
+		return b.HeaderByNumber(ctx, block)
+	}, func(h *types.Header) string { return h.Hash().Hex() })
+	if err != nil {
+		return common.Hash{}, report, err
+	}
+	return header.Hash(), report, nil
+}
+
+// CallContract 一致性执行 eth_call，blockNumber 为 nil 时使用 Pin 选出的区块；
+// 合约回滚也是一种结果，多数节点回滚时返回回滚错误
+func (q *Quorum) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, *Report, error) {
+	block, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vote(ctx, q, "eth_call", block, func(ctx context.Context, b backend.EthBackend) ([]byte, error) {
+		return b.CallContract(ctx, msg, block)
+	}, func(v []byte) string { return hexutil.Encode(v) })
+}
+
+// TransactionReceipt 一致性读取收据：比较收据的共识字段（状态、累计 Gas、布隆过滤器、日志）
+// 以及所在区块，交易不存在（NotFound）也参与投票
+func (q *Quorum) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, *Report, error) {
+	return vote(ctx, q, "eth_getTransactionReceipt "+hash.Hex(), nil, func(ctx context.Context, b backend.EthBackend) (*types.Receipt, error) {
+		return b.TransactionReceipt(ctx, hash)
+	}, receiptKey)
+}
+
+// receiptKey 收据的规范化表示：区块号、区块哈希和共识编码的摘要
+func receiptKey(r *types.Receipt) string {
+	enc, err := r.MarshalBinary()
+	if err != nil {
+		return fmt.Sprintf("无法编码: %v", err)
+	}
+	return fmt.Sprintf("区块 %s (%s) 状态 %d Gas %d 日志 %d 摘要 %s",
+		r.BlockNumber, r.BlockHash.Hex(), r.Status, r.GasUsed, len(r.Logs), crypto.Keccak256Hash(enc).Hex())
+}
+
+// answer 单个节点的原始回答
+type answer[T any] struct {
+	value T
+	err   error
+}
+
+// vote 先并发询问 K 个节点，全部一致即返回；否则再询问其余节点，直到某个结果得到 K 票或节点问完。
+// 确定性错误（NotFound、合约回滚）按错误内容参与投票，网络错误只记为该节点的分歧
+func vote[T any](ctx context.Context, q *Quorum, method string, block *big.Int, fn func(context.Context, backend.EthBackend) (T, error), key func(T) string) (T, *Report, error) {
+	var zero T
+	endpoints := q.c.candidates()
+	if len(endpoints) < len(q.c.endpoints) {
+		// 不健康的节点排在最后，仍然可以在需要时参与投票
+		for _, e := range q.c.endpoints {
+			if !e.healthy() {
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+	report := &Report{Method: method, Block: block, Need: q.k}
+	answers := make(map[string]answer[T])
+
+	ask := func(batch []*Endpoint) {
+		results := make([]answer[T], len(batch))
+		var wg sync.WaitGroup
+		for i, e := range batch {
+			wg.Add(1)
+			go func(i int, e *Endpoint) {
+				defer wg.Done()
+				attemptCtx, cancel := q.c.attemptContext(ctx)
+				defer cancel()
+				v, err := fn(attemptCtx, e.Backend)
+				results[i] = answer[T]{v, err}
+			}(i, e)
+		}
+		wg.Wait()
+		for i, e := range batch {
+			r := results[i]
+			v := Vote{Endpoint: e.Name, Err: r.err}
+			switch {
+			case r.err == nil:
+				v.Value = key(r.value)
+				answers[v.Value] = r
+			case !shouldFailover(r.err):
+				v.Value = "错误: " + r.err.Error()
+				answers[v.Value] = r
+			}
+			report.Votes = append(report.Votes, v)
+		}
+	}
+	// tally 统计票数最多的结果
+	tally := func() (string, int) {
+		counts := make(map[string]int)
+		var best string
+		for _, v := range report.Votes {
+			if v.Value == "" {
+				continue
+			}
+			counts[v.Value]++
+			if counts[v.Value] > counts[best] {
+				best = v.Value
+			}
+		}
+		return best, counts[best]
+	}
+
+	ask(endpoints[:q.k])
+	best, n := tally()
+	if n < q.k && len(endpoints) > q.k {
+		ask(endpoints[q.k:])
+		best, n = tally()
+	}
+	for i := range report.Votes {
+		report.Votes[i].Agree = best != "" && report.Votes[i].Value == best
+	}
+	report.Agree = n
+	if ctx.Err() != nil {
+		return zero, report, ctx.Err()
+	}
+	if n < q.k {
+		return zero, report, fmt.Errorf("%w: %s", ErrNoQuorum, report.summary())
+	}
+	winner := answers[best]
+	return winner.value, report, winner.err
+}
+
+// summary 一行的分歧摘要，放在错误信息中
+func (r *Report) summary() string {
+	parts := make([]string, 0, len(r.Votes))
+	for _, v := range r.Votes {
+		if v.Err != nil && v.Value == "" {
+			parts = append(parts, fmt.Sprintf("%s=错误 %v", v.Endpoint, v.Err))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s=%s", v.Endpoint, v.Value))
+		}
+	}
+	return fmt.Sprintf("%s 只有 %d 个节点一致，需要 %d 个（%s）", r.Method, r.Agree, r.Need, strings.Join(parts, "；"))
+}
@@ -0,0 +1,181 @@
+package nft
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedURI tokenURI 的协议不受支持，目前只读取 file:// 和 data:
+	ErrUnsupportedURI = errors.New("不支持的 URI 协议（只支持 file:// 和 data:）")
+	// ErrInvalidMetadata 元数据不符合 ERC721 元数据 JSON 规范
+	ErrInvalidMetadata = errors.New("元数据不符合 ERC721 元数据规范")
+)
+
+// imageSchemes image、animation_url 等链接允许的协议
+var imageSchemes = []string{"https", "http", "ipfs", "ar", "data", "file"}
+
+// Attribute OpenSea 风格的属性，value 为字符串、数字或布尔值
+type Attribute struct {
+	TraitType   string      `json:"trait_type,omitempty"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+// Metadata tokenURI 指向的元数据 JSON
+type Metadata struct {
+	Name         string      `json:"name"`
+	Description  string      `json:"description,omitempty"`
+	Image        string      `json:"image,omitempty"`
+	ExternalURL  string      `json:"external_url,omitempty"`
+	AnimationURL string      `json:"animation_url,omitempty"`
+	Attributes   []Attribute `json:"attributes,omitempty"`
+}
+
+// FetchMetadata 读取 uri 指向的元数据并校验
+func FetchMetadata(uri string) (*Metadata, error) {
+	data, err := ReadURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMetadata(data)
+}
+
+// ReadURI 读取 file:// 或 data: URI 的内容
+//
+// data: URI 的媒体类型必须为空、application/json 或 text/plain，支持 base64 和百分号编码；
+// file:// 只接受本机路径（主机名为空或 localhost）
+func ReadURI(uri string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(uri, "data:"):
+		return readDataURI(uri)
+	case strings.HasPrefix(uri, "file://"):
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("解析 URI 失败: %w", err)
+		}
+		if u.Host != "" && u.Host != "localhost" {
+			return nil, fmt.Errorf("%w: 只能读取本机文件，主机名为 %q", ErrUnsupportedURI, u.Host)
+		}
+		data, err := os.ReadFile(u.Path)
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %w", u.Path, err)
+		}
+		return data, nil
+	}
+	scheme, _, _ := strings.Cut(uri, ":")
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedURI, scheme)
+}
+
+// readDataURI 解析 data:[<媒体类型>][;base64],<数据>
+func readDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, errors.New("data: URI 缺少逗号分隔的数据部分")
+	}
+	params := strings.Split(header, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+	if mediaType != "" && mediaType != "application/json" && mediaType != "text/plain" {
+		return nil, fmt.Errorf("data: URI 的媒体类型为 %s，应为 application/json", mediaType)
+	}
+	if params[len(params)-1] == "base64" {
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			// 部分合约生成的 base64 没有填充
+			if data, err = base64.RawStdEncoding.DecodeString(payload); err != nil {
+				return nil, fmt.Errorf("data: URI 的 base64 解码失败: %w", err)
+			}
+		}
+		return data, nil
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, fmt.Errorf("data: URI 的百分号编码无效: %w", err)
+	}
+	return []byte(data), nil
+}
+
+// ParseMetadata 解析并校验元数据 JSON：必须是对象，name 为非空字符串，
+// description、image、external_url、animation_url 为字符串，链接使用常见协议，
+// attributes 为数组且每一项的 value 为字符串、数字或布尔值。
+// 全部问题合并在一个错误中返回，此时仍返回已解析的字段；不是 JSON 对象时返回 nil
+func ParseMetadata(data []byte) (*Metadata, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("%w: 不是 JSON 对象", ErrInvalidMetadata)
+	}
+	m := &Metadata{}
+	var problems []error
+	str := func(key string, dst *string, required bool) {
+		raw, ok := fields[key]
+		if !ok || string(raw) == "null" {
+			if required {
+				problems = append(problems, fmt.Errorf("缺少 %s", key))
+			}
+			return
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			problems = append(problems, fmt.Errorf("%s 应为字符串", key))
+		} else if required && strings.TrimSpace(*dst) == "" {
+			problems = append(problems, fmt.Errorf("%s 为空", key))
+		}
+	}
+	link := func(key string, dst *string) {
+		str(key, dst, false)
+		if *dst == "" {
+			return
+		}
+		scheme, _, ok := strings.Cut(*dst, ":")
+		if !ok || !contains(imageSchemes, strings.ToLower(scheme)) {
+			problems = append(problems, fmt.Errorf("%s 的协议无效: %q", key, *dst))
+		}
+	}
+	str("name", &m.Name, true)
+	str("description", &m.Description, false)
+	link("image", &m.Image)
+	link("external_url", &m.ExternalURL)
+	link("animation_url", &m.AnimationURL)
+
+	if raw, ok := fields["attributes"]; ok && string(raw) != "null" {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			problems = append(problems, errors.New("attributes 应为数组"))
+		}
+		for i, item := range items {
+			var attr Attribute
+			dec := json.NewDecoder(bytes.NewReader(item))
+			dec.UseNumber()
+			if err := dec.Decode(&attr); err != nil {
+				problems = append(problems, fmt.Errorf("attributes[%d] 应为包含 trait_type 和 value 的对象", i))
+				continue
+			}
+			switch attr.Value.(type) {
+			case string, json.Number, bool:
+			case nil:
+				problems = append(problems, fmt.Errorf("attributes[%d] 缺少 value", i))
+			default:
+				problems = append(problems, fmt.Errorf("attributes[%d].value 应为字符串、数字或布尔值", i))
+			}
+			m.Attributes = append(m.Attributes, attr)
+		}
+	}
+	if len(problems) > 0 {
+		return m, fmt.Errorf("%w: %w", ErrInvalidMetadata, errors.Join(problems...))
+	}
+	return m, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
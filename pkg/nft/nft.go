@@ -0,0 +1,240 @@
+// Package nft 提供 ERC721 的查询、持有记录回放、安全转账和元数据校验
+//
+// 合约调用使用 pkg/bindings/simplenft 的绑定，SimpleNFT 实现了完整的 IERC721 和 IERC165，
+// 可用于任意 ERC721 合约。SimpleNFT 没有实现可选的 ERC721Metadata 扩展，
+// tokenURI 通过单独的 ABI 调用，并且只在合约声明支持该扩展时才查询。
+//
+//	c, _ := nft.Inspect(ctx, client, collection, nil)
+//	token, _ := nft.TokenInfo(ctx, client, collection, big.NewInt(1), nil)
+//	meta, _ := nft.FetchMetadata(token.URI)
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplenft"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+// ERC165 接口 ID
+var (
+	InterfaceERC721     = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceMetadata   = [4]byte{0x5b, 0x5e, 0x13, 0x9f} // ERC721Metadata：name、symbol、tokenURI
+	InterfaceEnumerable = [4]byte{0x78, 0x0e, 0x9d, 0x63} // ERC721Enumerable：totalSupply、tokenByIndex、tokenOfOwnerByIndex
+)
+
+// ReceivedSelector onERC721Received(address,address,uint256,bytes) 的选择器 0x150b7a02，
+// 接收方合约必须原样返回它才表示接受 NFT
+var ReceivedSelector = [4]byte{0x15, 0x0b, 0x7a, 0x02}
+
+var (
+	// ErrNotERC721 合约没有通过 supportsInterface 声明支持 ERC721
+	ErrNotERC721 = errors.New("合约不支持 ERC721")
+	// ErrNoToken tokenId 不存在（未铸造或已销毁）
+	ErrNoToken = errors.New("token 不存在")
+	// ErrNotOwner 发送方既不是持有人，也没有被授权
+	ErrNotOwner = errors.New("发送方不是持有人，也没有被授权")
+	// ErrNotReceiver 接收方是合约，但没有正确实现 onERC721Received
+	ErrNotReceiver = errors.New("接收方合约没有实现 onERC721Received")
+)
+
+// extABI ERC721Metadata 的 tokenURI 和 IERC721Receiver 的 onERC721Received，SimpleNFT 的绑定中没有这两个方法
+const extABI = `[
+	{"type":"function","name":"tokenURI","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"onERC721Received","stateMutability":"nonpayable","inputs":[{"name":"operator","type":"address"},{"name":"from","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes4"}]}
+]`
+
+var parsedExt = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(extABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// Collection NFT 合约的基本信息
+type Collection struct {
+	Address    common.Address
+	Name       string // 合约没有 name 方法时为空
+	Symbol     string
+	Metadata   bool // 支持 ERC721Metadata（tokenURI）
+	Enumerable bool // 支持 ERC721Enumerable
+}
+
+// Token 一个 NFT 的持有和授权情况
+type Token struct {
+	ID       *big.Int
+	Owner    common.Address
+	Approved common.Address // 单个 token 的授权地址，零地址表示没有授权
+	URI      string         // 合约不支持 ERC721Metadata 时为空
+}
+
+func callOpts(ctx context.Context, number *big.Int) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx, BlockNumber: number}
+}
+
+// Inspect 读取合约信息，合约不支持 ERC721 时返回 ErrNotERC721；number 为 nil 表示最新区块
+func Inspect(ctx context.Context, b backend.EthBackend, addr common.Address, number *big.Int) (*Collection, error) {
+	caller, err := simplenft.NewSimpleNFTCaller(addr, b)
+	if err != nil {
+		return nil, err
+	}
+	opts := callOpts(ctx, number)
+	supports := func(id [4]byte) bool {
+		ok, err := caller.SupportsInterface(opts, id)
+		return err == nil && ok // 没有实现 ERC165 的合约调用会回滚，按不支持处理
+	}
+	if !supports(InterfaceERC721) {
+		return nil, fmt.Errorf("%w: %s", ErrNotERC721, addr.Hex())
+	}
+	c := &Collection{Address: addr, Metadata: supports(InterfaceMetadata), Enumerable: supports(InterfaceEnumerable)}
+	// name 和 symbol 是可选的，SimpleNFT 虽然没有声明 ERC721Metadata 也提供了这两个方法
+	c.Name, _ = caller.Name(opts)
+	c.Symbol, _ = caller.Symbol(opts)
+	return c, nil
+}
+
+// TokenInfo 查询 tokenId 的持有人、授权地址和 tokenURI，token 不存在时返回 ErrNoToken
+func TokenInfo(ctx context.Context, b backend.EthBackend, addr common.Address, id *big.Int, number *big.Int) (*Token, error) {
+	caller, err := simplenft.NewSimpleNFTCaller(addr, b)
+	if err != nil {
+		return nil, err
+	}
+	opts := callOpts(ctx, number)
+	owner, err := caller.OwnerOf(opts, id)
+	if err != nil {
+		// 不存在的 token 按标准 ownerOf 必须回滚；网络错误等其他情况不属于这一类
+		if reason := revert.NewDecoder(nil).FromError(err); reason != nil {
+			return nil, fmt.Errorf("%w: #%s: %s", ErrNoToken, id, reason)
+		}
+		return nil, fmt.Errorf("查询 ownerOf(%s) 失败: %w", id, err)
+	}
+	t := &Token{ID: id, Owner: owner}
+	if t.Approved, err = caller.GetApproved(opts, id); err != nil {
+		return nil, fmt.Errorf("查询 getApproved(%s) 失败: %w", id, err)
+	}
+	if ok, _ := caller.SupportsInterface(opts, InterfaceMetadata); ok {
+		if t.URI, err = TokenURI(ctx, b, addr, id, number); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// TokenURI 调用 ERC721Metadata 的 tokenURI
+func TokenURI(ctx context.Context, b backend.EthBackend, addr common.Address, id *big.Int, number *big.Int) (string, error) {
+	input, err := parsedExt.Pack("tokenURI", id)
+	if err != nil {
+		return "", err
+	}
+	out, err := b.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: input}, number)
+	if err != nil {
+		return "", fmt.Errorf("调用 tokenURI(%s) 失败: %w", id, err)
+	}
+	values, err := parsedExt.Unpack("tokenURI", out)
+	if err != nil {
+		return "", fmt.Errorf("解析 tokenURI(%s) 返回数据失败: %w", id, err)
+	}
+	return values[0].(string), nil
+}
+
+// Balance 查询 owner 持有的 token 数量
+func Balance(ctx context.Context, b backend.EthBackend, addr, owner common.Address, number *big.Int) (*big.Int, error) {
+	caller, err := simplenft.NewSimpleNFTCaller(addr, b)
+	if err != nil {
+		return nil, err
+	}
+	return caller.BalanceOf(callOpts(ctx, number), owner)
+}
+
+// IsApprovedForAll 查询 operator 是否被 owner 授权管理其全部 token
+func IsApprovedForAll(ctx context.Context, b backend.EthBackend, addr, owner, operator common.Address, number *big.Int) (bool, error) {
+	caller, err := simplenft.NewSimpleNFTCaller(addr, b)
+	if err != nil {
+		return false, err
+	}
+	return caller.IsApprovedForAll(callOpts(ctx, number), owner, operator)
+}
+
+// CheckReceiver 检查 to 能否接收 safeTransferFrom：普通账户总是可以；
+// 合约账户以 NFT 合约的身份调用 onERC721Received，返回值必须是 ReceivedSelector
+func CheckReceiver(ctx context.Context, b backend.EthBackend, addr, operator, from, to common.Address, id *big.Int, data []byte) error {
+	code, err := b.CodeAt(ctx, to, nil)
+	if err != nil {
+		return fmt.Errorf("查询接收方代码失败: %w", err)
+	}
+	if len(code) == 0 {
+		return nil
+	}
+	input, err := parsedExt.Pack("onERC721Received", operator, from, id, data)
+	if err != nil {
+		return err
+	}
+	out, err := b.CallContract(ctx, ethereum.CallMsg{From: addr, To: &to, Data: input}, nil)
+	if err != nil {
+		if reason := revert.NewDecoder(nil).FromError(err); reason != nil {
+			return fmt.Errorf("%w: %s: %s", ErrNotReceiver, to.Hex(), reason)
+		}
+		return fmt.Errorf("调用 %s 的 onERC721Received 失败: %w", to.Hex(), err)
+	}
+	if len(out) < 4 || [4]byte(out[:4]) != ReceivedSelector {
+		return fmt.Errorf("%w: %s 返回 0x%x，应为 0x%x", ErrNotReceiver, to.Hex(), out, ReceivedSelector)
+	}
+	return nil
+}
+
+// SafeTransfer 以 signer 的身份调用 safeTransferFrom(from, to, id, data)
+//
+// 发送前检查 signer 是持有人或被授权者，接收方是合约时检查它实现了 onERC721Received，
+// 否则分别返回 ErrNotOwner 和 ErrNotReceiver，交易不会被发送；opts.GasLimit 为 0 时自动估算
+func SafeTransfer(ctx context.Context, b backend.EthBackend, signer *backend.Signer, addr, from, to common.Address, id *big.Int, data []byte, opts *backend.TxOptions) (*types.Transaction, error) {
+	token, err := TokenInfo(ctx, b, addr, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token.Owner != from {
+		return nil, fmt.Errorf("%w: #%s 的持有人是 %s，不是 %s", ErrNotOwner, id, token.Owner.Hex(), from.Hex())
+	}
+	if signer.Address != from && signer.Address != token.Approved {
+		ok, err := IsApprovedForAll(ctx, b, addr, from, signer.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotOwner, signer.Address.Hex())
+		}
+	}
+	if err := CheckReceiver(ctx, b, addr, signer.Address, from, to, id, data); err != nil {
+		return nil, err
+	}
+
+	parsed, err := simplenft.SimpleNFTMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	// safeTransferFrom 有两个重载，绑定把带 data 的版本命名为 safeTransferFrom0
+	input, err := parsed.Pack("safeTransferFrom0", from, to, id, data)
+	if err != nil {
+		return nil, fmt.Errorf("编码 safeTransferFrom 调用数据失败: %w", err)
+	}
+	tx, err := backend.BuildTx(ctx, b, signer.Address, &addr, big.NewInt(0), input, opts)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := backend.SignAndSend(ctx, b, signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("转移 NFT 失败: %w", err)
+	}
+	return signed, nil
+}
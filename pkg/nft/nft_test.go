@@ -0,0 +1,190 @@
+package nft_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplenft"
+	"github.com/duanyu/new-eth-project/pkg/contract"
+	"github.com/duanyu/new-eth-project/pkg/nft"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// receiverCode 返回一个对任意调用都返回 selector 的合约创建字节码：
+// 运行时代码为 PUSH4 selector PUSH1 0xe0 SHL PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN（16 字节），
+// 前 12 字节的创建代码把它复制到内存后返回
+func receiverCode(selector [4]byte) []byte {
+	return common.FromHex(fmt.Sprintf("6010600c60003960106000f363%x60e01b60005260206000f3", selector))
+}
+
+func TestSimpleNFT(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob, carol, dave := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2], chain.Accounts[3]
+	deployed := chain.Head().Number.Uint64() + 1
+	addr, _, collection, err := simplenft.DeploySimpleNFT(alice.Opts(), chain, "Simple NFT", "SNFT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+
+	c, err := nft.Inspect(ctx, chain, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Simple NFT" || c.Symbol != "SNFT" || c.Metadata || c.Enumerable {
+		t.Errorf("Inspect = %+v", c)
+	}
+	if _, err := nft.Inspect(ctx, chain, tokenAddr, nil); !errors.Is(err, nft.ErrNotERC721) {
+		t.Errorf("ERC20 合约应返回 ErrNotERC721，实际 %v", err)
+	}
+
+	// alice 持有 0、1、2，bob 持有 3
+	for _, to := range []common.Address{alice.Address, alice.Address, alice.Address, bob.Address} {
+		if _, err := collection.Mint(alice.Opts(), to); err != nil {
+			t.Fatal(err)
+		}
+	}
+	token, err := nft.TokenInfo(ctx, chain, addr, big.NewInt(0), nil)
+	if err != nil || token.Owner != alice.Address || token.Approved != (common.Address{}) || token.URI != "" {
+		t.Fatalf("TokenInfo(0) = %+v, %v", token, err)
+	}
+	if _, err := nft.TokenInfo(ctx, chain, addr, big.NewInt(99), nil); !errors.Is(err, nft.ErrNoToken) {
+		t.Errorf("不存在的 token 应返回 ErrNoToken，实际 %v", err)
+	}
+
+	good, err := contract.DeployAndWait(ctx, chain, alice.Signer(), receiverCode(nft.ReceivedSelector), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, err := contract.DeployAndWait(ctx, chain, alice.Signer(), receiverCode([4]byte{0xde, 0xad, 0xbe, 0xef}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 接收方检查：实现了 onERC721Received 的合约可以接收，返回值错误或没有该方法的合约不能
+	if _, err := nft.SafeTransfer(ctx, chain, alice.Signer(), addr, alice.Address, good.Address, big.NewInt(1), []byte("hi"), nil); err != nil {
+		t.Fatalf("转给接收方合约失败: %v", err)
+	}
+	for name, to := range map[string]common.Address{"返回值错误": bad.Address, "没有该方法": tokenAddr} {
+		if _, err := nft.SafeTransfer(ctx, chain, alice.Signer(), addr, alice.Address, to, big.NewInt(2), nil, nil); !errors.Is(err, nft.ErrNotReceiver) {
+			t.Errorf("%s: 应返回 ErrNotReceiver，实际 %v", name, err)
+		}
+	}
+
+	// 授权检查：carol 不是持有人，得到单个 token 的授权后才能转移
+	if _, err := nft.SafeTransfer(ctx, chain, carol.Signer(), addr, alice.Address, dave.Address, big.NewInt(0), nil, nil); !errors.Is(err, nft.ErrNotOwner) {
+		t.Errorf("未授权时应返回 ErrNotOwner，实际 %v", err)
+	}
+	if _, err := nft.SafeTransfer(ctx, chain, alice.Signer(), addr, bob.Address, dave.Address, big.NewInt(0), nil, nil); !errors.Is(err, nft.ErrNotOwner) {
+		t.Errorf("from 不是持有人时应返回 ErrNotOwner，实际 %v", err)
+	}
+	if _, err := collection.Approve(alice.Opts(), carol.Address, big.NewInt(0)); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := nft.TokenInfo(ctx, chain, addr, big.NewInt(0), nil); token.Approved != carol.Address {
+		t.Errorf("Approved = %s", token.Approved.Hex())
+	}
+	if _, err := nft.SafeTransfer(ctx, chain, carol.Signer(), addr, alice.Address, dave.Address, big.NewInt(0), nil, nil); err != nil {
+		t.Fatalf("被授权者转移失败: %v", err)
+	}
+	if _, err := collection.SetApprovalForAll(bob.Opts(), carol.Address, true); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := nft.IsApprovedForAll(ctx, chain, addr, bob.Address, carol.Address, nil); !ok {
+		t.Error("IsApprovedForAll 应为 true")
+	}
+
+	// 回放 Transfer 日志得到每个地址持有的 token，与 balanceOf 一致
+	head := chain.Head().Number.Uint64()
+	o, err := nft.Replay(ctx, chain, addr, deployed, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Supply() != 4 || o.Mints != 4 || o.Transfers != 6 {
+		t.Errorf("Supply = %d, Mints = %d, Transfers = %d", o.Supply(), o.Mints, o.Transfers)
+	}
+	want := map[common.Address]string{alice.Address: "[2]", bob.Address: "[3]", dave.Address: "[0]", good.Address: "[1]", carol.Address: "[]"}
+	for owner, ids := range want {
+		if got := fmt.Sprint(o.Tokens(owner)); got != ids {
+			t.Errorf("%s 持有 %s，期望 %s", owner.Hex(), got, ids)
+		}
+		bal, _ := nft.Balance(ctx, chain, addr, owner, nil)
+		if int(bal.Int64()) != len(o.Tokens(owner)) {
+			t.Errorf("%s 的 balanceOf = %s", owner.Hex(), bal)
+		}
+	}
+	if owner, ok := o.Owner(big.NewInt(1)); !ok || owner != good.Address {
+		t.Errorf("Owner(1) = %s, %v", owner.Hex(), ok)
+	}
+	if counts := o.Owners(); len(counts) != 4 || counts[alice.Address] != 1 {
+		t.Errorf("Owners = %v", counts)
+	}
+	// ERC20 的 Transfer 签名相同，但 tokenId 不是 indexed，解码失败
+	if _, err := nft.Replay(ctx, chain, tokenAddr, 0, head); err == nil {
+		t.Error("回放 ERC20 合约应该失败")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	valid := `{"name":"Token #1","description":"测试","image":"ipfs://bafy/1.png","attributes":[{"trait_type":"level","value":3},{"trait_type":"rare","value":true}]}`
+	path := filepath.Join(t.TempDir(), "1.json")
+	if err := os.WriteFile(path, []byte(valid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, uri := range []string{
+		"data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(valid)),
+		"data:application/json;charset=utf-8;base64," + base64.RawStdEncoding.EncodeToString([]byte(valid)),
+		"data:application/json,%7B%22name%22%3A%22Token%20%231%22%2C%22image%22%3A%22ipfs%3A%2F%2Fbafy%2F1.png%22%7D",
+		"file://" + path,
+	} {
+		m, err := nft.FetchMetadata(uri)
+		if err != nil {
+			t.Errorf("%.40s: %v", uri, err)
+			continue
+		}
+		if m.Name != "Token #1" || m.Image != "ipfs://bafy/1.png" {
+			t.Errorf("%.40s: %+v", uri, m)
+		}
+	}
+	m, _ := nft.FetchMetadata("file://" + path)
+	if len(m.Attributes) != 2 || m.Attributes[0].TraitType != "level" || fmt.Sprint(m.Attributes[0].Value) != "3" {
+		t.Errorf("Attributes = %+v", m.Attributes)
+	}
+
+	for name, doc := range map[string]string{
+		"不是对象":     `["name"]`,
+		"缺少 name":  `{"image":"https://example.com/1.png"}`,
+		"name 类型":  `{"name":1}`,
+		"image 协议": `{"name":"a","image":"javascript:alert(1)"}`,
+		"属性值类型":    `{"name":"a","attributes":[{"trait_type":"x","value":{"a":1}}]}`,
+		"属性缺少值":    `{"name":"a","attributes":[{"trait_type":"x"}]}`,
+		"属性不是数组":   `{"name":"a","attributes":{"x":1}}`,
+	} {
+		if _, err := nft.ParseMetadata([]byte(doc)); !errors.Is(err, nft.ErrInvalidMetadata) {
+			t.Errorf("%s: 应返回 ErrInvalidMetadata，实际 %v", name, err)
+		}
+	}
+	// 全部问题合并在一个错误中
+	if _, err := nft.ParseMetadata([]byte(`{"name":"","image":5}`)); err == nil || !strings.Contains(err.Error(), "name 为空") || !strings.Contains(err.Error(), "image 应为字符串") {
+		t.Errorf("多个问题: %v", err)
+	}
+
+	for _, uri := range []string{"ipfs://bafy/1.json", "https://example.com/1.json", "file://remote-host/1.json"} {
+		if _, err := nft.FetchMetadata(uri); !errors.Is(err, nft.ErrUnsupportedURI) {
+			t.Errorf("%s: 应返回 ErrUnsupportedURI，实际 %v", uri, err)
+		}
+	}
+	if _, err := nft.ReadURI("data:image/png;base64,AAAA"); err == nil {
+		t.Error("非 JSON 的 data: URI 应该失败")
+	}
+}
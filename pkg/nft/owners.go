@@ -0,0 +1,113 @@
+package nft
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplenft"
+	"github.com/duanyu/new-eth-project/pkg/events"
+)
+
+// Ownership 由 Transfer 日志重建的持有记录
+//
+// 没有实现 ERC721Enumerable 的合约无法直接列出某个地址的全部 token，
+// 从部署区块开始回放 Transfer 事件即可得到每个 token 的当前持有人
+type Ownership struct {
+	Collection common.Address
+	From, To   uint64 // 回放的区块范围（含两端）
+	Transfers  int    // 回放的 Transfer 事件数，包括铸造和销毁
+	Mints      int
+	Burns      int
+
+	owners map[string]common.Address // tokenId（十进制）-> 持有人，已销毁的 token 不在其中
+	ids    map[string]*big.Int
+}
+
+// Replay 回放 collection 在 [from, to] 区块内的全部 Transfer 事件；from 应不晚于部署区块
+//
+// ERC721 与 ERC20 的 Transfer 事件签名相同，但 ERC721 的 tokenId 是 indexed 参数，
+// 对 ERC20 合约回放时解码会失败
+func Replay(ctx context.Context, b backend.EthBackend, collection common.Address, from, to uint64) (*Ownership, error) {
+	decoder, err := events.NewDecoder(simplenft.SimpleNFTMetaData.ABI)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := events.History(ctx, b, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{collection},
+		Topics:    [][]common.Hash{{decoder.Topic("Transfer")}},
+	}, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("%w（合约可能不是 ERC721）", err)
+	}
+	o := &Ownership{
+		Collection: collection,
+		From:       from,
+		To:         to,
+		owners:     make(map[string]common.Address),
+		ids:        make(map[string]*big.Int),
+	}
+	for _, ev := range logs {
+		src, _ := ev.Fields["from"].(common.Address)
+		dst, _ := ev.Fields["to"].(common.Address)
+		id, ok := ev.Fields["tokenId"].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("区块 %d 中的 Transfer 事件没有 tokenId 字段", ev.Log.BlockNumber)
+		}
+		o.apply(src, dst, id)
+	}
+	return o, nil
+}
+
+func (o *Ownership) apply(from, to common.Address, id *big.Int) {
+	o.Transfers++
+	key := id.String()
+	switch {
+	case from == (common.Address{}):
+		o.Mints++
+	case to == (common.Address{}):
+		o.Burns++
+		delete(o.owners, key)
+		delete(o.ids, key)
+		return
+	}
+	o.owners[key] = to
+	o.ids[key] = id
+}
+
+// Owner 返回 token 的持有人，token 不存在或已销毁时返回 false
+func (o *Ownership) Owner(id *big.Int) (common.Address, bool) {
+	owner, ok := o.owners[id.String()]
+	return owner, ok
+}
+
+// Supply 当前存在的 token 数量（铸造减销毁）
+func (o *Ownership) Supply() int { return len(o.owners) }
+
+// Tokens 返回 owner 持有的全部 tokenId，从小到大排列
+func (o *Ownership) Tokens(owner common.Address) []*big.Int {
+	var out []*big.Int
+	for key, addr := range o.owners {
+		if addr == owner {
+			out = append(out, new(big.Int).Set(o.ids[key]))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Cmp(out[j]) < 0 })
+	return out
+}
+
+// Owners 返回每个持有人持有的 token 数量
+func (o *Ownership) Owners() map[common.Address]int {
+	out := make(map[common.Address]int)
+	for _, addr := range o.owners {
+		out[addr]++
+	}
+	return out
+}
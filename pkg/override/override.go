@@ -0,0 +1,239 @@
+// Package override 带状态覆盖和区块覆盖的 eth_call
+//
+// ethclient 的 CallContract 只能在某个区块的真实状态上执行。geth 及大多数节点的 eth_call
+// 还接受两个可选参数：
+//   - 状态覆盖（第 3 个参数）：临时修改任意账户的余额、nonce、代码和存储槽，
+//     可以测试“如果这个账户持有 X 个代币会怎样”，或把本地编译的合约代码放到主网地址上运行
+//   - 区块覆盖（第 4 个参数）：修改执行时的区块号、时间戳、Gas 上限、coinbase、基础费等
+//
+// 覆盖只在这一次调用中生效，不会修改链上状态。
+//
+//	client, _ := override.FromBackend(backend)
+//	state := override.State{}
+//	state.SetBalance(holder, big.NewInt(1e18))
+//	out, err := client.Call(ctx, msg, nil, state, &override.Block{Time: &ts})
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/batch"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+var (
+	// ErrBlockOverridesNotSupported 节点的 eth_call 不接受第 4 个参数
+	ErrBlockOverridesNotSupported = errors.New("节点不支持区块覆盖（eth_call 的第 4 个参数）")
+	// ErrSlotNotFound 没有找到代币余额所在的存储槽
+	ErrSlotNotFound = errors.New("没有找到代币余额的存储槽")
+)
+
+// Account 单个账户的覆盖项，nil 字段保持链上的原值
+type Account struct {
+	Balance   *big.Int
+	Nonce     *uint64
+	Code      []byte                      // nil 表示不覆盖
+	State     map[common.Hash]common.Hash // 替换整个存储，未列出的槽视为零
+	StateDiff map[common.Hash]common.Hash // 只覆盖列出的槽，与 State 不能同时使用
+}
+
+// accountJSON Account 在 JSON-RPC 中的格式
+type accountJSON struct {
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      *hexutil.Bytes              `json:"code,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// MarshalJSON 按 geth 的格式编码
+func (a *Account) MarshalJSON() ([]byte, error) {
+	enc := accountJSON{Balance: (*hexutil.Big)(a.Balance), Nonce: (*hexutil.Uint64)(a.Nonce), State: a.State, StateDiff: a.StateDiff}
+	if a.Code != nil {
+		code := hexutil.Bytes(a.Code)
+		enc.Code = &code
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON 解析 geth 格式的账户覆盖项
+func (a *Account) UnmarshalJSON(data []byte) error {
+	var dec accountJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*a = Account{Balance: (*big.Int)(dec.Balance), Nonce: (*uint64)(dec.Nonce), State: dec.State, StateDiff: dec.StateDiff}
+	if dec.Code != nil {
+		a.Code = *dec.Code
+	}
+	return nil
+}
+
+// State 状态覆盖集合，按地址索引；JSON 格式与 geth 的 eth_call 第 3 个参数相同
+type State map[common.Address]*Account
+
+// Account 返回 addr 的覆盖项，不存在时创建
+func (s State) Account(addr common.Address) *Account {
+	a, ok := s[addr]
+	if !ok {
+		a = &Account{}
+		s[addr] = a
+	}
+	return a
+}
+
+// SetBalance 覆盖 addr 的 ETH 余额（Wei）
+func (s State) SetBalance(addr common.Address, wei *big.Int) { s.Account(addr).Balance = wei }
+
+// SetNonce 覆盖 addr 的 nonce
+func (s State) SetNonce(addr common.Address, nonce uint64) { s.Account(addr).Nonce = &nonce }
+
+// SetCode 把 addr 的代码替换为 code（运行时字节码，不是创建字节码）
+func (s State) SetCode(addr common.Address, code []byte) { s.Account(addr).Code = code }
+
+// SetStorage 覆盖 addr 的单个存储槽，其余槽保持原值
+func (s State) SetStorage(addr common.Address, slot, value common.Hash) {
+	a := s.Account(addr)
+	if a.StateDiff == nil {
+		a.StateDiff = make(map[common.Hash]common.Hash)
+	}
+	a.StateDiff[slot] = value
+}
+
+// Block 区块覆盖，nil 字段保持所在区块的原值；JSON 格式与 geth 的 eth_call 第 4 个参数相同
+type Block struct {
+	Number   *big.Int
+	Time     *uint64
+	GasLimit *uint64
+	Coinbase *common.Address
+	Random   *common.Hash // 合并后的 prevrandao
+	BaseFee  *big.Int
+}
+
+// MarshalJSON 按 geth 的格式编码
+func (b *Block) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Number   *hexutil.Big    `json:"number,omitempty"`
+		Time     *hexutil.Uint64 `json:"time,omitempty"`
+		GasLimit *hexutil.Uint64 `json:"gasLimit,omitempty"`
+		Coinbase *common.Address `json:"coinbase,omitempty"`
+		Random   *common.Hash    `json:"random,omitempty"`
+		BaseFee  *hexutil.Big    `json:"baseFee,omitempty"`
+	}{(*hexutil.Big)(b.Number), (*hexutil.Uint64)(b.Time), (*hexutil.Uint64)(b.GasLimit), b.Coinbase, b.Random, (*hexutil.Big)(b.BaseFee)})
+}
+
+// MappingSlot 计算 Solidity 映射 mapping(key => ...) 中 key 对应的存储位置：
+// keccak256(key . slot)，key 和 slot 都是 32 字节
+func MappingSlot(key common.Hash, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(key.Bytes(), common.BigToHash(new(big.Int).SetUint64(slot)).Bytes())
+}
+
+// vyperMappingSlot Vyper 的映射布局：keccak256(slot . key)
+func vyperMappingSlot(key common.Hash, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(common.BigToHash(new(big.Int).SetUint64(slot)).Bytes(), key.Bytes())
+}
+
+// Caller 能发送单个 JSON-RPC 请求的客户端，*rpc.Client 满足此接口
+type Caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Client 带覆盖的 eth_call 客户端
+type Client struct {
+	caller Caller
+}
+
+// New 创建客户端
+func New(caller Caller) *Client { return &Client{caller: caller} }
+
+// FromBackend 后端实现了 backend.RPCClient 时返回对应的客户端
+func FromBackend(b backend.EthBackend) (*Client, bool) {
+	client, ok := backend.RPC(b)
+	if !ok {
+		return nil, false
+	}
+	return New(client), true
+}
+
+// Call 在 blockNumber（nil 表示最新区块）的状态上应用 state 和 block 覆盖后执行 msg；
+// state、block 都可以为 nil。合约回滚时返回的错误带有 revert 数据，可交给 revert.Decoder.FromError 解码
+func (c *Client) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, state State, block *Block) ([]byte, error) {
+	args := []interface{}{batch.CallArg(msg), batch.BlockArg(blockNumber)}
+	if state != nil || block != nil {
+		if state == nil {
+			state = State{}
+		}
+		args = append(args, state)
+	}
+	if block != nil {
+		args = append(args, block)
+	}
+	var out hexutil.Bytes
+	if err := c.caller.CallContext(ctx, &out, "eth_call", args...); err != nil {
+		// 不支持区块覆盖的节点会拒绝多出来的参数
+		if block != nil && strings.Contains(strings.ToLower(err.Error()), "too many arguments") {
+			return nil, fmt.Errorf("%w: %v", ErrBlockOverridesNotSupported, err)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// balanceOfSelector balanceOf(address) 的函数选择器
+var balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+
+// MaxProbeSlot FindBalanceSlot 尝试的最大映射槽号
+const MaxProbeSlot = 100
+
+// FindBalanceSlot 找出 ERC20 代币合约中 holder 余额所在的存储位置：
+// 依次假设余额映射位于槽 0..MaxProbeSlot（Solidity 和 Vyper 两种布局），把对应位置覆盖为一个特殊值，
+// balanceOf(holder) 返回该值即说明找到。代理合约、余额经过换算（如 rebase 代币）的合约找不到，返回 ErrSlotNotFound。
+// 只有合约执行失败时才继续尝试，网络错误、限流、节点不支持状态覆盖等错误直接返回
+func (c *Client) FindBalanceSlot(ctx context.Context, token, holder common.Address, blockNumber *big.Int) (common.Hash, error) {
+	probe := common.HexToHash("0x5eed5eed5eed5eed5eed5eed5eed5eed")
+	data := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(holder.Bytes(), 32)...)
+	key := common.BytesToHash(holder.Bytes())
+	reverts := revert.NewDecoder(nil)
+	for slot := uint64(0); slot <= MaxProbeSlot; slot++ {
+		for _, loc := range []common.Hash{MappingSlot(key, slot), vyperMappingSlot(key, slot)} {
+			if err := ctx.Err(); err != nil {
+				return common.Hash{}, err
+			}
+			state := State{}
+			state.SetStorage(token, loc, probe)
+			out, err := c.Call(ctx, ethereum.CallMsg{To: &token, Data: data}, blockNumber, state, nil)
+			if err != nil {
+				// 回滚说明这不是余额映射（例如覆盖破坏了其他变量），继续尝试
+				if reverts.FromError(err) != nil {
+					continue
+				}
+				return common.Hash{}, err
+			}
+			if len(out) == 32 && common.BytesToHash(out) == probe {
+				return loc, nil
+			}
+		}
+	}
+	return common.Hash{}, fmt.Errorf("%w: %s", ErrSlotNotFound, token.Hex())
+}
+
+// SetTokenBalance 找到 holder 在 token 中的余额存储位置，并在 state 中把余额覆盖为 amount（最小单位）
+func (c *Client) SetTokenBalance(ctx context.Context, state State, token, holder common.Address, amount *big.Int, blockNumber *big.Int) error {
+	loc, err := c.FindBalanceSlot(ctx, token, holder, blockNumber)
+	if err != nil {
+		return err
+	}
+	state.SetStorage(token, loc, common.BigToHash(amount))
+	return nil
+}
@@ -0,0 +1,160 @@
+package override_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/override"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+func TestTokenBalanceOverride(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	client, ok := override.FromBackend(chain)
+	if !ok {
+		t.Fatal("simchain should provide an RPC client")
+	}
+
+	// bob 没有代币，转账回滚
+	msg := ethereum.CallMsg{From: bob.Address, To: &token, Data: transfer.EncodeTransfer(alice.Address, big.NewInt(5))}
+	_, err := client.Call(ctx, msg, nil, nil, nil)
+	reason := revert.NewDecoder(calldata.DefaultSignatures()).FromError(err)
+	if reason == nil || reason.Message != "ERC20: transfer amount exceeds balance" {
+		t.Fatalf("err = %v, reason = %v", err, reason)
+	}
+
+	// 假设 bob 持有 10 个最小单位
+	state := override.State{}
+	if err := client.SetTokenBalance(ctx, state, token, bob.Address, big.NewInt(10), nil); err != nil {
+		t.Fatal(err)
+	}
+	out, err := client.Call(ctx, msg, nil, state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret, err := calldata.DecodeOutput("bool", out); err != nil || ret[0] != true {
+		t.Fatalf("transfer returned %v, %v", ret, err)
+	}
+
+	// 覆盖只在调用中生效
+	balanceOf, _ := calldata.Encode("balanceOf(address)", bob.Address.Hex())
+	out, err = client.Call(ctx, ethereum.CallMsg{To: &token, Data: balanceOf}, nil, nil, nil)
+	if err != nil || new(big.Int).SetBytes(out).Sign() != 0 {
+		t.Fatalf("balanceOf after override = %x, %v", out, err)
+	}
+
+	// 不是 ERC20 的地址找不到余额槽
+	if _, err := client.FindBalanceSlot(ctx, bob.Address, alice.Address, nil); !errors.Is(err, override.ErrSlotNotFound) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestCodeAndBlockOverride(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice := chain.Accounts[0]
+	mcAddr, _, _, err := multicall3.DeployMulticall3(alice.Opts(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Commit()
+	code, err := chain.CodeAt(ctx, mcAddr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := override.New(chain.Client())
+
+	// 把 Multicall3 的代码放到一个空地址上，同时修改余额、区块号和时间
+	target := common.HexToAddress("0x00000000000000000000000000000000000c0de5")
+	number, ts := big.NewInt(123_456), uint64(2_000_000_000)
+	state := override.State{}
+	state.SetCode(target, code)
+	state.SetBalance(alice.Address, big.NewInt(42))
+	block := &override.Block{Number: number, Time: &ts}
+
+	call := func(sig string, args ...string) *big.Int {
+		t.Helper()
+		data, err := calldata.Encode(sig, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := client.Call(ctx, ethereum.CallMsg{To: &target, Data: data}, nil, state, block)
+		if err != nil {
+			t.Fatalf("%s: %v", sig, err)
+		}
+		return new(big.Int).SetBytes(out)
+	}
+	if got := call("getBlockNumber()"); got.Cmp(number) != 0 {
+		t.Fatalf("block number = %s", got)
+	}
+	if got := call("getCurrentBlockTimestamp()"); got.Uint64() != ts {
+		t.Fatalf("timestamp = %s", got)
+	}
+	if got := call("getEthBalance(address)", alice.Address.Hex()); got.Int64() != 42 {
+		t.Fatalf("balance = %s", got)
+	}
+
+	// JSON 格式与 geth 一致，可以从文件读取
+	raw, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded override.State
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if a := decoded[alice.Address]; a == nil || a.Balance.Int64() != 42 || len(decoded[target].Code) != len(code) {
+		t.Fatalf("round trip = %s", raw)
+	}
+}
+
+// recorder 记录 eth_call 的参数并返回固定错误
+type recorder struct {
+	calls int
+	args  []interface{}
+	err   error
+}
+
+func (r *recorder) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	r.calls++
+	r.args = args
+	return r.err
+}
+
+func TestFindBalanceSlotErrors(t *testing.T) {
+	token, holder := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	// 与合约执行无关的错误直接返回，不再继续尝试
+	down := &recorder{err: errors.New("connection refused")}
+	if _, err := override.New(down).FindBalanceSlot(context.Background(), token, holder, nil); err != down.err || down.calls != 1 {
+		t.Fatalf("err = %v, calls = %d", err, down.calls)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelled := &recorder{}
+	if _, err := override.New(cancelled).FindBalanceSlot(ctx, token, holder, nil); !errors.Is(err, context.Canceled) || cancelled.calls != 0 {
+		t.Fatalf("err = %v, calls = %d", err, cancelled.calls)
+	}
+
+	// EIP-1559 的费用字段原样传给节点
+	fees := &recorder{}
+	msg := ethereum.CallMsg{To: &token, GasFeeCap: big.NewInt(30), GasTipCap: big.NewInt(2)}
+	override.New(fees).Call(context.Background(), msg, nil, nil, nil)
+	arg := fees.args[0].(map[string]interface{})
+	if arg["maxFeePerGas"] == nil || arg["maxPriorityFeePerGas"] == nil {
+		t.Fatalf("call arg = %v", arg)
+	}
+}
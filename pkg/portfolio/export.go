@@ -0,0 +1,102 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Totals 按资产汇总全部地址的余额，顺序与 Balances 中资产第一次出现的顺序一致
+func (s *Snapshot) Totals() []Balance {
+	var out []Balance
+	index := make(map[common.Address]int)
+	for _, b := range s.Balances {
+		i, ok := index[b.Token]
+		if !ok {
+			i = len(out)
+			index[b.Token] = i
+			out = append(out, Balance{Token: b.Token, Symbol: b.Symbol, Decimals: b.Decimals, Raw: new(big.Int)})
+		}
+		out[i].Raw.Add(out[i].Raw, b.Raw)
+	}
+	return out
+}
+
+// csvHeader CSV 的列，每行是一个采样点上一个地址的一种资产
+var csvHeader = []string{"block", "time", "holder", "token", "symbol", "raw", "value"}
+
+// WriteCSV 以长表格式写出时间序列：每个采样点、每个地址、每种资产一行，ETH 的 token 列为空
+func WriteCSV(w io.Writer, series []*Snapshot) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, snap := range series {
+		at := time.Unix(int64(snap.Time), 0).UTC().Format(time.RFC3339)
+		for _, b := range snap.Balances {
+			token := ""
+			if b.Token != ETH {
+				token = b.Token.Hex()
+			}
+			row := []string{strconv.FormatUint(snap.Block, 10), at, b.Holder.Hex(), token, b.Symbol, b.Raw.String(), b.Value().Text('f', -1)}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonBalance JSON 输出中的余额，数量用字符串表示以免丢失精度
+type jsonBalance struct {
+	Holder *common.Address `json:"holder,omitempty"`
+	Token  *common.Address `json:"token,omitempty"` // ETH 省略
+	Symbol string          `json:"symbol"`
+	Raw    string          `json:"raw"`
+	Value  string          `json:"value"`
+}
+
+type jsonSnapshot struct {
+	Block    uint64        `json:"block"`
+	Time     string        `json:"time"`
+	Balances []jsonBalance `json:"balances"`
+	Totals   []jsonBalance `json:"totals"`
+}
+
+// WriteJSON 写出时间序列，每个采样点包含各地址的余额和按资产汇总的组合快照
+func WriteJSON(w io.Writer, series []*Snapshot) error {
+	out := make([]jsonSnapshot, len(series))
+	for i, snap := range series {
+		out[i] = jsonSnapshot{
+			Block:    snap.Block,
+			Time:     time.Unix(int64(snap.Time), 0).UTC().Format(time.RFC3339),
+			Balances: toJSON(snap.Balances, true),
+			Totals:   toJSON(snap.Totals(), false),
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func toJSON(balances []Balance, withHolder bool) []jsonBalance {
+	out := make([]jsonBalance, len(balances))
+	for i, b := range balances {
+		out[i] = jsonBalance{Symbol: b.Symbol, Raw: b.Raw.String(), Value: b.Value().Text('f', -1)}
+		if withHolder {
+			holder := b.Holder
+			out[i].Holder = &holder
+		}
+		if b.Token != ETH {
+			token := b.Token
+			out[i].Token = &token
+		}
+	}
+	return out
+}
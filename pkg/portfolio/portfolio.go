@@ -0,0 +1,365 @@
+// Package portfolio 按固定的区块或时间间隔采样一组地址的 ETH 和 ERC20 余额，生成余额时间序列
+//
+// 每个采样点上全部余额来自同一个区块：优先用 Multicall3 的 getEthBalance 和 balanceOf
+// 合并成一次 eth_call；采样区块早于 Multicall3 部署（或链上没有 Multicall3）时，
+// 退回 JSON-RPC 批量请求（pkg/batch），后端不支持批量请求时再逐个查询。
+//
+// 查询历史区块的状态需要归档节点。普通全节点只保留最近约 128 个区块的状态，
+// 更早的区块会返回 "missing trie node" 等错误，这类错误统一包装为 ErrNoArchive。
+//
+//	s := portfolio.New(client, holders, portfolio.WithTokens(usdc, dai))
+//	if err := s.CheckArchive(ctx, from); err != nil { ... }
+//	series, err := s.Series(ctx, portfolio.Blocks(from, to, 7200))
+//	portfolio.WriteCSV(os.Stdout, series)
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/batch"
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/middleware"
+	"github.com/duanyu/new-eth-project/pkg/multicall"
+	"github.com/duanyu/new-eth-project/pkg/query"
+)
+
+// ErrNoArchive 节点没有采样区块的历史状态，需要换用归档节点
+var ErrNoArchive = errors.New("节点没有该区块的历史状态（需要归档节点）")
+
+// ETH 表示 ETH 余额的代币地址（零地址）
+var ETH = common.Address{}
+
+// Balance 一个地址在某个采样点上的一种资产余额
+type Balance struct {
+	Holder   common.Address
+	Token    common.Address // ETH（零地址）表示 ETH 余额
+	Symbol   string
+	Decimals uint8
+	Raw      *big.Int
+}
+
+// Value 按小数位换算后的余额
+func (b Balance) Value() *big.Float {
+	return query.ToUnit(b.Raw, int(b.Decimals))
+}
+
+// Snapshot 一个采样区块上全部地址的余额
+type Snapshot struct {
+	Block     uint64
+	Time      uint64 // 区块时间戳
+	Balances  []Balance
+	Multicall bool // 是否通过 Multicall3 一次查询
+}
+
+// Token 代币信息
+type Token struct {
+	Address  common.Address
+	Symbol   string
+	Decimals uint8
+}
+
+// Config 采样配置
+type Config struct {
+	Tokens    []common.Address
+	Multicall bool               // 是否优先使用 Multicall3
+	MCOptions []multicall.Option // 传给 multicall.New 的选项，如本地链上的合约地址
+}
+
+// DefaultConfig 返回默认配置：只采样 ETH 余额，优先使用 Multicall3
+func DefaultConfig() *Config {
+	return &Config{Multicall: true}
+}
+
+// Option 修改采样配置
+type Option func(*Config)
+
+// WithTokens 同时采样这些 ERC20 代币的余额
+func WithTokens(tokens ...common.Address) Option {
+	return func(c *Config) { c.Tokens = append(c.Tokens, tokens...) }
+}
+
+// WithMulticall 设置 Multicall3 客户端的选项（如 multicall.WithAddress）
+func WithMulticall(opts ...multicall.Option) Option {
+	return func(c *Config) { c.MCOptions = append(c.MCOptions, opts...) }
+}
+
+// WithoutMulticall 不使用 Multicall3，全部通过批量或逐个请求查询
+func WithoutMulticall() Option { return func(c *Config) { c.Multicall = false } }
+
+// Sampler 余额采样器
+type Sampler struct {
+	b       backend.EthBackend
+	holders []common.Address
+	cfg     *Config
+	mc      *multicall.Client
+	batcher *batch.Batcher // 后端不支持批量请求时为 nil
+	mcABI   *abi.ABI
+	erc20   *abi.ABI
+	tokens  []Token // 在最新区块上读取的代币信息，第一次采样时获取
+}
+
+// New 创建采样器，holders 为要采样的地址
+func New(b backend.EthBackend, holders []common.Address, opts ...Option) *Sampler {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	mcABI, err := multicall3.Multicall3MetaData.GetAbi()
+	if err != nil {
+		panic(err) // 绑定中的 ABI 是生成的，解析失败说明绑定已损坏
+	}
+	erc20, err := mytoken.MyTokenMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	s := &Sampler{b: b, holders: holders, cfg: cfg, mcABI: mcABI, erc20: erc20}
+	if cfg.Multicall {
+		s.mc = multicall.New(b, cfg.MCOptions...)
+	}
+	s.batcher, _ = batch.FromBackend(b)
+	return s
+}
+
+// Tokens 返回代币信息（符号、小数位），在最新区块上读取一次
+func (s *Sampler) Tokens(ctx context.Context) ([]Token, error) {
+	if s.tokens != nil || len(s.cfg.Tokens) == 0 {
+		return s.tokens, nil
+	}
+	tokens := make([]Token, len(s.cfg.Tokens))
+	for i, addr := range s.cfg.Tokens {
+		info, err := query.TokenBalance(ctx, s.b, addr, addr)
+		if err != nil {
+			return nil, fmt.Errorf("读取代币 %s 的信息失败: %w", addr.Hex(), err)
+		}
+		tokens[i] = Token{Address: addr, Symbol: info.Symbol, Decimals: info.Decimals}
+	}
+	s.tokens = tokens
+	return tokens, nil
+}
+
+// CheckArchive 检查节点能否提供 block 区块的状态，不能时返回 ErrNoArchive
+func (s *Sampler) CheckArchive(ctx context.Context, block uint64) error {
+	var probe common.Address
+	if len(s.holders) > 0 {
+		probe = s.holders[0]
+	}
+	_, err := s.b.BalanceAt(ctx, probe, new(big.Int).SetUint64(block))
+	return s.wrap(block, err)
+}
+
+// Snapshot 采样 block 区块上全部地址的余额
+func (s *Sampler) Snapshot(ctx context.Context, block uint64) (*Snapshot, error) {
+	tokens, err := s.Tokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	number := new(big.Int).SetUint64(block)
+	header, err := s.b.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块头 %d 失败: %w", block, err)
+	}
+	snap := &Snapshot{Block: block, Time: header.Time}
+
+	var raw []*big.Int // 按 ETH 余额、各代币余额的顺序排列，每组 len(holders) 个
+	if s.mc != nil {
+		raw, err = s.viaMulticall(ctx, number, tokens)
+		if err == nil {
+			snap.Multicall = true
+		} else if !errors.Is(err, multicall.ErrNotDeployed) {
+			return nil, s.wrap(block, err)
+		}
+	}
+	if !snap.Multicall {
+		// 采样区块早于 Multicall3 部署或链上没有 Multicall3
+		if raw, err = s.direct(ctx, number, tokens); err != nil {
+			return nil, s.wrap(block, err)
+		}
+	}
+
+	n := len(s.holders)
+	for i, holder := range s.holders {
+		snap.Balances = append(snap.Balances, Balance{Holder: holder, Token: ETH, Symbol: "ETH", Decimals: 18, Raw: raw[i]})
+		for j, t := range tokens {
+			snap.Balances = append(snap.Balances, Balance{Holder: holder, Token: t.Address, Symbol: t.Symbol, Decimals: t.Decimals, Raw: raw[(j+1)*n+i]})
+		}
+	}
+	return snap, nil
+}
+
+// Series 依次采样 blocks 中的每个区块
+func (s *Sampler) Series(ctx context.Context, blocks []uint64) ([]*Snapshot, error) {
+	out := make([]*Snapshot, 0, len(blocks))
+	for _, block := range blocks {
+		snap, err := s.Snapshot(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	return out, nil
+}
+
+// viaMulticall 用 getEthBalance 和 balanceOf 合并成一次（或按 MaxCalls 分组的几次）aggregate3
+func (s *Sampler) viaMulticall(ctx context.Context, number *big.Int, tokens []Token) ([]*big.Int, error) {
+	calls := make([]multicall.Call, 0, len(s.holders)*(len(tokens)+1))
+	for _, holder := range s.holders {
+		call, err := multicall.NewCall(s.mc.Address(), s.mcABI, "getEthBalance", holder)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	for _, t := range tokens {
+		for _, holder := range s.holders {
+			data, err := s.erc20.Pack("balanceOf", holder)
+			if err != nil {
+				return nil, err
+			}
+			// 代币可能在采样区块之后才部署，允许失败并自行解码返回值
+			calls = append(calls, multicall.Call{Target: t.Address, Data: data, AllowFailure: true})
+		}
+	}
+	results, err := s.mc.Aggregate(ctx, number, calls)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*big.Int, len(results))
+	for i, r := range results {
+		if i < len(s.holders) {
+			if r.Err != nil {
+				return nil, r.Err
+			}
+			out[i] = r.Values[0].(*big.Int)
+			continue
+		}
+		if !r.Success {
+			return nil, fmt.Errorf("查询代币 %s 的余额失败: %w", calls[i].Target.Hex(), r.Err)
+		}
+		out[i] = tokenBalance(r.ReturnData)
+	}
+	return out, nil
+}
+
+// direct 不使用 Multicall3：ETH 余额和 balanceOf 通过批量请求（或逐个请求）查询
+func (s *Sampler) direct(ctx context.Context, number *big.Int, tokens []Token) ([]*big.Int, error) {
+	out := make([]*big.Int, 0, len(s.holders)*(len(tokens)+1))
+	msgs := make([]ethereum.CallMsg, 0, len(s.holders)*len(tokens))
+	for _, t := range tokens {
+		for _, holder := range s.holders {
+			data, err := s.erc20.Pack("balanceOf", holder)
+			if err != nil {
+				return nil, err
+			}
+			to := t.Address
+			msgs = append(msgs, ethereum.CallMsg{To: &to, Data: data})
+		}
+	}
+
+	if s.batcher != nil {
+		balances, err := s.batcher.Balances(ctx, s.holders, number)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range balances {
+			if r.Err != nil {
+				return nil, r.Err
+			}
+			out = append(out, r.Value)
+		}
+		results, err := s.batcher.Calls(ctx, msgs, number)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range results {
+			if r.Err != nil {
+				return nil, fmt.Errorf("查询代币 %s 的余额失败: %w", msgs[i].To.Hex(), r.Err)
+			}
+			out = append(out, tokenBalance(r.Value))
+		}
+		return out, nil
+	}
+
+	for _, holder := range s.holders {
+		wei, err := s.b.BalanceAt(ctx, holder, number)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, wei)
+	}
+	for _, msg := range msgs {
+		data, err := s.b.CallContract(ctx, msg, number)
+		if err != nil {
+			return nil, fmt.Errorf("查询代币 %s 的余额失败: %w", msg.To.Hex(), err)
+		}
+		out = append(out, tokenBalance(data))
+	}
+	return out, nil
+}
+
+// wrap 把节点缺少历史状态的错误包装为 ErrNoArchive
+func (s *Sampler) wrap(block uint64, err error) error {
+	if err == nil {
+		return nil
+	}
+	if middleware.Classify(err) == middleware.KindMissingState {
+		return fmt.Errorf("区块 %d: %w: %w", block, ErrNoArchive, err)
+	}
+	return fmt.Errorf("采样区块 %d 失败: %w", block, err)
+}
+
+// tokenBalance 解码 balanceOf 的返回值；代币在该区块还没有部署时返回数据为空，按 0 处理
+func tokenBalance(data []byte) *big.Int {
+	if len(data) < 32 {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(data[:32])
+}
+
+// Blocks 返回 [from, to] 内每隔 step 个区块的采样点，总是包含 to
+func Blocks(from, to, step uint64) []uint64 {
+	if step == 0 {
+		step = 1
+	}
+	var out []uint64
+	for n := from; n <= to; n += step {
+		out = append(out, n)
+		if n+step < n { // 溢出
+			break
+		}
+	}
+	if len(out) > 0 && out[len(out)-1] != to {
+		out = append(out, to)
+	}
+	return out
+}
+
+// TimeBlocks 返回 [since, until] 内每隔 every 的时间点上链上状态所在的区块（时间戳不晚于该时刻的最后一个区块），
+// 相邻时间点落在同一个区块时只保留一个
+func TimeBlocks(ctx context.Context, r *blocktime.Resolver, since, until time.Time, every time.Duration) ([]uint64, error) {
+	if every <= 0 {
+		return nil, fmt.Errorf("无效的采样间隔 %s", every)
+	}
+	var out []uint64
+	for t := since; !t.After(until); t = t.Add(every) {
+		header, err := r.Before(ctx, t)
+		if err != nil {
+			return nil, fmt.Errorf("查找 %s 的区块失败: %w", t.UTC().Format(time.RFC3339), err)
+		}
+		n := header.Number.Uint64()
+		if len(out) == 0 || out[len(out)-1] != n {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
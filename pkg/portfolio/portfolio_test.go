@@ -0,0 +1,247 @@
+package portfolio_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/blocktime"
+	"github.com/duanyu/new-eth-project/pkg/multicall"
+	"github.com/duanyu/new-eth-project/pkg/portfolio"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// plainBackend 隐藏 simchain 的 JSON-RPC 连接，模拟不支持批量请求的后端
+type plainBackend struct{ backend.EthBackend }
+
+// prunedBackend 模拟只保留最近 keep 个区块状态的全节点
+type prunedBackend struct {
+	*simchain.Chain
+	keep uint64
+}
+
+func (p *prunedBackend) pruned(number *big.Int) error {
+	if number != nil && number.Uint64()+p.keep < p.Head().Number.Uint64() {
+		return fmt.Errorf("missing trie node %x (path ) state %d is not available", number.Bytes(), number)
+	}
+	return nil
+}
+
+func (p *prunedBackend) BalanceAt(ctx context.Context, account common.Address, number *big.Int) (*big.Int, error) {
+	if err := p.pruned(number); err != nil {
+		return nil, err
+	}
+	return p.Chain.BalanceAt(ctx, account, number)
+}
+
+func (p *prunedBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, number *big.Int) ([]byte, error) {
+	if err := p.pruned(number); err != nil {
+		return nil, err
+	}
+	return p.Chain.CallContract(ctx, msg, number)
+}
+
+// history 准备一段有余额变化的历史：先发 ETH，再部署代币并转账，最后才部署 Multicall3
+type history struct {
+	chain     *simchain.Chain
+	holders   []common.Address
+	token     common.Address
+	tokenAt   uint64 // 代币部署所在的区块
+	multicall common.Address
+	mcAt      uint64 // Multicall3 部署所在的区块
+}
+
+func newHistory(t *testing.T) *history {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob, carol := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2]
+	h := &history{chain: chain, holders: []common.Address{bob.Address, carol.Address}}
+
+	chain.TransferETH(alice, bob.Address, big.NewInt(1e18))
+	chain.TransferETH(alice, carol.Address, big.NewInt(2e18))
+	token, instance := chain.DeployMyToken(alice, "Test Token", "TT", 6, big.NewInt(1_000_000_000))
+	h.token, h.tokenAt = token, chain.Head().Number.Uint64()
+	if _, err := instance.Transfer(alice.Opts(), bob.Address, big.NewInt(5_000_000)); err != nil {
+		t.Fatal(err)
+	}
+	chain.TransferETH(bob, carol.Address, big.NewInt(3e17))
+	addr, err := multicall.EnsureDeployed(ctx, chain, alice.Signer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.multicall, h.mcAt = addr, chain.Head().Number.Uint64()
+	if _, err := instance.Transfer(alice.Opts(), carol.Address, big.NewInt(7_000_000)); err != nil {
+		t.Fatal(err)
+	}
+	chain.MineBlocks(3)
+	return h
+}
+
+// check 把采样结果与直接查询的余额比较
+func (h *history) check(t *testing.T, snap *portfolio.Snapshot) {
+	t.Helper()
+	ctx := context.Background()
+	number := new(big.Int).SetUint64(snap.Block)
+	if len(snap.Balances) != len(h.holders)*2 {
+		t.Fatalf("区块 %d 有 %d 项余额", snap.Block, len(snap.Balances))
+	}
+	for _, b := range snap.Balances {
+		var want *big.Int
+		if b.Token == portfolio.ETH {
+			want, _ = h.chain.BalanceAt(ctx, b.Holder, number)
+		} else {
+			want = new(big.Int)
+			if snap.Block >= h.tokenAt {
+				data, _ := h.chain.CallContract(ctx, ethereum.CallMsg{To: &h.token, Data: append(common.FromHex("0x70a08231"), common.LeftPadBytes(b.Holder.Bytes(), 32)...)}, number)
+				want.SetBytes(data)
+			}
+		}
+		if b.Raw.Cmp(want) != 0 {
+			t.Errorf("区块 %d %s 的 %s 余额 = %s，期望 %s", snap.Block, b.Holder.Hex(), b.Symbol, b.Raw, want)
+		}
+	}
+}
+
+func TestSeries(t *testing.T) {
+	h := newHistory(t)
+	ctx := context.Background()
+	head := h.chain.Head().Number.Uint64()
+	blocks := portfolio.Blocks(0, head, 2)
+
+	for name, b := range map[string]backend.EthBackend{"批量请求": h.chain, "逐个请求": plainBackend{h.chain}} {
+		s := portfolio.New(b, h.holders, portfolio.WithTokens(h.token), portfolio.WithMulticall(multicall.WithAddress(h.multicall)))
+		series, err := s.Series(ctx, blocks)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(series) != len(blocks) || series[len(series)-1].Block != head {
+			t.Fatalf("%s: 采样了 %d 个区块", name, len(series))
+		}
+		for _, snap := range series {
+			h.check(t, snap)
+			// Multicall3 部署之后的区块合并成一次 eth_call，之前的退回批量或逐个请求
+			if snap.Multicall != (snap.Block >= h.mcAt) {
+				t.Errorf("%s: 区块 %d Multicall = %v（Multicall3 部署于区块 %d）", name, snap.Block, snap.Multicall, h.mcAt)
+			}
+		}
+	}
+
+	// 代币信息在最新区块读取，早于代币部署的区块余额为 0
+	first, _ := portfolio.New(h.chain, h.holders, portfolio.WithTokens(h.token), portfolio.WithoutMulticall()).Snapshot(ctx, 0)
+	if tb := first.Balances[1]; tb.Symbol != "TT" || tb.Decimals != 6 || tb.Raw.Sign() != 0 {
+		t.Errorf("创世区块的代币余额 = %+v", tb)
+	}
+
+	// 组合快照：按资产汇总全部地址
+	last, _ := portfolio.New(h.chain, h.holders, portfolio.WithTokens(h.token), portfolio.WithMulticall(multicall.WithAddress(h.multicall))).Snapshot(ctx, head)
+	totals := last.Totals()
+	if len(totals) != 2 || totals[1].Raw.Cmp(big.NewInt(12_000_000)) != 0 {
+		t.Errorf("汇总 = %+v", totals)
+	}
+	if want := new(big.Int).Add(h.chain.Balance(h.holders[0]), h.chain.Balance(h.holders[1])); totals[0].Raw.Cmp(want) != 0 {
+		t.Errorf("ETH 汇总 = %s，期望 %s", totals[0].Raw, want)
+	}
+}
+
+func TestNoArchive(t *testing.T) {
+	h := newHistory(t)
+	ctx := context.Background()
+	head := h.chain.Head().Number.Uint64()
+	pruned := &prunedBackend{Chain: h.chain, keep: 2}
+	s := portfolio.New(pruned, h.holders, portfolio.WithTokens(h.token), portfolio.WithMulticall(multicall.WithAddress(h.multicall)))
+
+	if err := s.CheckArchive(ctx, head); err != nil {
+		t.Fatalf("最新区块应该可以查询: %v", err)
+	}
+	if err := s.CheckArchive(ctx, 1); !errors.Is(err, portfolio.ErrNoArchive) {
+		t.Errorf("裁剪的区块应返回 ErrNoArchive，实际 %v", err)
+	}
+	if _, err := s.Snapshot(ctx, h.mcAt); !errors.Is(err, portfolio.ErrNoArchive) {
+		t.Errorf("Multicall 采样裁剪的区块应返回 ErrNoArchive，实际 %v", err)
+	}
+	if _, err := portfolio.New(plainBackend{pruned}, h.holders, portfolio.WithoutMulticall()).Snapshot(ctx, 1); !errors.Is(err, portfolio.ErrNoArchive) {
+		t.Errorf("逐个请求采样裁剪的区块应返回 ErrNoArchive，实际 %v", err)
+	}
+}
+
+func TestExport(t *testing.T) {
+	h := newHistory(t)
+	ctx := context.Background()
+	s := portfolio.New(h.chain, h.holders, portfolio.WithTokens(h.token), portfolio.WithMulticall(multicall.WithAddress(h.multicall)))
+	series, err := s.Series(ctx, []uint64{h.tokenAt, h.chain.Head().Number.Uint64()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := portfolio.WriteCSV(&buf, series); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 表头 + 2 个区块 × 2 个地址 × 2 种资产
+	if len(rows) != 1+2*2*2 {
+		t.Fatalf("CSV 有 %d 行", len(rows))
+	}
+	if rows[1][3] != "" || rows[1][4] != "ETH" || rows[2][3] != h.token.Hex() || rows[2][4] != "TT" {
+		t.Errorf("CSV 行 = %v / %v", rows[1], rows[2])
+	}
+	if last := rows[len(rows)-1]; last[5] != "7000000" || last[6] != "7" {
+		t.Errorf("最后一行 = %v", last)
+	}
+
+	buf.Reset()
+	if err := portfolio.WriteJSON(&buf, series); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []struct {
+		Block    uint64
+		Time     string
+		Balances []struct{ Holder, Token, Symbol, Raw, Value string }
+		Totals   []struct{ Symbol, Raw string }
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || len(decoded[1].Balances) != 4 || len(decoded[1].Totals) != 2 || decoded[1].Totals[1].Raw != "12000000" {
+		t.Errorf("JSON = %s", buf.String())
+	}
+	if decoded[0].Balances[0].Token != "" {
+		t.Errorf("ETH 余额不应包含 token 字段: %+v", decoded[0].Balances[0])
+	}
+}
+
+func TestSampling(t *testing.T) {
+	if got := portfolio.Blocks(10, 20, 4); fmt.Sprint(got) != "[10 14 18 20]" {
+		t.Errorf("Blocks(10, 20, 4) = %v", got)
+	}
+	if got := portfolio.Blocks(10, 20, 5); fmt.Sprint(got) != "[10 15 20]" {
+		t.Errorf("Blocks(10, 20, 5) = %v", got)
+	}
+
+	chain := simchain.New(t)
+	chain.MineBlocks(30)
+	ctx := context.Background()
+	bc := chain.Blockchain()
+	since := time.Unix(int64(bc.GetHeaderByNumber(5).Time), 0)
+	gap := time.Duration(bc.GetHeaderByNumber(6).Time-bc.GetHeaderByNumber(5).Time) * time.Second
+	blocks, err := portfolio.TimeBlocks(ctx, blocktime.New(chain), since, since.Add(10*gap), 5*gap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(blocks) != "[5 10 15]" {
+		t.Errorf("TimeBlocks = %v", blocks)
+	}
+}
@@ -0,0 +1,289 @@
+// Package proof 通过 eth_getProof 获取并验证账户和存储槽的 Merkle 证明
+//
+// eth_getBalance、eth_getStorageAt 返回的值只能相信节点。eth_getProof 额外返回从状态根到账户、
+// 从账户存储根到存储槽的 Merkle-Patricia 路径节点：
+//   - 账户证明：状态树中键为 keccak256(地址) 的叶子，值为 RLP(nonce, balance, storageRoot, codeHash)
+//   - 存储证明：账户存储树中键为 keccak256(槽号) 的叶子，值为 RLP(槽的值)
+//
+// 只要区块头（其中的 stateRoot）可信——例如来自轻客户端或另一个独立的节点——沿路径逐个校验节点哈希，
+// 就能确认余额、nonce、codeHash 和存储值没有被节点篡改。
+//
+//	client, _ := proof.FromBackend(backend)
+//	res, err := client.Verified(ctx, account, []common.Hash{slot}, nil)
+//	fmt.Print(res)
+package proof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/batch"
+)
+
+var (
+	// ErrInvalidProof 证明节点与根哈希对不上
+	ErrInvalidProof = errors.New("Merkle 证明无效")
+	// ErrAccountMismatch 节点返回的账户字段与证明中的不一致
+	ErrAccountMismatch = errors.New("账户字段与证明不一致")
+	// ErrStorageMismatch 节点返回的存储值与证明中的不一致
+	ErrStorageMismatch = errors.New("存储值与证明不一致")
+)
+
+// Caller 能发送单个 JSON-RPC 请求的客户端，*rpc.Client 满足此接口
+type Caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// HeaderReader 读取区块头，用来取得可信的状态根
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// StorageProof 单个存储槽的证明
+type StorageProof struct {
+	Key   common.Hash
+	Value *big.Int
+	Proof [][]byte
+}
+
+// AccountProof eth_getProof 的结果
+type AccountProof struct {
+	Address      common.Address
+	Balance      *big.Int
+	Nonce        uint64
+	CodeHash     common.Hash
+	StorageHash  common.Hash // 账户存储树的根
+	AccountProof [][]byte
+	Storage      []*StorageProof
+
+	Header   *types.Header // 验证所用的区块头，由 Client.Verified 填写
+	Verified bool          // Verify 通过后为 true
+}
+
+// proofJSON eth_getProof 返回的 JSON；存储槽的 key 可能是 32 字节哈希，也可能是去掉前导零的数值
+type proofJSON struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []struct {
+		Key   string          `json:"key"`
+		Value *hexutil.Big    `json:"value"`
+		Proof []hexutil.Bytes `json:"proof"`
+	} `json:"storageProof"`
+}
+
+// Client eth_getProof 客户端
+type Client struct {
+	caller  Caller
+	headers HeaderReader
+}
+
+// New 创建客户端；headers 提供验证用的区块头，可以与 caller 是不同的（更可信的）节点
+func New(caller Caller, headers HeaderReader) *Client {
+	return &Client{caller: caller, headers: headers}
+}
+
+// FromBackend 后端实现了 backend.RPCClient 时返回对应的客户端，区块头也从该后端读取
+func FromBackend(b backend.EthBackend) (*Client, bool) {
+	client, ok := backend.RPC(b)
+	if !ok {
+		return nil, false
+	}
+	return New(client, b), true
+}
+
+// GetProof 调用 eth_getProof 获取 blockNumber（nil 表示最新区块）上账户和存储槽的证明，不做验证
+func (c *Client) GetProof(ctx context.Context, account common.Address, keys []common.Hash, blockNumber *big.Int) (*AccountProof, error) {
+	if keys == nil {
+		keys = []common.Hash{} // 节点不接受 null
+	}
+	var res proofJSON
+	if err := c.caller.CallContext(ctx, &res, "eth_getProof", account, keys, batch.BlockArg(blockNumber)); err != nil {
+		return nil, fmt.Errorf("eth_getProof 失败: %w", err)
+	}
+	if res.Balance == nil {
+		return nil, fmt.Errorf("eth_getProof 没有返回账户 %s 的数据", account.Hex())
+	}
+	out := &AccountProof{
+		Address:      res.Address,
+		Balance:      res.Balance.ToInt(),
+		Nonce:        uint64(res.Nonce),
+		CodeHash:     res.CodeHash,
+		StorageHash:  res.StorageHash,
+		AccountProof: toBytes(res.AccountProof),
+	}
+	for _, s := range res.StorageProof {
+		key, err := parseKey(s.Key)
+		if err != nil {
+			return nil, err
+		}
+		sp := &StorageProof{Key: key, Value: new(big.Int), Proof: toBytes(s.Proof)}
+		if s.Value != nil {
+			sp.Value = s.Value.ToInt()
+		}
+		out.Storage = append(out.Storage, sp)
+	}
+	return out, nil
+}
+
+// Verified 先读取区块头，再在同一个区块上获取证明，并用区块头的 stateRoot 验证
+func (c *Client) Verified(ctx context.Context, account common.Address, keys []common.Hash, blockNumber *big.Int) (*AccountProof, error) {
+	header, err := c.headers.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("读取区块头失败: %w", err)
+	}
+	// 固定区块号，避免两次请求之间出了新区块
+	p, err := c.GetProof(ctx, account, keys, header.Number)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Verify(header.Root); err != nil {
+		return nil, err
+	}
+	p.Header = header
+	return p, nil
+}
+
+// Verify 用状态根验证账户证明，再用账户的存储根验证每个存储证明
+func (p *AccountProof) Verify(stateRoot common.Hash) error {
+	if err := p.verifyAccount(stateRoot); err != nil {
+		return err
+	}
+	for _, s := range p.Storage {
+		if err := s.Verify(p.StorageHash); err != nil {
+			return err
+		}
+	}
+	p.Verified = true
+	return nil
+}
+
+// verifyAccount 证明的叶子为空表示账户不存在，此时节点返回的字段必须都是空值
+func (p *AccountProof) verifyAccount(stateRoot common.Hash) error {
+	leaf, err := verify(stateRoot, crypto.Keccak256(p.Address.Bytes()), p.AccountProof)
+	if err != nil {
+		return fmt.Errorf("账户 %s: %w", p.Address.Hex(), err)
+	}
+	want := types.NewEmptyStateAccount()
+	if leaf != nil {
+		if err := rlp.DecodeBytes(leaf, want); err != nil {
+			return fmt.Errorf("账户 %s: %w: %v", p.Address.Hex(), ErrInvalidProof, err)
+		}
+	}
+	var mismatch []string
+	if p.Nonce != want.Nonce {
+		mismatch = append(mismatch, fmt.Sprintf("nonce %d ≠ %d", p.Nonce, want.Nonce))
+	}
+	if p.Balance.Cmp(want.Balance) != 0 {
+		mismatch = append(mismatch, fmt.Sprintf("余额 %s ≠ %s", p.Balance, want.Balance))
+	}
+	// 不存在的账户，部分节点返回全零的 codeHash / storageHash
+	if p.CodeHash != common.BytesToHash(want.CodeHash) && !(leaf == nil && p.CodeHash == common.Hash{}) {
+		mismatch = append(mismatch, fmt.Sprintf("codeHash %s ≠ %s", p.CodeHash.Hex(), common.BytesToHash(want.CodeHash).Hex()))
+	}
+	if p.StorageHash != want.Root && !(leaf == nil && p.StorageHash == common.Hash{}) {
+		mismatch = append(mismatch, fmt.Sprintf("storageHash %s ≠ %s", p.StorageHash.Hex(), want.Root.Hex()))
+	}
+	if len(mismatch) > 0 {
+		return fmt.Errorf("账户 %s: %w（%s）", p.Address.Hex(), ErrAccountMismatch, strings.Join(mismatch, "，"))
+	}
+	return nil
+}
+
+// Verify 用账户的存储根验证存储槽的值；空存储树中所有槽都是 0
+func (s *StorageProof) Verify(storageRoot common.Hash) error {
+	want := new(big.Int)
+	if storageRoot != types.EmptyRootHash && storageRoot != (common.Hash{}) {
+		leaf, err := verify(storageRoot, crypto.Keccak256(s.Key.Bytes()), s.Proof)
+		if err != nil {
+			return fmt.Errorf("槽 %s: %w", s.Key.Hex(), err)
+		}
+		if leaf != nil {
+			// 存储树中的值是去掉前导零后的 RLP 字节串
+			var content []byte
+			if err := rlp.DecodeBytes(leaf, &content); err != nil {
+				return fmt.Errorf("槽 %s: %w: %v", s.Key.Hex(), ErrInvalidProof, err)
+			}
+			want.SetBytes(content)
+		}
+	}
+	if s.Value.Cmp(want) != 0 {
+		return fmt.Errorf("槽 %s: %w（%s ≠ %s）", s.Key.Hex(), ErrStorageMismatch, s.Value, want)
+	}
+	return nil
+}
+
+// verify 把证明节点按哈希放进内存数据库，从 root 出发沿 key 查找叶子；返回 nil 表示证明了 key 不存在
+func verify(root common.Hash, key []byte, nodes [][]byte) ([]byte, error) {
+	db := memorydb.New()
+	for _, node := range nodes {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, key, db)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	return value, nil
+}
+
+// String 显示验证结果
+func (p *AccountProof) String() string {
+	var sb strings.Builder
+	if p.Header != nil {
+		fmt.Fprintf(&sb, "区块: %d (%s)\n状态根: %s\n", p.Header.Number, p.Header.Hash().Hex(), p.Header.Root.Hex())
+	}
+	status := "未验证"
+	if p.Verified {
+		status = "✓ 已验证"
+	}
+	fmt.Fprintf(&sb, "账户: %s [%s，证明 %d 个节点]\n", p.Address.Hex(), status, len(p.AccountProof))
+	fmt.Fprintf(&sb, "  余额: %s Wei\n", p.Balance)
+	fmt.Fprintf(&sb, "  Nonce: %d\n", p.Nonce)
+	fmt.Fprintf(&sb, "  CodeHash: %s", p.CodeHash.Hex())
+	if p.CodeHash == types.EmptyCodeHash {
+		sb.WriteString("（无代码）")
+	}
+	fmt.Fprintf(&sb, "\n  StorageHash: %s\n", p.StorageHash.Hex())
+	for _, s := range p.Storage {
+		fmt.Fprintf(&sb, "  槽 %s = %s [%s，证明 %d 个节点]\n", s.Key.Hex(), common.BigToHash(s.Value).Hex(), status, len(s.Proof))
+	}
+	return sb.String()
+}
+
+// parseKey 解析 storageProof 中的 key，兼容数值格式（如 0x0）
+func parseKey(s string) (common.Hash, error) {
+	digits := strings.TrimPrefix(s, "0x")
+	if digits == "" {
+		return common.Hash{}, nil
+	}
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("无效的存储槽 %q", s)
+	}
+	return common.BigToHash(n), nil
+}
+
+func toBytes(list []hexutil.Bytes) [][]byte {
+	out := make([][]byte, len(list))
+	for i, b := range list {
+		out[i] = b
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package proof_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/override"
+	"github.com/duanyu/new-eth-project/pkg/proof"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+func TestVerified(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	token, mt := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	before := chain.Head().Number
+	if _, err := mt.Transfer(alice.Opts(), bob.Address, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	client, ok := proof.FromBackend(chain)
+	if !ok {
+		t.Fatal("simchain should provide an RPC client")
+	}
+	// MyToken 的 _balances 在槽 0，_totalSupply 在槽 2；再加一个从未写过的槽
+	bobSlot := override.MappingSlot(common.BytesToHash(bob.Address.Bytes()), 0)
+	keys := []common.Hash{bobSlot, common.BigToHash(big.NewInt(2)), common.BigToHash(big.NewInt(99))}
+	p, err := client.Verified(ctx, token, keys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supply, _ := mt.TotalSupply(nil)
+	if !p.Verified || p.Storage[0].Value.Int64() != 100 || p.Storage[1].Value.Cmp(supply) != 0 || p.Storage[2].Value.Sign() != 0 {
+		t.Fatalf("proof = %+v", p)
+	}
+	if p.Header.Number.Cmp(chain.Head().Number) != 0 || p.CodeHash == types.EmptyCodeHash {
+		t.Fatalf("header %s, code hash %s", p.Header.Number, p.CodeHash)
+	}
+	if !strings.Contains(p.String(), "✓ 已验证") {
+		t.Fatalf("output:\n%s", p)
+	}
+
+	// 普通账户和不存在的账户
+	eoa, err := client.Verified(ctx, alice.Address, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eoa.Balance.Cmp(chain.Balance(alice.Address)) != 0 || eoa.Nonce == 0 {
+		t.Fatalf("alice = %+v", eoa)
+	}
+	missing, err := client.Verified(ctx, common.HexToAddress("0x000000000000000000000000000000000000dEaD"), keys[:1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.Balance.Sign() != 0 || missing.Storage[0].Value.Sign() != 0 {
+		t.Fatalf("missing = %+v", missing)
+	}
+
+	// 历史区块：转账之前 bob 的余额为 0
+	old, err := client.Verified(ctx, token, keys[:1], before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.Storage[0].Value.Sign() != 0 || old.Header.Number.Cmp(before) != 0 {
+		t.Fatalf("old = %+v", old)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice := chain.Accounts[0]
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	client := proof.New(chain.Client(), chain)
+	root := chain.Head().Root
+	keys := []common.Hash{common.BigToHash(big.NewInt(2))}
+
+	fetch := func() *proof.AccountProof {
+		t.Helper()
+		p, err := client.GetProof(ctx, token, keys, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	if err := fetch().Verify(root); err != nil {
+		t.Fatal(err)
+	}
+
+	p := fetch()
+	p.Balance = big.NewInt(1)
+	if err := p.Verify(root); !errors.Is(err, proof.ErrAccountMismatch) {
+		t.Fatalf("tampered balance: %v", err)
+	}
+	p = fetch()
+	p.Storage[0].Value = big.NewInt(1)
+	if err := p.Verify(root); !errors.Is(err, proof.ErrStorageMismatch) {
+		t.Fatalf("tampered storage: %v", err)
+	}
+	p = fetch()
+	p.AccountProof[len(p.AccountProof)-1][5] ^= 0xff
+	if err := p.Verify(root); !errors.Is(err, proof.ErrInvalidProof) {
+		t.Fatalf("tampered node: %v", err)
+	}
+	// 用另一个区块的状态根验证
+	if err := fetch().Verify(chain.Blockchain().GetHeaderByNumber(0).Root); !errors.Is(err, proof.ErrInvalidProof) {
+		t.Fatal("expected error for wrong state root")
+	}
+}
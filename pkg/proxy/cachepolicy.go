@@ -0,0 +1,133 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// immutableMethods 不带区块参数的可缓存方法：链 ID、按区块哈希查询、按哈希查询交易和收据
+// 交易和收据在 store 中按所在区块判断是否已确定
+var immutableMethods = map[string]bool{
+	"eth_chainId":                           true,
+	"net_version":                           true,
+	"eth_getBlockByHash":                    true,
+	"eth_getBlockTransactionCountByHash":    true,
+	"eth_getTransactionByBlockHashAndIndex": true,
+	"eth_getUncleByBlockHashAndIndex":       true,
+	"eth_getUncleCountByBlockHash":          true,
+	"eth_getTransactionByHash":              true,
+	"eth_getTransactionReceipt":             true,
+}
+
+// blockParam 按区块查询的方法及区块参数的位置；区块已确定或按哈希指定时结果不变
+var blockParam = map[string]int{
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getUncleByBlockNumberAndIndex":       0,
+	"eth_getBlockReceipts":                    0,
+	"eth_getBalance":                          1,
+	"eth_getCode":                             1,
+	"eth_getTransactionCount":                 1,
+	"eth_getStorageAt":                        2,
+	"eth_call":                                1,
+	"eth_getProof":                            2,
+}
+
+// blockRef 解析后的区块参数
+type blockRef struct {
+	hash   bool   // 按区块哈希指定
+	number uint64 // 按区块号指定时的区块号
+}
+
+// parseBlockRef 解析区块号、区块哈希或 EIP-1898 对象；"latest" 等标签返回 false
+func parseBlockRef(raw json.RawMessage) (blockRef, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if len(s) == 2+2*common.HashLength {
+			return blockRef{hash: true}, true
+		}
+		n, err := hexutil.DecodeUint64(s)
+		return blockRef{number: n}, err == nil
+	}
+	var obj struct {
+		BlockHash   *common.Hash    `json:"blockHash"`
+		BlockNumber *hexutil.Uint64 `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return blockRef{}, false
+	}
+	switch {
+	case obj.BlockHash != nil:
+		return blockRef{hash: true}, true
+	case obj.BlockNumber != nil:
+		return blockRef{number: uint64(*obj.BlockNumber)}, true
+	}
+	return blockRef{}, false
+}
+
+// cacheKey 返回请求的缓存键，不可能被缓存的请求（如查询 latest 区块）返回 false
+// 键为方法名加参数的 keccak256 哈希，可以直接作为磁盘存储的路径
+func cacheKey(method string, params []json.RawMessage) (string, bool) {
+	if !immutableMethods[method] {
+		pos, ok := blockParam[method]
+		if !ok || pos >= len(params) {
+			return "", false
+		}
+		if _, ok := parseBlockRef(params[pos]); !ok {
+			return "", false
+		}
+	}
+	var buf []byte
+	for _, p := range params {
+		buf = append(buf, p...)
+		buf = append(buf, 0)
+	}
+	return method + "/" + strings.TrimPrefix(crypto.Keccak256Hash(buf).Hex(), "0x"), true
+}
+
+// store 按方法决定响应是否已经不可变：
+//   - 空结果（区块或交易尚不存在）不缓存
+//   - 按哈希查询和按已确定区块查询的结果写入磁盘
+//   - 交易和收据按所在区块判断，未确定时只保存在内存中，重组时失效
+func (s *Server) store(ctx context.Context, key, method string, params []json.RawMessage, result json.RawMessage) {
+	if string(result) == "null" {
+		return
+	}
+	switch method {
+	case "eth_getTransactionByHash", "eth_getTransactionReceipt":
+		var located struct {
+			BlockNumber *hexutil.Uint64 `json:"blockNumber"`
+		}
+		if err := json.Unmarshal(result, &located); err != nil || located.BlockNumber == nil {
+			return // 交易还在交易池中
+		}
+		finalized, err := s.cache.Finalized(ctx)
+		if err != nil {
+			return
+		}
+		number := uint64(*located.BlockNumber)
+		s.cache.PutRaw(ctx, key, result, number, number <= finalized)
+		return
+	}
+	if immutableMethods[method] {
+		s.cache.PutRaw(ctx, key, result, 0, true)
+		return
+	}
+	ref, ok := parseBlockRef(params[blockParam[method]])
+	if !ok {
+		return
+	}
+	if !ref.hash {
+		finalized, err := s.cache.Finalized(ctx)
+		if err != nil || ref.number > finalized {
+			return
+		}
+	}
+	s.cache.PutRaw(ctx, key, result, ref.number, true)
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// JSON-RPC 2.0 标准错误码
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// request 客户端发来的 JSON-RPC 请求
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// params 把参数数组拆成逐个的原始 JSON，转发时原样传给上游
+func (r *request) params() ([]json.RawMessage, error) {
+	if len(r.Params) == 0 || string(r.Params) == "null" {
+		return nil, nil
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(r.Params, &params); err != nil {
+		return nil, fmt.Errorf("params 必须是数组: %w", err)
+	}
+	return params, nil
+}
+
+// response 返回给客户端的 JSON-RPC 响应
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// notification WebSocket 订阅推送
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  struct {
+		Subscription rpc.ID      `json:"subscription"`
+		Result       interface{} `json:"result"`
+	} `json:"params"`
+}
+
+// rpcError JSON-RPC 错误对象
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func resultResponse(id json.RawMessage, result json.RawMessage) *response {
+	return &response{JSONRPC: "2.0", ID: normalizeID(id), Result: result}
+}
+
+func errorResponse(id json.RawMessage, err *rpcError) *response {
+	return &response{JSONRPC: "2.0", ID: normalizeID(id), Error: err}
+}
+
+// normalizeID 请求没有 id 时响应中使用 null
+func normalizeID(id json.RawMessage) json.RawMessage {
+	if len(id) == 0 {
+		return json.RawMessage("null")
+	}
+	return id
+}
+
+func errParse(err error) *rpcError {
+	return &rpcError{Code: codeParseError, Message: "解析请求失败: " + err.Error()}
+}
+
+// toRPCError 保留上游返回的错误码和 data（如合约回滚数据），其他错误按内部错误返回
+func toRPCError(err error) *rpcError {
+	out := &rpcError{Code: codeInternalError, Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		out.Code, out.Message = rpcErr.ErrorCode(), rpcErr.Error()
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		out.Data = dataErr.ErrorData()
+	}
+	return out
+}
+
+// encode 编码响应；响应只包含可编码的类型，出错说明程序有问题
+func encode(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(errorResponse(nil, &rpcError{Code: codeInternalError, Message: err.Error()}))
+	}
+	return data
+}
@@ -0,0 +1,104 @@
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
+)
+
+// methodMetrics 单个方法的计数
+type methodMetrics struct {
+	requests  uint64
+	errors    uint64
+	cacheHits uint64
+	blocked   uint64
+	seconds   float64 // 累计耗时
+}
+
+// metrics 按方法统计的请求指标
+type metrics struct {
+	mu      sync.Mutex
+	methods map[string]*methodMetrics
+}
+
+func newMetrics() *metrics {
+	return &metrics{methods: make(map[string]*methodMetrics)}
+}
+
+func (m *metrics) observe(method string, elapsed time.Duration, isErr, cached, blocked bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mm, ok := m.methods[method]
+	if !ok {
+		mm = &methodMetrics{}
+		m.methods[method] = mm
+	}
+	mm.requests++
+	mm.seconds += elapsed.Seconds()
+	if isErr {
+		mm.errors++
+	}
+	if cached {
+		mm.cacheHits++
+	}
+	if blocked {
+		mm.blocked++
+	}
+}
+
+// endpointReporter 能报告各节点健康状态的上游，*multiclient.Client 满足此接口
+type endpointReporter interface {
+	Endpoints() []multiclient.Status
+}
+
+// serveMetrics 以 Prometheus 文本格式输出指标
+func (s *Server) serveMetrics(w http.ResponseWriter) {
+	var buf bytes.Buffer
+
+	s.metrics.mu.Lock()
+	names := make([]string, 0, len(s.metrics.methods))
+	for name := range s.metrics.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	counter := func(metric, help string, value func(*methodMetrics) uint64) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s counter\n", metric, help, metric)
+		for _, name := range names {
+			fmt.Fprintf(&buf, "%s{method=%q} %d\n", metric, name, value(s.metrics.methods[name]))
+		}
+	}
+	counter("ethproxy_requests_total", "JSON-RPC requests by method.", func(m *methodMetrics) uint64 { return m.requests })
+	counter("ethproxy_errors_total", "JSON-RPC requests that returned an error.", func(m *methodMetrics) uint64 { return m.errors })
+	counter("ethproxy_cache_hits_total", "JSON-RPC requests served from the response cache.", func(m *methodMetrics) uint64 { return m.cacheHits })
+	counter("ethproxy_blocked_total", "JSON-RPC requests rejected by the method policy.", func(m *methodMetrics) uint64 { return m.blocked })
+	fmt.Fprintf(&buf, "# HELP ethproxy_request_duration_seconds JSON-RPC request latency.\n# TYPE ethproxy_request_duration_seconds summary\n")
+	for _, name := range names {
+		mm := s.metrics.methods[name]
+		fmt.Fprintf(&buf, "ethproxy_request_duration_seconds_sum{method=%q} %g\n", name, mm.seconds)
+		fmt.Fprintf(&buf, "ethproxy_request_duration_seconds_count{method=%q} %d\n", name, mm.requests)
+	}
+	s.metrics.mu.Unlock()
+
+	if s.cache != nil {
+		fmt.Fprintf(&buf, "# HELP ethproxy_cache_entries Entries in the in-memory response cache.\n# TYPE ethproxy_cache_entries gauge\n")
+		fmt.Fprintf(&buf, "ethproxy_cache_entries %d\n", s.cache.Stats().Entries)
+	}
+	if r, ok := s.upstream.(endpointReporter); ok {
+		fmt.Fprintf(&buf, "# HELP ethproxy_upstream_healthy Whether the upstream endpoint passes health checks.\n# TYPE ethproxy_upstream_healthy gauge\n")
+		for _, st := range r.Endpoints() {
+			healthy := 0
+			if st.Healthy {
+				healthy = 1
+			}
+			fmt.Fprintf(&buf, "ethproxy_upstream_healthy{endpoint=%q} %d\n", st.Name, healthy)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
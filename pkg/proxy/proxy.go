@@ -0,0 +1,343 @@
+// Package proxy 实现本地 JSON-RPC 代理：钱包和脚本只需连接一个本地地址
+//
+// 代理通过 HTTP 和 WebSocket 接收 JSON-RPC 请求（支持批量请求），经由项目自己的客户端
+// （通常是 multiclient，带健康检查和故障切换）转发给上游节点，并且：
+//   - 不可变的响应（按哈希查询的区块、已确定区块上的查询、已打包交易的收据等）由 pkg/cache 缓存
+//   - 拒绝危险方法：默认拒绝 DefaultDenied 中会使用节点托管私钥或修改节点状态的方法，
+//     也可以用 WithAllowed 只放行指定的方法
+//   - 以 JSON Lines 格式记录每个请求的方法、耗时、是否命中缓存，可选记录参数和结果
+//   - 在 /metrics 以 Prometheus 文本格式暴露请求数、错误数、缓存命中数和耗时
+//
+// WebSocket 连接上的 eth_subscribe 支持 newHeads 和 logs 两种订阅，
+// 通过客户端的 SubscribeNewHead、SubscribeFilterLogs 建立。
+// 默认拒绝跨源的 WebSocket 连接，防止用户浏览的任意网页借浏览器连上本地代理；
+// 需要让网页版钱包等连接时用 WithAllowedOrigins 放行它们的 Origin。
+//
+//	upstream, _ := multiclient.Dial(ctx, urls)
+//	srv := proxy.New(upstream, proxy.WithLog(os.Stdout, false))
+//	http.ListenAndServe("127.0.0.1:8545", srv)
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/cache"
+)
+
+// Upstream 代理转发请求使用的客户端，*multiclient.Client 满足此接口
+type Upstream interface {
+	backend.EthBackend
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// DefaultDenied 默认拒绝的方法：使用节点上托管的私钥签名，或修改节点配置和状态
+// 以 "*" 结尾的模式按前缀匹配
+var DefaultDenied = []string{
+	"eth_sendTransaction",
+	"eth_sign",
+	"eth_signTransaction",
+	"eth_signTypedData*",
+	"personal_*",
+	"admin_*",
+	"miner_*",
+	"debug_setHead",
+}
+
+// maxBodySize 单个 HTTP 请求体或 WebSocket 消息的最大字节数
+const maxBodySize = 5 * 1024 * 1024
+
+// Config 代理配置
+type Config struct {
+	Allowed      []string       // 非空时只放行匹配的方法
+	Denied       []string       // 拒绝的方法，优先于 Allowed
+	CacheOptions []cache.Option // 响应缓存配置
+	NoCache      bool           // 关闭响应缓存
+	Log          io.Writer      // 请求日志输出，nil 表示不记录
+	LogBodies    bool           // 日志中是否包含请求参数和响应结果
+
+	// AllowedOrigins 允许跨源建立 WebSocket 连接的 Origin（如 https://app.example.com），"*" 表示全部放行；
+	// 同源和不带 Origin 头的连接（非浏览器客户端）总是放行
+	AllowedOrigins []string
+}
+
+// DefaultConfig 返回默认配置：拒绝 DefaultDenied 中的方法，启用内存缓存，不记录日志
+func DefaultConfig() *Config {
+	return &Config{Denied: append([]string(nil), DefaultDenied...)}
+}
+
+// Option 修改代理配置
+type Option func(*Config)
+
+// WithAllowed 只放行匹配的方法（仍会拒绝 Denied 中的方法）
+func WithAllowed(methods ...string) Option { return func(c *Config) { c.Allowed = methods } }
+
+// WithDenied 替换默认的拒绝列表
+func WithDenied(methods ...string) Option { return func(c *Config) { c.Denied = methods } }
+
+// WithAllowedOrigins 允许这些 Origin 的网页跨源建立 WebSocket 连接，"*" 表示全部放行
+func WithAllowedOrigins(origins ...string) Option {
+	return func(c *Config) { c.AllowedOrigins = origins }
+}
+
+// WithCache 设置响应缓存，例如 WithCache(cache.WithStore(store)) 启用磁盘存储
+func WithCache(opts ...cache.Option) Option {
+	return func(c *Config) { c.CacheOptions, c.NoCache = opts, false }
+}
+
+// WithoutCache 关闭响应缓存，所有请求都转发给上游
+func WithoutCache() Option { return func(c *Config) { c.NoCache = true } }
+
+// WithLog 把请求日志写入 w；bodies 为 true 时同时记录参数和结果
+func WithLog(w io.Writer, bodies bool) Option {
+	return func(c *Config) { c.Log, c.LogBodies = w, bodies }
+}
+
+// Server JSON-RPC 代理，实现 http.Handler
+type Server struct {
+	upstream Upstream
+	cfg      Config
+	cache    *cache.Client // 关闭缓存时为 nil
+	metrics  *metrics
+	upgrader websocket.Upgrader
+
+	logMu sync.Mutex
+}
+
+// New 创建代理
+func New(upstream Upstream, opts ...Option) *Server {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	s := &Server{
+		upstream: upstream,
+		cfg:      *cfg,
+		metrics:  newMetrics(),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+		},
+	}
+	s.upgrader.CheckOrigin = s.checkOrigin
+	if !cfg.NoCache {
+		s.cache = cache.Wrap(upstream, cfg.CacheOptions...)
+	}
+	return s
+}
+
+// checkOrigin 决定是否接受 WebSocket 升级请求：不带 Origin 或同源时接受，
+// 跨源时只接受 AllowedOrigins 中的 Origin
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range s.cfg.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheStats 返回响应缓存的统计，关闭缓存时返回零值
+func (s *Server) CacheStats() cache.Stats {
+	if s.cache == nil {
+		return cache.Stats{}
+	}
+	return s.cache.Stats()
+}
+
+// ServeHTTP 处理 /metrics、WebSocket 升级和 HTTP POST 形式的 JSON-RPC 请求
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	switch {
+	case r.URL.Path == "/metrics":
+		s.serveMetrics(w)
+	case websocket.IsWebSocketUpgrade(r):
+		s.serveWebSocket(w, r)
+	case r.Method == http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost:
+		s.serveHTTP(w, r)
+	default:
+		http.Error(w, "JSON-RPC 请求需要使用 POST", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveHTTP 处理一次 HTTP JSON-RPC 请求（单个或批量）
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "读取请求失败: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	out := s.handleMessage(r.Context(), body, &session{remote: r.RemoteAddr, transport: "http"})
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out)
+}
+
+// handleMessage 解析单个或批量请求，依次处理后编码响应
+func (s *Server) handleMessage(ctx context.Context, body []byte, sess *session) []byte {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var reqs []*request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			return encode(errorResponse(nil, errParse(err)))
+		}
+		if len(reqs) == 0 {
+			return encode(errorResponse(nil, &rpcError{Code: codeInvalidRequest, Message: "空的批量请求"}))
+		}
+		resps := make([]*response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = s.handle(ctx, req, sess)
+		}
+		return encode(resps)
+	}
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return encode(errorResponse(nil, errParse(err)))
+	}
+	return encode(s.handle(ctx, &req, sess))
+}
+
+// handle 处理单个请求：检查权限、查缓存、转发上游，并记录日志和指标
+func (s *Server) handle(ctx context.Context, req *request, sess *session) *response {
+	start := time.Now()
+	entry := logEntry{Time: start, Remote: sess.remote, Transport: sess.transport, Method: req.Method}
+	resp := s.dispatch(ctx, req, sess, &entry)
+
+	elapsed := time.Since(start)
+	entry.DurationMs = float64(elapsed.Microseconds()) / 1000
+	if resp.Error != nil {
+		entry.Error = resp.Error.Message
+	}
+	if s.cfg.LogBodies {
+		entry.Params, entry.Result = req.Params, resp.Result
+	}
+	s.metrics.observe(req.Method, elapsed, resp.Error != nil, entry.Cached, entry.Blocked)
+	s.writeLog(&entry)
+	return resp
+}
+
+// dispatch 返回请求的响应，entry 中记录是否命中缓存、是否被拒绝
+func (s *Server) dispatch(ctx context.Context, req *request, sess *session, entry *logEntry) *response {
+	if req.Method == "" {
+		return errorResponse(req.ID, &rpcError{Code: codeInvalidRequest, Message: "缺少 method 字段"})
+	}
+	if !s.permitted(req.Method) {
+		entry.Blocked = true
+		return errorResponse(req.ID, &rpcError{Code: codeMethodNotFound, Message: "代理禁止调用 " + req.Method})
+	}
+	params, err := req.params()
+	if err != nil {
+		return errorResponse(req.ID, &rpcError{Code: codeInvalidParams, Message: err.Error()})
+	}
+
+	switch req.Method {
+	case "eth_subscribe", "eth_unsubscribe":
+		if sess.subs == nil {
+			return errorResponse(req.ID, &rpcError{Code: codeMethodNotFound, Message: "订阅需要 WebSocket 连接"})
+		}
+		result, err := sess.subs.handle(ctx, s.upstream, req.Method, params)
+		if err != nil {
+			return errorResponse(req.ID, toRPCError(err))
+		}
+		return resultResponse(req.ID, result)
+	}
+
+	key, cacheable := cacheKey(req.Method, params)
+	if cacheable && s.cache != nil {
+		if data, ok := s.cache.GetRaw(ctx, key); ok {
+			entry.Cached = true
+			return resultResponse(req.ID, data)
+		}
+	}
+
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+	var result json.RawMessage
+	if err := s.upstream.CallContext(ctx, &result, req.Method, args...); err != nil {
+		return errorResponse(req.ID, toRPCError(err))
+	}
+	if len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+	if cacheable && s.cache != nil {
+		s.store(ctx, key, req.Method, params, result)
+	}
+	return resultResponse(req.ID, result)
+}
+
+// permitted 判断方法是否可以转发
+func (s *Server) permitted(method string) bool {
+	if matchAny(s.cfg.Denied, method) {
+		return false
+	}
+	return len(s.cfg.Allowed) == 0 || matchAny(s.cfg.Allowed, method)
+}
+
+// matchAny 判断 method 是否匹配任一模式，以 "*" 结尾的模式按前缀匹配
+func matchAny(patterns []string, method string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if p == method {
+			return true
+		}
+	}
+	return false
+}
+
+// logEntry 一条请求日志
+type logEntry struct {
+	Time       time.Time       `json:"time"`
+	Remote     string          `json:"remote"`
+	Transport  string          `json:"transport"`
+	Method     string          `json:"method"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Cached     bool            `json:"cached,omitempty"`
+	Blocked    bool            `json:"blocked,omitempty"`
+	DurationMs float64         `json:"durationMs"`
+}
+
+func (s *Server) writeLog(entry *logEntry) {
+	if s.cfg.Log == nil {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+	s.cfg.Log.Write(append(line, '\n'))
+}
+
+// session 一个 HTTP 请求或 WebSocket 连接的上下文
+type session struct {
+	remote    string
+	transport string
+	subs      *subscriptions // 仅 WebSocket 连接有值
+}
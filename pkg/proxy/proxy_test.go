@@ -0,0 +1,226 @@
+package proxy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/cache"
+	"github.com/duanyu/new-eth-project/pkg/multiclient"
+	"github.com/duanyu/new-eth-project/pkg/proxy"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// startProxy 以测试链为上游启动代理，返回代理地址
+func startProxy(t *testing.T, chain *simchain.Chain, opts ...proxy.Option) (*proxy.Server, string) {
+	t.Helper()
+	upstream, err := multiclient.New(context.Background(), []*multiclient.Endpoint{multiclient.NewEndpoint("sim", chain)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(upstream.Close)
+	srv := proxy.New(upstream, opts...)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return srv, ts.URL
+}
+
+func TestProxyForwardsAndCaches(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tx := chain.TransferETH(alice, bob.Address, big.NewInt(1000))
+	chain.MineBlocks(5)
+
+	var logs bytes.Buffer
+	srv, url := startProxy(t, chain, proxy.WithCache(cache.WithConfirmations(2), cache.WithFinalizedTTL(0)), proxy.WithLog(&logs, false))
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil || chainID.Int64() != 1337 {
+		t.Fatalf("chainID = %v, %v", chainID, err)
+	}
+	balance, err := client.BalanceAt(ctx, bob.Address, nil)
+	if err != nil || balance.Cmp(chain.Balance(bob.Address)) != 0 {
+		t.Fatalf("balance = %v, %v", balance, err)
+	}
+
+	// 已确定的区块和已确定的收据第二次查询命中缓存，最新区块不缓存
+	for i := 0; i < 2; i++ {
+		header, err := client.HeaderByNumber(ctx, big.NewInt(1))
+		if err != nil || header.Number.Uint64() != 1 {
+			t.Fatalf("header = %v, %v", header, err)
+		}
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("receipt = %+v, %v", receipt, err)
+		}
+		if _, err := client.HeaderByNumber(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if st := srv.CacheStats(); st.Hits != 2 {
+		t.Fatalf("cache stats = %+v, want 2 hits", st)
+	}
+	if !strings.Contains(logs.String(), `"method":"eth_getTransactionReceipt"`) || !strings.Contains(logs.String(), `"cached":true`) {
+		t.Fatalf("log = %s", logs.String())
+	}
+}
+
+func TestProxyBlocksDangerousMethods(t *testing.T) {
+	chain := simchain.New(t)
+	_, url := startProxy(t, chain)
+	client, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, method := range []string{"personal_listAccounts", "eth_sendTransaction", "admin_peers"} {
+		var result interface{}
+		err := client.Call(&result, method)
+		var rpcErr rpc.Error
+		if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+			t.Fatalf("%s: err = %v, want method not found", method, err)
+		}
+	}
+
+	// 放行列表之外的方法同样被拒绝
+	_, url = startProxy(t, chain, proxy.WithAllowed("eth_chainId"))
+	client2, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client2.Close()
+	var id string
+	if err := client2.Call(&id, "eth_chainId"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client2.Call(&id, "eth_blockNumber"); err == nil {
+		t.Fatal("eth_blockNumber should be blocked by the allow list")
+	}
+}
+
+func TestProxyMetrics(t *testing.T) {
+	chain := simchain.New(t)
+	_, url := startProxy(t, chain)
+	client, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// 批量请求中的每个请求分别统计
+	batch := []rpc.BatchElem{
+		{Method: "eth_chainId", Result: new(string)},
+		{Method: "eth_chainId", Result: new(string)},
+		{Method: "personal_sign", Result: new(string)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].Error != nil || batch[2].Error == nil {
+		t.Fatalf("batch = %+v", batch)
+	}
+
+	resp, err := http.Get(url + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		`ethproxy_requests_total{method="eth_chainId"} 2`,
+		`ethproxy_cache_hits_total{method="eth_chainId"} 1`,
+		`ethproxy_blocked_total{method="personal_sign"} 1`,
+		`ethproxy_upstream_healthy{endpoint="sim"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("metrics missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestProxyWebSocketSubscription(t *testing.T) {
+	chain := simchain.New(t)
+	_, url := startProxy(t, chain)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, "ws"+strings.TrimPrefix(url, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	heads := make(chan *types.Header, 4)
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	chain.MineBlocks(1)
+	select {
+	case head := <-heads:
+		if head.Number.Uint64() != 1 {
+			t.Fatalf("head = %d, want 1", head.Number)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-ctx.Done():
+		t.Fatal("no head notification received")
+	}
+
+	// 普通请求也可以走 WebSocket
+	if n, err := client.BlockNumber(ctx); err != nil || n != 1 {
+		t.Fatalf("block number = %d, %v", n, err)
+	}
+}
+
+func TestProxyWebSocketOrigin(t *testing.T) {
+	chain := simchain.New(t)
+	_, base := startProxy(t, chain, proxy.WithAllowedOrigins("https://wallet.example"))
+	_, open := startProxy(t, chain, proxy.WithAllowedOrigins("*"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dial := func(url, origin string) error {
+		client, err := rpc.DialWebsocket(ctx, "ws"+strings.TrimPrefix(url, "http"), origin)
+		if err == nil {
+			client.Close()
+		}
+		return err
+	}
+	// 不带 Origin（非浏览器客户端）和同源的连接总是放行
+	if err := dial(base, ""); err != nil {
+		t.Fatalf("connection without Origin: %v", err)
+	}
+	if err := dial(base, base); err != nil {
+		t.Fatalf("same-origin connection: %v", err)
+	}
+	if err := dial(base, "https://wallet.example"); err != nil {
+		t.Fatalf("allowed origin: %v", err)
+	}
+	// 其他网页的跨源连接默认拒绝
+	if err := dial(base, "https://evil.example"); err == nil {
+		t.Fatal("cross-origin upgrade should be rejected")
+	}
+	if err := dial(open, "https://evil.example"); err != nil {
+		t.Fatalf("\"*\" should allow every origin: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// serveWebSocket 处理 WebSocket 连接：逐条读取请求并返回响应，订阅的推送也写到同一个连接
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade 已经返回了 HTTP 错误
+	}
+	defer conn.Close()
+	conn.SetReadLimit(maxBodySize)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	var writeMu sync.Mutex
+	send := func(data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteMessage(websocket.TextMessage, data)
+	}
+	subs := newSubscriptions(send)
+	defer subs.close()
+	sess := &session{remote: r.RemoteAddr, transport: "ws", subs: subs}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err := send(s.handleMessage(ctx, msg, sess)); err != nil {
+			return
+		}
+		// 先返回订阅 ID，再开始推送，保证客户端能识别推送属于哪个订阅
+		subs.start()
+	}
+}
+
+// subscriptions 一个 WebSocket 连接上的全部订阅
+type subscriptions struct {
+	send func([]byte) error
+
+	mu      sync.Mutex
+	active  map[rpc.ID]ethereum.Subscription
+	pending []func() // 已建立、等待响应发出后开始推送的订阅
+}
+
+func newSubscriptions(send func([]byte) error) *subscriptions {
+	return &subscriptions{send: send, active: make(map[rpc.ID]ethereum.Subscription)}
+}
+
+// handle 处理 eth_subscribe 和 eth_unsubscribe，返回 JSON 编码的结果
+func (s *subscriptions) handle(ctx context.Context, upstream Upstream, method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method == "eth_unsubscribe" {
+		var id rpc.ID
+		if len(params) != 1 || json.Unmarshal(params[0], &id) != nil {
+			return nil, &paramsError{"eth_unsubscribe 需要一个订阅 ID"}
+		}
+		s.mu.Lock()
+		sub, ok := s.active[id]
+		delete(s.active, id)
+		s.mu.Unlock()
+		if ok {
+			sub.Unsubscribe()
+		}
+		return json.Marshal(ok)
+	}
+
+	var kind string
+	if len(params) == 0 || json.Unmarshal(params[0], &kind) != nil {
+		return nil, &paramsError{"eth_subscribe 缺少订阅类型"}
+	}
+	// 订阅的生命周期跟随连接，而不是单个请求
+	subCtx := context.WithoutCancel(ctx)
+	id := rpc.NewID()
+	var (
+		sub     ethereum.Subscription
+		forward func()
+		err     error
+	)
+	switch kind {
+	case "newHeads":
+		ch := make(chan *types.Header, 16)
+		sub, err = upstream.SubscribeNewHead(subCtx, ch)
+		forward = func() { pipe(s, id, sub, ch) }
+	case "logs":
+		var crit filters.FilterCriteria
+		if len(params) > 1 {
+			if err := json.Unmarshal(params[1], &crit); err != nil {
+				return nil, &paramsError{"解析日志过滤条件失败: " + err.Error()}
+			}
+		}
+		ch := make(chan types.Log, 128)
+		sub, err = upstream.SubscribeFilterLogs(subCtx, ethereum.FilterQuery(crit), ch)
+		forward = func() { pipe(s, id, sub, ch) }
+	default:
+		return nil, &paramsError{fmt.Sprintf("不支持的订阅类型 %q，只支持 newHeads 和 logs", kind)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.active[id] = sub
+	s.pending = append(s.pending, forward)
+	s.mu.Unlock()
+	return json.Marshal(id)
+}
+
+// start 开始推送新建立的订阅
+func (s *subscriptions) start() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	for _, forward := range pending {
+		go forward()
+	}
+}
+
+// close 连接断开时取消全部订阅
+func (s *subscriptions) close() {
+	s.mu.Lock()
+	active := s.active
+	s.active = make(map[rpc.ID]ethereum.Subscription)
+	s.mu.Unlock()
+	for _, sub := range active {
+		sub.Unsubscribe()
+	}
+}
+
+// pipe 把上游推送转换为 eth_subscription 通知写给客户端，直到订阅结束
+func pipe[T any](s *subscriptions, id rpc.ID, sub ethereum.Subscription, ch <-chan T) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.active, id)
+		s.mu.Unlock()
+	}()
+	for {
+		select {
+		case v := <-ch:
+			n := notification{JSONRPC: "2.0", Method: "eth_subscription"}
+			n.Params.Subscription, n.Params.Result = id, v
+			if err := s.send(encode(n)); err != nil {
+				sub.Unsubscribe()
+				return
+			}
+		case <-sub.Err():
+			return
+		}
+	}
+}
+
+// paramsError 订阅参数错误，按 JSON-RPC 的 invalid params 返回
+type paramsError struct{ msg string }
+
+func (e *paramsError) Error() string  { return e.msg }
+func (e *paramsError) ErrorCode() int { return codeInvalidParams }
+
+var _ rpc.Error = (*paramsError)(nil)
@@ -0,0 +1,421 @@
+// Package query 提供区块、交易、收据和余额的查询功能
+//
+// 对应 cmd/query-block、query-transaction、query-receipt、query-balance、
+// query-token-balance 和 subscribe-blocks 中的逻辑。
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/batch"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+)
+
+// BlockInfo 区块摘要信息
+type BlockInfo struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Time       uint64
+	Difficulty *big.Int
+	Nonce      uint64
+	Coinbase   common.Address
+	GasUsed    uint64
+	GasLimit   uint64
+	TxCount    int
+	Block      *types.Block
+}
+
+func newBlockInfo(block *types.Block) *BlockInfo {
+	return &BlockInfo{
+		Number:     block.NumberU64(),
+		Hash:       block.Hash(),
+		ParentHash: block.ParentHash(),
+		Time:       block.Time(),
+		Difficulty: block.Difficulty(),
+		Nonce:      block.Nonce(),
+		Coinbase:   block.Coinbase(),
+		GasUsed:    block.GasUsed(),
+		GasLimit:   block.GasLimit(),
+		TxCount:    len(block.Transactions()),
+		Block:      block,
+	}
+}
+
+// Block 按区块号查询完整区块，number 为 nil 时查询最新区块
+func Block(ctx context.Context, b backend.EthBackend, number *big.Int) (*BlockInfo, error) {
+	block, err := b.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块 %v 失败: %w", number, err)
+	}
+	return newBlockInfo(block), nil
+}
+
+// BlockByHash 按区块哈希查询完整区块
+func BlockByHash(ctx context.Context, b backend.EthBackend, hash common.Hash) (*BlockInfo, error) {
+	block, err := b.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块 %s 失败: %w", hash.Hex(), err)
+	}
+	return newBlockInfo(block), nil
+}
+
+// BlockConsistency 区块头、完整区块与交易数量三种查询方式的比对结果
+type BlockConsistency struct {
+	Header     *types.Header
+	Block      *BlockInfo
+	TxCount    uint // 通过 TransactionCount 按哈希查询到的交易数量
+	Consistent bool
+}
+
+// CheckBlock 分别通过区块头、完整区块和交易数量查询同一区块，并检查结果是否一致
+func CheckBlock(ctx context.Context, b backend.EthBackend, number *big.Int) (*BlockConsistency, error) {
+	header, err := b.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块头失败: %w", err)
+	}
+	info, err := Block(ctx, b, number)
+	if err != nil {
+		return nil, err
+	}
+	count, err := b.TransactionCount(ctx, info.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易数量失败: %w", err)
+	}
+	return &BlockConsistency{
+		Header:  header,
+		Block:   info,
+		TxCount: count,
+		Consistent: header.Number.Uint64() == info.Number &&
+			header.Hash() == info.Hash &&
+			uint(info.TxCount) == count,
+	}, nil
+}
+
+// TxInfo 交易及其发送方、打包状态
+type TxInfo struct {
+	Tx        *types.Transaction
+	From      common.Address
+	IsPending bool
+	Receipt   *types.Receipt // 交易尚未打包时为 nil
+}
+
+// Transaction 按哈希查询交易，已打包的交易同时返回收据
+func Transaction(ctx context.Context, b backend.EthBackend, hash common.Hash) (*TxInfo, error) {
+	tx, isPending, err := b.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易 %s 失败: %w", hash.Hex(), err)
+	}
+	info := &TxInfo{Tx: tx, IsPending: isPending}
+	if info.From, err = sender(ctx, b, tx); err != nil {
+		return nil, err
+	}
+	if !isPending {
+		if info.Receipt, err = Receipt(ctx, b, hash); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// BlockTransactions 返回区块中的全部交易及其收据
+// 区块只获取一次，收据查询方式见 BlockTransactionsOf
+func BlockTransactions(ctx context.Context, b backend.EthBackend, blockHash common.Hash) ([]*TxInfo, error) {
+	block, err := b.BlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块 %s 失败: %w", blockHash.Hex(), err)
+	}
+	return BlockTransactionsOf(ctx, b, block)
+}
+
+// BlockTransactionsOf 为已获取的区块补全交易发送方和收据
+// 全部交易共用一个签名器；后端实现了 backend.BlockReceiptsReader 时用一次 eth_getBlockReceipts 取得收据，
+// 否则按交易哈希查询（后端支持批量请求时合并发送，见 Receipts）
+func BlockTransactionsOf(ctx context.Context, b backend.EthBackend, block *types.Block) ([]*TxInfo, error) {
+	signer, err := chainSigner(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*TxInfo, 0, len(block.Transactions()))
+	hashes := make([]common.Hash, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("恢复交易 %s 的发送方地址失败: %w", tx.Hash().Hex(), err)
+		}
+		txs = append(txs, &TxInfo{Tx: tx, From: from})
+		hashes = append(hashes, tx.Hash())
+	}
+	if len(txs) == 0 {
+		return txs, nil
+	}
+
+	var receipts []*types.Receipt
+	if r, ok := b.(backend.BlockReceiptsReader); ok {
+		if receipts, err = r.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false)); err != nil {
+			return nil, fmt.Errorf("批量查询收据失败: %w", err)
+		}
+		if len(receipts) != len(txs) {
+			return nil, fmt.Errorf("区块 %s 有 %d 笔交易，但返回了 %d 个收据", block.Hash().Hex(), len(txs), len(receipts))
+		}
+	} else if receipts, err = Receipts(ctx, b, hashes); err != nil {
+		return nil, err
+	}
+	for i, receipt := range receipts {
+		txs[i].Receipt = receipt
+	}
+	return txs, nil
+}
+
+// chainSigner 返回当前链最新规则下的签名器，可以恢复任意类型交易的发送方
+func chainSigner(ctx context.Context, b backend.EthBackend) (types.Signer, error) {
+	chainID, err := b.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取链ID失败: %w", err)
+	}
+	return types.LatestSignerForChainID(chainID), nil
+}
+
+// sender 从签名中恢复交易发送方
+func sender(ctx context.Context, b backend.EthBackend, tx *types.Transaction) (common.Address, error) {
+	signer, err := chainSigner(ctx, b)
+	if err != nil {
+		return common.Address{}, err
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("恢复发送方地址失败: %w", err)
+	}
+	return from, nil
+}
+
+// Receipt 查询单笔交易的收据
+func Receipt(ctx context.Context, b backend.EthBackend, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易 %s 的收据失败: %w", hash.Hex(), err)
+	}
+	return receipt, nil
+}
+
+// Receipts 查询多笔交易的收据，后端支持批量请求时合并发送，否则逐笔查询
+func Receipts(ctx context.Context, b backend.EthBackend, hashes []common.Hash) ([]*types.Receipt, error) {
+	receipts := make([]*types.Receipt, len(hashes))
+	batcher, ok := batch.FromBackend(b)
+	if !ok {
+		for i, hash := range hashes {
+			receipt, err := Receipt(ctx, b, hash)
+			if err != nil {
+				return nil, err
+			}
+			receipts[i] = receipt
+		}
+		return receipts, nil
+	}
+
+	results, err := batcher.Receipts(ctx, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("批量查询收据失败: %w", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("获取交易 %s 的收据失败: %w", hashes[i].Hex(), r.Err)
+		}
+		receipts[i] = r.Value
+	}
+	return receipts, nil
+}
+
+// BlockReceipts 批量查询区块中全部交易的收据
+// 后端实现了 backend.BlockReceiptsReader 时使用 eth_getBlockReceipts，否则按交易查询（见 Receipts）；
+// 逐笔查询时 safe、finalized 标签先解析为具体的区块，pending 区块没有收据，返回错误
+func BlockReceipts(ctx context.Context, b backend.EthBackend, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	if r, ok := b.(backend.BlockReceiptsReader); ok {
+		receipts, err := r.BlockReceipts(ctx, blockNrOrHash)
+		if err != nil {
+			return nil, fmt.Errorf("批量查询收据失败: %w", err)
+		}
+		return receipts, nil
+	}
+
+	var (
+		block *types.Block
+		err   error
+	)
+	number, byNumber := blockNrOrHash.Number()
+	switch hash, byHash := blockNrOrHash.Hash(); {
+	case byHash:
+		block, err = b.BlockByHash(ctx, hash)
+	case !byNumber, number == rpc.LatestBlockNumber:
+		block, err = b.BlockByNumber(ctx, nil)
+	case number == rpc.EarliestBlockNumber:
+		block, err = b.BlockByNumber(ctx, new(big.Int))
+	case number == rpc.PendingBlockNumber:
+		return nil, errors.New("待打包区块中的交易还没有收据")
+	case number < 0:
+		// safe、finalized 等标签：先取得对应的区块头，再按哈希取区块，不能退回到最新区块
+		header, headerErr := b.HeaderByNumber(ctx, big.NewInt(number.Int64()))
+		if headerErr == nil && header == nil {
+			headerErr = ethereum.NotFound
+		}
+		if headerErr != nil {
+			return nil, fmt.Errorf("解析区块标签 %s 失败: %w", number, headerErr)
+		}
+		block, err = b.BlockByHash(ctx, header.Hash())
+	default:
+		block, err = b.BlockByNumber(ctx, big.NewInt(number.Int64()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取区块失败: %w", err)
+	}
+	hashes := make([]common.Hash, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		hashes = append(hashes, tx.Hash())
+	}
+	return Receipts(ctx, b, hashes)
+}
+
+// BalanceInfo 账户余额
+type BalanceInfo struct {
+	Address     common.Address
+	BlockNumber *big.Int // 为 nil 表示最新区块
+	Wei         *big.Int
+}
+
+// Ether 以 ETH 为单位的余额
+func (bi *BalanceInfo) Ether() *big.Float {
+	return ToUnit(bi.Wei, 18)
+}
+
+// Balance 查询账户在指定区块的 ETH 余额，blockNumber 为 nil 时查询最新区块
+func Balance(ctx context.Context, b backend.EthBackend, account common.Address, blockNumber *big.Int) (*BalanceInfo, error) {
+	wei, err := b.BalanceAt(ctx, account, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("查询 %s 的余额失败: %w", account.Hex(), err)
+	}
+	return &BalanceInfo{Address: account, BlockNumber: blockNumber, Wei: wei}, nil
+}
+
+// Balances 查询多个账户在同一区块的余额，后端支持批量请求时合并发送，否则逐个查询
+func Balances(ctx context.Context, b backend.EthBackend, accounts []common.Address, blockNumber *big.Int) ([]*BalanceInfo, error) {
+	out := make([]*BalanceInfo, len(accounts))
+	batcher, ok := batch.FromBackend(b)
+	if !ok {
+		for i, account := range accounts {
+			info, err := Balance(ctx, b, account, blockNumber)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = info
+		}
+		return out, nil
+	}
+
+	results, err := batcher.Balances(ctx, accounts, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("批量查询余额失败: %w", err)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("查询 %s 的余额失败: %w", accounts[i].Hex(), r.Err)
+		}
+		out[i] = &BalanceInfo{Address: accounts[i], BlockNumber: blockNumber, Wei: r.Value}
+	}
+	return out, nil
+}
+
+// PendingBalance 查询账户的待处理余额，后端必须实现 backend.PendingBalanceReader
+func PendingBalance(ctx context.Context, b backend.EthBackend, account common.Address) (*big.Int, error) {
+	r, ok := b.(backend.PendingBalanceReader)
+	if !ok {
+		return nil, fmt.Errorf("后端 %T 不支持查询待处理余额", b)
+	}
+	wei, err := r.PendingBalanceAt(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("查询待处理余额失败: %w", err)
+	}
+	return wei, nil
+}
+
+// TokenBalanceInfo ERC20 代币余额及代币元数据
+type TokenBalanceInfo struct {
+	Token    common.Address
+	Holder   common.Address
+	Name     string
+	Symbol   string
+	Decimals uint8
+	Raw      *big.Int // 最小单位的余额
+}
+
+// Value 按精度换算后的余额
+func (tb *TokenBalanceInfo) Value() *big.Float {
+	return ToUnit(tb.Raw, int(tb.Decimals))
+}
+
+// TokenBalance 查询 ERC20 代币余额；MyToken 的绑定只用到标准 ERC20 方法，可用于任意 ERC20 合约
+func TokenBalance(ctx context.Context, b backend.EthBackend, token, holder common.Address) (*TokenBalanceInfo, error) {
+	instance, err := mytoken.NewMyToken(token, b)
+	if err != nil {
+		return nil, fmt.Errorf("创建代币合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	info := &TokenBalanceInfo{Token: token, Holder: holder}
+	if info.Raw, err = instance.BalanceOf(opts, holder); err != nil {
+		return nil, fmt.Errorf("查询代币余额失败: %w", err)
+	}
+	if info.Name, err = instance.Name(opts); err != nil {
+		return nil, fmt.Errorf("查询代币名称失败: %w", err)
+	}
+	if info.Symbol, err = instance.Symbol(opts); err != nil {
+		return nil, fmt.Errorf("查询代币符号失败: %w", err)
+	}
+	if info.Decimals, err = instance.Decimals(opts); err != nil {
+		return nil, fmt.Errorf("查询代币小数位数失败: %w", err)
+	}
+	return info, nil
+}
+
+// ToUnit 把最小单位的数量按精度换算为浮点数，例如 Wei -> ETH 使用 decimals=18
+func ToUnit(amount *big.Int, decimals int) *big.Float {
+	value := new(big.Float).SetInt(amount)
+	unit := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	return value.Quo(value, unit)
+}
+
+// WatchBlocks 订阅新区块，每到达一个区块就获取完整区块并交给 handle 处理
+// 直到 ctx 取消、订阅出错或 handle 返回错误为止
+func WatchBlocks(ctx context.Context, b backend.EthBackend, handle func(*BlockInfo) error) error {
+	headers := make(chan *types.Header)
+	sub, err := b.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return fmt.Errorf("订阅新区块失败: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return fmt.Errorf("订阅出现错误: %w", err)
+		case header := <-headers:
+			info, err := BlockByHash(ctx, b, header.Hash())
+			if err != nil {
+				return err
+			}
+			if err := handle(info); err != nil {
+				return err
+			}
+		}
+	}
+}
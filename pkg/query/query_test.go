@@ -0,0 +1,340 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/query"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// plainBackend 只暴露 EthBackend，用于测试后端缺少可选接口时的回退逻辑
+type plainBackend struct {
+	backend.EthBackend
+}
+
+// countingBackend 统计按交易逐笔访问节点的次数
+type countingBackend struct {
+	backend.EthBackend
+	chainIDs, txLookups int
+}
+
+func (b *countingBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	b.chainIDs++
+	return b.EthBackend.ChainID(ctx)
+}
+
+func (b *countingBackend) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	b.txLookups++
+	return b.EthBackend.TransactionInBlock(ctx, blockHash, index)
+}
+
+func TestBlockAndTransactions(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	tx1 := chain.TransferETH(alice, bob.Address, big.NewInt(1))
+	tx2 := chain.TransferETH(bob, alice.Address, big.NewInt(2))
+	chain.Mine()
+
+	check, err := query.CheckBlock(ctx, chain, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check.Consistent || check.Block.TxCount != 2 || check.TxCount != 2 {
+		t.Fatalf("CheckBlock = %+v", check)
+	}
+	if latest, _ := query.Block(ctx, chain, nil); latest.Hash != check.Block.Hash || latest.ParentHash != chain.Blockchain().Genesis().Hash() {
+		t.Fatalf("latest block = %s", latest.Hash.Hex())
+	}
+
+	txs, err := query.BlockTransactions(ctx, chain, check.Block.Hash)
+	if err != nil || len(txs) != 2 {
+		t.Fatalf("BlockTransactions = %d, %v", len(txs), err)
+	}
+	if txs[0].Tx.Hash() != tx1.Hash() || txs[0].From != alice.Address || txs[1].From != bob.Address {
+		t.Fatalf("unexpected transactions: %+v", txs)
+	}
+	if txs[1].Receipt.TransactionIndex != 1 || txs[1].Receipt.TxHash != tx2.Hash() {
+		t.Fatalf("receipt = %+v", txs[1].Receipt)
+	}
+
+	// 区块只取一次，所有交易共用一个签名器
+	counter := &countingBackend{EthBackend: chain}
+	if txs, err := query.BlockTransactions(ctx, counter, check.Block.Hash); err != nil || txs[1].Receipt.TxHash != tx2.Hash() {
+		t.Fatalf("BlockTransactions via plain backend = %v", err)
+	}
+	if counter.chainIDs != 1 || counter.txLookups != 0 {
+		t.Fatalf("ChainID called %d times, TransactionInBlock %d times", counter.chainIDs, counter.txLookups)
+	}
+
+	info, err := query.Transaction(ctx, chain, tx2.Hash())
+	if err != nil || info.IsPending || info.From != bob.Address || info.Receipt == nil {
+		t.Fatalf("Transaction = %+v, %v", info, err)
+	}
+
+	if _, err := query.Block(ctx, chain, big.NewInt(99)); err == nil {
+		t.Fatal("expected error for missing block")
+	}
+}
+
+func TestBlockReceiptsFallback(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	chain.TransferETH(chain.Accounts[0], chain.Accounts[1].Address, big.NewInt(1))
+	chain.TransferETH(chain.Accounts[1], chain.Accounts[0].Address, big.NewInt(1))
+	hash := chain.Mine()
+
+	byNumber, err := query.BlockReceipts(ctx, chain, rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil || len(byNumber) != 2 {
+		t.Fatalf("BlockReceipts = %d, %v", len(byNumber), err)
+	}
+	// 不支持 eth_getBlockReceipts 的后端逐笔查询，结果应一致
+	byHash, err := query.BlockReceipts(ctx, plainBackend{chain}, rpc.BlockNumberOrHashWithHash(hash, false))
+	if err != nil || len(byHash) != 2 {
+		t.Fatalf("fallback BlockReceipts = %d, %v", len(byHash), err)
+	}
+	for i := range byHash {
+		if byHash[i].TxHash != byNumber[i].TxHash {
+			t.Fatalf("receipt %d mismatch", i)
+		}
+	}
+
+	// safe 标签解析为具体的区块，不能退回到最新区块
+	safe := rpc.BlockNumberOrHashWithNumber(rpc.SafeBlockNumber)
+	if _, err := query.BlockReceipts(ctx, plainBackend{chain}, safe); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("safe block before %d blocks: err = %v", simchain.SafeDepth, err)
+	}
+	for i := 0; i < simchain.SafeDepth; i++ {
+		chain.Mine()
+	}
+	bySafe, err := query.BlockReceipts(ctx, plainBackend{chain}, safe)
+	if err != nil || len(bySafe) != 2 || bySafe[0].TxHash != byNumber[0].TxHash {
+		t.Fatalf("safe BlockReceipts = %d, %v", len(bySafe), err)
+	}
+	if _, err := query.BlockReceipts(ctx, plainBackend{chain}, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)); err == nil {
+		t.Fatal("expected error for pending block")
+	}
+}
+
+func TestBalances(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	chain.TransferETH(alice, bob.Address, big.NewInt(1e18))
+	pending, err := query.PendingBalance(ctx, chain, bob.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Mine()
+
+	latest, err := query.Balance(ctx, chain, bob.Address, nil)
+	if err != nil || latest.Wei.Cmp(pending) != 0 {
+		t.Fatalf("latest = %v, pending = %s, err = %v", latest, pending, err)
+	}
+	genesis, _ := query.Balance(ctx, chain, bob.Address, big.NewInt(0))
+	if eth, _ := genesis.Ether().Float64(); eth != 100 {
+		t.Fatalf("genesis balance = %v ETH, want 100", eth)
+	}
+	if _, err := query.PendingBalance(ctx, plainBackend{chain}, bob.Address); err == nil {
+		t.Fatal("expected error for backend without PendingBalanceAt")
+	}
+
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 6, big.NewInt(500))
+	tb, err := query.TokenBalance(ctx, chain, token, alice.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tb.Name != "My Token" || tb.Symbol != "MTK" || tb.Decimals != 6 || tb.Raw.Int64() != 500_000_000 {
+		t.Fatalf("TokenBalance = %+v", tb)
+	}
+	if v, _ := tb.Value().Float64(); v != 500 {
+		t.Fatalf("token value = %v, want 500", v)
+	}
+}
+
+func TestBalancesBatch(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	chain.TransferETH(alice, bob.Address, big.NewInt(1e18))
+
+	accounts := []common.Address{alice.Address, bob.Address, {0x01}}
+	// simchain 支持批量请求，plainBackend 只能逐个查询，两者结果应一致
+	batched, err := query.Balances(ctx, chain, accounts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := query.Balances(ctx, plainBackend{chain}, accounts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range accounts {
+		if batched[i].Address != accounts[i] || batched[i].Wei.Cmp(single[i].Wei) != 0 {
+			t.Fatalf("balance %d: batch = %s, single = %s", i, batched[i].Wei, single[i].Wei)
+		}
+	}
+	if batched[2].Wei.Sign() != 0 {
+		t.Fatalf("empty account balance = %s", batched[2].Wei)
+	}
+}
+
+func TestWatchBlocks(t *testing.T) {
+	chain := simchain.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var seen []uint64
+	done := make(chan error, 1)
+	go func() {
+		done <- query.WatchBlocks(ctx, chain, func(b *query.BlockInfo) error {
+			seen = append(seen, b.Number)
+			if len(seen) == 2 {
+				cancel()
+			}
+			return nil
+		})
+	}()
+
+	// 订阅建立之前出的块不会被推送，因此持续出块直到处理函数收到两个区块
+	timeout := time.After(5 * time.Second)
+	for {
+		chain.Mine()
+		select {
+		case err := <-done:
+			if err != context.Canceled {
+				t.Fatalf("WatchBlocks returned %v, want context.Canceled", err)
+			}
+			if seen[1] != seen[0]+1 {
+				t.Fatalf("blocks = %v, want consecutive numbers", seen)
+			}
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for new blocks")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// reorderedBackend 把区块中的交易倒序返回，模拟篡改了区块内容的节点
+type reorderedBackend struct {
+	backend.EthBackend
+}
+
+func (b reorderedBackend) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	block, err := b.EthBackend.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	reversed := make([]*types.Transaction, len(txs))
+	for i, tx := range txs {
+		reversed[len(txs)-1-i] = tx
+	}
+	return types.NewBlockWithHeader(block.Header()).WithBody(reversed, block.Uncles()), nil
+}
+
+// forgedHeaderBackend 修改某个区块头的 extraData，模拟伪造区块头的节点
+type forgedHeaderBackend struct {
+	backend.EthBackend
+	number uint64
+}
+
+func (b forgedHeaderBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	header, err := b.EthBackend.HeaderByNumber(ctx, number)
+	if err == nil && header.Number.Uint64() == b.number {
+		header.Extra = []byte("forged")
+	}
+	return header, err
+}
+
+func TestVerifyBlock(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	chain.SetAutoMine(true)
+	_, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	chain.SetAutoMine(false)
+	chain.TransferETH(alice, bob.Address, big.NewInt(1))
+	if _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	chain.TransferETH(bob, alice.Address, big.NewInt(2))
+	chain.Mine()
+	chain.MineBlocks(3)
+	number := new(big.Int).Sub(chain.Head().Number, big.NewInt(3))
+
+	v, err := query.VerifyBlock(ctx, chain, number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 区块哈希、transactionsRoot、sha3Uncles、receiptsRoot、logsBloom
+	if !v.OK || len(v.Checks) != 5 || v.Checks[0].Name != "区块哈希" || len(v.Unknown) != 0 {
+		t.Fatalf("verification:\n%s", v)
+	}
+
+	// 交易顺序被打乱：交易树对不上（收据按哈希查询，不受影响）
+	bad, err := query.VerifyBlock(ctx, reorderedBackend{chain}, number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := map[string]bool{}
+	for _, c := range bad.Checks {
+		if !c.OK {
+			failed[c.Name] = true
+		}
+	}
+	if bad.OK || !failed["transactionsRoot"] || failed["receiptsRoot"] {
+		t.Fatalf("tampered verification:\n%s", bad)
+	}
+	if !strings.Contains(bad.String(), "✗ transactionsRoot") {
+		t.Fatalf("output:\n%s", bad)
+	}
+
+	// 后端不支持 backend.RPCClient 时拿不到节点声称的哈希，明确标记为无法校验
+	plain, err := query.VerifyBlock(ctx, plainBackend{chain}, number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := plain.Checks[0]; hash.Name != "区块哈希" || hash.OK || hash.Note == "" || !strings.Contains(plain.String(), "? 区块哈希") {
+		t.Fatalf("verification without RPC client:\n%s", plain)
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	chain.MineBlocks(6)
+
+	c, err := query.VerifyChain(ctx, chain, 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.OK || len(c.Headers) != 6 {
+		t.Fatalf("chain:\n%s", c)
+	}
+
+	// 区块 3 的区块头被伪造，区块 4 的 parentHash 与之对不上
+	forged, err := query.VerifyChain(ctx, forgedHeaderBackend{plainBackend{chain}, 3}, 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forged.OK || len(forged.Breaks) != 1 || forged.Breaks[0].Number != 4 {
+		t.Fatalf("forged chain:\n%s", forged)
+	}
+	if _, err := query.VerifyChain(ctx, chain, 5, 2); err == nil {
+		t.Fatal("expected error for invalid range")
+	}
+}
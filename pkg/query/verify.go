@@ -0,0 +1,276 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ErrNoHeader 节点没有返回区块头
+var ErrNoHeader = errors.New("节点没有返回区块头")
+
+// blockFields eth_getBlockByNumber 返回的 JSON 中不属于区块头的字段
+var blockFields = map[string]bool{
+	"hash": true, "transactions": true, "uncles": true, "size": true,
+	"totalDifficulty": true, "withdrawals": true, "sealFields": true,
+}
+
+// HeaderCheck 一项校验：节点给出的值与本地重新计算的值
+type HeaderCheck struct {
+	Name     string
+	Reported common.Hash // 节点给出的（区块头中的）值
+	Computed common.Hash // 本地从区块内容重新计算的值
+	OK       bool
+	Note     string // 无法校验时的说明
+}
+
+// BlockVerification 单个区块的校验结果
+type BlockVerification struct {
+	Number  uint64
+	Hash    common.Hash // 对 RLP 编码的区块头重新计算的哈希
+	Checks  []HeaderCheck
+	Unknown []string // 节点返回的、当前 go-ethereum 版本不认识的区块头字段
+	OK      bool     // 能校验的各项都一致；Note 非空的项没有校验，需另行查看
+}
+
+// VerifyBlock 校验区块（nil 表示最新区块）的内容与区块头是否一致：
+//   - 区块哈希：对 RLP 编码的区块头重新计算 keccak256，与节点声称的 hash 比较（需要 backend.RPCClient）
+//   - transactionsRoot：用区块中的交易重建交易树
+//   - receiptsRoot、logsBloom：用全部收据重建收据树和布隆过滤器
+//   - sha3Uncles、withdrawalsRoot：用叔块头和提款列表重新计算
+//
+// 节点返回了当前版本不认识的区块头字段（新硬分叉引入）时，重新计算的哈希必然不同，
+// 此时哈希一项标记为无法校验，而不是报告不一致。
+func VerifyBlock(ctx context.Context, b backend.EthBackend, number *big.Int) (*BlockVerification, error) {
+	block, err := b.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("获取区块失败: %w", err)
+	}
+	header := block.Header()
+	v := &BlockVerification{Number: header.Number.Uint64(), Hash: header.Hash()}
+
+	// ===== 区块哈希 =====
+	raw, err := RawHeader(ctx, b, header.Number)
+	if err != nil {
+		return nil, err
+	}
+	if raw != nil {
+		v.Unknown = raw.Unknown
+		check := HeaderCheck{Name: "区块哈希", Reported: raw.Claimed, Computed: raw.Header.Hash()}
+		check.OK = check.Reported == check.Computed
+		if !check.OK && len(raw.Unknown) > 0 {
+			check.Note = fmt.Sprintf("区块头含未知字段 %s，无法重新计算哈希", strings.Join(raw.Unknown, ", "))
+		}
+		v.Checks = append(v.Checks, check)
+		if raw.Header.Hash() != v.Hash && len(raw.Unknown) == 0 {
+			// 两次请求得到的区块头不同（通常是最新区块发生了重组）
+			v.Checks = append(v.Checks, HeaderCheck{Name: "两次返回的区块头", Reported: raw.Header.Hash(), Computed: v.Hash})
+		}
+	} else {
+		// 拿不到节点声称的哈希，不能把这一项当作通过
+		v.Checks = append(v.Checks, HeaderCheck{Name: "区块哈希", Computed: v.Hash,
+			Note: fmt.Sprintf("后端 %T 不支持直接发送 JSON-RPC 请求（backend.RPCClient），无法取得节点声称的哈希", b)})
+	}
+
+	// ===== 交易树、叔块、提款 =====
+	v.add("transactionsRoot", header.TxHash, types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)))
+	v.add("sha3Uncles", header.UncleHash, types.CalcUncleHash(block.Uncles()))
+	if header.WithdrawalsHash != nil {
+		v.add("withdrawalsRoot", *header.WithdrawalsHash, types.DeriveSha(block.Withdrawals(), trie.NewStackTrie(nil)))
+	}
+
+	// ===== 收据树与日志布隆过滤器 =====
+	receipts, err := BlockReceipts(ctx, b, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(block.Transactions()) {
+		return nil, fmt.Errorf("区块 %d 有 %d 笔交易，但只返回了 %d 个收据", v.Number, len(block.Transactions()), len(receipts))
+	}
+	v.add("receiptsRoot", header.ReceiptHash, types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)))
+	// 布隆过滤器有 256 字节，比较其 keccak256 摘要
+	bloom := types.CreateBloom(receipts)
+	v.add("logsBloom", crypto.Keccak256Hash(header.Bloom[:]), crypto.Keccak256Hash(bloom[:]))
+
+	v.OK = true
+	for _, c := range v.Checks {
+		if !c.OK && c.Note == "" {
+			v.OK = false
+		}
+	}
+	return v, nil
+}
+
+func (v *BlockVerification) add(name string, reported, computed common.Hash) {
+	v.Checks = append(v.Checks, HeaderCheck{Name: name, Reported: reported, Computed: computed, OK: reported == computed})
+}
+
+// String 逐项显示校验结果
+func (v *BlockVerification) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "区块 %d（重新计算的哈希 %s）\n", v.Number, v.Hash.Hex())
+	for _, c := range v.Checks {
+		switch {
+		case c.OK:
+			fmt.Fprintf(&sb, "  ✓ %-16s %s\n", c.Name, c.Computed.Hex())
+		case c.Note != "":
+			fmt.Fprintf(&sb, "  ? %-16s %s\n", c.Name, c.Note)
+		default:
+			fmt.Fprintf(&sb, "  ✗ %-16s 节点: %s\n    %-16s 计算: %s\n", c.Name, c.Reported.Hex(), "", c.Computed.Hex())
+		}
+	}
+	return sb.String()
+}
+
+// RawHeaderInfo 直接从 JSON-RPC 读取的区块头
+type RawHeaderInfo struct {
+	Header  *types.Header
+	Claimed common.Hash // 节点在 hash 字段中声称的区块哈希
+	Unknown []string    // 不认识的区块头字段，存在时 Header.Hash() 不可信
+}
+
+// RawHeader 通过 eth_getBlockByNumber 读取区块头和节点声称的区块哈希。
+// ethclient 解码区块头时会丢弃 hash 字段，只能直接发送请求；后端没有实现 backend.RPCClient 时返回 nil
+func RawHeader(ctx context.Context, b backend.EthBackend, number *big.Int) (*RawHeaderInfo, error) {
+	client, ok := backend.RPC(b)
+	if !ok {
+		return nil, nil
+	}
+	arg := "latest"
+	if number != nil {
+		arg = rpc.BlockNumber(number.Int64()).String()
+	}
+	var raw json.RawMessage
+	if err := client.CallContext(ctx, &raw, "eth_getBlockByNumber", arg, false); err != nil {
+		return nil, fmt.Errorf("获取区块头失败: %w", err)
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, ErrNoHeader
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("解析区块头失败: %w", err)
+	}
+	info := &RawHeaderInfo{Header: new(types.Header)}
+	if err := json.Unmarshal(raw, info.Header); err != nil {
+		return nil, fmt.Errorf("解析区块头失败: %w", err)
+	}
+	if err := json.Unmarshal(fields["hash"], &info.Claimed); err != nil {
+		return nil, fmt.Errorf("区块头缺少 hash 字段: %w", err)
+	}
+	known := headerFields()
+	for name := range fields {
+		if !known[name] && !blockFields[name] {
+			info.Unknown = append(info.Unknown, name)
+		}
+	}
+	sort.Strings(info.Unknown)
+	return info, nil
+}
+
+// headerFields types.Header 的 JSON 字段名
+func headerFields() map[string]bool {
+	enc, _ := json.Marshal(&types.Header{
+		Difficulty: new(big.Int), Number: new(big.Int), BaseFee: new(big.Int),
+		WithdrawalsHash: new(common.Hash), BlobGasUsed: new(uint64), ExcessBlobGas: new(uint64), ParentBeaconRoot: new(common.Hash),
+	})
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(enc, &fields)
+	out := make(map[string]bool, len(fields))
+	for name := range fields {
+		out[name] = true
+	}
+	return out
+}
+
+// LinkBreak 父哈希链接断开的位置
+type LinkBreak struct {
+	Number     uint64      // 子区块号
+	ParentHash common.Hash // 子区块头中的 parentHash
+	Expected   common.Hash // 重新计算的父区块哈希
+}
+
+// ChainVerification 一段区块的链接校验结果
+type ChainVerification struct {
+	From, To uint64
+	Headers  []*types.Header
+	Breaks   []LinkBreak
+	BadHash  []uint64 // 节点声称的哈希与重新计算的不一致的区块
+	// HashUnchecked 后端不支持 backend.RPCClient，没有比较节点声称的哈希，只检查了父哈希链接
+	HashUnchecked bool
+	OK            bool
+}
+
+// VerifyChain 读取 [from, to] 范围内的区块头，检查每个区块的 parentHash 是否等于前一个区块重新计算的哈希，
+// 以及节点声称的哈希是否与重新计算的一致（需要 backend.RPCClient）；只读取区块头，适合较长的范围
+func VerifyChain(ctx context.Context, b backend.EthBackend, from, to uint64) (*ChainVerification, error) {
+	if from > to {
+		return nil, fmt.Errorf("无效的区块范围 %d-%d", from, to)
+	}
+	out := &ChainVerification{From: from, To: to, OK: true}
+	var prev common.Hash // 前一个区块的哈希
+	for n := from; n <= to; n++ {
+		number := new(big.Int).SetUint64(n)
+		raw, err := RawHeader(ctx, b, number)
+		if err != nil {
+			return nil, fmt.Errorf("区块 %d: %w", n, err)
+		}
+		var header *types.Header
+		if raw != nil {
+			header = raw.Header
+			if raw.Claimed != header.Hash() && len(raw.Unknown) == 0 {
+				out.BadHash = append(out.BadHash, n)
+				out.OK = false
+			}
+		} else {
+			out.HashUnchecked = true
+			if header, err = b.HeaderByNumber(ctx, number); err != nil {
+				return nil, fmt.Errorf("获取区块头 %d 失败: %w", n, err)
+			}
+		}
+		if header.Number.Uint64() != n {
+			return nil, fmt.Errorf("请求区块 %d，节点返回了区块 %s", n, header.Number)
+		}
+		if n > from && header.ParentHash != prev {
+			out.Breaks = append(out.Breaks, LinkBreak{Number: n, ParentHash: header.ParentHash, Expected: prev})
+			out.OK = false
+		}
+		// 区块头含未知字段时无法重新计算哈希，只能退而使用节点声称的哈希检查链接
+		if prev = header.Hash(); raw != nil && len(raw.Unknown) > 0 {
+			prev = raw.Claimed
+		}
+		out.Headers = append(out.Headers, header)
+	}
+	return out, nil
+}
+
+// String 显示链接校验结果
+func (c *ChainVerification) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "区块 %d - %d，共 %d 个区块头\n", c.From, c.To, len(c.Headers))
+	if c.HashUnchecked {
+		sb.WriteString("  ? 后端不支持直接发送 JSON-RPC 请求，没有校验节点声称的区块哈希\n")
+	}
+	for _, n := range c.BadHash {
+		fmt.Fprintf(&sb, "  ✗ 区块 %d 的哈希与区块头内容不符\n", n)
+	}
+	for _, br := range c.Breaks {
+		fmt.Fprintf(&sb, "  ✗ 区块 %d 的 parentHash %s ≠ 区块 %d 的哈希 %s\n", br.Number, br.ParentHash.Hex(), br.Number-1, br.Expected.Hex())
+	}
+	if c.OK {
+		sb.WriteString("  ✓ 父哈希链接完整\n")
+	}
+	return sb.String()
+}
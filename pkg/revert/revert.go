@@ -0,0 +1,332 @@
+// Package revert 解码失败交易的回滚原因（revert reason）
+//
+// 交易回滚时 EVM 返回一段 revert 数据，按前 4 字节区分三种形式：
+//   - Error(string)：require(cond, "消息") 和 revert("消息") 产生
+//   - Panic(uint256)：assert 失败、算术溢出、除零、数组越界等，按错误码给出说明
+//   - 自定义错误：Solidity 0.8.4 引入的 error X(...)，编码方式与方法调用相同，
+//     按 AddABI 登记的 ABI 或 4 字节签名库解码
+//
+// 已打包的失败交易回执里没有 revert 数据，Replay 在父区块的状态上用 eth_call 重放交易取回数据；
+// 发送交易前 EstimateGas 失败时，节点返回的错误本身就带有 revert 数据，用 FromError 解码即可。
+//
+//	d := revert.NewDecoder(calldata.DefaultSignatures())
+//	d.AddABI("MyToken", mytoken.MyTokenMetaData.ABI)
+//	reason, err := d.Replay(ctx, client, txHash)
+//	fmt.Println(reason)
+package revert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+)
+
+var (
+	// ErrNotFailed 交易执行成功，没有回滚原因
+	ErrNotFailed = errors.New("交易执行成功，没有回滚原因")
+	// ErrNotReproduced 在父区块状态上重放时交易没有失败，
+	// 通常是因为同一区块中排在它前面的交易改变了状态
+	ErrNotReproduced = errors.New("重放交易没有失败，无法取得回滚原因")
+)
+
+var (
+	// ErrorSelector Error(string) 的选择器 0x08c379a0
+	ErrorSelector = calldata.SelectorOf("Error(string)")
+	// PanicSelector Panic(uint256) 的选择器 0x4e487b71
+	PanicSelector = calldata.SelectorOf("Panic(uint256)")
+)
+
+// Kind 回滚原因的类型
+type Kind string
+
+const (
+	KindError   Kind = "Error"   // Error(string)
+	KindPanic   Kind = "Panic"   // Panic(uint256)
+	KindCustom  Kind = "custom"  // 自定义错误
+	KindUnknown Kind = "unknown" // 有 revert 数据但无法解码
+	KindEmpty   Kind = "empty"   // 回滚但没有 revert 数据，如 revert() 或不带消息的 require
+	KindFailure Kind = "failure" // 不是回滚而是其他执行错误，如 Gas 不足、无效操作码
+)
+
+// panicReasons Solidity 定义的 Panic 错误码
+var panicReasons = map[uint64]string{
+	0x00: "通用的编译器插入的 panic",
+	0x01: "assert 条件不成立",
+	0x11: "算术运算上溢或下溢（不在 unchecked 块中）",
+	0x12: "除以零或对零取模",
+	0x21: "把过大的值或负数转换为枚举类型",
+	0x22: "访问编码错误的存储字节数组",
+	0x31: "对空数组调用 pop()",
+	0x32: "数组或切片下标越界",
+	0x41: "分配的内存过大或创建的数组过大",
+	0x51: "调用未初始化的内部函数指针",
+}
+
+// PanicReason 返回 Panic 错误码的说明，未知的错误码返回空字符串
+func PanicReason(code *big.Int) string {
+	if code == nil || !code.IsUint64() {
+		return ""
+	}
+	return panicReasons[code.Uint64()]
+}
+
+// Reason 解码后的回滚原因
+type Reason struct {
+	Kind    Kind
+	Data    []byte   // 原始 revert 数据
+	Message string   // Error(string) 的消息；KindEmpty、KindFailure 时为节点返回的错误信息
+	Code    *big.Int // Panic 错误码
+
+	// 自定义错误
+	Source       string // ABI 名称，来自签名库时为 calldata.SourceSignatureDB
+	Name         string
+	Signature    string
+	Args         []calldata.Arg
+	Alternatives []string // 选择器碰撞时的其他候选签名
+}
+
+// String 返回适合直接打印的说明
+func (r *Reason) String() string {
+	switch r.Kind {
+	case KindError:
+		return fmt.Sprintf("Error(string): %s", r.Message)
+	case KindPanic:
+		explain := PanicReason(r.Code)
+		if explain == "" {
+			explain = "未知的错误码"
+		}
+		return fmt.Sprintf("Panic(0x%x): %s", r.Code, explain)
+	case KindCustom:
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "自定义错误 %s（来源: %s）", r.Signature, r.Source)
+		for _, arg := range r.Args {
+			fmt.Fprintf(&buf, "\n  %s %s = %s", arg.Type, arg.Name, calldata.FormatValue(arg.Value))
+		}
+		if len(r.Alternatives) > 0 {
+			fmt.Fprintf(&buf, "\n  其他候选签名（选择器碰撞）: %s", strings.Join(r.Alternatives, ", "))
+		}
+		return buf.String()
+	case KindUnknown:
+		// 不足 4 字节时没有选择器
+		if len(r.Data) < 4 {
+			return fmt.Sprintf("无法解码的 revert 数据: %s", hexutil.Encode(r.Data))
+		}
+		return fmt.Sprintf("无法解码的 revert 数据（选择器 %s）: %s", hexutil.Encode(r.Data[:4]), hexutil.Encode(r.Data))
+	case KindEmpty:
+		return "回滚但没有附带原因（revert() 或不带消息的 require）"
+	default:
+		return fmt.Sprintf("执行失败: %s", r.Message)
+	}
+}
+
+// errorSource 登记的自定义错误 ABI
+type errorSource struct {
+	name string
+	abi  abi.ABI
+}
+
+// Decoder 回滚原因解码器，登记 ABI 之后并发使用是安全的
+type Decoder struct {
+	abis []*errorSource
+	sigs *calldata.Decoder
+}
+
+// NewDecoder 创建解码器，db 为 nil 时自定义错误只按登记的 ABI 解码
+func NewDecoder(db *calldata.SignatureDB) *Decoder {
+	d := &Decoder{}
+	if db != nil {
+		d.sigs = calldata.NewDecoder(db)
+	}
+	return d
+}
+
+// AddABI 登记包含自定义错误定义的合约 ABI
+func (d *Decoder) AddABI(name, abiJSON string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("解析 %s 的 ABI 失败: %w", name, err)
+	}
+	d.abis = append(d.abis, &errorSource{name: name, abi: parsed})
+	return nil
+}
+
+// Decode 解码 revert 数据，数据为空时返回 KindEmpty
+func (d *Decoder) Decode(data []byte) *Reason {
+	r := &Reason{Data: data}
+	if len(data) == 0 {
+		r.Kind = KindEmpty
+		return r
+	}
+	if len(data) < 4 {
+		r.Kind = KindUnknown
+		return r
+	}
+	var sel calldata.Selector
+	copy(sel[:], data[:4])
+
+	switch sel {
+	case ErrorSelector:
+		if msg, err := unpackOne(data, "string"); err == nil {
+			r.Kind, r.Message = KindError, msg.(string)
+			return r
+		}
+	case PanicSelector:
+		if code, err := unpackOne(data, "uint256"); err == nil {
+			r.Kind, r.Code = KindPanic, code.(*big.Int)
+			return r
+		}
+	}
+
+	for _, src := range d.abis {
+		for _, e := range src.abi.Errors {
+			if !bytes.Equal(e.ID[:4], data[:4]) {
+				continue
+			}
+			values, err := e.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+			r.Kind, r.Source, r.Name, r.Signature = KindCustom, src.name, e.Name, e.Sig
+			for i, arg := range e.Inputs {
+				r.Args = append(r.Args, calldata.Arg{Name: arg.Name, Type: arg.Type.String(), Value: values[i]})
+			}
+			return r
+		}
+	}
+
+	// 自定义错误与方法调用的编码方式相同，可以直接借用 calldata 的签名库解码
+	if d.sigs != nil {
+		if call := d.sigs.Decode(nil, data); call != nil && call.Best() != nil {
+			best := call.Best()
+			r.Kind, r.Source, r.Name, r.Signature, r.Args = KindCustom, best.Source, best.Name, best.Signature, best.Args
+			for _, c := range call.Candidates[1:] {
+				r.Alternatives = append(r.Alternatives, c.Signature)
+			}
+			return r
+		}
+	}
+	r.Kind = KindUnknown
+	return r
+}
+
+// unpackOne 解码选择器之后的单个参数
+func unpackOne(data []byte, typ string) (interface{}, error) {
+	t, err := abi.NewType(typ, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err := abi.Arguments{{Type: t}}.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// vmErrors 节点返回的非回滚执行错误，见 core/vm/errors.go
+var vmErrors = []string{
+	"out of gas",
+	"gas uint64 overflow",
+	"invalid opcode",
+	"invalid jump destination",
+	"stack underflow",
+	"stack overflow",
+	"write protection",
+	"return data out of bounds",
+	"max code size exceeded",
+	"max initcode size exceeded",
+	"contract address collision",
+	"insufficient balance for transfer",
+	"invalid code: must not begin with 0xef",
+}
+
+// FromError 从 eth_call、EstimateGas 返回的错误中提取并解码回滚原因
+// 错误与合约执行无关（如网络错误、nonce 过低）时返回 nil
+func (d *Decoder) FromError(err error) *Reason {
+	if err == nil {
+		return nil
+	}
+	// 节点把 revert 数据放在 JSON-RPC 错误的 data 字段里，ethclient 和模拟后端都实现了 rpc.DataError
+	var de rpc.DataError
+	if errors.As(err, &de) {
+		if s, ok := de.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(s); decodeErr == nil {
+				return d.Decode(data)
+			}
+		}
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "execution reverted") {
+		r := d.Decode(nil)
+		r.Message = msg
+		return r
+	}
+	for _, e := range vmErrors {
+		if strings.Contains(msg, e) {
+			return &Reason{Kind: KindFailure, Message: e}
+		}
+	}
+	return nil
+}
+
+// Replay 在交易所在区块的父区块状态上用 eth_call 重放失败的交易，返回回滚原因
+//
+// 重放时使用原交易的发送方、接收方、金额、数据和 Gas 上限，因此 Gas 不足导致的失败也能复现。
+// 父区块的状态不包含同一区块中排在前面的交易，少数情况下结果会与链上不同，此时返回 ErrNotReproduced；
+// 较早的区块需要节点保留历史状态（归档节点）。交易成功时返回 ErrNotFailed。
+func (d *Decoder) Replay(ctx context.Context, b backend.EthBackend, hash common.Hash) (*Reason, error) {
+	tx, pending, err := b.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("查询交易 %s 失败: %w", hash.Hex(), err)
+	}
+	if pending {
+		return nil, fmt.Errorf("交易 %s 还没有被打包", hash.Hex())
+	}
+	receipt, err := b.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("查询交易 %s 的收据失败: %w", hash.Hex(), err)
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil, ErrNotFailed
+	}
+	chainID, err := b.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取链ID失败: %w", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("恢复交易发送方失败: %w", err)
+	}
+
+	// ===== 在父区块上重放 =====
+	// 不设置 Gas 价格，避免发送方余额在父区块上不足以支付手续费而干扰结果
+	msg := ethereum.CallMsg{
+		From:       from,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, callErr := b.CallContract(ctx, msg, parent)
+	if callErr == nil {
+		return nil, ErrNotReproduced
+	}
+	if r := d.FromError(callErr); r != nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("在区块 %s 上重放交易失败（较早的区块需要归档节点）: %w", parent, callErr)
+}
@@ -0,0 +1,158 @@
+package revert_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+// vaultABI 测试用的自定义错误定义
+const vaultABI = `[{"type":"error","name":"Locked","inputs":[{"name":"until","type":"uint256"},{"name":"owner","type":"address"}]}]`
+
+func encode(t *testing.T, sig string, types []string, values ...interface{}) []byte {
+	t.Helper()
+	var args abi.Arguments
+	for _, typ := range types {
+		ty, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, abi.Argument{Type: ty})
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(calldata.SelectorOf(sig).Bytes(), packed...)
+}
+
+func TestDecode(t *testing.T) {
+	d := revert.NewDecoder(calldata.DefaultSignatures())
+	if err := d.AddABI("Vault", vaultABI); err != nil {
+		t.Fatal(err)
+	}
+	owner := common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+
+	r := d.Decode(encode(t, "Error(string)", []string{"string"}, "Not the owner"))
+	if r.Kind != revert.KindError || r.Message != "Not the owner" {
+		t.Fatalf("Error(string) = %+v", r)
+	}
+
+	r = d.Decode(encode(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11)))
+	if r.Kind != revert.KindPanic || r.Code.Int64() != 0x11 || !strings.Contains(r.String(), "上溢") {
+		t.Fatalf("Panic = %+v: %s", r, r)
+	}
+
+	// 登记的 ABI 中的自定义错误，参数带名称
+	r = d.Decode(encode(t, "Locked(uint256,address)", []string{"uint256", "address"}, big.NewInt(100), owner))
+	if r.Kind != revert.KindCustom || r.Source != "Vault" || r.Args[0].Name != "until" || r.Args[1].Value != owner {
+		t.Fatalf("Locked = %+v", r)
+	}
+
+	// 签名库中的自定义错误
+	r = d.Decode(encode(t, "ERC20InsufficientBalance(address,uint256,uint256)", []string{"address", "uint256", "uint256"},
+		owner, big.NewInt(1), big.NewInt(2)))
+	if r.Kind != revert.KindCustom || r.Source != calldata.SourceSignatureDB || r.Name != "ERC20InsufficientBalance" {
+		t.Fatalf("ERC20InsufficientBalance = %+v", r)
+	}
+
+	if r := d.Decode([]byte{0xde, 0xad, 0xbe, 0xef}); r.Kind != revert.KindUnknown {
+		t.Fatalf("unknown = %+v", r)
+	}
+	// 不足 4 字节的数据没有选择器
+	if r := d.Decode([]byte{0x01, 0x02}); r.Kind != revert.KindUnknown || r.String() != "无法解码的 revert 数据: 0x0102" {
+		t.Fatalf("short = %+v: %s", r, r)
+	}
+	if r := d.Decode(nil); r.Kind != revert.KindEmpty {
+		t.Fatalf("empty = %+v", r)
+	}
+	if r := d.FromError(errors.New("connection refused")); r != nil {
+		t.Fatalf("unrelated error decoded: %+v", r)
+	}
+}
+
+func TestFromError(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	d := revert.NewDecoder(nil)
+
+	// 发送前估算 Gas 时回滚，错误中带有 revert 数据
+	_, err := transfer.SendToken(ctx, chain, bob.Signer(), tokenAddr, alice.Address, big.NewInt(1), nil)
+	r := d.FromError(err)
+	if r == nil || r.Kind != revert.KindError || r.Message != "ERC20: transfer amount exceeds balance" {
+		t.Fatalf("estimate gas: err = %v, reason = %+v", err, r)
+	}
+
+	// 经过 JSON-RPC 返回的错误同样可以解码
+	client := ethclient.NewClient(chain.Client())
+	input := transfer.EncodeTransfer(alice.Address, big.NewInt(1))
+	_, err = client.CallContract(ctx, ethereum.CallMsg{From: bob.Address, To: &tokenAddr, Data: input}, nil)
+	if r := d.FromError(err); r == nil || r.Message != "ERC20: transfer amount exceeds balance" {
+		t.Fatalf("eth_call: err = %v, reason = %+v", err, r)
+	}
+}
+
+// headCaller 模拟后端只能在最新区块上执行 eth_call，测试中把指定的历史区块改为最新区块；
+// 失败的交易没有改变代币余额，两者的执行结果相同
+type headCaller struct{ *simchain.Chain }
+
+func (h headCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return h.Chain.CallContract(ctx, msg, nil)
+}
+
+func TestReplay(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	d := revert.NewDecoder(calldata.DefaultSignatures())
+	b := headCaller{chain}
+
+	// 固定 Gas 上限跳过估算，回滚的交易照样被打包
+	res, err := transfer.SendToken(ctx, chain, bob.Signer(), tokenAddr, alice.Address, big.NewInt(1), &backend.TxOptions{GasLimit: 100_000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.WaitMined(ctx, chain, res.Tx); !errors.Is(err, backend.ErrTxFailed) {
+		t.Fatalf("wait mined: %v", err)
+	}
+	r, err := d.Replay(ctx, b, res.Tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != revert.KindError || r.Message != "ERC20: transfer amount exceeds balance" {
+		t.Fatalf("reason = %+v", r)
+	}
+
+	// Gas 不足的交易按原 Gas 上限重放，得到执行错误而不是回滚
+	res, err = transfer.SendToken(ctx, chain, alice.Signer(), tokenAddr, bob.Address, big.NewInt(1), &backend.TxOptions{GasLimit: 22_000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Receipt(res.Tx.Hash())
+	if r, err := d.Replay(ctx, b, res.Tx.Hash()); err != nil || r.Kind != revert.KindFailure || r.Message != "out of gas" {
+		t.Fatalf("out of gas: reason = %+v, err = %v", r, err)
+	}
+
+	// 成功的交易没有回滚原因
+	tx := chain.TransferETH(alice, bob.Address, big.NewInt(1))
+	chain.Receipt(tx.Hash())
+	if _, err := d.Replay(ctx, b, tx.Hash()); !errors.Is(err, revert.ErrNotFailed) {
+		t.Fatalf("successful tx: %v", err)
+	}
+}
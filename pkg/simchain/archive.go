@@ -0,0 +1,34 @@
+package simchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// CallContract 在指定区块上执行只读调用，支持 safe、finalized 等标签
+//
+// 模拟后端保留了全部历史状态，BalanceAt 等查询可以读取任意区块，
+// 但它的 CallContract 只接受最新区块。历史区块上的调用在该区块的状态上直接构造 EVM 执行，
+// 测试链因此与归档节点一样可以在历史区块上调用合约
+func (c *Chain) CallContract(ctx context.Context, msg ethereum.CallMsg, number *big.Int) ([]byte, error) {
+	n, err := c.tagNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil || n.Cmp(c.Head().Number) == 0 {
+		return c.SimulatedBackend.CallContract(ctx, msg, n)
+	}
+	args := callArgs{From: msg.From, To: msg.To, Gas: hexutil.Uint64(msg.Gas), Data: msg.Data}
+	if msg.Value != nil {
+		args.Value = (*hexutil.Big)(msg.Value)
+	}
+	if msg.GasPrice != nil {
+		args.GasPrice = (*hexutil.Big)(msg.GasPrice)
+	}
+	api := &ethAPI{chain: c}
+	return api.callWithOverrides(ctx, args, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n.Int64())), nil, nil)
+}
@@ -0,0 +1,284 @@
+package simchain_test
+
+// 本文件按 cmd/ 下各命令的实际流程（原始交易构造、手工 ABI 编码、日志查询等）
+// 在测试链上端到端运行，验证命令逻辑在没有真实节点时同样正确。
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/basicstorage"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplestorage"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+// sendRaw 按命令中的方式签名并发送一笔 legacy 交易，to 为 nil 时部署合约
+func sendRaw(t *testing.T, chain *simchain.Chain, from *simchain.Account, to *common.Address, gas uint64, data []byte) *types.Transaction {
+	t.Helper()
+	ctx := context.Background()
+	nonce, err := chain.PendingNonceAt(ctx, from.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gasPrice, err := chain.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx *types.Transaction
+	if to == nil {
+		tx = types.NewContractCreation(nonce, big.NewInt(0), gas, gasPrice, data)
+	} else {
+		tx = types.NewTransaction(nonce, *to, big.NewInt(0), gas, gasPrice, data)
+	}
+	chainID, err := chain.NetworkID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(chainID), from.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.SendTransaction(ctx, signed); err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// eth-transfer + query-balance + query-transaction
+func TestETHTransferFlow(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	before := chain.Balance(alice.Address)
+
+	tx := chain.TransferETH(alice, bob.Address, oneEther)
+	chain.Mine()
+
+	receipt := chain.Receipt(tx.Hash())
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.GasUsed != 21000 {
+		t.Fatalf("receipt status=%d gasUsed=%d", receipt.Status, receipt.GasUsed)
+	}
+	fee := new(big.Int).Mul(tx.GasPrice(), big.NewInt(int64(receipt.GasUsed)))
+	want := new(big.Int).Sub(before, new(big.Int).Add(oneEther, fee))
+	if got := chain.Balance(alice.Address); got.Cmp(want) != 0 {
+		t.Fatalf("sender balance = %s, want %s", got, want)
+	}
+
+	got, pending, err := chain.TransactionByHash(ctx, tx.Hash())
+	if err != nil || pending {
+		t.Fatalf("TransactionByHash: pending=%v err=%v", pending, err)
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(simchain.ChainID), got)
+	if err != nil || sender != alice.Address {
+		t.Fatalf("sender = %s, %v; want %s", sender.Hex(), err, alice.Address.Hex())
+	}
+
+	// query-block：区块中的交易与 TransactionInBlock 一致
+	block, err := chain.BlockByNumber(ctx, receipt.BlockNumber)
+	if err != nil || len(block.Transactions()) != 1 {
+		t.Fatalf("block txs = %v, %v", block, err)
+	}
+	inBlock, err := chain.TransactionInBlock(ctx, block.Hash(), 0)
+	if err != nil || inBlock.Hash() != tx.Hash() {
+		t.Fatalf("TransactionInBlock = %v, %v", inBlock, err)
+	}
+}
+
+// token-transfer + query-token-balance：手工拼接 transfer(address,uint256) 调用数据
+func TestTokenTransferFlow(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1_000_000))
+
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	data := append([]byte{}, crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]...)
+	data = append(data, common.LeftPadBytes(bob.Address.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	gas, err := chain.EstimateGas(ctx, ethereum.CallMsg{From: alice.Address, To: &tokenAddr, Data: data})
+	if err != nil {
+		t.Fatal("EstimateGas:", err)
+	}
+	tx := sendRaw(t, chain, alice, &tokenAddr, gas, data)
+	chain.Mine()
+
+	if receipt := chain.Receipt(tx.Hash()); receipt.Status != types.ReceiptStatusSuccessful || len(receipt.Logs) != 1 {
+		t.Fatalf("receipt status=%d logs=%d", receipt.Status, len(receipt.Logs))
+	}
+	if bal, _ := token.BalanceOf(nil, bob.Address); bal.Cmp(amount) != 0 {
+		t.Fatalf("recipient token balance = %s, want %s", bal, amount)
+	}
+}
+
+// deploy-contract + execute-contract：部署原始字节码，再用 ABI 编码调用和只读查询
+func TestDeployAndExecuteFlow(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice := chain.Accounts[0]
+
+	code := common.FromHex(simplestorage.SimpleStorageMetaData.Bin)
+	tx := sendRaw(t, chain, alice, nil, 3_000_000, code)
+	chain.Mine()
+	receipt := chain.Receipt(tx.Hash())
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("deployment failed")
+	}
+	if want := crypto.CreateAddress(alice.Address, tx.Nonce()); receipt.ContractAddress != want {
+		t.Fatalf("contract address = %s, want %s", receipt.ContractAddress.Hex(), want.Hex())
+	}
+	if deployed, _ := chain.CodeAt(ctx, receipt.ContractAddress, nil); len(deployed) == 0 {
+		t.Fatal("no code at contract address")
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(simplestorage.SimpleStorageMetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := parsed.Pack("set", big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := receipt.ContractAddress
+	sendRaw(t, chain, alice, &contract, 300_000, input)
+	chain.Mine()
+
+	callInput, _ := parsed.Pack("get")
+	result, err := chain.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: callInput}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := parsed.Unpack("get", result)
+	if err != nil || out[0].(*big.Int).Int64() != 42 {
+		t.Fatalf("get() = %v, %v; want 42", out, err)
+	}
+}
+
+// contract-events：订阅合约日志，收到新事件后按 ABI 解析
+func TestContractEventsFlow(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	alice := chain.Accounts[0]
+	addr, _, store, err := basicstorage.DeployBasicStorage(alice.Opts(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := make(chan types.Log, 4)
+	sub, err := chain.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{addr}}, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	if _, err := store.Set(alice.Opts(), big.NewInt(7)); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, _ := abi.JSON(strings.NewReader(basicstorage.BasicStorageMetaData.ABI))
+	select {
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case vLog := <-logs:
+		if vLog.Topics[0] != parsed.Events["DataStored"].ID {
+			t.Fatalf("topic0 = %s", vLog.Topics[0].Hex())
+		}
+		if by := common.BytesToAddress(vLog.Topics[1].Bytes()); by != alice.Address {
+			t.Fatalf("indexed by = %s, want %s", by.Hex(), alice.Address.Hex())
+		}
+		values, err := parsed.Unpack("DataStored", vLog.Data)
+		if err != nil || values[0].(*big.Int).Int64() != 7 {
+			t.Fatalf("DataStored data = %v, %v", values, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+// query-receipt + query-history-events：按区块取收据，并在区块范围内回放历史日志
+func TestReceiptsAndHistoryFlow(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1_000_000))
+
+	// 第一个区块里放两笔代币转账，之后再出两个各含一笔转账的区块
+	var txs []*types.Transaction
+	for i := 1; i <= 4; i++ {
+		tx, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(int64(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+		if i >= 2 {
+			chain.Mine()
+		}
+	}
+	first := chain.Receipt(txs[0].Hash()).BlockNumber
+
+	receipts, err := chain.BlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(first.Int64())))
+	if err != nil || len(receipts) != 2 {
+		t.Fatalf("block receipts = %d, %v; want 2", len(receipts), err)
+	}
+	for i, r := range receipts {
+		if r.TxHash != txs[i].Hash() || r.TransactionIndex != uint(i) {
+			t.Fatalf("receipt %d = %s (index %d)", i, r.TxHash.Hex(), r.TransactionIndex)
+		}
+	}
+
+	transferSig := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	query := ethereum.FilterQuery{
+		FromBlock: first,
+		ToBlock:   new(big.Int).Add(first, big.NewInt(1)),
+		Addresses: []common.Address{tokenAddr},
+		Topics:    [][]common.Hash{{transferSig}},
+	}
+	logs, err := chain.FilterLogs(ctx, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("got %d logs in range, want 3", len(logs))
+	}
+	parsed, _ := abi.JSON(strings.NewReader(mytoken.MyTokenMetaData.ABI))
+	for i, vLog := range logs {
+		values, err := parsed.Unpack("Transfer", vLog.Data)
+		if err != nil || values[0].(*big.Int).Int64() != int64(i+1) {
+			t.Fatalf("log %d value = %v, %v", i, values, err)
+		}
+	}
+
+	// 不带 ToBlock 时查询到最新区块
+	query.ToBlock = nil
+	if logs, _ = chain.FilterLogs(ctx, query); len(logs) != 4 {
+		t.Fatalf("got %d logs to latest, want 4", len(logs))
+	}
+}
+
+func TestDeployContracts(t *testing.T) {
+	chain := simchain.New(t)
+	c := chain.DeployContracts()
+	if len(c.Addresses) != 9 {
+		t.Fatalf("deployed %d contracts, want 9", len(c.Addresses))
+	}
+	if name, err := c.SimpleNFT.Name(nil); err != nil || name != simchain.DefaultNFTName {
+		t.Fatalf("SimpleNFT name = %q, %v", name, err)
+	}
+	staking, _ := c.TokenStaking.StakingToken(nil)
+	if staking != c.Addresses["MyToken"].Address {
+		t.Fatalf("staking token = %s, want MyToken", staking.Hex())
+	}
+	if owner, _ := c.BasicStorage.Owner(nil); owner != chain.Accounts[0].Address {
+		t.Fatalf("BasicStorage owner = %s", owner.Hex())
+	}
+}
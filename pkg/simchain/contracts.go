@@ -0,0 +1,117 @@
+package simchain
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/auction"
+	"github.com/duanyu/new-eth-project/pkg/bindings/basicstorage"
+	"github.com/duanyu/new-eth-project/pkg/bindings/crowdfunding"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simpledao"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplenft"
+	"github.com/duanyu/new-eth-project/pkg/bindings/simplestorage"
+	"github.com/duanyu/new-eth-project/pkg/bindings/tokenstaking"
+	"github.com/duanyu/new-eth-project/pkg/bindings/voting"
+)
+
+// 默认部署参数，与 contracts/README.md 中的示例保持一致
+const (
+	DefaultTokenName     = "My Token"
+	DefaultTokenSymbol   = "MTK"
+	DefaultTokenDecimals = 18
+	DefaultNFTName       = "Simple NFT"
+	DefaultNFTSymbol     = "SNFT"
+	DefaultAuctionTime   = time.Hour
+)
+
+// DefaultTokenSupply MyToken 默认初始供应量（未乘以精度）
+var DefaultTokenSupply = big.NewInt(1_000_000)
+
+// Deployed 已部署合约的地址与部署交易
+type Deployed struct {
+	Address common.Address
+	Tx      *types.Transaction
+}
+
+// Contracts contracts/ 中全部合约的部署结果，部署者均为 Accounts[0]
+type Contracts struct {
+	MyToken       *mytoken.MyToken
+	SimpleStorage *simplestorage.SimpleStorage
+	BasicStorage  *basicstorage.BasicStorage
+	Voting        *voting.Voting
+	Auction       *auction.Auction
+	Crowdfunding  *crowdfunding.Crowdfunding
+	SimpleNFT     *simplenft.SimpleNFT
+	TokenStaking  *tokenstaking.TokenStaking
+	SimpleDAO     *simpledao.SimpleDAO
+
+	// Addresses 以合约名为键的部署信息
+	Addresses map[string]Deployed
+}
+
+// DeployMyToken 由 deployer 部署一个 MyToken 并出块
+func (c *Chain) DeployMyToken(deployer *Account, name, symbol string, decimals uint8, supply *big.Int) (common.Address, *mytoken.MyToken) {
+	c.t.Helper()
+	addr, _, token, err := mytoken.DeployMyToken(deployer.Opts(), c, name, symbol, decimals, supply)
+	if err != nil {
+		c.t.Fatal("部署 MyToken 失败:", err)
+	}
+	c.Commit()
+	return addr, token
+}
+
+// DeployContracts 以默认参数部署 contracts/ 中的全部合约
+//
+// TokenStaking 使用同一个 MyToken 作为质押代币和奖励代币；
+// Auction 的受益人为部署者，竞拍时长为 DefaultAuctionTime。
+func (c *Chain) DeployContracts() *Contracts {
+	c.t.Helper()
+	deployer := c.Accounts[0]
+	out := &Contracts{Addresses: map[string]Deployed{}}
+
+	record := func(name string, addr common.Address, tx *types.Transaction, err error) {
+		c.t.Helper()
+		if err != nil {
+			c.t.Fatalf("部署 %s 失败: %v", name, err)
+		}
+		out.Addresses[name] = Deployed{Address: addr, Tx: tx}
+	}
+
+	var (
+		addr common.Address
+		tx   *types.Transaction
+		err  error
+	)
+	addr, tx, out.MyToken, err = mytoken.DeployMyToken(deployer.Opts(), c, DefaultTokenName, DefaultTokenSymbol, DefaultTokenDecimals, DefaultTokenSupply)
+	record("MyToken", addr, tx, err)
+	tokenAddr := addr
+
+	addr, tx, out.SimpleStorage, err = simplestorage.DeploySimpleStorage(deployer.Opts(), c)
+	record("SimpleStorage", addr, tx, err)
+	addr, tx, out.BasicStorage, err = basicstorage.DeployBasicStorage(deployer.Opts(), c)
+	record("BasicStorage", addr, tx, err)
+	addr, tx, out.Voting, err = voting.DeployVoting(deployer.Opts(), c)
+	record("Voting", addr, tx, err)
+	addr, tx, out.Auction, err = auction.DeployAuction(deployer.Opts(), c, big.NewInt(int64(DefaultAuctionTime/time.Second)), deployer.Address)
+	record("Auction", addr, tx, err)
+	addr, tx, out.Crowdfunding, err = crowdfunding.DeployCrowdfunding(deployer.Opts(), c)
+	record("Crowdfunding", addr, tx, err)
+	addr, tx, out.SimpleNFT, err = simplenft.DeploySimpleNFT(deployer.Opts(), c, DefaultNFTName, DefaultNFTSymbol)
+	record("SimpleNFT", addr, tx, err)
+	addr, tx, out.TokenStaking, err = tokenstaking.DeployTokenStaking(deployer.Opts(), c, tokenAddr, tokenAddr)
+	record("TokenStaking", addr, tx, err)
+	addr, tx, out.SimpleDAO, err = simpledao.DeploySimpleDAO(deployer.Opts(), c)
+	record("SimpleDAO", addr, tx, err)
+
+	c.Commit()
+	for name, d := range out.Addresses {
+		if receipt := c.Receipt(d.Tx.Hash()); receipt.Status != types.ReceiptStatusSuccessful {
+			c.t.Fatalf("部署 %s 的交易执行失败", name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package simchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	// 注册 callTracer、prestateTracer 等内置追踪器
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+// debugAPI 以 JSON-RPC 形式提供 debug_traceTransaction 和 debug_traceCall，只支持内置的 Go 追踪器
+type debugAPI struct {
+	chain *Chain
+}
+
+// traceConfig 追踪参数，与 geth 相同；Timeout 在测试链上被忽略
+type traceConfig struct {
+	Tracer       string          `json:"tracer"`
+	Timeout      string          `json:"timeout"`
+	TracerConfig json.RawMessage `json:"tracerConfig"`
+}
+
+// TraceTransaction 在父区块的状态上依次执行区块中排在前面的交易，再用追踪器执行目标交易
+func (api *debugAPI) TraceTransaction(ctx context.Context, hash common.Hash, config *traceConfig) (json.RawMessage, error) {
+	if config == nil || config.Tracer == "" {
+		return nil, fmt.Errorf("测试链只支持指定 tracer 的追踪")
+	}
+	receipt, err := api.chain.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	bc := api.chain.Blockchain()
+	block := bc.GetBlockByHash(receipt.BlockHash)
+	if block == nil {
+		return nil, fmt.Errorf("找不到区块 %s", receipt.BlockHash.Hex())
+	}
+	parent := bc.GetBlockByHash(block.ParentHash())
+	if parent == nil {
+		return nil, fmt.Errorf("找不到父区块 %s", block.ParentHash().Hex())
+	}
+	statedb, err := bc.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.MakeSigner(bc.Config(), block.Number(), block.Time())
+	blockCtx := core.NewEVMBlockContext(block.Header(), bc, nil)
+	for i, tx := range block.Transactions() {
+		msg, err := core.TransactionToMessage(tx, signer, block.BaseFee())
+		if err != nil {
+			return nil, err
+		}
+		vmConfig := vm.Config{NoBaseFee: true}
+		var tracer tracers.Tracer
+		if tx.Hash() == hash {
+			tracer, err = tracers.DefaultDirectory.New(config.Tracer, &tracers.Context{
+				BlockHash:   block.Hash(),
+				BlockNumber: block.Number(),
+				TxIndex:     i,
+				TxHash:      hash,
+			}, config.TracerConfig)
+			if err != nil {
+				return nil, err
+			}
+			vmConfig.Tracer = tracer
+		}
+		evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, bc.Config(), vmConfig)
+		statedb.SetTxContext(tx.Hash(), i)
+		if _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+			return nil, fmt.Errorf("执行交易 %s 失败: %w", tx.Hash().Hex(), err)
+		}
+		if tracer != nil {
+			return tracer.GetResult()
+		}
+		statedb.Finalise(true)
+	}
+	return nil, fmt.Errorf("区块中没有交易 %s", hash.Hex())
+}
+
+// TraceCall 在最新区块的状态上用追踪器执行调用，与 eth_call 一样不检查 nonce，
+// 未指定 Gas 上限时使用区块 Gas 上限；模拟后端只保留最新状态，指定其他区块时返回错误
+func (api *debugAPI) TraceCall(ctx context.Context, args callArgs, blockNrOrHash rpc.BlockNumberOrHash, config *traceConfig) (json.RawMessage, error) {
+	if config == nil || config.Tracer == "" {
+		return nil, fmt.Errorf("测试链只支持指定 tracer 的追踪")
+	}
+	bc := api.chain.Blockchain()
+	head := bc.CurrentBlock()
+	if number, err := (&ethAPI{chain: api.chain}).resolve(ctx, blockNrOrHash); err != nil {
+		return nil, err
+	} else if number != nil && number.Cmp(head.Number) != 0 {
+		return nil, fmt.Errorf("测试链只能在最新区块上追踪调用")
+	}
+	statedb, err := bc.State()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := callMessage(args, statedb, head.GasLimit)
+
+	tracer, err := tracers.DefaultDirectory.New(config.Tracer, &tracers.Context{BlockNumber: head.Number}, config.TracerConfig)
+	if err != nil {
+		return nil, err
+	}
+	blockCtx := core.NewEVMBlockContext(head, bc, nil)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, bc.Config(), vm.Config{Tracer: tracer, NoBaseFee: true})
+	if _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit)); err != nil {
+		return nil, fmt.Errorf("执行调用失败: %w", err)
+	}
+	return tracer.GetResult()
+}
@@ -0,0 +1,89 @@
+package simchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+)
+
+// maxFeeHistory 单次 FeeHistory 最多返回的区块数，与 geth 的限制相同
+const maxFeeHistory = 1024
+
+// FeeHistory 按 geth 的 eth_feeHistory 语义返回最近 blockCount 个区块的基础费、Gas 使用率
+// 和各百分位的优先费；BaseFee 比区块多一项，最后一项是下一个区块的基础费
+func (c *Chain) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("无效的百分位 %v", rewardPercentiles)
+		}
+	}
+	last := c.Head().Number.Uint64()
+	if lastBlock != nil {
+		if lastBlock.Uint64() > last {
+			return nil, fmt.Errorf("区块 %s 还不存在", lastBlock)
+		}
+		last = lastBlock.Uint64()
+	}
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+	if blockCount > last+1 {
+		blockCount = last + 1
+	}
+
+	oldest := last + 1 - blockCount
+	out := &ethereum.FeeHistory{OldestBlock: new(big.Int).SetUint64(oldest)}
+	for n := oldest; n <= last; n++ {
+		block, err := c.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		header := block.Header()
+		out.BaseFee = append(out.BaseFee, header.BaseFee)
+		out.GasUsedRatio = append(out.GasUsedRatio, float64(header.GasUsed)/float64(header.GasLimit))
+		if n == last {
+			out.BaseFee = append(out.BaseFee, eip1559.CalcBaseFee(c.Blockchain().Config(), header))
+		}
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+
+		// 按优先费从低到高排序，以 Gas 消耗加权取百分位，与 geth 的算法相同
+		type tip struct {
+			reward  *big.Int
+			gasUsed uint64
+		}
+		var tips []tip
+		for _, tx := range block.Transactions() {
+			receipt, err := c.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, err
+			}
+			reward, _ := tx.EffectiveGasTip(header.BaseFee)
+			tips = append(tips, tip{reward, receipt.GasUsed})
+		}
+		sort.SliceStable(tips, func(i, j int) bool { return tips[i].reward.Cmp(tips[j].reward) < 0 })
+		rewards := make([]*big.Int, len(rewardPercentiles))
+		for i, p := range rewardPercentiles {
+			rewards[i] = new(big.Int)
+			if len(tips) == 0 {
+				continue
+			}
+			threshold := uint64(float64(header.GasUsed) * p / 100)
+			var sum uint64
+			for j, t := range tips {
+				sum += t.gasUsed
+				if sum >= threshold || j == len(tips)-1 {
+					rewards[i] = t.reward
+					break
+				}
+			}
+		}
+		out.Reward = append(out.Reward, rewards)
+	}
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package simchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// 测试链没有共识层，safe 和 finalized 按固定的确认深度近似：
+// safe 为最新区块之前 SafeDepth 个区块，finalized 为之前 FinalizedDepth 个区块。
+// 链的长度还不到确认深度时与刚合并的节点一样，返回 ethereum.NotFound
+const (
+	SafeDepth      = 32
+	FinalizedDepth = 64
+)
+
+// tagNumber 把 rpc 区块标签（负数区块号）转换为模拟后端认识的区块号，nil 表示最新区块
+func (c *Chain) tagNumber(number *big.Int) (*big.Int, error) {
+	if number == nil || number.Sign() >= 0 || !number.IsInt64() {
+		return number, nil
+	}
+	head := c.Head().Number.Uint64()
+	depth := func(d uint64) (*big.Int, error) {
+		if head < d {
+			return nil, ethereum.NotFound
+		}
+		return new(big.Int).SetUint64(head - d), nil
+	}
+	switch rpc.BlockNumber(number.Int64()) {
+	case rpc.SafeBlockNumber:
+		return depth(SafeDepth)
+	case rpc.FinalizedBlockNumber:
+		return depth(FinalizedDepth)
+	}
+	// latest、pending 都按最新区块处理
+	return nil, nil
+}
+
+// HeaderByNumber 返回区块头，支持 safe、finalized、latest、pending 标签
+func (c *Chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	n, err := c.tagNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return c.SimulatedBackend.HeaderByNumber(ctx, n)
+}
+
+// BlockByNumber 返回区块，支持 safe、finalized、latest、pending 标签
+func (c *Chain) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	n, err := c.tagNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return c.SimulatedBackend.BlockByNumber(ctx, n)
+}
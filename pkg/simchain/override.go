@@ -0,0 +1,174 @@
+package simchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// accountOverride eth_call 状态覆盖中的单个账户，格式与 geth 相同
+type accountOverride struct {
+	Nonce     *hexutil.Uint64             `json:"nonce"`
+	Code      *hexutil.Bytes              `json:"code"`
+	Balance   *hexutil.Big                `json:"balance"`
+	State     map[common.Hash]common.Hash `json:"state"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// stateOverride eth_call 的第 3 个参数
+type stateOverride map[common.Address]accountOverride
+
+// apply 把覆盖项写入状态，state 与 stateDiff 不能同时指定
+func (o stateOverride) apply(statedb *state.StateDB) error {
+	for addr, account := range o {
+		if account.Nonce != nil {
+			statedb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			statedb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			statedb.SetBalance(addr, account.Balance.ToInt())
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("账户 %s 不能同时指定 state 和 stateDiff", addr.Hex())
+		}
+		if account.State != nil {
+			statedb.SetStorage(addr, account.State)
+		}
+		for slot, value := range account.StateDiff {
+			statedb.SetState(addr, slot, value)
+		}
+	}
+	statedb.Finalise(false)
+	return nil
+}
+
+// blockOverrides eth_call 的第 4 个参数，覆盖执行时的区块环境
+type blockOverrides struct {
+	Number     *hexutil.Big
+	Difficulty *hexutil.Big
+	Time       *hexutil.Uint64
+	GasLimit   *hexutil.Uint64
+	Coinbase   *common.Address
+	Random     *common.Hash
+	BaseFee    *hexutil.Big
+}
+
+// apply 修改区块上下文
+func (o *blockOverrides) apply(blockCtx *vm.BlockContext) {
+	if o == nil {
+		return
+	}
+	if o.Number != nil {
+		blockCtx.BlockNumber = o.Number.ToInt()
+	}
+	if o.Difficulty != nil {
+		blockCtx.Difficulty = o.Difficulty.ToInt()
+	}
+	if o.Time != nil {
+		blockCtx.Time = uint64(*o.Time)
+	}
+	if o.GasLimit != nil {
+		blockCtx.GasLimit = uint64(*o.GasLimit)
+	}
+	if o.Coinbase != nil {
+		blockCtx.Coinbase = *o.Coinbase
+	}
+	if o.Random != nil {
+		blockCtx.Random = o.Random
+	}
+	if o.BaseFee != nil {
+		blockCtx.BaseFee = o.BaseFee.ToInt()
+	}
+}
+
+// revertError 带 revert 数据的 JSON-RPC 错误（错误码 3），与 geth 的格式相同
+type revertError struct {
+	error
+	data string
+}
+
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+func newRevertError(result *core.ExecutionResult) error {
+	err := errors.New("execution reverted")
+	if reason, unpackErr := abi.UnpackRevert(result.Revert()); unpackErr == nil {
+		err = fmt.Errorf("execution reverted: %v", reason)
+	}
+	return &revertError{error: err, data: hexutil.Encode(result.Revert())}
+}
+
+// callMessage 按 eth_call 的规则把调用参数转换为消息：不检查 nonce 和余额，
+// 未指定 Gas 上限时使用 gasCap
+func callMessage(args callArgs, statedb *state.StateDB, gasCap uint64) *core.Message {
+	data := args.Input
+	if data == nil {
+		data = args.Data
+	}
+	msg := &core.Message{
+		From:              args.From,
+		To:                args.To,
+		Nonce:             statedb.GetNonce(args.From),
+		Value:             new(big.Int),
+		GasLimit:          uint64(args.Gas),
+		GasPrice:          new(big.Int),
+		GasFeeCap:         new(big.Int),
+		GasTipCap:         new(big.Int),
+		Data:              data,
+		SkipAccountChecks: true,
+	}
+	if args.Value != nil {
+		msg.Value = args.Value.ToInt()
+	}
+	if args.GasPrice != nil {
+		msg.GasPrice, msg.GasFeeCap, msg.GasTipCap = args.GasPrice.ToInt(), args.GasPrice.ToInt(), args.GasPrice.ToInt()
+	}
+	if msg.GasLimit == 0 {
+		msg.GasLimit = gasCap
+	}
+	return msg
+}
+
+// callWithOverrides 在指定区块的状态上应用覆盖项后执行调用；
+// 模拟后端的 CallContract 不支持覆盖，这里直接构造 EVM 执行
+func (api *ethAPI) callWithOverrides(ctx context.Context, args callArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides stateOverride, blockOv *blockOverrides) (hexutil.Bytes, error) {
+	bc := api.chain.Blockchain()
+	header, err := api.header(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	statedb, err := bc.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	if err := overrides.apply(statedb); err != nil {
+		return nil, err
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, bc, nil)
+	blockOv.apply(&blockCtx)
+	msg := callMessage(args, statedb, blockCtx.GasLimit)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, bc.Config(), vm.Config{NoBaseFee: true})
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		return nil, fmt.Errorf("执行调用失败: %w", err)
+	}
+	if errors.Is(result.Err, vm.ErrExecutionReverted) {
+		return nil, newRevertError(result)
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return result.Return(), nil
+}
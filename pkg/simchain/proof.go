@@ -0,0 +1,113 @@
+package simchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// proofList 收集 Merkle 证明中的节点，实现 ethdb.KeyValueWriter
+type proofList []hexutil.Bytes
+
+func (p *proofList) Put(key []byte, value []byte) error {
+	*p = append(*p, common.CopyBytes(value))
+	return nil
+}
+
+func (p *proofList) Delete(key []byte) error { return fmt.Errorf("proofList 不支持删除") }
+
+// storageResult eth_getProof 中单个存储槽的证明
+type storageResult struct {
+	Key   common.Hash     `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// accountResult eth_getProof 的返回值
+type accountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []storageResult `json:"storageProof"`
+}
+
+// header 返回区块号或区块哈希对应的区块头，未指定具体区块时为最新区块
+func (api *ethAPI) header(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	bc := api.chain.Blockchain()
+	number, err := api.resolve(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if number == nil {
+		return bc.CurrentBlock(), nil
+	}
+	header := bc.GetHeaderByNumber(number.Uint64())
+	if header == nil {
+		return nil, fmt.Errorf("找不到区块 %s", number)
+	}
+	return header, nil
+}
+
+// GetProof 实现 eth_getProof：返回账户在状态树中的证明，以及各存储槽在账户存储树中的证明，算法与 geth 相同
+func (api *ethAPI) GetProof(ctx context.Context, address common.Address, keys []common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (*accountResult, error) {
+	header, err := api.header(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	statedb, err := api.chain.Blockchain().StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	triedb := statedb.Database().TrieDB()
+	storageRoot := statedb.GetStorageRoot(address)
+	out := &accountResult{
+		Address:      address,
+		Balance:      (*hexutil.Big)(statedb.GetBalance(address)),
+		CodeHash:     statedb.GetCodeHash(address),
+		Nonce:        hexutil.Uint64(statedb.GetNonce(address)),
+		StorageHash:  storageRoot,
+		StorageProof: make([]storageResult, len(keys)),
+	}
+
+	// ===== 存储证明：存储树的键是 keccak256(槽号) =====
+	var storageTrie *trie.StateTrie
+	if storageRoot != types.EmptyRootHash && storageRoot != (common.Hash{}) {
+		id := trie.StorageTrieID(header.Root, crypto.Keccak256Hash(address.Bytes()), storageRoot)
+		if storageTrie, err = trie.NewStateTrie(id, triedb); err != nil {
+			return nil, err
+		}
+	}
+	for i, key := range keys {
+		out.StorageProof[i] = storageResult{Key: key, Value: new(hexutil.Big), Proof: []hexutil.Bytes{}}
+		if storageTrie == nil {
+			continue
+		}
+		var proof proofList
+		if err := storageTrie.Prove(crypto.Keccak256(key.Bytes()), &proof); err != nil {
+			return nil, err
+		}
+		out.StorageProof[i].Value = (*hexutil.Big)(statedb.GetState(address, key).Big())
+		out.StorageProof[i].Proof = proof
+	}
+
+	// ===== 账户证明：状态树的键是 keccak256(地址) =====
+	tr, err := trie.NewStateTrie(trie.StateTrieID(header.Root), triedb)
+	if err != nil {
+		return nil, err
+	}
+	var proof proofList
+	if err := tr.Prove(crypto.Keccak256(address.Bytes()), &proof); err != nil {
+		return nil, err
+	}
+	out.AccountProof = proof
+	return out, statedb.Error()
+}
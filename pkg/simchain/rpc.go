@@ -0,0 +1,162 @@
+package simchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client 返回连接到测试链的进程内 JSON-RPC 客户端，第一次调用时启动服务
+//
+// 模拟后端本身没有 RPC 接口，这里只实现了批量查询等功能库用到的 eth_ 方法：
+// eth_chainId、eth_blockNumber、eth_getBalance、eth_getTransactionCount、eth_getCode、
+// eth_getTransactionReceipt、eth_getBlockByNumber（只返回区块头字段）、eth_getProof 和 eth_call（支持状态覆盖和区块覆盖），
+// 以及使用内置追踪器（callTracer、prestateTracer 等）的 debug_traceTransaction。
+// Chain 因此满足 backend.RPCClient，pkg/query 会对它使用批量请求。
+func (c *Chain) Client() *rpc.Client {
+	c.rpcOnce.Do(func() {
+		server := rpc.NewServer()
+		if err := server.RegisterName("eth", &ethAPI{chain: c}); err != nil {
+			panic(err)
+		}
+		if err := server.RegisterName("debug", &debugAPI{chain: c}); err != nil {
+			panic(err)
+		}
+		c.rpcClient = rpc.DialInProc(server)
+		c.t.Cleanup(func() {
+			c.rpcClient.Close()
+			server.Stop()
+		})
+	})
+	return c.rpcClient
+}
+
+// ethAPI 以 JSON-RPC 形式暴露测试链
+type ethAPI struct {
+	chain *Chain
+}
+
+func (api *ethAPI) ChainId(ctx context.Context) (*hexutil.Big, error) {
+	id, err := api.chain.ChainID(ctx)
+	return (*hexutil.Big)(id), err
+}
+
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.chain.Head().Number.Uint64())
+}
+
+func (api *ethAPI) GetBalance(ctx context.Context, account common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	number, err := api.resolve(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := api.chain.BalanceAt(ctx, account, number)
+	return (*hexutil.Big)(balance), err
+}
+
+func (api *ethAPI) GetTransactionCount(ctx context.Context, account common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	if n, ok := blockNrOrHash.Number(); ok && n == rpc.PendingBlockNumber {
+		nonce, err := api.chain.PendingNonceAt(ctx, account)
+		return hexutil.Uint64(nonce), err
+	}
+	number, err := api.resolve(ctx, blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := api.chain.NonceAt(ctx, account, number)
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *ethAPI) GetCode(ctx context.Context, account common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	number, err := api.resolve(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.chain.CodeAt(ctx, account, number)
+}
+
+// GetTransactionReceipt 交易不存在时返回 null，与真实节点一致
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := api.chain.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+// GetBlockByNumber 只返回区块头字段（不含交易列表），区块不存在时返回 null
+func (api *ethAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	n, err := api.resolve(ctx, rpc.BlockNumberOrHashWithNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	header, err := api.chain.HeaderByNumber(ctx, n)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return header, err
+}
+
+// callArgs eth_call 的交易参数
+type callArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+	Input    hexutil.Bytes   `json:"input"`
+}
+
+// Call 实现 eth_call；带状态覆盖或区块覆盖时自行构造 EVM 执行，否则交给模拟后端
+func (api *ethAPI) Call(ctx context.Context, args callArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *stateOverride, blockOv *blockOverrides) (hexutil.Bytes, error) {
+	target := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		target = *blockNrOrHash
+	}
+	if overrides != nil || blockOv != nil {
+		var o stateOverride
+		if overrides != nil {
+			o = *overrides
+		}
+		return api.callWithOverrides(ctx, args, target, o, blockOv)
+	}
+	number, err := api.resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	data := args.Input
+	if data == nil {
+		data = args.Data
+	}
+	return api.chain.CallContract(ctx, ethereum.CallMsg{
+		From:     args.From,
+		To:       args.To,
+		Gas:      uint64(args.Gas),
+		GasPrice: (*big.Int)(args.GasPrice),
+		Value:    (*big.Int)(args.Value),
+		Data:     data,
+	}, number)
+}
+
+// resolve 把区块号或区块哈希转换为模拟后端使用的区块号，nil 表示最新区块
+func (api *ethAPI) resolve(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*big.Int, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header, err := api.chain.HeaderByHash(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		return header.Number, nil
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, errors.New("invalid block number or hash")
+	}
+	return api.chain.tagNumber(big.NewInt(number.Int64()))
+}
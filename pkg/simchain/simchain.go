@@ -0,0 +1,345 @@
+// Package simchain 提供基于 go-ethereum 模拟后端的进程内测试链
+//
+// 测试链在内存中运行，启动时为一组确定性的账户预置 ETH，
+// 并可一键部署 contracts/ 中的全部合约，使各命令的核心逻辑
+// （转账、代币转账、部署、调用、事件、收据、历史查询）无需真实节点即可端到端测试。
+//
+// 典型用法：
+//
+//	chain := simchain.New(t)
+//	alice, bob := chain.Accounts[0], chain.Accounts[1]
+//	tx := chain.TransferETH(alice, bob.Address, big.NewInt(1e18))
+//	chain.Mine()
+//	receipt := chain.Receipt(tx.Hash())
+package simchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ChainID 模拟后端固定使用的链 ID
+var ChainID = big.NewInt(1337)
+
+// Account 测试链上的预置账户
+type Account struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+}
+
+// Opts 返回该账户的交易签名参数，每次调用都返回新的副本，可放心修改 Value 等字段
+func (a *Account) Opts() *bind.TransactOpts {
+	opts, _ := bind.NewKeyedTransactorWithChainID(a.Key, ChainID)
+	return opts
+}
+
+// Signer 返回该账户对应的 backend.Signer
+func (a *Account) Signer() *backend.Signer {
+	return &backend.Signer{Key: a.Key, Address: a.Address}
+}
+
+// Config 测试链配置
+type Config struct {
+	Accounts int      // 预置账户数量
+	Balance  *big.Int // 每个预置账户的初始余额（Wei）
+	GasLimit uint64   // 区块 Gas 上限
+	AutoMine bool     // 为 true 时每发送一笔交易就立即出块
+}
+
+// DefaultConfig 返回默认配置：5 个账户，每个 100 ETH，区块 Gas 上限 3000 万
+func DefaultConfig() *Config {
+	return &Config{
+		Accounts: 5,
+		Balance:  new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)),
+		GasLimit: 30_000_000,
+	}
+}
+
+// Option 修改测试链配置
+type Option func(*Config)
+
+// WithAccounts 设置预置账户数量
+func WithAccounts(n int) Option { return func(c *Config) { c.Accounts = n } }
+
+// WithBalance 设置每个预置账户的初始余额
+func WithBalance(wei *big.Int) Option { return func(c *Config) { c.Balance = wei } }
+
+// WithAutoMine 开启自动出块
+func WithAutoMine() Option { return func(c *Config) { c.AutoMine = true } }
+
+// Chain 进程内测试链
+//
+// Chain 内嵌 *backends.SimulatedBackend，可直接作为 bind.ContractBackend 使用，
+// 并补充了 ethclient 中常用但模拟后端没有的方法（ChainID、NetworkID、BlockNumber、
+// PendingBalanceAt、BlockReceipts），区块查询也支持 safe、finalized 标签。
+type Chain struct {
+	*backends.SimulatedBackend
+	Accounts []*Account
+
+	t        testing.TB
+	autoMine bool
+
+	rpcOnce   sync.Once
+	rpcClient *rpc.Client
+}
+
+// Chain 可以直接传给 pkg 下的各功能库
+var (
+	_ backend.EthBackend           = (*Chain)(nil)
+	_ backend.BlockReceiptsReader  = (*Chain)(nil)
+	_ backend.PendingBalanceReader = (*Chain)(nil)
+	_ backend.FeeHistoryReader     = (*Chain)(nil)
+	_ backend.RPCClient            = (*Chain)(nil)
+)
+
+// New 启动一条新的测试链，测试结束时自动关闭
+func New(t testing.TB, opts ...Option) *Chain {
+	t.Helper()
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	alloc := core.GenesisAlloc{}
+	accounts := make([]*Account, cfg.Accounts)
+	for i := range accounts {
+		accounts[i] = deterministicAccount(i)
+		alloc[accounts[i].Address] = core.GenesisAccount{Balance: new(big.Int).Set(cfg.Balance)}
+	}
+
+	chain := &Chain{
+		SimulatedBackend: backends.NewSimulatedBackend(alloc, cfg.GasLimit),
+		Accounts:         accounts,
+		t:                t,
+		autoMine:         cfg.AutoMine,
+	}
+	t.Cleanup(func() { chain.Close() })
+	return chain
+}
+
+// deterministicAccount 由序号推导出固定的私钥，保证每次运行账户地址相同
+func deterministicAccount(i int) *Account {
+	seed := make([]byte, 8)
+	binary.BigEndian.PutUint64(seed, uint64(i))
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("simchain"), seed))
+	if err != nil {
+		panic(err)
+	}
+	return &Account{Key: key, Address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// ===== 出块控制 =====
+
+// SetAutoMine 切换自动出块模式
+func (c *Chain) SetAutoMine(on bool) { c.autoMine = on }
+
+// SendTransaction 发送交易；自动出块模式下立即打包
+//
+// 模拟后端在交易无法执行（如余额不足、Gas 上限低于固有消耗）时会直接 panic，
+// 这里把 panic 转换为错误返回，与真实节点拒绝交易的行为保持一致。
+func (c *Chain) SendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("交易无法执行: %v", r)
+		}
+	}()
+	if err := c.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if c.autoMine {
+		c.Commit()
+	}
+	return nil
+}
+
+// Mine 把待处理交易打包成一个新区块，返回区块哈希
+func (c *Chain) Mine() common.Hash {
+	return c.Commit()
+}
+
+// MineBlocks 连续产生 n 个区块，返回最后一个区块的哈希
+func (c *Chain) MineBlocks(n int) common.Hash {
+	var hash common.Hash
+	for i := 0; i < n; i++ {
+		hash = c.Commit()
+	}
+	return hash
+}
+
+// Warp 把下一个区块的时间戳向后推移 d，并立即出块
+// 待处理区块中不能有交易（模拟后端的限制）
+func (c *Chain) Warp(d time.Duration) {
+	c.t.Helper()
+	if err := c.AdjustTime(d); err != nil {
+		c.t.Fatal("调整区块时间失败:", err)
+	}
+	c.Commit()
+}
+
+// Head 返回当前规范链的最新区块头
+func (c *Chain) Head() *types.Header {
+	return c.Blockchain().CurrentBlock()
+}
+
+// Reorg 模拟链重组：丢弃最近 depth 个区块，在其祖先区块上构建一条更长的分叉链
+//
+// rebuild 在分叉链的第一个待处理区块上执行，可以在其中重新发送（或不发送）交易；
+// 之后会产生 depth+1 个区块，使分叉链成为规范链。返回重组前被替换的区块头。
+func (c *Chain) Reorg(depth int, rebuild func()) []*types.Header {
+	c.t.Helper()
+	head := c.Head().Number.Uint64()
+	if depth <= 0 || uint64(depth) > head {
+		c.t.Fatalf("无效的重组深度 %d（当前高度 %d）", depth, head)
+	}
+
+	dropped := make([]*types.Header, 0, depth)
+	for n := head - uint64(depth) + 1; n <= head; n++ {
+		dropped = append(dropped, c.Blockchain().GetHeaderByNumber(n))
+	}
+	ancestor := c.Blockchain().GetHeaderByNumber(head - uint64(depth))
+	if err := c.Fork(context.Background(), ancestor.Hash()); err != nil {
+		c.t.Fatal("创建分叉失败:", err)
+	}
+
+	autoMine := c.autoMine
+	c.autoMine = false
+	if rebuild != nil {
+		rebuild()
+	}
+	c.autoMine = autoMine
+	c.MineBlocks(depth + 1)
+	return dropped
+}
+
+// ===== ethclient 兼容方法 =====
+
+// ChainID 返回链 ID（模拟后端固定为 1337）
+func (c *Chain) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.Blockchain().Config().ChainID, nil
+}
+
+// NetworkID 返回网络 ID，与链 ID 相同
+func (c *Chain) NetworkID(ctx context.Context) (*big.Int, error) {
+	return c.ChainID(ctx)
+}
+
+// BlockNumber 返回最新区块号
+func (c *Chain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.Head().Number.Uint64(), nil
+}
+
+// PendingBalanceAt 返回账户在待处理区块中的余额
+//
+// 模拟后端没有直接暴露待处理状态，这里在待处理状态上执行一段
+// 只做 BALANCE 查询的合约创建代码，创建调用的返回值就是余额。
+func (c *Chain) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	code := append([]byte{0x73}, account.Bytes()...) // PUSH20 account
+	code = append(code,
+		0x31,       // BALANCE
+		0x60, 0x00, // PUSH1 0
+		0x52,       // MSTORE
+		0x60, 0x20, // PUSH1 32
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	)
+	out, err := c.PendingCallContract(ctx, ethereum.CallMsg{Data: code})
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(out), nil
+}
+
+// BlockReceipts 返回区块中全部交易的收据
+func (c *Chain) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = c.BlockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		var n *big.Int
+		if number >= 0 {
+			n = big.NewInt(number.Int64())
+		}
+		block, err = c.BlockByNumber(ctx, n)
+	} else {
+		return nil, errors.New("invalid block number or hash")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*types.Receipt, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		receipt, err := c.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// ===== 交易辅助方法 =====
+
+// TransferETH 按 eth-transfer 的方式构造、签名并发送一笔 ETH 转账
+func (c *Chain) TransferETH(from *Account, to common.Address, amount *big.Int) *types.Transaction {
+	c.t.Helper()
+	ctx := context.Background()
+	nonce, err := c.PendingNonceAt(ctx, from.Address)
+	if err != nil {
+		c.t.Fatal("获取nonce失败:", err)
+	}
+	gasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		c.t.Fatal("获取Gas价格失败:", err)
+	}
+	tx := types.NewTransaction(nonce, to, amount, 21000, gasPrice, nil)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(ChainID), from.Key)
+	if err != nil {
+		c.t.Fatal("交易签名失败:", err)
+	}
+	if err := c.SendTransaction(ctx, signed); err != nil {
+		c.t.Fatal("发送交易失败:", err)
+	}
+	return signed
+}
+
+// Receipt 返回已打包交易的收据，交易尚未打包时测试失败
+func (c *Chain) Receipt(hash common.Hash) *types.Receipt {
+	c.t.Helper()
+	receipt, err := c.TransactionReceipt(context.Background(), hash)
+	if err != nil {
+		c.t.Fatalf("获取交易 %s 的收据失败: %v", hash.Hex(), err)
+	}
+	return receipt
+}
+
+// Balance 返回账户在最新区块的余额
+func (c *Chain) Balance(addr common.Address) *big.Int {
+	c.t.Helper()
+	balance, err := c.BalanceAt(context.Background(), addr, nil)
+	if err != nil {
+		c.t.Fatal("查询余额失败:", err)
+	}
+	return balance
+}
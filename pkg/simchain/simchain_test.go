@@ -0,0 +1,153 @@
+package simchain_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+)
+
+var oneEther = big.NewInt(1e18)
+
+func TestFundedAccounts(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAccounts(3))
+	if len(chain.Accounts) != 3 {
+		t.Fatalf("got %d accounts, want 3", len(chain.Accounts))
+	}
+	want := new(big.Int).Mul(big.NewInt(100), oneEther)
+	for i, acc := range chain.Accounts {
+		if bal := chain.Balance(acc.Address); bal.Cmp(want) != 0 {
+			t.Errorf("account %d balance = %s, want %s", i, bal, want)
+		}
+	}
+
+	// 账户由序号确定，两条链上的地址相同
+	other := simchain.New(t, simchain.WithAccounts(1))
+	if other.Accounts[0].Address != chain.Accounts[0].Address {
+		t.Fatal("accounts are not deterministic")
+	}
+
+	ctx := context.Background()
+	if id, _ := chain.ChainID(ctx); id.Cmp(simchain.ChainID) != 0 {
+		t.Errorf("chainID = %s, want %s", id, simchain.ChainID)
+	}
+	if id, _ := chain.NetworkID(ctx); id.Cmp(simchain.ChainID) != 0 {
+		t.Errorf("networkID = %s, want %s", id, simchain.ChainID)
+	}
+}
+
+func TestManualMining(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	tx := chain.TransferETH(alice, bob.Address, oneEther)
+	if _, err := chain.TransactionReceipt(ctx, tx.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("receipt before mining: err = %v, want NotFound", err)
+	}
+	// 待处理余额已包含这笔转账，最新区块的余额还没有
+	pending, err := chain.PendingBalanceAt(ctx, bob.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := new(big.Int).Sub(pending, chain.Balance(bob.Address)); diff.Cmp(oneEther) != 0 {
+		t.Fatalf("pending - latest = %s, want %s", diff, oneEther)
+	}
+
+	chain.Mine()
+	if n, _ := chain.BlockNumber(ctx); n != 1 {
+		t.Fatalf("block number = %d, want 1", n)
+	}
+	if receipt := chain.Receipt(tx.Hash()); receipt.BlockNumber.Uint64() != 1 {
+		t.Fatalf("receipt block = %d, want 1", receipt.BlockNumber)
+	}
+
+	chain.MineBlocks(4)
+	if n, _ := chain.BlockNumber(ctx); n != 5 {
+		t.Fatalf("block number = %d, want 5", n)
+	}
+}
+
+func TestAutoMine(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	for i := 0; i < 3; i++ {
+		tx := chain.TransferETH(alice, bob.Address, oneEther)
+		if receipt := chain.Receipt(tx.Hash()); receipt.BlockNumber.Uint64() != uint64(i+1) {
+			t.Fatalf("tx %d mined in block %d, want %d", i, receipt.BlockNumber, i+1)
+		}
+	}
+	receipts, err := chain.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithNumber(2))
+	if err != nil || len(receipts) != 1 {
+		t.Fatalf("block receipts = %d, %v; want 1", len(receipts), err)
+	}
+}
+
+func TestWarp(t *testing.T) {
+	chain := simchain.New(t)
+	before := chain.Head().Time
+	chain.Warp(time.Hour)
+	if got := chain.Head().Time - before; got < 3600 {
+		t.Fatalf("time advanced %ds, want at least 3600", got)
+	}
+}
+
+func TestReorg(t *testing.T) {
+	chain := simchain.New(t)
+	ctx := context.Background()
+	alice, bob, carol := chain.Accounts[0], chain.Accounts[1], chain.Accounts[2]
+
+	chain.Mine()
+	tx := chain.TransferETH(alice, bob.Address, oneEther)
+	chain.Mine()
+	orphaned := chain.Receipt(tx.Hash()).BlockHash
+
+	// 在分叉链上把同一 nonce 的转账改发给 carol，原交易被替换
+	dropped := chain.Reorg(1, func() {
+		chain.TransferETH(alice, carol.Address, oneEther)
+	})
+	if len(dropped) != 1 || dropped[0].Hash() != orphaned {
+		t.Fatalf("dropped headers = %v, want [%s]", dropped, orphaned.Hex())
+	}
+	if n, _ := chain.BlockNumber(ctx); n != 3 {
+		t.Fatalf("block number after reorg = %d, want 3", n)
+	}
+	if _, err := chain.TransactionReceipt(ctx, tx.Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("orphaned receipt: err = %v, want NotFound", err)
+	}
+	want := new(big.Int).Mul(big.NewInt(101), oneEther)
+	if bal := chain.Balance(carol.Address); bal.Cmp(want) != 0 {
+		t.Fatalf("carol balance = %s, want %s", bal, want)
+	}
+	if bal := chain.Balance(bob.Address); bal.Cmp(new(big.Int).Mul(big.NewInt(100), oneEther)) != 0 {
+		t.Fatalf("bob balance = %s, transfer should have been reorged out", bal)
+	}
+}
+
+func TestSendTransactionRejectsInvalid(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAccounts(2), simchain.WithBalance(big.NewInt(1e18)))
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+
+	// 余额不足：模拟后端会 panic，Chain 应返回错误且链状态不受影响
+	gasPrice, _ := chain.SuggestGasPrice(ctx)
+	tx := types.NewTransaction(0, bob.Address, big.NewInt(2e18), 21000, gasPrice, nil)
+	signed, _ := types.SignTx(tx, types.NewEIP155Signer(simchain.ChainID), alice.Key)
+	if err := chain.SendTransaction(ctx, signed); err == nil {
+		t.Fatal("expected error for transfer exceeding balance")
+	}
+
+	chain.TransferETH(alice, bob.Address, big.NewInt(1))
+	chain.Mine()
+	if n, _ := chain.NonceAt(ctx, alice.Address, nil); n != 1 {
+		t.Fatalf("nonce after rejected tx = %d, want 1", n)
+	}
+}
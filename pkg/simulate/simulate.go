@@ -0,0 +1,517 @@
+// Package simulate 在签名发送之前模拟执行交易，预览执行结果
+//
+// 模拟使用与真实交易完全相同的发送方、接收方、金额、数据和 Gas 上限。与 eth_call 一样不带 Gas 价格执行，
+// 避免交易实际打包的区块与最新区块基础费不同造成的误报；手续费按 Gas 消耗 × 交易的 Gas 价格另行计入，
+// 并单独检查发送方余额是否足以支付金额和最大手续费：
+//   - 节点支持 debug_traceCall 时，用 callTracer 得到调用树和事件日志，用 prestateTracer
+//     得到 ETH 余额变化，结果与交易在当前区块上执行时一致
+//   - 不支持时退回 eth_call + EstimateGas：只能得到成功与否、回滚原因和预计 Gas，
+//     ETH 变化按金额和手续费计算，ERC20 变化和事件按调用数据（transfer、transferFrom）推算
+//
+// 模拟基于最新区块的状态，交易实际打包时状态可能已经变化，结果仅供参考。
+//
+// 把 Confirm 返回的函数设置到 backend.TxOptions.Confirm，transfer、contract 等功能库就会在签名前
+// 先模拟、打印结果并请用户确认：
+//
+//	sim, _ := simulate.New(client)
+//	opts := &backend.TxOptions{Confirm: sim.Confirm(dryRun, force, os.Stdin, os.Stdout)}
+//	_, err := transfer.SendToken(ctx, client, signer, token, to, amount, opts)
+//	if errors.Is(err, simulate.ErrDryRun) { ... }
+package simulate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/query"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/trace"
+)
+
+var (
+	// ErrDryRun dry-run 模式下模拟后不发送交易
+	ErrDryRun = errors.New("dry-run 模式，交易未发送")
+	// ErrCancelled 用户没有确认发送
+	ErrCancelled = errors.New("已取消，交易未发送")
+	// ErrWillFail 模拟执行失败且没有要求强制发送
+	ErrWillFail = errors.New("模拟执行失败，交易未发送")
+)
+
+// transferTopic ERC20 Transfer(address,address,uint256) 事件的 topics[0]
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// ContractABI 用于解码调用、事件和自定义错误的 ABI
+type ContractABI struct {
+	Name string
+	ABI  string
+}
+
+// Config 模拟器配置
+type Config struct {
+	ABIs       []ContractABI         // 登记的 ABI，默认包含通用 ERC20 ABI
+	Signatures *calldata.SignatureDB // 没有 ABI 时使用的 4 字节签名库
+	Trace      bool                  // 节点支持时使用 debug_traceCall
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() Config {
+	return Config{
+		ABIs:       []ContractABI{{Name: "ERC20", ABI: mytoken.MyTokenMetaData.ABI}},
+		Signatures: calldata.DefaultSignatures(),
+		Trace:      true,
+	}
+}
+
+// Option 修改 Config 的函数
+type Option func(*Config)
+
+// WithABI 登记合约 ABI，用于解码调用、事件和自定义错误
+func WithABI(name, abiJSON string) Option {
+	return func(c *Config) { c.ABIs = append(c.ABIs, ContractABI{Name: name, ABI: abiJSON}) }
+}
+
+// WithoutTrace 不使用 debug_traceCall，只用 eth_call 和 EstimateGas 模拟
+func WithoutTrace() Option { return func(c *Config) { c.Trace = false } }
+
+// namedABI 已解析的 ABI
+type namedABI struct {
+	name string
+	abi  abi.ABI
+}
+
+// Simulator 交易模拟器
+type Simulator struct {
+	backend backend.EthBackend
+	cfg     Config
+	abis    []*namedABI
+	reverts *revert.Decoder
+	tracer  *trace.Tracer // 后端不是 JSON-RPC 连接或不使用追踪时为 nil
+}
+
+// New 创建模拟器，ABI 解析失败时返回错误
+func New(b backend.EthBackend, opts ...Option) (*Simulator, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s := &Simulator{backend: b, cfg: cfg, reverts: revert.NewDecoder(cfg.Signatures)}
+	calls := calldata.NewDecoder(cfg.Signatures)
+	for _, c := range cfg.ABIs {
+		parsed, err := abi.JSON(strings.NewReader(c.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 的 ABI 失败: %w", c.Name, err)
+		}
+		s.abis = append(s.abis, &namedABI{name: c.Name, abi: parsed})
+		if err := calls.AddABI(c.Name, c.ABI); err != nil {
+			return nil, err
+		}
+		if err := s.reverts.AddABI(c.Name, c.ABI); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Trace {
+		s.tracer, _ = trace.FromBackend(b, trace.WithCalls(calls), trace.WithReverts(s.reverts))
+	}
+	return s, nil
+}
+
+// Result 模拟执行的结果
+type Result struct {
+	From     common.Address
+	Tx       *types.Transaction
+	Success  bool
+	Reason   *revert.Reason // 失败时的原因
+	GasUsed  uint64         // 追踪时为实际消耗，否则为 EstimateGas 的估算值
+	Fee      *big.Int       // GasUsed × Gas 价格
+	Traced   bool           // 是否通过 debug_traceCall 得到调用树、事件和状态变化
+	Trace    *trace.Frame   // 调用树，仅 Traced 时有值
+	Changes  []*BalanceChange
+	Events   []*Event
+	Inferred bool // 余额变化和事件是按调用数据推算的，而不是实际执行得到的
+}
+
+// BalanceChange 一个账户在 ETH 或某个 ERC20 代币上的余额变化
+type BalanceChange struct {
+	Account  common.Address
+	Token    *common.Address // nil 表示 ETH
+	Symbol   string
+	Decimals uint8
+	Before   *big.Int // 查询失败（如不是标准 ERC20）时为 nil
+	Delta    *big.Int
+}
+
+// After 返回变化后的余额，Before 未知时返回 nil
+func (c *BalanceChange) After() *big.Int {
+	if c.Before == nil {
+		return nil
+	}
+	return new(big.Int).Add(c.Before, c.Delta)
+}
+
+// Event 交易会产生的事件
+type Event struct {
+	Address   common.Address
+	Name      string // 无法解码时为空
+	Signature string
+	Args      []calldata.Arg
+	Log       *trace.Log
+}
+
+// Simulate 模拟执行 from 发出的交易 tx（签名前的交易即可）
+func (s *Simulator) Simulate(ctx context.Context, from common.Address, tx *types.Transaction) (*Result, error) {
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	res := &Result{From: from, Tx: tx}
+
+	// ===== 优先用 debug_traceCall 完整执行 =====
+	var (
+		logs []*trace.Log
+		diff trace.StateDiff
+	)
+	if s.tracer != nil {
+		root, err := s.tracer.TraceCall(ctx, msg, nil)
+		switch {
+		case err == nil:
+			res.Traced, res.Trace = true, root
+			res.Success, res.GasUsed = !root.Failed(), uint64(root.GasUsed)
+			res.Reason = origin(root)
+			logs = root.AllLogs()
+			if diff, err = s.tracer.CallStateDiff(ctx, msg, nil); err != nil {
+				return nil, err
+			}
+		case !errors.Is(err, trace.ErrNotSupported):
+			return nil, err
+		}
+	}
+
+	// ===== 退回 eth_call + EstimateGas =====
+	if !res.Traced {
+		if _, err := s.backend.CallContract(ctx, msg, nil); err != nil {
+			reason := s.reverts.FromError(err)
+			if reason == nil {
+				return nil, fmt.Errorf("模拟执行失败: %w", err)
+			}
+			res.Reason = reason
+		} else {
+			res.Success = true
+			estimate := msg
+			estimate.Gas = 0
+			if gas, err := s.backend.EstimateGas(ctx, estimate); err == nil {
+				res.GasUsed = gas
+			} else {
+				res.GasUsed = tx.Gas()
+			}
+			logs, res.Inferred = s.inferLogs(from, tx), true
+		}
+	}
+	res.Fee = new(big.Int).Mul(new(big.Int).SetUint64(res.GasUsed), tx.GasPrice())
+
+	// ===== 检查余额是否足以支付金额和最大手续费 =====
+	balance, err := s.backend.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("查询 %s 的余额失败: %w", from.Hex(), err)
+	}
+	// Gas 上限尚未估算（为 0）时按模拟的 Gas 消耗计算手续费
+	cost := tx.Cost()
+	if tx.Gas() == 0 {
+		cost = new(big.Int).Add(tx.Value(), res.Fee)
+	}
+	if cost.Cmp(balance) > 0 && res.Success {
+		res.Success = false
+		res.Reason = &revert.Reason{
+			Kind:    revert.KindFailure,
+			Message: fmt.Sprintf("余额不足: 需要 %s ETH（金额 + Gas 上限 × Gas 价格），当前 %s ETH", formatUnits(cost, 18), formatUnits(balance, 18)),
+		}
+	}
+
+	for _, l := range logs {
+		res.Events = append(res.Events, s.decodeEvent(l))
+	}
+	if err := s.balanceChanges(ctx, res, logs, diff); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// origin 返回最初出错那一层的回滚原因
+func origin(root *trace.Frame) *revert.Reason {
+	var reason *revert.Reason
+	root.Walk(func(f *trace.Frame, _ int) {
+		if reason == nil && f.Origin() {
+			reason = f.Reason
+		}
+	})
+	if reason == nil {
+		reason = root.Reason
+	}
+	return reason
+}
+
+// inferLogs 没有追踪时，按 ERC20 transfer / transferFrom 的调用数据推算 Transfer 事件
+func (s *Simulator) inferLogs(sender common.Address, tx *types.Transaction) []*trace.Log {
+	if tx.To() == nil || len(tx.Data()) < 4 {
+		return nil
+	}
+	var erc20 *abi.ABI
+	for _, a := range s.abis {
+		if _, ok := a.abi.Events["Transfer"]; ok {
+			erc20 = &a.abi
+			break
+		}
+	}
+	if erc20 == nil {
+		return nil
+	}
+	method, err := erc20.MethodById(tx.Data()[:4])
+	if err != nil {
+		return nil
+	}
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		return nil
+	}
+	var from, to common.Address
+	var amount *big.Int
+	switch method.Sig {
+	case "transfer(address,uint256)":
+		from, to, amount = sender, args[0].(common.Address), args[1].(*big.Int)
+	case "transferFrom(address,address,uint256)":
+		from, to, amount = args[0].(common.Address), args[1].(common.Address), args[2].(*big.Int)
+	default:
+		return nil
+	}
+	return []*trace.Log{{
+		Address: *tx.To(),
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.LeftPadBytes(amount.Bytes(), 32),
+	}}
+}
+
+// decodeEvent 按登记的 ABI 解码事件日志
+func (s *Simulator) decodeEvent(l *trace.Log) *Event {
+	ev := &Event{Address: l.Address, Log: l}
+	if len(l.Topics) == 0 {
+		return ev
+	}
+	for _, a := range s.abis {
+		event, err := a.abi.EventByID(l.Topics[0])
+		if err != nil {
+			continue
+		}
+		var indexed abi.Arguments
+		for _, arg := range event.Inputs {
+			if arg.Indexed {
+				indexed = append(indexed, arg)
+			}
+		}
+		fields := make(map[string]interface{})
+		// ERC20 与 ERC721 的 Transfer 签名相同但 indexed 参数个数不同
+		if len(indexed) != len(l.Topics)-1 || event.Inputs.NonIndexed().UnpackIntoMap(fields, l.Data) != nil ||
+			abi.ParseTopicsIntoMap(fields, indexed, l.Topics[1:]) != nil {
+			continue
+		}
+		ev.Name, ev.Signature = event.Name, event.Sig
+		for _, arg := range event.Inputs {
+			ev.Args = append(ev.Args, calldata.Arg{Name: arg.Name, Type: arg.Type.String(), Value: fields[arg.Name]})
+		}
+		break
+	}
+	return ev
+}
+
+// balanceChanges 汇总发送方、接收方以及 ERC20 转账涉及账户的余额变化
+func (s *Simulator) balanceChanges(ctx context.Context, res *Result, logs []*trace.Log, diff trace.StateDiff) error {
+	accounts := []common.Address{res.From}
+	if to := res.Tx.To(); to != nil && *to != res.From {
+		accounts = append(accounts, *to)
+	}
+
+	// ERC20 Transfer 事件：按 (代币, 账户) 累加
+	type key struct{ token, account common.Address }
+	var order []key
+	deltas := make(map[key]*big.Int)
+	add := func(k key, v *big.Int) {
+		if deltas[k] == nil {
+			deltas[k] = new(big.Int)
+			order = append(order, k)
+		}
+		deltas[k].Add(deltas[k], v)
+	}
+	for _, l := range logs {
+		if len(l.Topics) != 3 || l.Topics[0] != transferTopic || len(l.Data) != 32 {
+			continue
+		}
+		amount := new(big.Int).SetBytes(l.Data)
+		add(key{l.Address, common.BytesToAddress(l.Topics[1].Bytes())}, new(big.Int).Neg(amount))
+		add(key{l.Address, common.BytesToAddress(l.Topics[2].Bytes())}, amount)
+	}
+
+	// ETH：追踪时取状态变化（不含手续费），否则按金额计算；发送方再减去手续费
+	for _, addr := range accounts {
+		change := &BalanceChange{Account: addr, Symbol: "ETH", Decimals: 18, Delta: new(big.Int)}
+		if res.Traced {
+			if d := diff.Account(addr); d != nil && d.BalanceAfter != nil {
+				change.Before, change.Delta = d.BalanceBefore, new(big.Int).Sub(d.BalanceAfter, d.BalanceBefore)
+			}
+		} else if res.Success {
+			if to := res.Tx.To(); to != nil && *to == addr {
+				change.Delta.Add(change.Delta, res.Tx.Value())
+			}
+			if addr == res.From {
+				change.Delta.Sub(change.Delta, res.Tx.Value())
+			}
+		}
+		if addr == res.From {
+			change.Delta.Sub(change.Delta, res.Fee)
+		}
+		if change.Delta.Sign() == 0 {
+			continue
+		}
+		if change.Before == nil {
+			before, err := s.backend.BalanceAt(ctx, addr, nil)
+			if err != nil {
+				return fmt.Errorf("查询 %s 的余额失败: %w", addr.Hex(), err)
+			}
+			change.Before = before
+		}
+		res.Changes = append(res.Changes, change)
+	}
+
+	for _, k := range order {
+		if deltas[k].Sign() == 0 {
+			continue
+		}
+		token := k.token
+		change := &BalanceChange{Account: k.account, Token: &token, Delta: deltas[k]}
+		if info, err := query.TokenBalance(ctx, s.backend, k.token, k.account); err == nil {
+			change.Before, change.Symbol, change.Decimals = info.Raw, info.Symbol, info.Decimals
+		}
+		res.Changes = append(res.Changes, change)
+	}
+	return nil
+}
+
+// String 把模拟结果渲染为文本
+func (r *Result) String() string {
+	var buf bytes.Buffer
+	if r.Success {
+		fmt.Fprintln(&buf, "模拟结果: ✓ 执行成功")
+	} else {
+		fmt.Fprintln(&buf, "模拟结果: ✗ 执行失败")
+		if r.Reason != nil {
+			fmt.Fprintf(&buf, "失败原因: %s\n", r.Reason)
+		}
+	}
+	if r.Tx.Gas() == 0 {
+		fmt.Fprintf(&buf, "Gas: %d（上限在发送前估算），手续费 %s ETH\n", r.GasUsed, formatUnits(r.Fee, 18))
+	} else {
+		fmt.Fprintf(&buf, "Gas: %d（上限 %d），手续费 %s ETH\n", r.GasUsed, r.Tx.Gas(), formatUnits(r.Fee, 18))
+	}
+
+	if len(r.Changes) > 0 {
+		fmt.Fprintln(&buf, "余额变化:")
+		for _, c := range r.Changes {
+			symbol := c.Symbol
+			if symbol == "" {
+				symbol = c.Token.Hex()
+			}
+			sign := ""
+			if c.Delta.Sign() > 0 {
+				sign = "+"
+			}
+			if c.Before == nil {
+				fmt.Fprintf(&buf, "  %s %s: %s%s（最小单位）\n", c.Account.Hex(), symbol, sign, c.Delta)
+				continue
+			}
+			fmt.Fprintf(&buf, "  %s %s: %s → %s（%s%s）\n", c.Account.Hex(), symbol,
+				formatUnits(c.Before, c.Decimals), formatUnits(c.After(), c.Decimals), sign, formatUnits(c.Delta, c.Decimals))
+		}
+	}
+	if len(r.Events) > 0 {
+		fmt.Fprintln(&buf, "事件:")
+		for _, e := range r.Events {
+			if e.Name == "" {
+				fmt.Fprintf(&buf, "  %s 未知事件 %s\n", e.Address.Hex(), e.Log.Topics[0].Hex())
+				continue
+			}
+			args := make([]string, len(e.Args))
+			for i, arg := range e.Args {
+				args[i] = fmt.Sprintf("%s=%s", arg.Name, calldata.FormatValue(arg.Value))
+			}
+			fmt.Fprintf(&buf, "  %s %s(%s)\n", e.Address.Hex(), e.Name, strings.Join(args, ", "))
+		}
+	}
+	if r.Inferred {
+		fmt.Fprintln(&buf, "（节点不支持 debug_traceCall，代币余额变化和事件是按调用数据推算的）")
+	}
+	return buf.String()
+}
+
+// formatUnits 按精度把最小单位的数量精确地格式化为十进制小数
+func formatUnits(amount *big.Int, decimals uint8) string {
+	neg := amount.Sign() < 0
+	abs := new(big.Int).Abs(amount)
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(abs, unit, new(big.Int))
+	s := whole.String()
+	if frac.Sign() > 0 {
+		f := fmt.Sprintf("%0*s", int(decimals), frac.String())
+		s += "." + strings.TrimRight(f, "0")
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Confirm 返回用于 backend.TxOptions.Confirm 的函数：模拟交易并把结果写到 out，
+// dryRun 为 true 时返回 ErrDryRun；模拟执行失败时返回 ErrWillFail，force 为 true 才继续发送。
+// 之后从 in 读取一行确认，输入 y 或 yes 以外的内容返回 ErrCancelled；in 为 nil 时不询问，直接发送
+func (s *Simulator) Confirm(dryRun, force bool, in io.Reader, out io.Writer) func(ctx context.Context, from common.Address, tx *types.Transaction) error {
+	return func(ctx context.Context, from common.Address, tx *types.Transaction) error {
+		res, err := s.Simulate(ctx, from, tx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, res)
+		if dryRun {
+			return ErrDryRun
+		}
+		if !res.Success {
+			if !force {
+				return ErrWillFail
+			}
+			fmt.Fprintln(out, "⚠️ 模拟执行失败，发送后交易大概率会失败，并且仍然要支付 Gas 费用")
+		}
+		if in == nil {
+			return nil
+		}
+		fmt.Fprint(out, "确认发送？[y/N] ")
+		line, _ := bufio.NewReader(in).ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return nil
+		}
+		return ErrCancelled
+	}
+}
@@ -0,0 +1,189 @@
+package simulate_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/simulate"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+// noTrace 隐藏 simchain 的 RPC 客户端，模拟不支持 debug_traceCall 的后端
+type noTrace struct{ backend.EthBackend }
+
+func TestSimulateTokenTransfer(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	amount := new(big.Int).Mul(big.NewInt(15), big.NewInt(1e17)) // 1.5 MTK
+
+	for _, tc := range []struct {
+		name   string
+		b      backend.EthBackend
+		traced bool
+	}{
+		{"traced", chain, true},
+		{"fallback", noTrace{chain}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sim, err := simulate.New(tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx, err := backend.BuildTx(ctx, tc.b, alice.Address, &token, nil, transfer.EncodeTransfer(bob.Address, amount), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := sim.Simulate(ctx, alice.Address, tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !res.Success || res.Traced != tc.traced || res.Inferred == tc.traced || res.GasUsed == 0 {
+				t.Fatalf("result = %+v", res)
+			}
+			if want := new(big.Int).Mul(new(big.Int).SetUint64(res.GasUsed), tx.GasPrice()); res.Fee.Cmp(want) != 0 {
+				t.Fatalf("fee = %s, want %s", res.Fee, want)
+			}
+			if len(res.Events) != 1 || res.Events[0].Name != "Transfer" || res.Events[0].Address != token {
+				t.Fatalf("events = %+v", res.Events)
+			}
+
+			// 发送方付手续费，代币从 alice 转给 bob
+			var tokenDeltas []string
+			for _, c := range res.Changes {
+				if c.Token == nil {
+					if c.Account != alice.Address || c.Delta.Cmp(new(big.Int).Neg(res.Fee)) != 0 {
+						t.Fatalf("ETH change %s %s, fee %s", c.Account.Hex(), c.Delta, res.Fee)
+					}
+					continue
+				}
+				if c.Symbol != "MTK" || c.Decimals != 18 || c.Before == nil {
+					t.Fatalf("token change = %+v", c)
+				}
+				tokenDeltas = append(tokenDeltas, c.Account.Hex()+" "+c.Delta.String())
+			}
+			want := []string{alice.Address.Hex() + " -" + amount.String(), bob.Address.Hex() + " " + amount.String()}
+			if strings.Join(tokenDeltas, ",") != strings.Join(want, ",") {
+				t.Fatalf("token changes = %v, want %v", tokenDeltas, want)
+			}
+
+			out := res.String()
+			for _, s := range []string{"✓ 执行成功", "MTK: 0 → 1.5（+1.5）", "Transfer(from=" + alice.Address.Hex()} {
+				if !strings.Contains(out, s) {
+					t.Fatalf("output missing %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateRevert(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+
+	for _, b := range []backend.EthBackend{chain, noTrace{chain}} {
+		sim, err := simulate.New(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// bob 没有代币；固定 Gas 上限以跳过会失败的估算
+		tx, err := backend.BuildTx(ctx, b, bob.Address, &token, nil, transfer.EncodeTransfer(alice.Address, big.NewInt(1)),
+			&backend.TxOptions{GasLimit: 100_000})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := sim.Simulate(ctx, bob.Address, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Success || res.Reason == nil || res.Reason.Kind != revert.KindError ||
+			res.Reason.Message != "ERC20: transfer amount exceeds balance" || len(res.Events) != 0 {
+			t.Fatalf("result = %+v, reason = %+v", res, res.Reason)
+		}
+		if !strings.Contains(res.String(), "✗ 执行失败") {
+			t.Fatalf("output:\n%s", res)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	sim, err := simulate.New(chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount := big.NewInt(1e18)
+
+	// dry-run：打印模拟结果，不发送交易
+	var out bytes.Buffer
+	_, err = transfer.SendETH(ctx, chain, alice.Signer(), bob.Address, amount, &backend.TxOptions{Confirm: sim.Confirm(true, false, nil, &out)})
+	if !errors.Is(err, simulate.ErrDryRun) {
+		t.Fatalf("err = %v", err)
+	}
+	if nonce, _ := chain.NonceAt(ctx, alice.Address, nil); nonce != 0 {
+		t.Fatalf("dry run sent a transaction, nonce = %d", nonce)
+	}
+	if !strings.Contains(out.String(), "ETH: ") || !strings.Contains(out.String(), "（+1）") {
+		t.Fatalf("output:\n%s", out.String())
+	}
+
+	// 用户输入 n 取消
+	out.Reset()
+	_, err = transfer.SendETH(ctx, chain, alice.Signer(), bob.Address, amount,
+		&backend.TxOptions{Confirm: sim.Confirm(false, false, strings.NewReader("n\n"), &out)})
+	if !errors.Is(err, simulate.ErrCancelled) || !strings.Contains(out.String(), "确认发送？[y/N]") {
+		t.Fatalf("err = %v, output:\n%s", err, out.String())
+	}
+
+	// 用户输入 y 发送
+	before := chain.Balance(bob.Address)
+	res, err := transfer.SendETH(ctx, chain, alice.Signer(), bob.Address, amount,
+		&backend.TxOptions{Confirm: sim.Confirm(false, false, strings.NewReader("y\n"), &out)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Receipt(res.Tx.Hash())
+	if got := new(big.Int).Sub(chain.Balance(bob.Address), before); got.Cmp(amount) != 0 {
+		t.Fatalf("bob received %s", got)
+	}
+
+	// 会回滚的交易：即使不询问（-yes）也不发送；未指定 Gas 上限时先模拟，能看到回滚原因而不是估算失败
+	token, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	out.Reset()
+	_, err = transfer.SendToken(ctx, chain, bob.Signer(), token, alice.Address, big.NewInt(1),
+		&backend.TxOptions{Confirm: sim.Confirm(false, false, nil, &out)})
+	if !errors.Is(err, simulate.ErrWillFail) || !strings.Contains(out.String(), "transfer amount exceeds balance") {
+		t.Fatalf("err = %v, output:\n%s", err, out.String())
+	}
+	if nonce, _ := chain.NonceAt(ctx, bob.Address, nil); nonce != 0 {
+		t.Fatalf("failing transaction was sent, nonce = %d", nonce)
+	}
+	// -force 时继续，但估算 Gas 失败，需要手动指定 Gas 上限
+	_, err = transfer.SendToken(ctx, chain, bob.Signer(), token, alice.Address, big.NewInt(1),
+		&backend.TxOptions{Confirm: sim.Confirm(false, true, nil, &out)})
+	if err == nil || errors.Is(err, simulate.ErrWillFail) {
+		t.Fatalf("forced send without gas limit: err = %v", err)
+	}
+	res, err = transfer.SendToken(ctx, chain, bob.Signer(), token, alice.Address, big.NewInt(1),
+		&backend.TxOptions{GasLimit: 100_000, Confirm: sim.Confirm(false, true, nil, &out)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt := chain.Receipt(res.Tx.Hash()); receipt.Status != types.ReceiptStatusFailed {
+		t.Fatalf("forced transaction status = %d", receipt.Status)
+	}
+}
@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Layout solc 输出的存储布局（--storage-layout / combined-json 的 storage-layout）
+type Layout struct {
+	Storage []*Variable      `json:"storage"`
+	Types   map[string]*Type `json:"types"`
+}
+
+// Variable 一个状态变量或结构体成员
+type Variable struct {
+	Label  string `json:"label"`
+	Slot   string `json:"slot"`   // 十进制槽号；结构体成员为相对结构体起始槽的偏移
+	Offset int    `json:"offset"` // 在槽内的字节偏移，从低位（右侧）算起
+	Type   string `json:"type"`   // Layout.Types 中的类型 ID，如 t_mapping(t_address,t_uint256)
+}
+
+// Type 存储类型
+type Type struct {
+	Encoding      string      `json:"encoding"` // inplace、mapping、dynamic_array、bytes
+	Label         string      `json:"label"`    // Solidity 中的写法，如 mapping(address => uint256)
+	NumberOfBytes string      `json:"numberOfBytes"`
+	Key           string      `json:"key,omitempty"`     // 映射的键类型
+	Value         string      `json:"value,omitempty"`   // 映射的值类型
+	Base          string      `json:"base,omitempty"`    // 数组的元素类型
+	Members       []*Variable `json:"members,omitempty"` // 结构体成员
+}
+
+// size 返回类型占用的字节数
+func (t *Type) size() int {
+	n, _ := strconv.Atoi(t.NumberOfBytes)
+	return n
+}
+
+// ParseLayout 解析存储布局 JSON；旧版 solc 的 combined-json 把布局编码为字符串，也一并支持
+func ParseLayout(data []byte) (*Layout, error) {
+	data = []byte(strings.TrimSpace(string(data)))
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		data = []byte(s)
+	}
+	var l Layout
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("解析存储布局失败: %w", err)
+	}
+	if l.Types == nil {
+		l.Types = make(map[string]*Type)
+	}
+	return &l, nil
+}
+
+// LoadArtifact 从 solc --combined-json 的编译产物（如 contracts/build/MyToken.json）中读取合约的存储布局，
+// name 为合约名，产物中只有一个合约时可以为空
+func LoadArtifact(path, name string) (*Layout, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取编译产物失败: %w", err)
+	}
+	var artifact struct {
+		Contracts map[string]map[string]json.RawMessage `json:"contracts"`
+	}
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		return nil, fmt.Errorf("解析编译产物失败: %w", err)
+	}
+	var names []string
+	for key, c := range artifact.Contracts {
+		if _, ok := c["storage-layout"]; !ok {
+			continue
+		}
+		names = append(names, key)
+		// 键的格式为 源文件:合约名
+		if name != "" && (key == name || strings.HasSuffix(key, ":"+name)) {
+			return ParseLayout(c["storage-layout"])
+		}
+	}
+	sort.Strings(names)
+	if name == "" && len(names) == 1 {
+		return ParseLayout(artifact.Contracts[names[0]]["storage-layout"])
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%s 中有多个合约 %v，请指定合约名", path, names)
+	}
+	return nil, fmt.Errorf("%s 中没有合约 %s 的存储布局（已有 %v）", path, name, names)
+}
+
+// Location 变量在存储中的位置
+type Location struct {
+	Path   string
+	Slot   *big.Int
+	Offset int
+	Type   *Type
+	TypeID string
+}
+
+// SlotHash 返回 32 字节的槽位置
+func (l *Location) SlotHash() common.Hash { return common.BigToHash(l.Slot) }
+
+// Locate 计算表达式对应的存储位置。表达式由变量名、[键] 和 .成员 组成，例如：
+//
+//	_totalSupply
+//	_balances[0x4592d8f8d7b001e72cb26a73e4fa1806a51ac79d]
+//	_allowances[0xOwner][0xSpender]
+//	proposals[1].voteCount
+//	items[3]           // 静态或动态数组的第 3 个元素
+//	items.length       // 动态数组的长度所在的槽
+func (l *Layout) Locate(expr string) (*Location, error) {
+	name, rest := splitName(expr)
+	var v *Variable
+	for _, s := range l.Storage {
+		if s.Label == name {
+			v = s
+			break
+		}
+	}
+	if v == nil {
+		return nil, fmt.Errorf("没有状态变量 %q", name)
+	}
+	loc, err := l.variable(v, new(big.Int), name)
+	if err != nil {
+		return nil, err
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("表达式 %q 缺少 ]", expr)
+			}
+			if loc, err = l.index(loc, rest[1:end]); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		case '.':
+			var member string
+			member, rest = splitName(rest[1:])
+			if loc, err = l.member(loc, member); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("无法解析表达式 %q", expr)
+		}
+	}
+	return loc, nil
+}
+
+// splitName 拆出开头的标识符
+func splitName(s string) (string, string) {
+	i := strings.IndexAny(s, "[.")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// variable 计算变量（或结构体成员）相对 base 的位置
+func (l *Layout) variable(v *Variable, base *big.Int, path string) (*Location, error) {
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if !ok {
+		return nil, fmt.Errorf("变量 %s 的槽号 %q 无效", v.Label, v.Slot)
+	}
+	t, ok := l.Types[v.Type]
+	if !ok {
+		return nil, fmt.Errorf("布局中缺少类型 %s", v.Type)
+	}
+	return &Location{Path: path, Slot: slot.Add(slot, base), Offset: v.Offset, Type: t, TypeID: v.Type}, nil
+}
+
+// member 结构体成员或动态数组的 length
+func (l *Layout) member(loc *Location, name string) (*Location, error) {
+	if name == "length" && loc.Type.Encoding == "dynamic_array" {
+		return &Location{Path: loc.Path + ".length", Slot: loc.Slot, Type: &Type{Encoding: "inplace", Label: "uint256", NumberOfBytes: "32"}, TypeID: "t_uint256"}, nil
+	}
+	for _, m := range loc.Type.Members {
+		if m.Label == name {
+			return l.variable(m, loc.Slot, loc.Path+"."+name)
+		}
+	}
+	return nil, fmt.Errorf("%s（%s）没有成员 %s", loc.Path, loc.Type.Label, name)
+}
+
+// index 映射的键或数组的下标
+func (l *Layout) index(loc *Location, key string) (*Location, error) {
+	key = strings.TrimSpace(key)
+	path := fmt.Sprintf("%s[%s]", loc.Path, key)
+	switch {
+	case loc.Type.Encoding == "mapping":
+		encoded, err := encodeKey(loc.Type.Key, key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		t, ok := l.Types[loc.Type.Value]
+		if !ok {
+			return nil, fmt.Errorf("布局中缺少类型 %s", loc.Type.Value)
+		}
+		// 映射值位于 keccak256(键 . 槽)
+		slot := crypto.Keccak256(encoded, common.BigToHash(loc.Slot).Bytes())
+		return &Location{Path: path, Slot: new(big.Int).SetBytes(slot), Type: t, TypeID: loc.Type.Value}, nil
+	case loc.Type.Base != "":
+		i, ok := new(big.Int).SetString(key, 0)
+		if !ok || i.Sign() < 0 {
+			return nil, fmt.Errorf("%s: 无效的下标", path)
+		}
+		if n, static := staticLength(loc.Type); static && i.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("%s: 下标越界（长度 %s）", path, n)
+		}
+		elem, err := l.element(loc, i)
+		if err != nil {
+			return nil, err
+		}
+		elem.Path = path
+		return elem, nil
+	}
+	return nil, fmt.Errorf("%s（%s）不能用 [] 访问", loc.Path, loc.Type.Label)
+}
+
+// element 数组第 i 个元素的位置：元素不超过 16 字节时多个元素打包在同一个槽中，
+// 否则每个元素占 ceil(大小/32) 个槽；动态数组的数据从 keccak256(槽) 开始
+func (l *Layout) element(loc *Location, i *big.Int) (*Location, error) {
+	base, ok := l.Types[loc.Type.Base]
+	if !ok {
+		return nil, fmt.Errorf("布局中缺少类型 %s", loc.Type.Base)
+	}
+	start := new(big.Int).Set(loc.Slot)
+	if loc.Type.Encoding == "dynamic_array" {
+		start.SetBytes(crypto.Keccak256(common.BigToHash(loc.Slot).Bytes()))
+	}
+	size := base.size()
+	elem := &Location{Type: base, TypeID: loc.Type.Base}
+	if size <= 16 {
+		perSlot := big.NewInt(int64(32 / size))
+		q, r := new(big.Int).QuoRem(i, perSlot, new(big.Int))
+		elem.Slot = q.Add(q, start)
+		elem.Offset = int(r.Int64()) * size
+	} else {
+		slots := big.NewInt(int64((size + 31) / 32))
+		elem.Slot = new(big.Int).Add(start, new(big.Int).Mul(i, slots))
+	}
+	return elem, nil
+}
+
+// staticLength 从类型标签（如 uint256[3]）中取出静态数组的长度
+func staticLength(t *Type) (*big.Int, bool) {
+	if t.Encoding != "inplace" || !strings.HasSuffix(t.Label, "]") {
+		return nil, false
+	}
+	open := strings.LastIndexByte(t.Label, '[')
+	n, ok := new(big.Int).SetString(t.Label[open+1:len(t.Label)-1], 10)
+	return n, ok
+}
+
+// encodeKey 按键类型编码映射的键：值类型左填充（bytesN 右填充）到 32 字节，string 和 bytes 使用原始内容
+func encodeKey(typeID, key string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(typeID, "t_address"), strings.HasPrefix(typeID, "t_contract"):
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("无效的地址键 %q", key)
+		}
+		return common.LeftPadBytes(common.HexToAddress(key).Bytes(), 32), nil
+	case typeID == "t_bool":
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return nil, err
+		}
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case strings.HasPrefix(typeID, "t_uint"), strings.HasPrefix(typeID, "t_int"), strings.HasPrefix(typeID, "t_enum"):
+		n, ok := new(big.Int).SetString(key, 0)
+		if !ok {
+			return nil, fmt.Errorf("无效的整数键 %q", key)
+		}
+		if n.Sign() < 0 {
+			// 负数按 256 位补码编码
+			n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return common.BigToHash(n).Bytes(), nil
+	case strings.HasPrefix(typeID, "t_string"):
+		return []byte(key), nil
+	case strings.HasPrefix(typeID, "t_bytes"):
+		b, err := hexutil.Decode(key)
+		if err != nil {
+			return nil, fmt.Errorf("无效的字节键 %q: %w", key, err)
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(typeID, "t_bytes")); err == nil {
+			return common.RightPadBytes(b, 32), nil // bytesN
+		}
+		return b, nil // bytes
+	}
+	return nil, fmt.Errorf("不支持 %s 类型的键", typeID)
+}
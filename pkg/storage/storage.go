@@ -0,0 +1,321 @@
+// Package storage 按 Solidity 存储布局读取和解码合约的存储槽
+//
+// 合约的状态变量保存在 2^256 个 32 字节的存储槽中，eth_getStorageAt 只能按槽号读出原始字节。
+// 要知道某个变量在哪个槽、占槽内哪几个字节，需要 solc 输出的存储布局（storageLayout）：
+//   - 值类型按声明顺序从槽 0 开始排列，不足 32 字节的相邻变量打包在同一个槽中（如 MyToken 的 decimals 和 owner）
+//   - 映射本身占一个空槽，键 k 的值位于 keccak256(pad(k) . 槽号)
+//   - 动态数组的槽存长度，元素从 keccak256(槽号) 开始连续存放
+//   - string / bytes 不超过 31 字节时与长度一起放在本槽，否则槽中存 长度*2+1，内容从 keccak256(槽号) 开始
+//
+// Inspector 根据布局计算表达式（如 _balances[0x..]、_allowances[a][b]、proposals[1].name）对应的槽，
+// 读取并解码其中的值，也可以用 WithBlock 读取历史区块的状态（需要归档节点）。
+//
+//	layout, _ := storage.LoadArtifact("contracts/build/MyToken.json", "MyToken")
+//	in := storage.New(client, token, layout)
+//	v, err := in.Read(ctx, "_balances[0x..]")
+//	fmt.Print(v)
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrTooLong string / bytes 的长度异常，通常是布局与合约不匹配
+var ErrTooLong = errors.New("string/bytes 长度异常，请确认存储布局与合约一致")
+
+// DefaultArrayLimit 默认最多展开的数组元素个数
+const DefaultArrayLimit = 10
+
+// maxBytes 读取 string / bytes 的长度上限
+const maxBytes = 1 << 16
+
+// Reader 读取存储槽，backend.EthBackend 和 ethclient.Client 都满足
+type Reader interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Config 读取配置
+type Config struct {
+	Block      *big.Int // 读取哪个区块的状态，nil 表示最新区块
+	ArrayLimit int      // 最多展开的数组元素个数
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() Config {
+	return Config{ArrayLimit: DefaultArrayLimit}
+}
+
+// Option 修改 Config 的函数
+type Option func(*Config)
+
+// WithBlock 读取指定区块的状态
+func WithBlock(number *big.Int) Option { return func(c *Config) { c.Block = number } }
+
+// WithArrayLimit 设置最多展开的数组元素个数
+func WithArrayLimit(n int) Option { return func(c *Config) { c.ArrayLimit = n } }
+
+// Value 解码后的存储值
+type Value struct {
+	Path   string
+	Type   string      // 类型标签，如 uint256、mapping(address => uint256)
+	Slot   common.Hash // 所在的（第一个）槽
+	Offset int         // 在槽内的字节偏移
+	Raw    []byte      // 值类型为槽内对应的字节，string / bytes 为内容
+	Value  interface{} // common.Address、bool、*big.Int、string 或 []byte；复合类型为 nil
+	Length *big.Int    // 动态数组、string / bytes 的长度
+	Fields []*Value    // 结构体成员或数组元素
+	Note   string
+}
+
+// String 以缩进的树形格式显示
+func (v *Value) String() string {
+	var sb strings.Builder
+	v.write(&sb, "")
+	return sb.String()
+}
+
+func (v *Value) write(sb *strings.Builder, indent string) {
+	fmt.Fprintf(sb, "%s%s (%s) 槽 %s", indent, v.Path, v.Type, v.Slot.Hex())
+	if v.Offset > 0 {
+		fmt.Fprintf(sb, " 偏移 %d", v.Offset)
+	}
+	switch {
+	case v.Value != nil:
+		fmt.Fprintf(sb, " = %s", formatValue(v.Value))
+	case v.Length != nil:
+		fmt.Fprintf(sb, " 长度 %s", v.Length)
+	}
+	if v.Note != "" {
+		fmt.Fprintf(sb, "  [%s]", v.Note)
+	}
+	sb.WriteString("\n")
+	for _, f := range v.Fields {
+		f.write(sb, indent+"  ")
+	}
+}
+
+// formatValue 格式化解码后的值
+func formatValue(v interface{}) string {
+	switch x := v.(type) {
+	case common.Address:
+		return x.Hex()
+	case string:
+		return fmt.Sprintf("%q", x)
+	case []byte:
+		return hexutil.Encode(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// Inspector 按存储布局读取某个合约的状态
+type Inspector struct {
+	reader   Reader
+	contract common.Address
+	layout   *Layout
+	cfg      Config
+}
+
+// New 创建 Inspector
+func New(r Reader, contract common.Address, layout *Layout, opts ...Option) *Inspector {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Inspector{reader: r, contract: contract, layout: layout, cfg: cfg}
+}
+
+// Slot 读取一个原始存储槽
+func (in *Inspector) Slot(ctx context.Context, slot common.Hash) (common.Hash, error) {
+	raw, err := in.reader.StorageAt(ctx, in.contract, slot, in.cfg.Block)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("读取槽 %s 失败: %w", slot.Hex(), err)
+	}
+	return common.BytesToHash(raw), nil
+}
+
+// Read 读取并解码一个表达式，语法见 Layout.Locate
+func (in *Inspector) Read(ctx context.Context, expr string) (*Value, error) {
+	loc, err := in.layout.Locate(expr)
+	if err != nil {
+		return nil, err
+	}
+	return in.session().read(ctx, loc)
+}
+
+// Dump 读取所有状态变量；映射无法枚举，只列出位置，需要用 Read 指定键
+func (in *Inspector) Dump(ctx context.Context) ([]*Value, error) {
+	s := in.session()
+	out := make([]*Value, 0, len(in.layout.Storage))
+	for _, v := range in.layout.Storage {
+		loc, err := in.layout.variable(v, new(big.Int), v.Label)
+		if err != nil {
+			return nil, err
+		}
+		value, err := s.read(ctx, loc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
+
+// session 一次读取过程，缓存已读过的槽，打包在同一槽中的变量只读一次
+type session struct {
+	*Inspector
+	cache map[common.Hash]common.Hash
+}
+
+func (in *Inspector) session() *session {
+	return &session{Inspector: in, cache: make(map[common.Hash]common.Hash)}
+}
+
+func (s *session) word(ctx context.Context, slot *big.Int) (common.Hash, error) {
+	key := common.BigToHash(slot)
+	if w, ok := s.cache[key]; ok {
+		return w, nil
+	}
+	w, err := s.Slot(ctx, key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	s.cache[key] = w
+	return w, nil
+}
+
+// read 按类型的编码方式读取一个位置
+func (s *session) read(ctx context.Context, loc *Location) (*Value, error) {
+	v := &Value{Path: loc.Path, Type: loc.Type.Label, Slot: loc.SlotHash(), Offset: loc.Offset}
+	switch {
+	case loc.Type.Encoding == "mapping":
+		v.Note = fmt.Sprintf("映射需要指定键，如 %s[键]", loc.Path)
+	case loc.Type.Encoding == "bytes":
+		return v, s.readBytes(ctx, loc, v)
+	case loc.Type.Encoding == "dynamic_array":
+		w, err := s.word(ctx, loc.Slot)
+		if err != nil {
+			return nil, err
+		}
+		v.Length = w.Big()
+		return v, s.readElements(ctx, loc, v, v.Length)
+	case loc.Type.Members != nil:
+		for _, m := range loc.Type.Members {
+			mloc, err := s.layout.variable(m, loc.Slot, loc.Path+"."+m.Label)
+			if err != nil {
+				return nil, err
+			}
+			field, err := s.read(ctx, mloc)
+			if err != nil {
+				return nil, err
+			}
+			v.Fields = append(v.Fields, field)
+		}
+	case loc.Type.Base != "":
+		n, _ := staticLength(loc.Type)
+		v.Length = n
+		return v, s.readElements(ctx, loc, v, n)
+	default:
+		w, err := s.word(ctx, loc.Slot)
+		if err != nil {
+			return nil, err
+		}
+		size := loc.Type.size()
+		if size <= 0 || loc.Offset+size > 32 {
+			return nil, fmt.Errorf("%s: 无效的大小 %d / 偏移 %d", loc.Path, size, loc.Offset)
+		}
+		v.Raw = w[32-loc.Offset-size : 32-loc.Offset]
+		v.Value = decode(loc.TypeID, v.Raw)
+	}
+	return v, nil
+}
+
+// readElements 展开数组的前 ArrayLimit 个元素
+func (s *session) readElements(ctx context.Context, loc *Location, v *Value, length *big.Int) error {
+	n := length.Int64()
+	if !length.IsInt64() || n > int64(s.cfg.ArrayLimit) {
+		n = int64(s.cfg.ArrayLimit)
+		v.Note = fmt.Sprintf("只显示前 %d 个元素", n)
+	}
+	for i := int64(0); i < n; i++ {
+		eloc, err := s.layout.element(loc, big.NewInt(i))
+		if err != nil {
+			return err
+		}
+		eloc.Path = fmt.Sprintf("%s[%d]", loc.Path, i)
+		elem, err := s.read(ctx, eloc)
+		if err != nil {
+			return err
+		}
+		v.Fields = append(v.Fields, elem)
+	}
+	return nil
+}
+
+// readBytes 读取 string / bytes：最低位为 0 时是短格式，内容和 长度*2 在同一个槽；
+// 为 1 时槽中存 长度*2+1，内容从 keccak256(槽号) 开始
+func (s *session) readBytes(ctx context.Context, loc *Location, v *Value) error {
+	w, err := s.word(ctx, loc.Slot)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if w[31]&1 == 0 {
+		n := int(w[31]) / 2
+		if n > 31 {
+			return fmt.Errorf("%s: %w", loc.Path, ErrTooLong)
+		}
+		data = append(data, w[:n]...)
+	} else {
+		length := new(big.Int).Rsh(w.Big(), 1)
+		if !length.IsInt64() || length.Int64() > maxBytes {
+			return fmt.Errorf("%s: %w", loc.Path, ErrTooLong)
+		}
+		n := int(length.Int64())
+		start := new(big.Int).SetBytes(crypto.Keccak256(loc.SlotHash().Bytes()))
+		for i := 0; len(data) < n; i++ {
+			chunk, err := s.word(ctx, new(big.Int).Add(start, big.NewInt(int64(i))))
+			if err != nil {
+				return err
+			}
+			data = append(data, chunk[:]...)
+		}
+		data = data[:n]
+	}
+	v.Raw = data
+	v.Length = big.NewInt(int64(len(data)))
+	if strings.HasPrefix(loc.TypeID, "t_string") {
+		v.Value = string(data)
+	} else {
+		v.Value = data
+	}
+	return nil
+}
+
+// decode 按类型 ID 解码槽内的值类型字节
+func decode(typeID string, b []byte) interface{} {
+	switch {
+	case strings.HasPrefix(typeID, "t_address"), strings.HasPrefix(typeID, "t_contract"):
+		return common.BytesToAddress(b)
+	case typeID == "t_bool":
+		return b[len(b)-1] != 0
+	case strings.HasPrefix(typeID, "t_uint"), strings.HasPrefix(typeID, "t_enum"):
+		return new(big.Int).SetBytes(b)
+	case strings.HasPrefix(typeID, "t_int"):
+		// 有符号整数按补码存储，最高位为 1 时是负数
+		n := new(big.Int).SetBytes(b)
+		if b[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+		}
+		return n
+	}
+	// bytesN、用户定义值类型、函数类型等显示原始字节
+	return append([]byte(nil), b...)
+}
@@ -0,0 +1,211 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/storage"
+)
+
+const longName = "A Token Name Longer Than Thirty One Bytes"
+
+func TestMyToken(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	addr, token := chain.DeployMyToken(alice, longName, "MTK", 6, big.NewInt(1000))
+	if _, err := token.Approve(alice.Opts(), bob.Address, big.NewInt(77)); err != nil {
+		t.Fatal(err)
+	}
+	before := chain.Head().Number
+	if _, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	layout, err := storage.LoadArtifact("../../contracts/build/MyToken.json", "MyToken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := storage.New(chain, addr, layout)
+	supply, _ := token.TotalSupply(nil)
+	aliceBalance, _ := token.BalanceOf(nil, alice.Address)
+	for expr, want := range map[string]interface{}{
+		"_totalSupply":                           supply,
+		"decimals":                               big.NewInt(6),
+		"owner":                                  alice.Address, // 与 decimals 打包在槽 5，偏移 1
+		"name":                                   longName,      // 超过 31 字节，内容在 keccak256(3) 开始的槽中
+		"symbol":                                 "MTK",
+		"_balances[" + alice.Address.Hex() + "]": aliceBalance,
+		"_balances[" + bob.Address.Hex() + "]":   big.NewInt(100),
+		"_allowances[" + alice.Address.Hex() + "][" + bob.Address.Hex() + "]": big.NewInt(77),
+	} {
+		v, err := in.Read(ctx, expr)
+		if err != nil {
+			t.Fatalf("%s: %v", expr, err)
+		}
+		if formatted(v.Value) != formatted(want) {
+			t.Fatalf("%s = %v, want %v", expr, v.Value, want)
+		}
+	}
+	owner, _ := in.Read(ctx, "owner")
+	if owner.Offset != 1 || owner.Slot != common.BigToHash(big.NewInt(5)) {
+		t.Fatalf("owner at slot %s offset %d", owner.Slot, owner.Offset)
+	}
+
+	// 历史区块中 bob 还没有收到转账
+	old, err := storage.New(chain, addr, layout, storage.WithBlock(before)).Read(ctx, "_balances["+bob.Address.Hex()+"]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.Value.(*big.Int).Sign() != 0 {
+		t.Fatalf("balance at block %s = %v", before, old.Value)
+	}
+
+	values, err := in.Dump(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(layout.Storage) || values[0].Note == "" || values[0].Value != nil {
+		t.Fatalf("dump = %v", values)
+	}
+
+	for _, expr := range []string{"missing", "_balances[0x12]", "_totalSupply[1]", "owner.x"} {
+		if _, err := in.Read(ctx, expr); err == nil {
+			t.Fatalf("%s: expected error", expr)
+		}
+	}
+}
+
+func TestStructs(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	contracts := chain.DeployContracts()
+	voting := contracts.Voting
+	if _, err := voting.AddProposal(alice.Opts(), "first"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voting.AddProposal(alice.Opts(), "second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voting.RegisterVoter(alice.Opts(), bob.Address); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := voting.Vote(bob.Opts(), big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	layout, err := storage.LoadArtifact("../../contracts/build/contract-templates.json", "Voting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := storage.New(chain, contracts.Addresses["Voting"].Address, layout)
+	v, err := in.Read(ctx, "proposals[1]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Fields) != 3 || v.Fields[0].Value != "second" || v.Fields[1].Value.(*big.Int).Int64() != 1 || v.Fields[2].Value != true {
+		t.Fatalf("proposals[1] =\n%s", v)
+	}
+	voter, err := in.Read(ctx, "voters["+bob.Address.Hex()+"].proposalId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voter.Value.(*big.Int).Int64() != 1 {
+		t.Fatalf("proposalId = %v", voter.Value)
+	}
+	if !strings.Contains(v.String(), `proposals[1].name (string) 槽`) {
+		t.Fatalf("output:\n%s", v)
+	}
+}
+
+// memory 内存中的存储，用于构造数组等测试合约中没有的布局
+type memory map[common.Hash]common.Hash
+
+func (m memory) StorageAt(_ context.Context, _ common.Address, key common.Hash, _ *big.Int) ([]byte, error) {
+	w := m[key]
+	return w[:], nil
+}
+
+func TestArrays(t *testing.T) {
+	layout, err := storage.ParseLayout([]byte(`{
+		"storage": [
+			{"label": "fixed", "slot": "0", "offset": 0, "type": "t_array(t_uint256)2_storage"},
+			{"label": "small", "slot": "2", "offset": 0, "type": "t_array(t_uint64)dyn_storage"},
+			{"label": "delta", "slot": "3", "offset": 0, "type": "t_int16"}
+		],
+		"types": {
+			"t_array(t_uint256)2_storage": {"encoding": "inplace", "label": "uint256[2]", "numberOfBytes": "64", "base": "t_uint256"},
+			"t_array(t_uint64)dyn_storage": {"encoding": "dynamic_array", "label": "uint64[]", "numberOfBytes": "32", "base": "t_uint64"},
+			"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+			"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+			"t_int16": {"encoding": "inplace", "label": "int16", "numberOfBytes": "2"}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// small = [1, 2, 3, 4, 5]：每个槽放 4 个 uint64，第 5 个在下一个槽
+	data := new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(big.NewInt(2)).Bytes()))
+	var first common.Hash
+	for i := 0; i < 4; i++ {
+		first[31-8*i] = byte(i + 1)
+	}
+	var second common.Hash
+	second[31] = 5
+	var delta common.Hash
+	delta[30], delta[31] = 0xff, 0xfe // -2
+	m := memory{
+		common.BigToHash(big.NewInt(1)):                         common.BigToHash(big.NewInt(42)),
+		common.BigToHash(big.NewInt(2)):                         common.BigToHash(big.NewInt(5)),
+		common.BigToHash(data):                                  first,
+		common.BigToHash(new(big.Int).Add(data, big.NewInt(1))): second,
+		common.BigToHash(big.NewInt(3)):                         delta,
+	}
+	ctx := context.Background()
+	in := storage.New(m, common.Address{}, layout, storage.WithArrayLimit(3))
+	for expr, want := range map[string]int64{"fixed[1]": 42, "small.length": 5, "small[2]": 3, "small[4]": 5, "delta": -2} {
+		v, err := in.Read(ctx, expr)
+		if err != nil {
+			t.Fatalf("%s: %v", expr, err)
+		}
+		if v.Value.(*big.Int).Int64() != want {
+			t.Fatalf("%s = %v, want %d", expr, v.Value, want)
+		}
+	}
+	v, _ := in.Read(ctx, "small")
+	if v.Length.Int64() != 5 || len(v.Fields) != 3 || v.Note == "" {
+		t.Fatalf("small =\n%s", v)
+	}
+	if _, err := in.Read(ctx, "fixed[2]"); err == nil {
+		t.Fatal("expected out of range error")
+	}
+
+	// 长度异常的 string 不会无限读取
+	bad, _ := storage.ParseLayout([]byte(`{"storage": [{"label": "s", "slot": "0", "offset": 0, "type": "t_string_storage"}],
+		"types": {"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"}}}`))
+	m[common.Hash{}] = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	if _, err := storage.New(m, common.Address{}, bad).Read(ctx, "s"); !errors.Is(err, storage.ErrTooLong) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func formatted(v interface{}) string {
+	if a, ok := v.(common.Address); ok {
+		return a.Hex()
+	}
+	if b, ok := v.(*big.Int); ok {
+		return b.String()
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return "?"
+}
@@ -0,0 +1,173 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+// maxValueLen 调用树中单个参数值显示的最大长度，更长的 bytes 等值会被截断
+const maxValueLen = 66
+
+// Frame callTracer 返回的一层调用，字段与 geth 的输出一致
+type Frame struct {
+	Type         string          `json:"type"` // CALL、STATICCALL、DELEGATECALL、CREATE、CREATE2、SELFDESTRUCT 等
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*Frame        `json:"calls,omitempty"`
+	Logs         []*Log          `json:"logs,omitempty"` // 仅 TraceCall 返回
+
+	// 以下字段由 Tracer 解码填充
+	Call   *calldata.Call `json:"-"` // 解码后的输入；创建合约或输入不足 4 字节时为 nil
+	Reason *revert.Reason `json:"-"` // 调用失败时的原因
+}
+
+// Log 调用产生的事件日志；失败的调用产生的日志会随回滚一起撤销
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// AllLogs 按执行顺序返回整棵调用树中最终会保留的日志，失败调用（及其子调用）的日志被排除
+func (f *Frame) AllLogs() []*Log {
+	if f.Failed() {
+		return nil
+	}
+	// callTracer 把日志记在产生它的那一层，这里按先本层、后子调用的顺序合并，
+	// 同一层中日志与子调用交错时顺序可能与链上不同
+	logs := append([]*Log(nil), f.Logs...)
+	for _, c := range f.Calls {
+		logs = append(logs, c.AllLogs()...)
+	}
+	return logs
+}
+
+// Failed 该层调用是否失败
+func (f *Frame) Failed() bool { return f.Error != "" }
+
+// Origin 失败是否发生在这一层：本层失败且没有失败的子调用。
+// 失败会沿调用链向上传递，调用树中标记为“回滚点”的就是最初出错的那一层
+func (f *Frame) Origin() bool {
+	if !f.Failed() {
+		return false
+	}
+	for _, c := range f.Calls {
+		if c.Failed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Walk 按深度优先顺序访问每一层调用，depth 从 0 开始
+func (f *Frame) Walk(fn func(frame *Frame, depth int)) {
+	f.walk(fn, 0)
+}
+
+func (f *Frame) walk(fn func(*Frame, int), depth int) {
+	fn(f, depth)
+	for _, c := range f.Calls {
+		c.walk(fn, depth+1)
+	}
+}
+
+// decode 解码调用树中每一层的方法和失败原因
+func (t *Tracer) decode(root *Frame) {
+	root.Walk(func(f *Frame, _ int) {
+		if t.cfg.Calls != nil && !strings.HasPrefix(f.Type, "CREATE") {
+			f.Call = t.cfg.Calls.Decode(f.To, f.Input)
+		}
+		if !f.Failed() {
+			return
+		}
+		// callTracer 只在 "execution reverted" 时把 revert 数据放在 output 中，其他错误如 Gas 不足没有数据
+		if f.Error == "execution reverted" && t.cfg.Reverts != nil {
+			f.Reason = t.cfg.Reverts.Decode(f.Output)
+		} else {
+			f.Reason = &revert.Reason{Kind: revert.KindFailure, Message: f.Error}
+		}
+	})
+}
+
+// Method 返回该层调用的可读描述，如 "transfer(recipient=0x..., amount=1)"
+func (f *Frame) Method() string {
+	switch {
+	case strings.HasPrefix(f.Type, "CREATE"):
+		return "创建合约"
+	case f.Type == "SELFDESTRUCT":
+		return "自毁"
+	case len(f.Input) == 0:
+		return "转账"
+	case f.Call == nil:
+		return fmt.Sprintf("未知数据 %s", hexutil.Encode(f.Input))
+	}
+	best := f.Call.Best()
+	if best == nil {
+		return fmt.Sprintf("未知方法 %s", f.Call.Selector.Hex())
+	}
+	args := make([]string, len(best.Args))
+	for i, arg := range best.Args {
+		args[i] = fmt.Sprintf("%s=%s", arg.Name, shorten(calldata.FormatValue(arg.Value)))
+	}
+	return fmt.Sprintf("%s(%s)", best.Name, strings.Join(args, ", "))
+}
+
+func shorten(s string) string {
+	if len(s) <= maxValueLen {
+		return s
+	}
+	return s[:maxValueLen-3] + "..."
+}
+
+// String 把调用树渲染为缩进的文本
+func (f *Frame) String() string {
+	var buf bytes.Buffer
+	f.write(&buf, "", "")
+	return buf.String()
+}
+
+// write 输出一层调用；first 是本行的前缀，rest 是子调用行的前缀
+func (f *Frame) write(w io.Writer, first, rest string) {
+	to := "（新合约）"
+	if f.To != nil {
+		to = f.To.Hex()
+	}
+	line := fmt.Sprintf("%s%s %s %s", first, f.Type, to, f.Method())
+	if f.Value != nil && f.Value.ToInt().Sign() > 0 {
+		line += fmt.Sprintf(" 附带 %s Wei", f.Value.ToInt())
+	}
+	line += fmt.Sprintf(" [Gas %d/%d]", uint64(f.GasUsed), uint64(f.Gas))
+	if f.Failed() {
+		mark := " ✗ 失败"
+		if f.Origin() {
+			mark = " ✗ 回滚点"
+		}
+		line += mark
+		if f.Reason != nil {
+			line += ": " + f.Reason.String()
+		}
+	}
+	fmt.Fprintln(w, line)
+	for i, c := range f.Calls {
+		if i == len(f.Calls)-1 {
+			c.write(w, rest+"└─ ", rest+"   ")
+		} else {
+			c.write(w, rest+"├─ ", rest+"│  ")
+		}
+	}
+}
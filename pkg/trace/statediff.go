@@ -0,0 +1,167 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// account prestateTracer 输出的单个账户，未变化或为零的字段被省略
+type account struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// prestateDiff diffMode 下 prestateTracer 的输出：
+// pre 是被修改账户在交易前的状态，post 只包含发生变化的字段；
+// 只在 pre 中出现的账户在交易中自毁，只在 post 中出现的账户是新建的
+type prestateDiff struct {
+	Pre  map[common.Address]*account `json:"pre"`
+	Post map[common.Address]*account `json:"post"`
+}
+
+// StateDiff 交易造成的状态变化，按地址排序
+type StateDiff []*AccountDiff
+
+// AccountDiff 单个账户的变化
+type AccountDiff struct {
+	Address       common.Address `json:"address"`
+	Created       bool           `json:"created,omitempty"`       // 交易前不存在（新建的合约或首次收到 ETH 的地址）
+	Deleted       bool           `json:"deleted,omitempty"`       // 在交易中自毁
+	BalanceBefore *big.Int       `json:"balanceBefore,omitempty"` // 余额没有变化时 BalanceBefore 和 BalanceAfter 都为 nil
+	BalanceAfter  *big.Int       `json:"balanceAfter,omitempty"`
+	NonceBefore   uint64         `json:"nonceBefore"` // 两者相等表示 nonce 没有变化
+	NonceAfter    uint64         `json:"nonceAfter"`
+	CodeChanged   bool           `json:"codeChanged,omitempty"`
+	CodeSize      int            `json:"codeSize,omitempty"` // 交易后的代码长度
+	Storage       []SlotDiff     `json:"storage,omitempty"`
+}
+
+// SlotDiff 一个存储槽的变化
+type SlotDiff struct {
+	Slot   common.Hash `json:"slot"`
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+func (p *prestateDiff) diff() StateDiff {
+	addrs := make(map[common.Address]bool)
+	for addr := range p.Pre {
+		addrs[addr] = true
+	}
+	for addr := range p.Post {
+		addrs[addr] = true
+	}
+
+	var out StateDiff
+	for addr := range addrs {
+		pre, post := p.Pre[addr], p.Post[addr]
+		d := &AccountDiff{Address: addr, Created: pre == nil, Deleted: post == nil}
+		if pre == nil {
+			pre = &account{}
+		}
+		if post == nil {
+			// 自毁后账户清空：非零的字段都变为零
+			post = &account{Balance: (*hexutil.Big)(new(big.Int))}
+			if len(pre.Code) > 0 {
+				post.Code = hexutil.Bytes{}
+			}
+			for slot := range pre.Storage {
+				if post.Storage == nil {
+					post.Storage = make(map[common.Hash]common.Hash)
+				}
+				post.Storage[slot] = common.Hash{}
+			}
+		}
+
+		before := bigOrZero(pre.Balance)
+		if post.Balance != nil && before.Cmp(post.Balance.ToInt()) != 0 {
+			d.BalanceBefore, d.BalanceAfter = before, post.Balance.ToInt()
+		}
+		d.NonceBefore, d.NonceAfter = pre.Nonce, pre.Nonce
+		if post.Nonce != 0 {
+			d.NonceAfter = post.Nonce
+		}
+		if post.Code != nil && !bytes.Equal(pre.Code, post.Code) {
+			d.CodeChanged, d.CodeSize = true, len(post.Code)
+		}
+
+		// pre 中的槽在 post 中缺失表示被清零；post 中新出现的槽交易前为零
+		slots := make(map[common.Hash]bool)
+		for slot := range pre.Storage {
+			slots[slot] = true
+		}
+		for slot := range post.Storage {
+			slots[slot] = true
+		}
+		for slot := range slots {
+			if pre.Storage[slot] != post.Storage[slot] {
+				d.Storage = append(d.Storage, SlotDiff{Slot: slot, Before: pre.Storage[slot], After: post.Storage[slot]})
+			}
+		}
+		sort.Slice(d.Storage, func(i, j int) bool {
+			return bytes.Compare(d.Storage[i].Slot[:], d.Storage[j].Slot[:]) < 0
+		})
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Address[:], out[j].Address[:]) < 0
+	})
+	return out
+}
+
+func bigOrZero(b *hexutil.Big) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return b.ToInt()
+}
+
+// Account 返回指定地址的变化，没有变化时返回 nil
+func (s StateDiff) Account(addr common.Address) *AccountDiff {
+	for _, d := range s {
+		if d.Address == addr {
+			return d
+		}
+	}
+	return nil
+}
+
+// String 把状态变化渲染为文本
+func (s StateDiff) String() string {
+	var buf bytes.Buffer
+	for _, d := range s {
+		flag := ""
+		switch {
+		case d.Created:
+			flag = "（新建）"
+		case d.Deleted:
+			flag = "（已自毁）"
+		}
+		fmt.Fprintf(&buf, "%s%s\n", d.Address.Hex(), flag)
+		if d.BalanceAfter != nil {
+			delta := new(big.Int).Sub(d.BalanceAfter, d.BalanceBefore)
+			sign := ""
+			if delta.Sign() > 0 {
+				sign = "+"
+			}
+			fmt.Fprintf(&buf, "  余额: %s → %s Wei（%s%s）\n", d.BalanceBefore, d.BalanceAfter, sign, delta)
+		}
+		if d.NonceAfter != d.NonceBefore {
+			fmt.Fprintf(&buf, "  nonce: %d → %d\n", d.NonceBefore, d.NonceAfter)
+		}
+		if d.CodeChanged {
+			fmt.Fprintf(&buf, "  代码: 已变更（%d 字节）\n", d.CodeSize)
+		}
+		for _, slot := range d.Storage {
+			fmt.Fprintf(&buf, "  存储 %s: %s → %s\n", slot.Slot.Hex(), slot.Before.Hex(), slot.After.Hex())
+		}
+	}
+	return buf.String()
+}
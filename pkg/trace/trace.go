@@ -0,0 +1,187 @@
+// Package trace 通过 debug_traceTransaction 追踪交易的执行过程
+//
+// 收据只记录交易整体成功与否，看不到合约之间的内部调用。这里使用节点内置的两个追踪器：
+//   - callTracer：返回调用树，每一层包含调用类型、地址、金额、Gas、输入输出和错误，
+//     Tracer 用 pkg/calldata 解码每一层的方法和参数，用 pkg/revert 解码失败的原因
+//   - prestateTracer（diffMode）：返回交易前后发生变化的账户余额、nonce、代码和存储槽
+//
+// 已上链的交易用 debug_traceTransaction 追踪；还没发送的交易用 debug_traceCall，
+// 在指定区块的状态上模拟执行（见 TraceCall、CallStateDiff），调用树中同时带有产生的事件日志。
+//
+// debug 命名空间默认不开放，很多服务商也不提供，此时返回 ErrNotSupported，
+// 调用方可以退回到收据和 revert.Replay。
+//
+//	tracer, ok := trace.FromBackend(client)
+//	root, err := tracer.CallTree(ctx, txHash)
+//	if errors.Is(err, trace.ErrNotSupported) { ... }
+//	fmt.Print(root)
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/batch"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+)
+
+// DefaultTimeout 节点端执行追踪的默认超时，与 geth 的默认值相同
+const DefaultTimeout = 5 * time.Second
+
+// ErrNotSupported 节点没有开放 debug_traceTransaction / debug_traceCall
+var ErrNotSupported = errors.New("节点不支持 debug 追踪接口（未开放 debug 命名空间）")
+
+// Caller 能发送单个 JSON-RPC 请求的客户端，*rpc.Client 满足此接口
+type Caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Config 追踪器配置
+type Config struct {
+	Calls   *calldata.Decoder // 解码每一层调用的方法和参数
+	Reverts *revert.Decoder   // 解码失败调用的回滚原因
+	Timeout time.Duration     // 节点端执行追踪的超时
+}
+
+// DefaultConfig 返回默认配置：使用内置签名库解码，超时为 DefaultTimeout
+func DefaultConfig() Config {
+	db := calldata.DefaultSignatures()
+	return Config{
+		Calls:   calldata.NewDecoder(db),
+		Reverts: revert.NewDecoder(db),
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Option 修改 Config 的函数
+type Option func(*Config)
+
+// WithCalls 使用登记了合约 ABI 的 calldata 解码器
+func WithCalls(d *calldata.Decoder) Option { return func(c *Config) { c.Calls = d } }
+
+// WithReverts 使用登记了自定义错误 ABI 的回滚原因解码器
+func WithReverts(d *revert.Decoder) Option { return func(c *Config) { c.Reverts = d } }
+
+// WithTimeout 设置节点端执行追踪的超时，复杂交易可能需要更长时间
+func WithTimeout(d time.Duration) Option { return func(c *Config) { c.Timeout = d } }
+
+// Tracer 交易追踪器
+type Tracer struct {
+	caller Caller
+	cfg    Config
+}
+
+// New 创建追踪器
+func New(caller Caller, opts ...Option) *Tracer {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Tracer{caller: caller, cfg: cfg}
+}
+
+// FromBackend 后端实现了 backend.RPCClient 时返回对应的追踪器
+func FromBackend(b backend.EthBackend, opts ...Option) (*Tracer, bool) {
+	client, ok := backend.RPC(b)
+	if !ok {
+		return nil, false
+	}
+	return New(client, opts...), true
+}
+
+// traceConfig debug_traceTransaction 和 debug_traceCall 的追踪参数
+type traceConfig struct {
+	Tracer       string      `json:"tracer"`
+	Timeout      string      `json:"timeout,omitempty"`
+	TracerConfig interface{} `json:"tracerConfig,omitempty"`
+}
+
+// callTracerConfig callTracer 的参数
+type callTracerConfig struct {
+	WithLog bool `json:"withLog"`
+}
+
+// diffMode prestateTracer 只返回交易前后的差异
+var diffMode = map[string]bool{"diffMode": true}
+
+// trace 发送追踪请求，target 是追踪参数之前的参数：交易哈希，或调用参数和区块
+func (t *Tracer) trace(ctx context.Context, result interface{}, method, tracer string, tracerConfig interface{}, target ...interface{}) error {
+	cfg := traceConfig{Tracer: tracer, TracerConfig: tracerConfig}
+	if t.cfg.Timeout > 0 {
+		cfg.Timeout = t.cfg.Timeout.String()
+	}
+	err := t.caller.CallContext(ctx, result, method, append(target, cfg)...)
+	if err != nil {
+		if unsupported(err) {
+			return fmt.Errorf("%w: %v", ErrNotSupported, err)
+		}
+		return fmt.Errorf("%s 失败: %w", method, err)
+	}
+	return nil
+}
+
+// unsupported 判断错误是否表示节点没有这个方法：
+// geth 返回 -32601 "the method ... does not exist/is not available"，服务商的措辞各不相同
+func unsupported(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"does not exist", "not available", "method not found", "not supported", "not whitelisted", "unsupported method"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CallTree 用 callTracer 追踪交易，返回解码后的调用树
+func (t *Tracer) CallTree(ctx context.Context, hash common.Hash) (*Frame, error) {
+	var root Frame
+	if err := t.trace(ctx, &root, "debug_traceTransaction", "callTracer", nil, hash); err != nil {
+		return nil, err
+	}
+	t.decode(&root)
+	return &root, nil
+}
+
+// StateDiff 用 prestateTracer 的 diffMode 追踪交易，返回发生变化的账户状态
+func (t *Tracer) StateDiff(ctx context.Context, hash common.Hash) (StateDiff, error) {
+	var result prestateDiff
+	if err := t.trace(ctx, &result, "debug_traceTransaction", "prestateTracer", diffMode, hash); err != nil {
+		return nil, err
+	}
+	return result.diff(), nil
+}
+
+// TraceCall 用 debug_traceCall 在 blockNumber 的状态上模拟执行 msg（nil 表示最新区块），
+// 返回解码后的调用树，每一层都带有该层产生的事件日志
+func (t *Tracer) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*Frame, error) {
+	var root Frame
+	err := t.trace(ctx, &root, "debug_traceCall", "callTracer", callTracerConfig{WithLog: true}, batch.CallArg(msg), batch.BlockArg(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	t.decode(&root)
+	return &root, nil
+}
+
+// CallStateDiff 用 debug_traceCall 模拟执行 msg，返回会发生变化的账户状态
+func (t *Tracer) CallStateDiff(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (StateDiff, error) {
+	var result prestateDiff
+	if err := t.trace(ctx, &result, "debug_traceCall", "prestateTracer", diffMode, batch.CallArg(msg), batch.BlockArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return result.diff(), nil
+}
@@ -0,0 +1,149 @@
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/duanyu/new-eth-project/pkg/bindings/multicall3"
+	"github.com/duanyu/new-eth-project/pkg/bindings/mytoken"
+	"github.com/duanyu/new-eth-project/pkg/calldata"
+	"github.com/duanyu/new-eth-project/pkg/revert"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/trace"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+func TestCallTree(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, _ := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+	mcAddr, _, mc, err := multicall3.DeployMulticall3(alice.Opts(), chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.Commit()
+
+	// Multicall3 先查询余额，再以自己的名义转账；它没有代币，第二个子调用回滚并导致整体回滚
+	balanceOf := calldata.SelectorOf("balanceOf(address)").Bytes()
+	balanceOf = append(balanceOf, common.LeftPadBytes(alice.Address.Bytes(), 32)...)
+	opts := alice.Opts()
+	opts.GasLimit = 500_000
+	tx, err := mc.Aggregate3(opts, []multicall3.Multicall3Call3{
+		{Target: tokenAddr, AllowFailure: true, CallData: balanceOf},
+		{Target: tokenAddr, CallData: transfer.EncodeTransfer(bob.Address, big.NewInt(5))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := chain.Receipt(tx.Hash()); r.Status != types.ReceiptStatusFailed {
+		t.Fatal("expected the aggregate3 transaction to fail")
+	}
+
+	calls := calldata.NewDecoder(calldata.DefaultSignatures())
+	if err := calls.AddContract(tokenAddr, "MyToken", mytoken.MyTokenMetaData.ABI); err != nil {
+		t.Fatal(err)
+	}
+	tracer, ok := trace.FromBackend(chain, trace.WithCalls(calls))
+	if !ok {
+		t.Fatal("simchain should provide an RPC client")
+	}
+	root, err := tracer.CallTree(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Type != "CALL" || *root.To != mcAddr || root.Call.Best().Name != "aggregate3" || len(root.Calls) != 2 {
+		t.Fatalf("root = %+v", root)
+	}
+	if !root.Failed() || root.Origin() || root.Reason.Message != "Multicall3: call failed" {
+		t.Fatalf("root failure = %q, reason = %+v", root.Error, root.Reason)
+	}
+	query, send := root.Calls[0], root.Calls[1]
+	if query.Failed() || query.Method() != "balanceOf(account="+alice.Address.Hex()+")" {
+		t.Fatalf("first call = %s, error %q", query.Method(), query.Error)
+	}
+	if !send.Origin() || send.Reason.Kind != revert.KindError || send.Reason.Message != "ERC20: transfer amount exceeds balance" {
+		t.Fatalf("second call = %+v, reason = %+v", send, send.Reason)
+	}
+
+	out := root.String()
+	for _, want := range []string{"├─ CALL", "└─ CALL", "transfer(recipient=" + bob.Address.Hex() + ", amount=5)", "✗ 回滚点: Error(string): ERC20: transfer amount exceeds balance"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1000))
+
+	tx, err := token.Transfer(alice.Opts(), bob.Address, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt := chain.Receipt(tx.Hash())
+	tracer, _ := trace.FromBackend(chain)
+	diff, err := tracer.StateDiff(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 发送方支付手续费、nonce 加一；代币合约中两个余额槽发生变化
+	sender := diff.Account(alice.Address)
+	if sender == nil || sender.NonceAfter != sender.NonceBefore+1 || sender.BalanceAfter.Cmp(sender.BalanceBefore) >= 0 {
+		t.Fatalf("sender diff = %+v", sender)
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	if spent := new(big.Int).Sub(sender.BalanceBefore, sender.BalanceAfter); spent.Cmp(fee) != 0 {
+		t.Fatalf("sender spent %s, want fee %s", spent, fee)
+	}
+	tokenDiff := diff.Account(tokenAddr)
+	if tokenDiff == nil || len(tokenDiff.Storage) != 2 || tokenDiff.BalanceAfter != nil {
+		t.Fatalf("token diff = %+v", tokenDiff)
+	}
+	for _, slot := range tokenDiff.Storage {
+		after := slot.After.Big()
+		if after.Int64() != 7 && new(big.Int).Sub(slot.Before.Big(), after).Int64() != 7 {
+			t.Fatalf("unexpected slot change %+v", slot)
+		}
+	}
+	if out := diff.String(); !strings.Contains(out, "nonce: 1 → 2") || !strings.Contains(out, "存储 ") {
+		t.Fatalf("output:\n%s", out)
+	}
+}
+
+// noDebug 模拟没有开放 debug 命名空间的节点
+type noDebug struct{}
+
+func (noDebug) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return &methodNotFound{method}
+}
+
+type methodNotFound struct{ method string }
+
+func (e *methodNotFound) Error() string {
+	return "the method " + e.method + " does not exist/is not available"
+}
+func (e *methodNotFound) ErrorCode() int { return -32601 }
+
+var _ rpc.Error = (*methodNotFound)(nil)
+
+func TestNotSupported(t *testing.T) {
+	tracer := trace.New(noDebug{})
+	if _, err := tracer.CallTree(context.Background(), common.Hash{1}); !errors.Is(err, trace.ErrNotSupported) {
+		t.Fatalf("err = %v, want ErrNotSupported", err)
+	}
+	if _, err := tracer.StateDiff(context.Background(), common.Hash{1}); !errors.Is(err, trace.ErrNotSupported) {
+		t.Fatalf("err = %v, want ErrNotSupported", err)
+	}
+}
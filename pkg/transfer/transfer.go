@@ -0,0 +1,88 @@
+// Package transfer 提供 ETH 与 ERC20 代币转账功能
+//
+// 对应 cmd/eth-transfer 和 cmd/token-transfer 中的逻辑。
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+)
+
+// ETHTransferGas 普通 ETH 转账固定消耗的 Gas
+const ETHTransferGas = 21000
+
+// TransferSelector ERC20 transfer(address,uint256) 的函数选择器 0xa9059cbb
+var TransferSelector = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+
+// Result 已广播的转账交易
+type Result struct {
+	Tx       *types.Transaction
+	From     common.Address
+	To       common.Address // 接收方（代币转账时为代币接收者，而不是合约地址）
+	Token    *common.Address
+	Amount   *big.Int
+	Nonce    uint64
+	GasLimit uint64
+	GasPrice *big.Int
+}
+
+// SendETH 从 signer 向 to 转账 amount Wei
+// opts 为空时自动获取 nonce 和 Gas 价格，Gas 上限固定为 21000
+func SendETH(ctx context.Context, b backend.EthBackend, signer *backend.Signer, to common.Address, amount *big.Int, opts *backend.TxOptions) (*Result, error) {
+	o := backend.TxOptions{GasLimit: ETHTransferGas}
+	if opts != nil {
+		o = *opts
+		if o.GasLimit == 0 {
+			o.GasLimit = ETHTransferGas
+		}
+	}
+	tx, err := backend.BuildTx(ctx, b, signer.Address, &to, amount, nil, &o)
+	if err != nil {
+		return nil, err
+	}
+	return send(ctx, b, signer, tx, to, nil, amount)
+}
+
+// EncodeTransfer 手工编码 ERC20 transfer(to, amount) 的调用数据：
+// 4 字节函数选择器 + 左填充到 32 字节的地址 + 左填充到 32 字节的数量
+func EncodeTransfer(to common.Address, amount *big.Int) []byte {
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, TransferSelector...)
+	data = append(data, common.LeftPadBytes(to.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	return data
+}
+
+// SendToken 调用代币合约的 transfer，把 amount（最小单位）代币转给 to
+// 交易的接收地址是代币合约，value 为 0；opts.GasLimit 为 0 时自动估算
+func SendToken(ctx context.Context, b backend.EthBackend, signer *backend.Signer, token, to common.Address, amount *big.Int, opts *backend.TxOptions) (*Result, error) {
+	tx, err := backend.BuildTx(ctx, b, signer.Address, &token, big.NewInt(0), EncodeTransfer(to, amount), opts)
+	if err != nil {
+		return nil, err
+	}
+	return send(ctx, b, signer, tx, to, &token, amount)
+}
+
+func send(ctx context.Context, b backend.EthBackend, signer *backend.Signer, tx *types.Transaction, to common.Address, token *common.Address, amount *big.Int) (*Result, error) {
+	signed, err := backend.SignAndSend(ctx, b, signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("转账失败: %w", err)
+	}
+	return &Result{
+		Tx:       signed,
+		From:     signer.Address,
+		To:       to,
+		Token:    token,
+		Amount:   amount,
+		Nonce:    signed.Nonce(),
+		GasLimit: signed.Gas(),
+		GasPrice: signed.GasPrice(),
+	}, nil
+}
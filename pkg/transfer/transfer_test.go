@@ -0,0 +1,81 @@
+package transfer_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/duanyu/new-eth-project/pkg/backend"
+	"github.com/duanyu/new-eth-project/pkg/simchain"
+	"github.com/duanyu/new-eth-project/pkg/transfer"
+)
+
+func TestEncodeTransfer(t *testing.T) {
+	to := common.HexToAddress("0x4592d8f8d7b001e72cb26a73e4fa1806a51ac79d")
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	want := "a9059cbb" +
+		"0000000000000000000000004592d8f8d7b001e72cb26a73e4fa1806a51ac79d" +
+		"00000000000000000000000000000000000000000000003635c9adc5dea00000"
+	if got := hex.EncodeToString(transfer.EncodeTransfer(to, amount)); got != want {
+		t.Fatalf("calldata = %s\nwant       %s", got, want)
+	}
+}
+
+func TestSendETH(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	before := chain.Balance(bob.Address)
+
+	res, err := transfer.SendETH(ctx, chain, alice.Signer(), bob.Address, big.NewInt(1e18), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GasLimit != transfer.ETHTransferGas || res.From != alice.Address || res.Nonce != 0 {
+		t.Fatalf("result = %+v", res)
+	}
+	if _, err := backend.WaitMined(ctx, chain, res.Tx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := new(big.Int).Sub(chain.Balance(bob.Address), before); diff.Int64() != 1e18 {
+		t.Fatalf("recipient received %s", diff)
+	}
+
+	// 余额不足时发送失败，错误信息中包含发送失败的原因
+	poor := chain.Accounts[2]
+	huge := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	if _, err := transfer.SendETH(ctx, chain, poor.Signer(), bob.Address, huge, nil); err == nil {
+		t.Fatal("expected error when balance is insufficient")
+	}
+}
+
+func TestSendToken(t *testing.T) {
+	chain := simchain.New(t, simchain.WithAutoMine())
+	ctx := context.Background()
+	alice, bob := chain.Accounts[0], chain.Accounts[1]
+	tokenAddr, token := chain.DeployMyToken(alice, "My Token", "MTK", 18, big.NewInt(1_000_000))
+
+	amount := big.NewInt(12345)
+	res, err := transfer.SendToken(ctx, chain, alice.Signer(), tokenAddr, bob.Address, amount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *res.Tx.To() != tokenAddr || res.Tx.Value().Sign() != 0 || res.To != bob.Address {
+		t.Fatalf("tx to=%s value=%s", res.Tx.To().Hex(), res.Tx.Value())
+	}
+	receipt, err := backend.WaitMined(ctx, chain, res.Tx)
+	if err != nil || len(receipt.Logs) != 1 {
+		t.Fatalf("receipt = %v, %v", receipt, err)
+	}
+	if bal, _ := token.BalanceOf(nil, bob.Address); bal.Cmp(amount) != 0 {
+		t.Fatalf("recipient token balance = %s, want %s", bal, amount)
+	}
+
+	// 估算 Gas 时就能发现转账会 revert
+	if _, err := transfer.SendToken(ctx, chain, bob.Signer(), tokenAddr, alice.Address, big.NewInt(99999), nil); err == nil {
+		t.Fatal("expected error for transfer exceeding balance")
+	}
+}
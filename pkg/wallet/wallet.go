@@ -0,0 +1,56 @@
+// Package wallet 提供以太坊钱包（私钥、公钥、地址）的生成与推导
+//
+// 对应 cmd/create-wallet 中的逻辑。
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Wallet 钱包信息
+type Wallet struct {
+	PrivateKey    *ecdsa.PrivateKey
+	PrivateKeyHex string // 不含 0x 前缀
+	PublicKeyHex  string // 未压缩公钥，去掉 0x 和 0x04 前缀
+	Address       common.Address
+}
+
+// New 生成一个新的随机钱包
+func New() (*Wallet, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("生成私钥失败: %w", err)
+	}
+	return FromKey(key), nil
+}
+
+// FromHex 由十六进制私钥（不含 0x 前缀）恢复钱包
+func FromHex(hexKey string) (*Wallet, error) {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("私钥解析失败: %w", err)
+	}
+	return FromKey(key), nil
+}
+
+// FromKey 由私钥推导公钥和地址
+func FromKey(key *ecdsa.PrivateKey) *Wallet {
+	return &Wallet{
+		PrivateKey:    key,
+		PrivateKeyHex: hexutil.Encode(crypto.FromECDSA(key))[2:],
+		PublicKeyHex:  hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey))[4:],
+		Address:       crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// AddressFromPublicKey 手工推导地址：对去掉 0x04 前缀的公钥做 Keccak256，取后 20 字节
+// 结果应与 crypto.PubkeyToAddress 一致
+func AddressFromPublicKey(pub *ecdsa.PublicKey) common.Address {
+	hash := crypto.Keccak256(crypto.FromECDSAPub(pub)[1:])
+	return common.BytesToAddress(hash[12:])
+}
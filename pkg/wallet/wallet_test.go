@@ -0,0 +1,28 @@
+package wallet_test
+
+import (
+	"testing"
+
+	"github.com/duanyu/new-eth-project/pkg/wallet"
+)
+
+func TestNewWallet(t *testing.T) {
+	w, err := wallet.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.PrivateKeyHex) != 64 || len(w.PublicKeyHex) != 128 {
+		t.Fatalf("key lengths: private=%d public=%d", len(w.PrivateKeyHex), len(w.PublicKeyHex))
+	}
+	if got := wallet.AddressFromPublicKey(&w.PrivateKey.PublicKey); got != w.Address {
+		t.Fatalf("manual derivation = %s, want %s", got.Hex(), w.Address.Hex())
+	}
+
+	restored, err := wallet.FromHex(w.PrivateKeyHex)
+	if err != nil || restored.Address != w.Address {
+		t.Fatalf("FromHex = %v, %v", restored, err)
+	}
+	if _, err := wallet.FromHex("zz"); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+}